@@ -21,8 +21,11 @@ func authActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		Command:        newAuthTokenCmd(),
 		FlagsResolver:  newAuthTokenFlags,
 		ActionResolver: newAuthTokenAction,
-		OutputFormats:  []output.Format{output.JsonFormat},
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
 		DefaultFormat:  output.NoneFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Footer: getCmdAuthTokenHelpFooter,
+		},
 	})
 
 	group.Add("login", &actions.ActionDescriptorOptions{
@@ -35,8 +38,34 @@ func authActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 
 	group.Add("logout", &actions.ActionDescriptorOptions{
 		Command:        newLogoutCmd("auth"),
+		FlagsResolver:  newLogoutFlags,
 		ActionResolver: newLogoutAction,
 	})
 
+	group.Add("list", &actions.ActionDescriptorOptions{
+		Command:        newAuthListCmd(),
+		ActionResolver: newAuthListAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+	})
+
+	group.Add("docker-credential-helper", &actions.ActionDescriptorOptions{
+		Command:        newAuthDockerCredentialHelperCmd(),
+		FlagsResolver:  newAuthDockerCredentialHelperFlags,
+		ActionResolver: newAuthDockerCredentialHelperAction,
+	})
+
+	group.Add("git-credential", &actions.ActionDescriptorOptions{
+		Command:        newAuthGitCredentialCmd(),
+		FlagsResolver:  newAuthGitCredentialFlags,
+		ActionResolver: newAuthGitCredentialAction,
+	})
+
+	group.Add("switch", &actions.ActionDescriptorOptions{
+		Command:        newAuthSwitchCmd(),
+		FlagsResolver:  newAuthSwitchFlags,
+		ActionResolver: newAuthSwitchAction,
+	})
+
 	return group
 }
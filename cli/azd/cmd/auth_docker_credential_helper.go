@@ -0,0 +1,172 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// cAcrTokenUsername is the user name of the registry refresh tokens of Azure Container Registry.
+const cAcrTokenUsername = "00000000-0000-0000-0000-000000000000"
+
+type authDockerCredentialHelperFlags struct {
+	tenantID string
+	global   *internal.GlobalCommandOptions
+}
+
+func (f *authDockerCredentialHelperFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(&f.tenantID, "tenant-id", "", "The tenant id to use when requesting an access token.")
+	f.global = global
+}
+
+func newAuthDockerCredentialHelperFlags(
+	cmd *cobra.Command,
+	global *internal.GlobalCommandOptions,
+) *authDockerCredentialHelperFlags {
+	flags := &authDockerCredentialHelperFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newAuthDockerCredentialHelperCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "docker-credential-helper <get|store|erase|list>",
+		Short: "Docker credential helper for Azure Container Registry.",
+		Long: heredoc.Doc(`
+		Docker credential helper for Azure Container Registry.
+
+		Implements the docker credential helper protocol: docker runs the helper to get the credentials of a registry,
+		which are exchanged for the logged in account. To use it, create an executable named docker-credential-azd
+		on the PATH which runs 'azd auth docker-credential-helper "$@"', and add the registries to the
+		"credHelpers" of the docker config, like { "credHelpers": { "myregistry.azurecr.io": "azd" } }. Registries
+		which are not Azure Container Registries of the selected cloud get no credentials.
+		`),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"get", "store", "erase", "list"},
+	}
+}
+
+// dockerCredentials are the credentials of a registry in the docker credential helper protocol.
+type dockerCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+type authDockerCredentialHelperAction struct {
+	credentialProvider       CredentialProviderFn
+	envResolver              environment.EnvironmentResolver
+	subResolver              account.SubscriptionTenantResolver
+	containerRegistryService azcli.ContainerRegistryService
+	cloud                    *cloud.Cloud
+	console                  input.Console
+	writer                   io.Writer
+	flags                    *authDockerCredentialHelperFlags
+	args                     []string
+}
+
+func newAuthDockerCredentialHelperAction(
+	credentialProvider CredentialProviderFn,
+	envResolver environment.EnvironmentResolver,
+	subResolver account.SubscriptionTenantResolver,
+	containerRegistryService azcli.ContainerRegistryService,
+	cloud *cloud.Cloud,
+	console input.Console,
+	writer io.Writer,
+	flags *authDockerCredentialHelperFlags,
+	args []string,
+) actions.Action {
+	return &authDockerCredentialHelperAction{
+		credentialProvider:       credentialProvider,
+		envResolver:              envResolver,
+		subResolver:              subResolver,
+		containerRegistryService: containerRegistryService,
+		cloud:                    cloud,
+		console:                  console,
+		writer:                   writer,
+		flags:                    flags,
+		args:                     args,
+	}
+}
+
+func (a *authDockerCredentialHelperAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	request, err := io.ReadAll(a.console.Handles().Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	switch a.args[0] {
+	case "get":
+		serverUrl := strings.TrimSpace(string(request))
+		if serverUrl == "" {
+			return nil, errors.New("no server URL in input")
+		}
+
+		// The server URL may include a scheme and a path, like https://myregistry.azurecr.io/v2/
+		loginServer := strings.TrimPrefix(strings.TrimPrefix(serverUrl, "https://"), "http://")
+		loginServer, _, _ = strings.Cut(loginServer, "/")
+		if !isContainerRegistryHost(loginServer, a.cloud) {
+			// Printing nothing keeps the access token of the account from being sent to a registry which is not an
+			// Azure Container Registry.
+			log.Printf("docker credential helper: '%s' is not an Azure Container Registry", loginServer)
+			return nil, nil
+		}
+
+		token, err := getToken(
+			ctx, a.credentialProvider, a.envResolver, a.subResolver, a.flags.tenantID, auth.LoginScopes(a.cloud))
+		if err != nil {
+			return nil, err
+		}
+
+		refreshToken, err := a.containerRegistryService.ExchangeToken(ctx, loginServer, token.Token)
+		if err != nil {
+			return nil, fmt.Errorf("exchanging token for registry '%s': %w", loginServer, err)
+		}
+
+		return nil, json.NewEncoder(a.writer).Encode(dockerCredentials{
+			ServerURL: serverUrl,
+			Username:  cAcrTokenUsername,
+			Secret:    refreshToken,
+		})
+	case "list":
+		// The credentials are not stored, so there are none to list.
+		_, err := fmt.Fprintln(a.writer, "{}")
+		return nil, err
+	case "store", "erase":
+		// The credentials are exchanged on each request, there is nothing to store or erase.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported docker credential helper operation: '%s'", a.args[0])
+	}
+}
+
+// isContainerRegistryHost returns true when host is the login server of an Azure Container Registry of the cloud, like
+// myregistry.azurecr.io.
+func isContainerRegistryHost(host string, azureCloud *cloud.Cloud) bool {
+	if azureCloud.ContainerRegistryEndpointSuffix == "" {
+		return false
+	}
+
+	host, _, _ = strings.Cut(strings.ToLower(host), ":")
+	return strings.HasSuffix(host, "."+strings.ToLower(azureCloud.ContainerRegistryEndpointSuffix))
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthDockerCredentialHelper(t *testing.T) {
+	token := authTokenFn(func(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+		return azcore.AccessToken{Token: "ABC123"}, nil
+	})
+
+	run := func(t *testing.T, azureCloud *cloud.Cloud, request string) (string, []string) {
+		console := &stdinConsole{MockConsole: mockinput.NewMockConsole(), stdin: strings.NewReader(request)}
+		registries := &exchangeTokenRegistryService{}
+
+		buf := &bytes.Buffer{}
+		a := newAuthDockerCredentialHelperAction(
+			credentialProviderForTokenFn(token),
+			nil,
+			nil,
+			registries,
+			azureCloud,
+			console,
+			buf,
+			&authDockerCredentialHelperFlags{tenantID: "TENANT_ID"},
+			[]string{"get"},
+		)
+
+		_, err := a.Run(context.Background())
+		require.NoError(t, err)
+
+		return buf.String(), registries.loginServers
+	}
+
+	t.Run("ContainerRegistry", func(t *testing.T) {
+		output, loginServers := run(t, cloud.AzurePublic(), "https://myregistry.azurecr.io/v2/\n")
+		require.Equal(t, []string{"myregistry.azurecr.io"}, loginServers)
+		require.JSONEq(t,
+			`{"ServerURL":"https://myregistry.azurecr.io/v2/",`+
+				`"Username":"00000000-0000-0000-0000-000000000000","Secret":"refresh-ABC123"}`,
+			output)
+
+		_, loginServers = run(t, cloud.AzureGovernment(), "myregistry.azurecr.us")
+		require.Equal(t, []string{"myregistry.azurecr.us"}, loginServers)
+	})
+
+	t.Run("OtherHost", func(t *testing.T) {
+		for _, request := range []string{"ghcr.io", "https://index.docker.io/v1/", "azurecr.io.example.com"} {
+			output, loginServers := run(t, cloud.AzurePublic(), request)
+			require.Empty(t, output, request)
+			require.Empty(t, loginServers, request)
+		}
+
+		// The registries of other clouds are not trusted either.
+		output, loginServers := run(t, cloud.AzureChina(), "myregistry.azurecr.io")
+		require.Empty(t, output)
+		require.Empty(t, loginServers)
+	})
+}
+
+// exchangeTokenRegistryService is a container registry service which records the login servers it exchanges tokens for.
+type exchangeTokenRegistryService struct {
+	azcli.ContainerRegistryService
+	loginServers []string
+}
+
+func (s *exchangeTokenRegistryService) ExchangeToken(
+	ctx context.Context,
+	loginServer string,
+	accessToken string,
+) (string, error) {
+	s.loginServers = append(s.loginServers, loginServer)
+	return "refresh-" + accessToken, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// cGitCredentialUsername is the user name returned with Azure DevOps tokens when the request has none. Azure Repos accepts
+// any user name with an access token.
+const cGitCredentialUsername = "azd"
+
+type authGitCredentialFlags struct {
+	tenantID string
+	global   *internal.GlobalCommandOptions
+}
+
+func (f *authGitCredentialFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(&f.tenantID, "tenant-id", "", "The tenant id to use when requesting an access token.")
+	f.global = global
+}
+
+func newAuthGitCredentialFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *authGitCredentialFlags {
+	flags := &authGitCredentialFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newAuthGitCredentialCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "git-credential <get|store|erase>",
+		Short: "Git credential helper for Azure Repos.",
+		Long: heredoc.Doc(`
+		Git credential helper for Azure Repos.
+
+		Implements the git credential helper protocol: git runs the helper to get the credentials of Azure Repos, which
+		are an access token of the logged in account. Other hosts are left to the other credential helpers. To use it,
+		run: git config --global credential.https://dev.azure.com.helper "!azd auth git-credential"
+		`),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"get", "store", "erase"},
+	}
+}
+
+type authGitCredentialAction struct {
+	credentialProvider CredentialProviderFn
+	console            input.Console
+	writer             io.Writer
+	flags              *authGitCredentialFlags
+	args               []string
+}
+
+func newAuthGitCredentialAction(
+	credentialProvider CredentialProviderFn,
+	console input.Console,
+	writer io.Writer,
+	flags *authGitCredentialFlags,
+	args []string,
+) actions.Action {
+	return &authGitCredentialAction{
+		credentialProvider: credentialProvider,
+		console:            console,
+		writer:             writer,
+		flags:              flags,
+		args:               args,
+	}
+}
+
+func (a *authGitCredentialAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	attributes, err := readGitCredentialAttributes(a.console.Handles().Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	switch a.args[0] {
+	case "get":
+		if attributes["protocol"] != "https" || !isAzureDevOpsHost(attributes["host"]) {
+			// Printing nothing lets git ask the next credential helper.
+			return nil, nil
+		}
+
+		cred, err := a.credentialProvider(ctx, &auth.CredentialForCurrentUserOptions{
+			TenantID: a.flags.tenantID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		token, err := cred.GetToken(ctx, policy.TokenRequestOptions{
+			Scopes: auth.AzureDevOpsScopes,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetching token: %w", err)
+		}
+
+		username := attributes["username"]
+		if username == "" {
+			username = cGitCredentialUsername
+		}
+
+		_, err = fmt.Fprintf(a.writer, "username=%s\npassword=%s\n", username, token.Token)
+		return nil, err
+	case "store", "erase":
+		// The token is fetched on each request, there is nothing to store or erase.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported git credential operation: '%s'", a.args[0])
+	}
+}
+
+// readGitCredentialAttributes reads the key=value lines of a git credential helper request, up to a blank line or the end
+// of the input.
+func readGitCredentialAttributes(reader io.Reader) (map[string]string, error) {
+	attributes := map[string]string{}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		if key, value, has := strings.Cut(line, "="); has {
+			attributes[key] = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// isAzureDevOpsHost returns true when host is a host of Azure Repos: dev.azure.com, or <organization>.visualstudio.com.
+func isAzureDevOpsHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthGitCredential(t *testing.T) {
+	token := authTokenFn(func(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+		require.ElementsMatch(t, auth.AzureDevOpsScopes, options.Scopes)
+
+		return azcore.AccessToken{Token: "ABC123"}, nil
+	})
+
+	run := func(t *testing.T, request string) string {
+		console := &stdinConsole{MockConsole: mockinput.NewMockConsole(), stdin: strings.NewReader(request)}
+
+		buf := &bytes.Buffer{}
+		a := newAuthGitCredentialAction(
+			credentialProviderForTokenFn(token), console, buf, &authGitCredentialFlags{}, []string{"get"})
+
+		_, err := a.Run(context.Background())
+		require.NoError(t, err)
+
+		return buf.String()
+	}
+
+	t.Run("AzureRepos", func(t *testing.T) {
+		require.Equal(t,
+			"username=azd\npassword=ABC123\n",
+			run(t, "protocol=https\nhost=dev.azure.com\npath=org/project/_git/repo\n\n"))
+
+		require.Equal(t,
+			"username=org\npassword=ABC123\n",
+			run(t, "protocol=https\nhost=org.visualstudio.com\nusername=org\n\n"))
+	})
+
+	t.Run("OtherHost", func(t *testing.T) {
+		require.Empty(t, run(t, "protocol=https\nhost=github.com\n\n"))
+	})
+}
+
+// stdinConsole is a mock console which reads the standard input from a reader.
+type stdinConsole struct {
+	*mockinput.MockConsole
+	stdin io.Reader
+}
+
+func (c *stdinConsole) Handles() input.ConsoleHandles {
+	handles := c.MockConsole.Handles()
+	handles.Stdin = c.stdin
+	return handles
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newAuthListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List the auth profiles.",
+		Aliases: []string{"ls"},
+	}
+}
+
+type authListAction struct {
+	authManager *auth.Manager
+	formatter   output.Formatter
+	writer      io.Writer
+}
+
+func newAuthListAction(
+	authManager *auth.Manager,
+	formatter output.Formatter,
+	writer io.Writer,
+) actions.Action {
+	return &authListAction{
+		authManager: authManager,
+		formatter:   formatter,
+		writer:      writer,
+	}
+}
+
+func (a *authListAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	profiles, err := a.authManager.ListProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing profiles: %w", err)
+	}
+
+	if a.formatter.Kind() == output.TableFormat {
+		columns := []output.Column{
+			{
+				Heading:       "NAME",
+				ValueTemplate: "{{.Name}}",
+			},
+			{
+				Heading:       "CURRENT",
+				ValueTemplate: "{{.IsCurrent}}",
+			},
+			{
+				Heading:       "TYPE",
+				ValueTemplate: "{{.Type}}",
+			},
+			{
+				Heading:       "ACCOUNT",
+				ValueTemplate: "{{.Account}}",
+			},
+			{
+				Heading:       "TENANT",
+				ValueTemplate: "{{.TenantID}}",
+			},
+		}
+
+		err = a.formatter.Format(profiles, a.writer, output.TableFormatterOptions{
+			Columns: columns,
+		})
+	} else {
+		err = a.formatter.Format(profiles, a.writer, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
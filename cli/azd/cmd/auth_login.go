@@ -51,6 +51,10 @@ type loginFlags struct {
 	clientSecret           stringPtr
 	clientCertificate      string
 	federatedTokenProvider string
+	federatedTokenEnvVar   string
+	federatedTokenCommand  string
+	managedIdentity        bool
+	profile                string
 	scopes                 []string
 	redirectPort           int
 	global                 *internal.GlobalCommandOptions
@@ -106,6 +110,9 @@ const (
 	cClientSecretFlagName                = "client-secret"
 	cClientCertificateFlagName           = "client-certificate"
 	cFederatedCredentialProviderFlagName = "federated-credential-provider"
+	cFederatedTokenEnvVarFlagName        = "federated-token-env-var"
+	cFederatedTokenCommandFlagName       = "federated-token-command"
+	cManagedIdentityFlagName             = "managed-identity"
 )
 
 func (lf *loginFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
@@ -118,7 +125,11 @@ func (lf *loginFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandO
 	)
 	// ensure the flag behaves as a common boolean flag which is set to true when used without any other arg
 	f.NoOptDefVal = "true"
-	local.StringVar(&lf.clientID, "client-id", "", "The client id for the service principal to authenticate with.")
+	local.StringVar(
+		&lf.clientID,
+		"client-id",
+		"",
+		"The client id for the service principal or user-assigned managed identity to authenticate with.")
 	local.Var(
 		&lf.clientSecret,
 		cClientSecretFlagName,
@@ -133,12 +144,36 @@ func (lf *loginFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandO
 		&lf.federatedTokenProvider,
 		cFederatedCredentialProviderFlagName,
 		"",
-		"The provider to use to acquire a federated token to authenticate with.")
+		"The provider to use to acquire a federated token to authenticate with: "+
+			strings.Join(auth.FederatedTokenProviders, ", ")+".")
+	local.StringVar(
+		&lf.federatedTokenEnvVar,
+		cFederatedTokenEnvVarFlagName,
+		"",
+		"The environment variable holding the federated token, for the oidc federated credential provider "+
+			"(default AZURE_FEDERATED_TOKEN).")
+	local.StringVar(
+		&lf.federatedTokenCommand,
+		cFederatedTokenCommandFlagName,
+		"",
+		"The command printing the federated token, for the oidc federated credential provider. "+
+			"The command is run without a shell.")
+	local.BoolVar(
+		&lf.managedIdentity,
+		cManagedIdentityFlagName,
+		false,
+		"Log in with the managed identity of the Azure resource azd runs on. "+
+			"Pass --client-id to use a user-assigned managed identity.")
 	local.StringVar(
 		&lf.tenantID,
 		"tenant-id",
 		"",
 		"The tenant id or domain name to authenticate with.")
+	local.StringVar(
+		&lf.profile,
+		"profile",
+		"",
+		"The named profile to log in to. The profile becomes the current profile.")
 	local.StringArrayVar(
 		&lf.scopes,
 		"scope",
@@ -173,6 +208,11 @@ func newLoginCmd(parent string) *cobra.Command {
 		
 		To log in as a service principal, pass --client-id and --tenant-id as well as one of: --client-secret, 
 		--client-certificate, or --federated-credential-provider.
+
+		To log in with a managed identity, pass --managed-identity, and --client-id for a user-assigned managed identity.
+
+		To keep several logins, pass --profile to log in to a named profile. Use 'azd auth list' to list the profiles and
+		'azd auth switch' to change the current profile.
 		`),
 		Annotations: map[string]string{
 			loginCmdParentAnnotation: parent,
@@ -245,7 +285,7 @@ const cLoginSuccessMessage = "Logged in to Azure."
 
 func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	if len(la.flags.scopes) == 0 {
-		la.flags.scopes = auth.LoginScopes
+		la.flags.scopes = la.authManager.LoginScopes()
 	}
 
 	if la.annotations[loginCmdParentAnnotation] == "" {
@@ -258,6 +298,14 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 			"Next time use `azd auth login`.")
 	}
 
+	if la.flags.profile != "" {
+		if err := auth.ValidateProfileName(la.flags.profile); err != nil {
+			return nil, err
+		}
+
+		la.authManager = la.authManager.WithProfile(la.flags.profile)
+	}
+
 	if la.flags.onlyCheckStatus {
 		// In check status mode, we always print the final status to stdout.
 		// We print any non-setup related errors to stderr.
@@ -311,7 +359,7 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	// Rehydrate or clear the account's subscriptions cache.
 	// The caching is done here to increase responsiveness of listing subscriptions (during azd init).
 	// It also allows an implicit command for the user to refresh cached subscriptions.
-	if la.flags.clientID == "" {
+	if la.flags.clientID == "" && !la.flags.managedIdentity {
 		// Deleting subscriptions on file is very unlikely to fail, unless there are serious filesystem issues.
 		// If this does fail, we want the user to be aware of this. Like other stored azd account data,
 		// stored subscriptions are currently tied to the OS user, and not the individual account being logged in,
@@ -327,7 +375,8 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 			log.Printf("failed retrieving subscriptions: %v", err)
 		}
 	} else {
-		// Service principals do not typically require subscription caching (running in CI scenarios)
+		// Service principals and managed identities do not typically require subscription caching (running in CI
+		// scenarios)
 		// We simply clear the cache, which is much faster than rehydrating.
 		err := la.accountSubManager.ClearSubscriptions(ctx)
 		if err != nil {
@@ -394,6 +443,27 @@ func runningOnCodespacesBrowser(ctx context.Context, commandRunner exec.CommandR
 }
 
 func (la *loginAction) login(ctx context.Context) error {
+	if la.flags.managedIdentity {
+		if countTrue(
+			la.flags.clientSecret.ptr != nil,
+			la.flags.clientCertificate != "",
+			la.flags.federatedTokenProvider != "",
+		) != 0 {
+			return fmt.Errorf(
+				"%s cannot be combined with %s", cManagedIdentityFlagName, strings.Join([]string{
+					cClientSecretFlagName,
+					cClientCertificateFlagName,
+					cFederatedCredentialProviderFlagName,
+				}, ", "))
+		}
+
+		if _, err := la.authManager.LoginWithManagedIdentity(ctx, la.flags.clientID); err != nil {
+			return fmt.Errorf("logging in: %w", err)
+		}
+
+		return nil
+	}
+
 	if la.flags.clientID != "" {
 		if la.flags.tenantID == "" {
 			return errors.New("must set both `client-id` and `tenant-id` for service principal login")
@@ -448,7 +518,14 @@ func (la *loginAction) login(ctx context.Context) error {
 			}
 		case la.flags.federatedTokenProvider != "":
 			if _, err := la.authManager.LoginWithServicePrincipalFederatedTokenProvider(
-				ctx, la.flags.tenantID, la.flags.clientID, la.flags.federatedTokenProvider,
+				ctx,
+				la.flags.tenantID,
+				la.flags.clientID,
+				la.flags.federatedTokenProvider,
+				&auth.FederatedTokenProviderOptions{
+					TokenEnvVar:  la.flags.federatedTokenEnvVar,
+					TokenCommand: la.flags.federatedTokenCommand,
+				},
 			); err != nil {
 				return fmt.Errorf("logging in: %w", err)
 			}
@@ -9,13 +9,37 @@ import (
 	"io"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+type logoutFlags struct {
+	profile string
+	global  *internal.GlobalCommandOptions
+}
+
+func (lf *logoutFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(
+		&lf.profile,
+		"profile",
+		"",
+		"The named profile to log out of, instead of the current profile.")
+
+	lf.global = global
+}
+
+func newLogoutFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *logoutFlags {
+	flags := &logoutFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
 func newLogoutCmd(parent string) *cobra.Command {
 	return &cobra.Command{
 		Use:   "logout",
@@ -34,6 +58,7 @@ type logoutAction struct {
 	writer            io.Writer
 	console           input.Console
 	annotations       CmdAnnotations
+	flags             *logoutFlags
 }
 
 func newLogoutAction(
@@ -42,7 +67,8 @@ func newLogoutAction(
 	formatter output.Formatter,
 	writer io.Writer,
 	console input.Console,
-	annotations CmdAnnotations) actions.Action {
+	annotations CmdAnnotations,
+	flags *logoutFlags) actions.Action {
 	return &logoutAction{
 		authManager:       authManager,
 		accountSubManager: accountSubManager,
@@ -50,6 +76,7 @@ func newLogoutAction(
 		writer:            writer,
 		console:           console,
 		annotations:       annotations,
+		flags:             flags,
 	}
 }
 
@@ -64,7 +91,12 @@ func (la *logoutAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			"Next time use `azd auth logout`.")
 	}
 
-	err := la.authManager.Logout(ctx)
+	authManager := la.authManager
+	if la.flags.profile != "" {
+		authManager = authManager.WithProfile(la.flags.profile)
+	}
+
+	err := authManager.Logout(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type authSwitchFlags struct {
+	envFlag
+	pin    bool
+	global *internal.GlobalCommandOptions
+}
+
+func (f *authSwitchFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.envFlag.Bind(local, global)
+	local.BoolVar(
+		&f.pin,
+		"pin",
+		false,
+		"Pin the profile to the environment, instead of changing the current profile. "+
+			"Commands run for the environment then use the profile.")
+	f.global = global
+}
+
+func newAuthSwitchFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *authSwitchFlags {
+	flags := &authSwitchFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newAuthSwitchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "switch <profile>",
+		Short: "Switch to another auth profile.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+type authSwitchAction struct {
+	authManager    *auth.Manager
+	lazyEnv        *lazy.Lazy[*environment.Environment]
+	lazyEnvManager *lazy.Lazy[environment.Manager]
+	console        input.Console
+	flags          *authSwitchFlags
+	args           []string
+}
+
+func newAuthSwitchAction(
+	authManager *auth.Manager,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	lazyEnvManager *lazy.Lazy[environment.Manager],
+	console input.Console,
+	flags *authSwitchFlags,
+	args []string,
+) actions.Action {
+	return &authSwitchAction{
+		authManager:    authManager,
+		lazyEnv:        lazyEnv,
+		lazyEnvManager: lazyEnvManager,
+		console:        console,
+		flags:          flags,
+		args:           args,
+	}
+}
+
+func (a *authSwitchAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	profile := a.args[0]
+	if err := auth.ValidateProfileName(profile); err != nil {
+		return nil, err
+	}
+
+	if !a.flags.pin {
+		if err := a.authManager.SwitchProfile(profile); err != nil {
+			return nil, err
+		}
+
+		a.console.Message(ctx, fmt.Sprintf("Switched to auth profile %s.", output.WithHighLightFormat(profile)))
+		return nil, nil
+	}
+
+	// The profile is pinned even when it has no login yet, so the login can follow.
+	env, err := a.lazyEnv.GetValue()
+	if err != nil {
+		return nil, fmt.Errorf("loading environment: %w", err)
+	}
+
+	envManager, err := a.lazyEnvManager.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := env.Config.Set(auth.ProfileConfigPath, profile); err != nil {
+		return nil, fmt.Errorf("setting auth profile: %w", err)
+	}
+
+	if err := envManager.Save(ctx, env); err != nil {
+		return nil, fmt.Errorf("saving environment: %w", err)
+	}
+
+	a.console.Message(ctx, fmt.Sprintf(
+		"Pinned auth profile %s to environment %s.",
+		output.WithHighLightFormat(profile),
+		output.WithHighLightFormat(env.Name())))
+	return nil, nil
+}
@@ -5,16 +5,19 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -25,6 +28,7 @@ import (
 type authTokenFlags struct {
 	tenantID string
 	scopes   []string
+	resource string
 	global   *internal.GlobalCommandOptions
 }
 
@@ -37,14 +41,41 @@ func newAuthTokenFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions
 
 func newAuthTokenCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:    "token --output json",
-		Hidden: true,
+		Use:   "token",
+		Short: "Get an access token.",
+		Long: heredoc.Doc(`
+		Get an access token for the logged in account.
+
+		By default, the token is for Azure Resource Manager. Pass --resource or --scope to get a token for another
+		resource, like Azure Database for PostgreSQL or Azure Storage.
+
+		The token is printed as is, which can be passed to other tools. Pass --output json to also get its expiration.
+		`),
 	}
 }
 
+func getCmdAuthTokenHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Get a token for Azure Database for PostgreSQL, to use as the password of psql.": fmt.Sprintf("%s %s",
+			output.WithHighLightFormat("azd auth token --resource"),
+			output.WithWarningFormat("https://ossrdbms-aad.database.windows.net"),
+		),
+		"Get a token for Azure Storage with its expiration.": fmt.Sprintf("%s %s %s",
+			output.WithHighLightFormat("azd auth token --scope"),
+			output.WithWarningFormat("https://storage.azure.com/.default"),
+			output.WithHighLightFormat("--output json"),
+		),
+	})
+}
+
 func (f *authTokenFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	f.global = global
 	local.StringArrayVar(&f.scopes, "scope", nil, "The scope to use when requesting an access token")
+	local.StringVar(
+		&f.resource,
+		"resource",
+		"",
+		"The URI of the resource to use when requesting an access token, instead of a scope.")
 	local.StringVar(&f.tenantID, "tenant-id", "", "The tenant id to use when requesting an access token.")
 }
 
@@ -56,6 +87,7 @@ type authTokenAction struct {
 	writer             io.Writer
 	envResolver        environment.EnvironmentResolver
 	subResolver        account.SubscriptionTenantResolver
+	cloud              *cloud.Cloud
 	flags              *authTokenFlags
 }
 
@@ -66,11 +98,13 @@ func newAuthTokenAction(
 	flags *authTokenFlags,
 	envResolver environment.EnvironmentResolver,
 	subResolver account.SubscriptionTenantResolver,
+	cloud *cloud.Cloud,
 ) actions.Action {
 	return &authTokenAction{
 		credentialProvider: credentialProvider,
 		envResolver:        envResolver,
 		subResolver:        subResolver,
+		cloud:              cloud,
 		formatter:          formatter,
 		writer:             writer,
 		flags:              flags,
@@ -123,45 +157,74 @@ func getTenantIdFromEnv(
 	return tenantId, nil
 }
 
-func (a *authTokenAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	if len(a.flags.scopes) == 0 {
-		a.flags.scopes = auth.LoginScopes
-	}
-
-	var cred azcore.TokenCredential
-
+// getToken fetches a token for the given scopes. The tenant is the given tenant, or else the tenant of the subscription of
+// the azd environment or of the AZURE_SUBSCRIPTION_ID environment variable, or else the home tenant of the logged in
+// account.
+func getToken(
+	ctx context.Context,
+	credentialProvider CredentialProviderFn,
+	envResolver environment.EnvironmentResolver,
+	subResolver account.SubscriptionTenantResolver,
+	tenantId string,
+	scopes []string,
+) (azcore.AccessToken, error) {
 	// 1) flag --tenant-id is the highest priority. If it is not use, azd will check if subscriptionId is set as env var
-	tenantId := a.flags.tenantID
 	// 2) From azd env
 	if tenantId == "" {
-		tenantIdFromAzdEnv, err := getTenantIdFromAzdEnv(ctx, a.envResolver, a.subResolver)
+		tenantIdFromAzdEnv, err := getTenantIdFromAzdEnv(ctx, envResolver, subResolver)
 		if err != nil {
-			return nil, err
+			return azcore.AccessToken{}, err
 		}
 		tenantId = tenantIdFromAzdEnv
 	}
 	// 3) From system env
 	if tenantId == "" {
-		tenantIdFromSysEnv, err := getTenantIdFromEnv(ctx, a.subResolver)
+		tenantIdFromSysEnv, err := getTenantIdFromEnv(ctx, subResolver)
 		if err != nil {
-			return nil, err
+			return azcore.AccessToken{}, err
 		}
 		tenantId = tenantIdFromSysEnv
 	}
 
 	// If tenantId is still empty, the fallback is to use current logged in user's home-tenant id.
-	cred, err := a.credentialProvider(ctx, &auth.CredentialForCurrentUserOptions{
+	cred, err := credentialProvider(ctx, &auth.CredentialForCurrentUserOptions{
 		TenantID: tenantId,
 	})
 	if err != nil {
-		return nil, err
+		return azcore.AccessToken{}, err
 	}
 
 	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{
-		Scopes: a.flags.scopes,
+		Scopes: scopes,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("fetching token: %w", err)
+		return azcore.AccessToken{}, fmt.Errorf("fetching token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (a *authTokenAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if a.flags.resource != "" {
+		if len(a.flags.scopes) != 0 {
+			return nil, errors.New("only one of --resource or --scope can be set")
+		}
+
+		a.flags.scopes = auth.ResourceScopes(a.flags.resource)
+	}
+
+	if len(a.flags.scopes) == 0 {
+		a.flags.scopes = auth.LoginScopes(a.cloud)
+	}
+
+	token, err := getToken(ctx, a.credentialProvider, a.envResolver, a.subResolver, a.flags.tenantID, a.flags.scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.formatter.Kind() == output.NoneFormat {
+		_, err := fmt.Fprintln(a.writer, token.Token)
+		return nil, err
 	}
 
 	res := contracts.AuthTokenResult{
@@ -18,6 +18,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -49,6 +50,7 @@ func TestAuthToken(t *testing.T) {
 			return nil, fmt.Errorf("not an azd env directory")
 		},
 		&mockSubscriptionTenantResolver{},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -91,6 +93,7 @@ func TestAuthTokenSysEnv(t *testing.T) {
 		&mockSubscriptionTenantResolver{
 			TenantId: expectedTenant,
 		},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -138,6 +141,7 @@ func TestAuthTokenSysEnvError(t *testing.T) {
 		&mockSubscriptionTenantResolver{
 			Err: fmt.Errorf(expectedError),
 		},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -181,6 +185,7 @@ func TestAuthTokenAzdEnvError(t *testing.T) {
 		&mockSubscriptionTenantResolver{
 			Err: fmt.Errorf(expectedError),
 		},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -221,6 +226,7 @@ func TestAuthTokenAzdEnv(t *testing.T) {
 		&mockSubscriptionTenantResolver{
 			TenantId: expectedTenant,
 		},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -261,6 +267,7 @@ func TestAuthTokenAzdEnvWithEmpty(t *testing.T) {
 		&mockSubscriptionTenantResolver{
 			TenantId: expectedTenant,
 		},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -297,6 +304,7 @@ func TestAuthTokenCustomScopes(t *testing.T) {
 			return nil, fmt.Errorf("not an azd env directory")
 		},
 		&mockSubscriptionTenantResolver{},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -318,6 +326,7 @@ func TestAuthTokenFailure(t *testing.T) {
 			return nil, fmt.Errorf("not an azd env directory")
 		},
 		&mockSubscriptionTenantResolver{},
+		cloud.AzurePublic(),
 	)
 
 	_, err := a.Run(context.Background())
@@ -354,3 +363,41 @@ func (m *mockSubscriptionTenantResolver) LookupTenant(
 
 	return m.TenantId, nil
 }
+
+func TestAuthTokenResource(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	token := authTokenFn(func(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+		require.ElementsMatch(t, []string{"https://ossrdbms-aad.database.windows.net/.default"}, options.Scopes)
+
+		return azcore.AccessToken{
+			Token:     "ABC123",
+			ExpiresOn: time.Unix(1669153000, 0).UTC(),
+		}, nil
+	})
+
+	flags := &authTokenFlags{
+		resource: "https://ossrdbms-aad.database.windows.net",
+	}
+
+	a := newAuthTokenAction(
+		credentialProviderForTokenFn(token),
+		&output.NoneFormatter{},
+		buf,
+		flags,
+		func(ctx context.Context) (*environment.Environment, error) {
+			return nil, fmt.Errorf("not an azd env directory")
+		},
+		&mockSubscriptionTenantResolver{},
+		cloud.AzurePublic(),
+	)
+
+	// Without an output format, the token is printed as is.
+	_, err := a.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "ABC123\n", buf.String())
+
+	flags.scopes = []string{"scopeA"}
+	_, err = a.Run(context.Background())
+	require.ErrorContains(t, err, "only one of --resource or --scope can be set")
+}
@@ -195,7 +195,7 @@ func (ba *buildAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		ba.console.MessageUxItem(ctx, buildResult)
 	}
 
-	if ba.formatter.Kind() == output.JsonFormat {
+	if ba.formatter.Kind().IsStructured() {
 		buildResult := BuildResult{
 			Timestamp: time.Now(),
 			Services:  buildResults,
@@ -19,6 +19,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 	"github.com/spf13/cobra"
 )
 
@@ -104,6 +105,14 @@ func (cb *CobraBuilder) configureActionResolver(cmd *cobra.Command, descriptor *
 		ctx := cmd.Context()
 		ctx = tools.WithInstalledCheckCache(ctx)
 
+		// Tools which are missing or out of date are installed into the azd tool cache when possible, and found by the
+		// commands azd runs through the search path of the context.
+		var toolCache *toolcache.Cache
+		if err := cb.container.Resolve(&toolCache); err == nil {
+			ctx = exec.WithSearchPath(ctx, exec.NewSearchPath())
+			ctx = tools.WithToolAcquirer(ctx, toolCache)
+		}
+
 		// Registers the following to enable injection into actions that require them
 		ioc.RegisterInstance(cb.container, cb.runner)
 		ioc.RegisterInstance(cb.container, middleware.MiddlewareContext(cb.runner))
@@ -153,8 +162,14 @@ func (cb *CobraBuilder) configureActionResolver(cmd *cobra.Command, descriptor *
 					FollowUp:       actionResult.Message.FollowUp,
 				}
 			} else if err != nil {
+				errResult := middleware.NewErrorResult(err)
+				if actionResult != nil {
+					errResult.TraceId = actionResult.TraceID
+				}
+
 				displayResult = &ux.ActionResult{
-					Err: err,
+					Err:       err,
+					ErrResult: errResult,
 				}
 			}
 
@@ -194,7 +194,7 @@ func (a *configShowAction) Run(ctx context.Context) (*actions.ActionResult, erro
 
 	values := azdConfig.Raw()
 
-	if a.formatter.Kind() == output.JsonFormat {
+	if a.formatter.Kind().IsStructured() {
 		err := a.formatter.Format(values, a.writer, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failing formatting config values: %w", err)
@@ -268,7 +268,7 @@ func (a *configGetAction) Run(ctx context.Context) (*actions.ActionResult, error
 		return nil, fmt.Errorf("no value stored at path '%s'", key)
 	}
 
-	if a.formatter.Kind() == output.JsonFormat {
+	if a.formatter.Kind().IsStructured() {
 		err := a.formatter.Format(value, a.writer, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failing formatting config values: %w", err)
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -19,6 +21,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azd"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/containerapps"
 	"github.com/azure/azure-dev/cli/azd/pkg/devcenter"
@@ -39,16 +42,22 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/state"
 	"github.com/azure/azure-dev/cli/azd/pkg/templates"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bundler"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/cargo"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/composer"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/docker"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/github"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/golang"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/gradle"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/kubectl"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/maven"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/npm"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/python"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/swa"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
@@ -102,8 +111,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		formatter output.Formatter,
 		cmd *cobra.Command) input.Console {
 		writer := cmd.OutOrStdout()
-		// When using JSON formatting, we want to ensure we always write messages from the console to stderr.
-		if formatter != nil && formatter.Kind() == output.JsonFormat {
+		// When using a structured format like JSON, we want to ensure we always write messages from the console to stderr.
+		// JSON Lines is the exception: the messages are events of the stream written to stdout.
+		if formatter != nil && formatter.Kind().IsStructured() && formatter.Kind() != output.JsonLinesFormat {
 			writer = cmd.ErrOrStderr()
 		}
 
@@ -294,6 +304,51 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		return remoteStateConfig, nil
 	})
 
+	// The Azure cloud azd logs in to and manages resources in
+	container.RegisterSingleton(func(
+		lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+		userConfigManager config.UserConfigManager,
+	) (*cloud.Cloud, error) {
+		var cloudConfig *cloud.Config
+
+		userConfig, err := userConfigManager.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading user config: %w", err)
+		}
+
+		// The project config may not be available yet
+		// Ex) When logging in outside of a project
+		projectConfig, _ := lazyProjectConfig.GetValue()
+
+		// Lookup cloud config in the following precedence:
+		// 1. Project azure.yaml
+		// 2. User configuration
+		if projectConfig != nil && projectConfig.Cloud != nil {
+			// Metadata files are relative to the project. Copy the config so the path isn't saved back to azure.yaml.
+			projectCloudConfig := *projectConfig.Cloud
+			cloudConfig = &projectCloudConfig
+			if cloudConfig.MetadataFile != "" && !filepath.IsAbs(cloudConfig.MetadataFile) {
+				cloudConfig.MetadataFile = filepath.Join(projectConfig.Path, cloudConfig.MetadataFile)
+			}
+		} else {
+			if _, err := userConfig.GetSection("cloud", &cloudConfig); err != nil {
+				return nil, fmt.Errorf("getting cloud config: %w", err)
+			}
+		}
+
+		azureCloud, err := cloud.NewCloud(cloudConfig)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w. Run %s to set or %s to reset",
+				err,
+				output.WithBackticks("azd config set cloud.name <name>"),
+				output.WithBackticks("azd config unset cloud"),
+			)
+		}
+
+		return azureCloud, nil
+	})
+
 	// Lazy loads an existing environment, erroring out if not available
 	// One can repeatedly call GetValue to wait until the environment is available.
 	container.RegisterSingleton(
@@ -367,9 +422,11 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		ctx context.Context,
 		credential azcore.TokenCredential,
 		httpClient httputil.HttpClient,
+		azureCloud *cloud.Cloud,
 	) (*armresourcegraph.Client, error) {
 		options := azsdk.
 			DefaultClientOptionsBuilder(ctx, httpClient, "azd").
+			WithCloud(azureCloud.Configuration).
 			BuildArmClientOptions()
 
 		return armresourcegraph.NewClient(credential, options)
@@ -404,6 +461,45 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.RegisterSingleton(config.NewManager)
 	container.RegisterSingleton(config.NewFileConfigManager)
 	container.RegisterSingleton(auth.NewManager)
+
+	// The auth profile pinned by the selected environment. The config of the environment is read from the local file
+	// directly, since loading the environment may need credentials for the remote state.
+	container.RegisterSingleton(func(fileConfigManager config.FileConfigManager) auth.ProfileResolver {
+		return func(ctx context.Context) (string, error) {
+			azdCtx, err := azdcontext.NewAzdContext()
+			if errors.Is(err, azdcontext.ErrNoProject) {
+				return "", nil
+			} else if err != nil {
+				return "", err
+			}
+
+			environmentName := os.Getenv(environment.EnvNameEnvVarName)
+
+			var cmd *cobra.Command
+			if err := container.Resolve(&cmd); err == nil {
+				if flag := cmd.Flags().Lookup(environmentNameFlag); flag != nil && flag.Value.String() != "" {
+					environmentName = flag.Value.String()
+				}
+			}
+
+			if environmentName == "" {
+				if environmentName, err = azdCtx.GetDefaultEnvironmentName(); err != nil || environmentName == "" {
+					return "", err
+				}
+			}
+
+			envConfig, err := fileConfigManager.Load(
+				filepath.Join(azdCtx.EnvironmentRoot(environmentName), environment.ConfigFileName))
+			if errors.Is(err, os.ErrNotExist) {
+				return "", nil
+			} else if err != nil {
+				return "", fmt.Errorf("loading environment config: %w", err)
+			}
+
+			profile, _ := envConfig.GetString(auth.ProfileConfigPath)
+			return profile, nil
+		}
+	})
 	container.RegisterSingleton(azcli.NewUserProfileService)
 	container.RegisterSingleton(account.NewSubscriptionsService)
 	container.RegisterSingleton(account.NewManager)
@@ -432,24 +528,34 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		rootOptions *internal.GlobalCommandOptions,
 		credentialProvider account.SubscriptionCredentialProvider,
 		httpClient httputil.HttpClient,
+		azureCloud *cloud.Cloud,
 	) azcli.AzCli {
 		return azcli.NewAzCli(credentialProvider, httpClient, azcli.NewAzCliArgs{
 			EnableDebug:     rootOptions.EnableDebugLogging,
 			EnableTelemetry: rootOptions.EnableTelemetry,
+			Cloud:           azureCloud,
 		})
 	})
 	container.RegisterSingleton(azapi.NewDeployments)
 	container.RegisterSingleton(azapi.NewDeploymentOperations)
+	container.RegisterSingleton(azapi.NewDeploymentStacks)
+	container.RegisterSingleton(azapi.NewResourceAvailability)
+	container.RegisterSingleton(bundler.NewBundlerCli)
+	container.RegisterSingleton(cargo.NewCargoCli)
+	container.RegisterSingleton(composer.NewComposerCli)
 	container.RegisterSingleton(docker.NewDocker)
 	container.RegisterSingleton(dotnet.NewDotNetCli)
 	container.RegisterSingleton(git.NewGitCli)
 	container.RegisterSingleton(github.NewGitHubCli)
+	container.RegisterSingleton(golang.NewGoCli)
+	container.RegisterSingleton(gradle.NewGradleCli)
 	container.RegisterSingleton(javac.NewCli)
 	container.RegisterSingleton(kubectl.NewKubectl)
 	container.RegisterSingleton(maven.NewMavenCli)
 	container.RegisterSingleton(npm.NewNpmCli)
 	container.RegisterSingleton(python.NewPythonCli)
 	container.RegisterSingleton(swa.NewSwaCli)
+	container.RegisterSingleton(toolcache.NewCache)
 
 	// Provisioning
 	container.RegisterSingleton(infra.NewAzureResourceManager)
@@ -488,6 +594,11 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		project.ServiceLanguageJavaScript: project.NewNpmProject,
 		project.ServiceLanguageTypeScript: project.NewNpmProject,
 		project.ServiceLanguageJava:       project.NewMavenProject,
+		project.ServiceLanguageGradle:     project.NewGradleProject,
+		project.ServiceLanguageGo:         project.NewGoProject,
+		project.ServiceLanguageRuby:       project.NewRubyProject,
+		project.ServiceLanguagePhp:        project.NewPhpProject,
+		project.ServiceLanguageRust:       project.NewRustProject,
 		project.ServiceLanguageDocker:     project.NewDockerProject,
 	}
 
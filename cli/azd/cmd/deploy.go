@@ -15,6 +15,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -28,10 +30,11 @@ import (
 )
 
 type deployFlags struct {
-	serviceName string
-	all         bool
-	fromPackage string
-	global      *internal.GlobalCommandOptions
+	serviceName  string
+	all          bool
+	fromPackage  string
+	syncSettings bool
+	global       *internal.GlobalCommandOptions
 	*envFlag
 }
 
@@ -71,6 +74,12 @@ func (d *deployFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCo
 		"",
 		"Deploys the application from an existing package.",
 	)
+	local.BoolVar(
+		&d.syncSettings,
+		"sync-settings",
+		false,
+		"Removes the settings applied on a previous deploy which are no longer declared in the env section of the service.",
+	)
 }
 
 func (d *deployFlags) setCommon(envFlag *envFlag) {
@@ -113,6 +122,7 @@ type deployAction struct {
 	packageActionInitializer actions.ActionInitializer[*packageAction]
 	alphaFeatureManager      *alpha.FeatureManager
 	importManager            *project.ImportManager
+	cloud                    *cloud.Cloud
 }
 
 func newDeployAction(
@@ -134,6 +144,7 @@ func newDeployAction(
 	packageActionInitializer actions.ActionInitializer[*packageAction],
 	alphaFeatureManager *alpha.FeatureManager,
 	importManager *project.ImportManager,
+	cloud *cloud.Cloud,
 ) actions.Action {
 	return &deployAction{
 		flags:                    flags,
@@ -154,6 +165,7 @@ func newDeployAction(
 		packageActionInitializer: packageActionInitializer,
 		alphaFeatureManager:      alphaFeatureManager,
 		importManager:            importManager,
+		cloud:                    cloud,
 	}
 }
 
@@ -256,6 +268,11 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 				for packageProgress := range packageTask.Progress() {
 					progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, packageProgress.Message)
 					da.console.ShowSpinner(ctx, progressMessage, input.Step)
+					da.console.Event(ctx, contracts.ServiceProgressEventDataType, contracts.ServiceProgressEvent{
+						Service:   svc.Name,
+						Operation: "package",
+						Message:   packageProgress.Message,
+					})
 				}
 				close(done)
 			}()
@@ -270,12 +287,19 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			}
 		}
 
-		deployTask := da.serviceManager.Deploy(ctx, svc, packageResult)
+		deployTask := da.serviceManager.Deploy(ctx, svc, packageResult, &project.DeployOptions{
+			SyncSettings: da.flags.syncSettings,
+		})
 		done := make(chan struct{})
 		go func() {
 			for deployProgress := range deployTask.Progress() {
 				progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, deployProgress.Message)
 				da.console.ShowSpinner(ctx, progressMessage, input.Step)
+				da.console.Event(ctx, contracts.ServiceProgressEventDataType, contracts.ServiceProgressEvent{
+					Service:   svc.Name,
+					Operation: "deploy",
+					Message:   deployProgress.Message,
+				})
 			}
 			close(done)
 		}()
@@ -294,7 +318,7 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		da.console.MessageUxItem(ctx, deployResult)
 	}
 
-	if da.formatter.Kind() == output.JsonFormat {
+	if da.formatter.Kind().IsStructured() {
 		deployResult := DeploymentResult{
 			Timestamp: time.Now(),
 			Services:  deployResults,
@@ -307,8 +331,9 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
-			Header:   fmt.Sprintf("Your application was deployed to Azure in %s.", ux.DurationAsText(since(startTime))),
-			FollowUp: getResourceGroupFollowUp(ctx, da.formatter, da.projectConfig, da.resourceManager, da.env, false),
+			Header: fmt.Sprintf("Your application was deployed to Azure in %s.", ux.DurationAsText(since(startTime))),
+			FollowUp: getResourceGroupFollowUp(
+				ctx, da.formatter, da.projectConfig, da.resourceManager, da.env, da.cloud, false),
 		},
 	}, nil
 }
@@ -339,5 +364,8 @@ func getCmdDeployHelpFooter(*cobra.Command) string {
 		"Deploy the service named 'api' to Azure from a previously generated package.": output.WithHighLightFormat(
 			"azd deploy api --from-package <package-path>",
 		),
+		"Deploy the service named 'api' and remove settings no longer declared in 'azure.yaml'.": output.WithHighLightFormat(
+			"azd deploy api --sync-settings",
+		),
 	})
 }
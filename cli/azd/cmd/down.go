@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -21,6 +23,7 @@ import (
 type downFlags struct {
 	forceDelete bool
 	purgeDelete bool
+	layer       string
 	global      *internal.GlobalCommandOptions
 	envFlag
 }
@@ -34,6 +37,11 @@ func (i *downFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		//nolint:lll
 		"Does not require confirmation before it permanently deletes resources that are soft-deleted by default (for example, key vaults).",
 	)
+	local.StringVar(
+		&i.layer,
+		"layer",
+		"",
+		"Deletes only the named infrastructure layer of the project (defined in 'infra.layers' in azure.yaml).")
 	i.envFlag.Bind(local, global)
 	i.global = global
 }
@@ -46,14 +54,18 @@ func newDownFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *do
 }
 
 func newDownCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "down",
+	cmd := &cobra.Command{
+		Use:   "down [<service>]",
 		Short: "Delete Azure resources for an application.",
 	}
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	return cmd
 }
 
 type downAction struct {
 	flags            *downFlags
+	args             []string
 	provisionManager *provisioning.Manager
 	importManager    *project.ImportManager
 	env              *environment.Environment
@@ -63,6 +75,7 @@ type downAction struct {
 
 func newDownAction(
 	flags *downFlags,
+	args []string,
 	provisionManager *provisioning.Manager,
 	env *environment.Environment,
 	projectConfig *project.ProjectConfig,
@@ -72,6 +85,7 @@ func newDownAction(
 ) actions.Action {
 	return &downAction{
 		flags:            flags,
+		args:             args,
 		provisionManager: provisionManager,
 		env:              env,
 		console:          console,
@@ -95,13 +109,63 @@ func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	}
 	defer func() { _ = infra.Cleanup() }()
 
-	if err := a.provisionManager.Initialize(ctx, a.projectConfig.Path, infra.Options); err != nil {
-		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+	servicesStable, err := a.importManager.ServiceStable(ctx, a.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	targetServiceName := ""
+	if len(a.args) == 1 {
+		targetServiceName = a.args[0]
+	}
+
+	layers, err := infraLayers(infra.Options, servicesStable, targetServiceName, a.flags.layer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shared layers are used by every environment of the project, so they are only deleted when targeted explicitly.
+	if a.flags.layer == "" {
+		layers = slices.DeleteFunc(layers, func(layer provisioning.Options) bool {
+			if layer.IsShared() {
+				a.console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: fmt.Sprintf(
+						"The infrastructure layer %s is shared with other environments and was not deleted."+
+							" Run 'azd down --layer %s' to delete it.", layer.Name, layer.Name),
+				})
+				return true
+			}
+			return false
+		})
 	}
 
+	// Layers are destroyed in the reverse order they are provisioned in, so the infrastructure owned by services is
+	// removed before the shared infrastructure of the project.
 	destroyOptions := provisioning.NewDestroyOptions(a.flags.forceDelete, a.flags.purgeDelete)
-	if _, err := a.provisionManager.Destroy(ctx, destroyOptions); err != nil {
-		return nil, fmt.Errorf("deleting infrastructure: %w", err)
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		if err := a.provisionManager.Initialize(ctx, a.projectConfig.Path, layer); err != nil {
+			return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+		}
+
+		if layer.Name != "" {
+			a.console.Message(ctx, fmt.Sprintf(
+				"\nInfrastructure layer %s:", output.WithHighLightFormat(layer.Name)))
+		}
+
+		destroyResult, err := a.provisionManager.Destroy(ctx, destroyOptions)
+		if err != nil {
+			return nil, fmt.Errorf("deleting infrastructure: %w", err)
+		}
+
+		if len(destroyResult.SharedResourceGroups) > 0 {
+			a.console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: fmt.Sprintf(
+					"The resource groups %s are shared with other infrastructure and were not deleted, only the "+
+						"resources of this layer were deleted from them.",
+					strings.Join(destroyResult.SharedResourceGroups, ", ")),
+			})
+		}
 	}
 
 	return &actions.ActionResult{
@@ -124,5 +188,9 @@ func getCmdDownHelpFooter(*cobra.Command) string {
 		"Forcibly delete all applications resources without confirmation.": output.WithHighLightFormat("azd down --force"),
 		"Permanently delete resources that are soft-deleted by default," +
 			" without confirmation.": output.WithHighLightFormat("azd down --purge"),
+		"Delete only the infrastructure owned by a service, keeping shared resources.": output.WithHighLightFormat(
+			"azd down <service>"),
+		"Delete only the named infrastructure layer of the project.": output.WithHighLightFormat(
+			"azd down --layer <layer>"),
 	})
 }
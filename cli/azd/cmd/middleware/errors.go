@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+)
+
+// The codes of the errors returned by Azure services when a quota, or the capacity of a SKU in a region, is exceeded.
+var quotaErrorCodes = map[string]bool{
+	"QuotaExceeded":                 true,
+	"InsufficientQuota":             true,
+	"SubscriptionIsOverQuotaForSku": true,
+	"SkuNotAvailable":               true,
+	"OutOfCapacity":                 true,
+	"RegionOutOfCapacity":           true,
+}
+
+// The codes of the errors returned by Azure Resource Manager when a deployment is not valid.
+var validationErrorCodes = map[string]bool{
+	"InvalidTemplate":           true,
+	"InvalidTemplateDeployment": true,
+	"InvalidParameter":          true,
+	"InvalidRequestContent":     true,
+	"DeploymentValidationError": true,
+}
+
+// NewErrorResult maps an error to the error contract, with a stable code and category.
+func NewErrorResult(err error) *contracts.ErrorResult {
+	code, category := mapErrorCode(err)
+	result := &contracts.ErrorResult{
+		Code:     code,
+		Category: category,
+		Message:  strings.TrimSpace(output.WithoutFormat(err.Error())),
+		Details:  errorDetails(err),
+	}
+
+	var suggestionErr *azcli.ErrorWithSuggestion
+	switch {
+	case errors.As(err, &suggestionErr):
+		suggestion := strings.TrimSpace(output.WithoutFormat(suggestionErr.Suggestion))
+		result.Suggestion = strings.TrimSpace(strings.TrimPrefix(suggestion, "Suggested Action:"))
+	case code == contracts.ErrorCodeNotLoggedIn:
+		result.Suggestion = "Run `azd auth login` to log in."
+	case code == contracts.ErrorCodeReLoginRequired:
+		result.Suggestion = "Run `azd auth login` to log in again."
+	}
+
+	return result
+}
+
+// mapErrorCode returns the code and the category of an error.
+func mapErrorCode(err error) (contracts.ErrorCode, contracts.ErrorCategory) {
+	var reLoginErr *auth.ReLoginRequiredError
+	var authFailedErr *auth.AuthFailedError
+	var armDeployErr *azapi.AzureDeploymentError
+	var availabilityErr *azapi.ResourceAvailabilityError
+	var missingToolsErr *tools.MissingToolErrors
+	var toolExecErr *exec.ExitError
+	var respErr *azcore.ResponseError
+
+	switch {
+	case errors.Is(err, terminal.InterruptErr):
+		return contracts.ErrorCodeUserCancelled, contracts.ErrorCategoryUserCancelled
+	case errors.As(err, &reLoginErr):
+		return contracts.ErrorCodeReLoginRequired, contracts.ErrorCategoryAuth
+	case errors.Is(err, auth.ErrNoCurrentUser):
+		return contracts.ErrorCodeNotLoggedIn, contracts.ErrorCategoryAuth
+	case errors.As(err, &authFailedErr):
+		return contracts.ErrorCodeAuthFailed, contracts.ErrorCategoryAuth
+	case errors.As(err, &armDeployErr):
+		if hasErrorCode(armDeployErr.Details, quotaErrorCodes) {
+			return contracts.ErrorCodeQuotaExceeded, contracts.ErrorCategoryQuota
+		} else if hasErrorCode(armDeployErr.Details, validationErrorCodes) {
+			return contracts.ErrorCodeDeploymentValidationFailed, contracts.ErrorCategoryValidation
+		}
+
+		return contracts.ErrorCodeDeploymentFailed, contracts.ErrorCategoryService
+	case errors.As(err, &availabilityErr):
+		for _, violation := range availabilityErr.Violations {
+			if violation.Code == "QuotaExceeded" {
+				return contracts.ErrorCodeQuotaExceeded, contracts.ErrorCategoryQuota
+			}
+		}
+
+		return contracts.ErrorCodeSkuNotAvailable, contracts.ErrorCategoryQuota
+	case errors.As(err, &missingToolsErr):
+		return contracts.ErrorCodeToolMissing, contracts.ErrorCategoryToolMissing
+	case errors.As(err, &toolExecErr):
+		return contracts.ErrorCodeToolFailed, contracts.ErrorCategoryTool
+	case errors.As(err, &respErr):
+		if quotaErrorCodes[respErr.ErrorCode] {
+			return contracts.ErrorCodeQuotaExceeded, contracts.ErrorCategoryQuota
+		}
+
+		return contracts.ErrorCodeServiceRequestFailed, contracts.ErrorCategoryService
+	default:
+		return contracts.ErrorCodeUnknown, contracts.ErrorCategoryUnknown
+	}
+}
+
+// errorDetails returns the inner errors of an error, like the errors of an Azure deployment.
+func errorDetails(err error) []contracts.ErrorDetail {
+	var authFailedErr *auth.AuthFailedError
+	var armDeployErr *azapi.AzureDeploymentError
+	var availabilityErr *azapi.ResourceAvailabilityError
+	var missingToolsErr *tools.MissingToolErrors
+	var toolExecErr *exec.ExitError
+	var respErr *azcore.ResponseError
+
+	switch {
+	case errors.As(err, &authFailedErr) && authFailedErr.Parsed != nil:
+		return []contracts.ErrorDetail{
+			{
+				Code:    authFailedErr.Parsed.Error,
+				Message: authFailedErr.Parsed.ErrorDescription,
+			},
+		}
+	case errors.As(err, &armDeployErr) && armDeployErr.Details != nil:
+		return []contracts.ErrorDetail{deploymentErrorDetail(armDeployErr.Details)}
+	case errors.As(err, &availabilityErr):
+		details := []contracts.ErrorDetail{}
+		for _, violation := range availabilityErr.Violations {
+			message := violation.Message
+			if len(violation.AlternativeLocations) > 0 {
+				message = fmt.Sprintf("%s. Available in: %s", message, strings.Join(violation.AlternativeLocations, ", "))
+			}
+
+			details = append(details, contracts.ErrorDetail{
+				Code:    violation.Code,
+				Message: message,
+			})
+		}
+
+		return details
+	case errors.As(err, &missingToolsErr):
+		details := []contracts.ErrorDetail{}
+		for _, toolErr := range missingToolsErr.Unwrap() {
+			details = append(details, contracts.ErrorDetail{
+				Message: output.WithoutFormat(toolErr.Error()),
+			})
+		}
+
+		return details
+	case errors.As(err, &toolExecErr):
+		return []contracts.ErrorDetail{
+			{
+				Code:    cmdAsName(toolExecErr.Cmd),
+				Message: fmt.Sprintf("exit code: %d", toolExecErr.ExitCode),
+			},
+		}
+	case errors.As(err, &respErr):
+		return []contracts.ErrorDetail{
+			{
+				Code:    respErr.ErrorCode,
+				Message: fmt.Sprintf("%d %s", respErr.StatusCode, http.StatusText(respErr.StatusCode)),
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// deploymentErrorDetail returns the error detail of an error line of an Azure deployment, with its inner errors.
+func deploymentErrorDetail(line *azapi.DeploymentErrorLine) contracts.ErrorDetail {
+	detail := contracts.ErrorDetail{
+		Code:    line.Code,
+		Message: line.Message,
+	}
+
+	for _, inner := range line.Inner {
+		if inner != nil {
+			detail.Details = append(detail.Details, deploymentErrorDetail(inner))
+		}
+	}
+
+	return detail
+}
+
+// hasErrorCode returns true when the code of the error line or of one of its inner errors is one of the given codes.
+func hasErrorCode(line *azapi.DeploymentErrorLine, codes map[string]bool) bool {
+	if line == nil {
+		return false
+	}
+
+	if codes[line.Code] {
+		return true
+	}
+
+	for _, inner := range line.Inner {
+		if hasErrorCode(inner, codes) {
+			return true
+		}
+	}
+
+	return false
+}
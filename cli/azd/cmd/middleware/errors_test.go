@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewErrorResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantCode     contracts.ErrorCode
+		wantCategory contracts.ErrorCategory
+	}{
+		{
+			name:         "WithOtherError",
+			err:          errors.New("something bad happened!"),
+			wantCode:     contracts.ErrorCodeUnknown,
+			wantCategory: contracts.ErrorCategoryUnknown,
+		},
+		{
+			name:         "WithNotLoggedIn",
+			err:          fmt.Errorf("fetching token: %w", auth.ErrNoCurrentUser),
+			wantCode:     contracts.ErrorCodeNotLoggedIn,
+			wantCategory: contracts.ErrorCategoryAuth,
+		},
+		{
+			name:         "WithInterrupt",
+			err:          fmt.Errorf("prompting for location: %w", terminal.InterruptErr),
+			wantCode:     contracts.ErrorCodeUserCancelled,
+			wantCategory: contracts.ErrorCategoryUserCancelled,
+		},
+		{
+			name: "WithDeploymentError",
+			err: &azapi.AzureDeploymentError{
+				Details: &azapi.DeploymentErrorLine{
+					Inner: []*azapi.DeploymentErrorLine{
+						{Code: "ResourceNotFound", Message: "The resource was not found."},
+					},
+				},
+			},
+			wantCode:     contracts.ErrorCodeDeploymentFailed,
+			wantCategory: contracts.ErrorCategoryService,
+		},
+		{
+			name: "WithDeploymentValidationError",
+			err: &azapi.AzureDeploymentError{
+				Details: &azapi.DeploymentErrorLine{
+					Code: "InvalidTemplate",
+				},
+			},
+			wantCode:     contracts.ErrorCodeDeploymentValidationFailed,
+			wantCategory: contracts.ErrorCategoryValidation,
+		},
+		{
+			name: "WithQuotaError",
+			err: &azapi.AzureDeploymentError{
+				Details: &azapi.DeploymentErrorLine{
+					Code: "InvalidTemplateDeployment",
+					Inner: []*azapi.DeploymentErrorLine{
+						{Code: "QuotaExceeded", Message: "Operation results in exceeding quota limits of Core."},
+					},
+				},
+			},
+			wantCode:     contracts.ErrorCodeQuotaExceeded,
+			wantCategory: contracts.ErrorCategoryQuota,
+		},
+		{
+			name: "WithSkuNotAvailable",
+			err: &azapi.ResourceAvailabilityError{
+				Location: "westus",
+				Violations: []azapi.ResourceAvailabilityViolation{
+					{Code: "SkuNotAvailable", ResourceType: "microsoft.web/serverfarms"},
+				},
+			},
+			wantCode:     contracts.ErrorCodeSkuNotAvailable,
+			wantCategory: contracts.ErrorCategoryQuota,
+		},
+		{
+			name: "WithPreflightQuotaExceeded",
+			err: &azapi.ResourceAvailabilityError{
+				Location: "westus",
+				Violations: []azapi.ResourceAvailabilityViolation{
+					{Code: "SkuNotAvailable", ResourceType: "microsoft.web/serverfarms"},
+					{Code: "QuotaExceeded", ResourceType: "Microsoft.Compute/usages"},
+				},
+			},
+			wantCode:     contracts.ErrorCodeQuotaExceeded,
+			wantCategory: contracts.ErrorCategoryQuota,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewErrorResult(tt.err)
+
+			require.Equal(t, tt.wantCode, result.Code)
+			require.Equal(t, tt.wantCategory, result.Category)
+		})
+	}
+
+	t.Run("Details", func(t *testing.T) {
+		result := NewErrorResult(&azcli.ErrorWithSuggestion{
+			Suggestion: "\nSuggested Action: Request access to the service.",
+			Err: fmt.Errorf("deployment failed: %w", &azapi.AzureDeploymentError{
+				Details: &azapi.DeploymentErrorLine{
+					Code: "InvalidTemplateDeployment",
+					Inner: []*azapi.DeploymentErrorLine{
+						{Code: "SkuNotAvailable", Message: "The SKU is not available in the location."},
+					},
+				},
+			}),
+		})
+
+		require.Equal(t, contracts.ErrorCodeQuotaExceeded, result.Code)
+		require.Equal(t, "Request access to the service.", result.Suggestion)
+		require.Equal(t, []contracts.ErrorDetail{
+			{
+				Code: "InvalidTemplateDeployment",
+				Details: []contracts.ErrorDetail{
+					{Code: "SkuNotAvailable", Message: "The SKU is not available in the location."},
+				},
+			},
+		}, result.Details)
+	})
+	t.Run("AvailabilityDetails", func(t *testing.T) {
+		result := NewErrorResult(&azapi.ResourceAvailabilityError{
+			Location: "westus",
+			Violations: []azapi.ResourceAvailabilityViolation{
+				{
+					Code:                 "SkuNotAvailable",
+					ResourceType:         "microsoft.web/serverfarms",
+					ResourceName:         "plan",
+					Message:              "the App Service tier PremiumV3 is not available in westus",
+					AlternativeLocations: []string{"eastus", "westus2"},
+				},
+			},
+		})
+
+		require.Equal(t, []contracts.ErrorDetail{
+			{
+				Code:    "SkuNotAvailable",
+				Message: "the App Service tier PremiumV3 is not available in westus. Available in: eastus, westus2",
+			},
+		}, result.Details)
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EventsMiddleware streams the start and the end of commands as events, with the JSON Lines output format.
+type EventsMiddleware struct {
+	options *Options
+	console input.Console
+}
+
+// Creates a new instance of the events middleware
+func NewEventsMiddleware(options *Options, console input.Console) Middleware {
+	return &EventsMiddleware{
+		options: options,
+		console: console,
+	}
+}
+
+// Invokes the events middleware, which writes the command start and command end events around the action
+func (m *EventsMiddleware) Run(ctx context.Context, next NextFn) (*actions.ActionResult, error) {
+	var traceId string
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		traceId = spanCtx.TraceID().String()
+	}
+
+	m.console.Event(ctx, contracts.CommandStartEventDataType, contracts.CommandStartEvent{
+		Command: m.options.CommandPath,
+		TraceId: traceId,
+	})
+
+	startTime := time.Now()
+	result, err := next(ctx)
+
+	endEvent := contracts.CommandEndEvent{
+		Command:    m.options.CommandPath,
+		TraceId:    traceId,
+		DurationMs: time.Since(startTime).Milliseconds(),
+	}
+	if err != nil {
+		endEvent.Error = err.Error()
+	}
+
+	m.console.Event(ctx, contracts.CommandEndEventDataType, endEvent)
+
+	return result, err
+}
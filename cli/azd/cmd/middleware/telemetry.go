@@ -190,6 +190,11 @@ func mapError(err error, span tracing.Span) {
 		span.SetAttributes(errDetails...)
 	}
 
+	if err != nil {
+		_, category := mapErrorCode(err)
+		span.SetAttributes(fields.ErrCategory.String(string(category)))
+	}
+
 	span.SetStatus(codes.Error, errCode)
 }
 
@@ -103,10 +103,12 @@ func Test_mapError(t *testing.T) {
 			wantErrDetails: nil,
 		},
 		{
-			name:           "WithOtherError",
-			err:            errors.New("something bad happened!"),
-			wantErrReason:  "UnknownError",
-			wantErrDetails: nil,
+			name:          "WithOtherError",
+			err:           errors.New("something bad happened!"),
+			wantErrReason: "UnknownError",
+			wantErrDetails: []attribute.KeyValue{
+				fields.ErrCategory.String("unknown"),
+			},
 		},
 		{
 			name: "WithToolExitError",
@@ -118,6 +120,7 @@ func Test_mapError(t *testing.T) {
 			wantErrDetails: []attribute.KeyValue{
 				fields.ErrorKey(fields.ToolName).String("any"),
 				fields.ErrorKey(fields.ToolExitCode).Int(51),
+				fields.ErrCategory.String("tool"),
 			},
 		},
 		{
@@ -173,6 +176,7 @@ func Test_mapError(t *testing.T) {
 							string(fields.ErrFrame): 2,
 						},
 					})),
+				fields.ErrCategory.String("quota"),
 			},
 		},
 		{
@@ -195,6 +199,7 @@ func Test_mapError(t *testing.T) {
 				fields.ErrorKey(fields.ServiceMethod).String("GET"),
 				fields.ErrorKey(fields.ServiceErrorCode).String("ServiceUnavailable"),
 				fields.ErrorKey(fields.ServiceStatusCode).Int(503),
+				fields.ErrCategory.String("service"),
 			},
 		},
 		{
@@ -216,6 +221,7 @@ func Test_mapError(t *testing.T) {
 				fields.ErrorKey(fields.ServiceErrorCode).String("50076,50078,50079"),
 				fields.ErrorKey(fields.ServiceStatusCode).String("invalid_grant"),
 				fields.ErrorKey(fields.ServiceCorrelationId).String("12345"),
+				fields.ErrCategory.String("auth"),
 			},
 		},
 	}
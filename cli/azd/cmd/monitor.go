@@ -13,6 +13,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
@@ -66,6 +67,7 @@ type monitorAction struct {
 	deploymentOperations azapi.DeploymentOperations
 	console              input.Console
 	flags                *monitorFlags
+	cloud                *cloud.Cloud
 }
 
 func newMonitorAction(
@@ -76,6 +78,7 @@ func newMonitorAction(
 	deploymentOperations azapi.DeploymentOperations,
 	console input.Console,
 	flags *monitorFlags,
+	cloud *cloud.Cloud,
 ) actions.Action {
 	return &monitorAction{
 		azdCtx:               azdCtx,
@@ -85,6 +88,7 @@ func newMonitorAction(
 		console:              console,
 		flags:                flags,
 		subResolver:          subResolver,
+		cloud:                cloud,
 	}
 }
 
@@ -155,7 +159,7 @@ func (m *monitorAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	for _, portalResource := range portalResources {
 		if m.flags.monitorOverview {
 			openWithDefaultBrowser(ctx, m.console,
-				fmt.Sprintf("https://portal.azure.com/#@%s/dashboard/arm%s", tenantId, portalResource.Id),
+				fmt.Sprintf("%s/#@%s/dashboard/arm%s", m.cloud.PortalUrlBase, tenantId, portalResource.Id),
 			)
 		}
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -201,6 +202,11 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			for packageProgress := range packageTask.Progress() {
 				progressMessage := fmt.Sprintf("Packaging service %s (%s)", svc.Name, packageProgress.Message)
 				pa.console.ShowSpinner(ctx, progressMessage, input.Step)
+				pa.console.Event(ctx, contracts.ServiceProgressEventDataType, contracts.ServiceProgressEvent{
+					Service:   svc.Name,
+					Operation: "package",
+					Message:   packageProgress.Message,
+				})
 			}
 			close(done)
 		}()
@@ -222,7 +228,7 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		}
 	}
 
-	if pa.formatter.Kind() == output.JsonFormat {
+	if pa.formatter.Kind().IsStructured() {
 		packageResult := PackageResult{
 			Timestamp: time.Now(),
 			Services:  packageResults,
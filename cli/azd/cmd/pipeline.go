@@ -53,7 +53,7 @@ func (pc *pipelineConfigFlags) Bind(local *pflag.FlagSet, global *internal.Globa
 		&pc.PipelineAuthTypeName,
 		"auth-type",
 		"",
-		"The authentication type used between the pipeline provider and Azure for deployment (Only valid for GitHub provider). Valid values: federated, client-credentials.",
+		"The authentication type used between the pipeline provider and Azure for deployment. Valid values: federated, client-credentials. Azure DevOps defaults to client-credentials.",
 	)
 	//nolint:lll
 	local.StringArrayVar(
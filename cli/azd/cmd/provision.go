@@ -2,15 +2,20 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -22,12 +27,17 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"go.uber.org/multierr"
+	"golang.org/x/exp/maps"
 )
 
 type provisionFlags struct {
 	noProgress            bool
 	preview               bool
+	checkDrift            bool
 	ignoreDeploymentState bool
+	layer                 string
+	parameters            []string
+	parametersFile        string
 	global                *internal.GlobalCommandOptions
 	*envFlag
 }
@@ -36,7 +46,6 @@ const (
 	AINotValid                  = "is not valid according to the validation procedure"
 	openAIsubscriptionNoQuotaId = "The subscription does not have QuotaId/Feature required by SKU 'S0' from kind 'OpenAI'"
 	responsibleAITerms          = "until you agree to Responsible AI terms for this resource"
-	azurePortalURL              = "https://ms.portal.azure.com/"
 )
 
 func (i *provisionFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
@@ -48,16 +57,37 @@ func (i *provisionFlags) bindNonCommon(local *pflag.FlagSet, global *internal.Gl
 	local.BoolVar(&i.noProgress, "no-progress", false, "Suppresses progress information.")
 	//deprecate:Flag hide --no-progress
 	_ = local.MarkHidden("no-progress")
+	local.StringArrayVar(
+		&i.parameters,
+		"parameters",
+		nil,
+		"Sets an infrastructure parameter for this run only, as key=value, or <layer>.key=value for a named layer. "+
+			"Takes precedence over parameter files.")
+	local.StringVar(
+		&i.parametersFile,
+		"parameters-file",
+		"",
+		"Path to a parameters file whose values take precedence over the infrastructure parameter files for this run only.")
 	i.global = global
 }
 
 func (i *provisionFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	local.BoolVar(&i.preview, "preview", false, "Preview changes to Azure resources.")
+	local.BoolVar(
+		&i.checkDrift,
+		"check-drift",
+		false,
+		"Checks whether Azure resources were changed outside of azd, without changing them. Fails when drift is found.")
 	local.BoolVar(
 		&i.ignoreDeploymentState,
 		"no-state",
 		false,
 		"Do not use latest Deployment State (bicep only).")
+	local.StringVar(
+		&i.layer,
+		"layer",
+		"",
+		"Provisions only the named infrastructure layer of the project (defined in 'infra.layers' in azure.yaml).")
 
 	i.envFlag = &envFlag{}
 	i.envFlag.Bind(local, global)
@@ -75,14 +105,18 @@ func newProvisionFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions
 }
 
 func newProvisionCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "provision",
+	cmd := &cobra.Command{
+		Use:   "provision [<service>]",
 		Short: "Provision the Azure resources for an application.",
 	}
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	return cmd
 }
 
 type provisionAction struct {
 	flags            *provisionFlags
+	args             []string
 	provisionManager *provisioning.Manager
 	projectManager   project.ProjectManager
 	resourceManager  project.ResourceManager
@@ -94,10 +128,12 @@ type provisionAction struct {
 	console          input.Console
 	subManager       *account.SubscriptionsManager
 	importManager    *project.ImportManager
+	cloud            *cloud.Cloud
 }
 
 func newProvisionAction(
 	flags *provisionFlags,
+	args []string,
 	provisionManager *provisioning.Manager,
 	projectManager project.ProjectManager,
 	importManager *project.ImportManager,
@@ -109,9 +145,11 @@ func newProvisionAction(
 	formatter output.Formatter,
 	writer io.Writer,
 	subManager *account.SubscriptionsManager,
+	cloud *cloud.Cloud,
 ) actions.Action {
 	return &provisionAction{
 		flags:            flags,
+		args:             args,
 		provisionManager: provisionManager,
 		projectManager:   projectManager,
 		resourceManager:  resourceManager,
@@ -123,6 +161,7 @@ func newProvisionAction(
 		console:          console,
 		subManager:       subManager,
 		importManager:    importManager,
+		cloud:            cloud,
 	}
 }
 
@@ -138,12 +177,19 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 	}
 	previewMode := p.flags.preview
 
+	if previewMode && p.flags.checkDrift {
+		return nil, errors.New("'--preview' and '--check-drift' cannot be used together")
+	}
+
 	// Command title
 	defaultTitle := "Provisioning Azure resources (azd provision)"
 	defaultTitleNote := "Provisioning Azure resources can take some time"
 	if previewMode {
 		defaultTitle = "Previewing Azure resource changes (azd provision --preview)"
 		defaultTitleNote = "This is a preview. No changes will be applied to your Azure resources."
+	} else if p.flags.checkDrift {
+		defaultTitle = "Checking Azure resources for drift (azd provision --check-drift)"
+		defaultTitleNote = "No changes will be applied to your Azure resources."
 	}
 
 	p.console.MessageUxItem(ctx, &ux.MessageTitle{
@@ -194,9 +240,55 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 	}
 
-	infraOptions := infra.Options
-	infraOptions.IgnoreDeploymentState = p.flags.ignoreDeploymentState
-	if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, infraOptions); err != nil {
+	servicesStable, err := p.importManager.ServiceStable(ctx, p.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	targetServiceName := ""
+	if len(p.args) == 1 {
+		targetServiceName = p.args[0]
+	}
+
+	layers, err := infraLayers(infra.Options, servicesStable, targetServiceName, p.flags.layer)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := parseParameters(p.flags.parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	parametersFile := p.flags.parametersFile
+	if parametersFile != "" {
+		if parametersFile, err = filepath.Abs(parametersFile); err != nil {
+			return nil, fmt.Errorf("resolving parameters file path: %w", err)
+		}
+	}
+
+	for i := range layers {
+		layers[i].IgnoreDeploymentState = p.flags.ignoreDeploymentState
+	}
+
+	if err := assignParameterOverrides(layers, parameters, parametersFile); err != nil {
+		return nil, err
+	}
+
+	// A targeted layer or service consumes the outputs of the layers provisioned before it, which are not provisioned
+	// again and are read from their last deployment instead.
+	preceding, err := precedingLayers(infra.Options, servicesStable, targetServiceName, p.flags.layer)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := p.precedingOutputs(ctx, preceding)
+	if err != nil {
+		return nil, err
+	}
+	layers[0].Inputs = maps.Clone(inputs)
+
+	if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layers[0]); err != nil {
 		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
 	}
 
@@ -224,25 +316,97 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		log.Printf("failed getting subscriptions. Skip displaying sub and location: %v", subErr)
 	}
 
-	var deployResult *provisioning.DeployResult
-	var deployPreviewResult *provisioning.DeployPreviewResult
+	if p.flags.checkDrift {
+		return p.checkDrift(ctx, layers, inputs, startTime)
+	}
+
+	// The results of all the layers are merged, so hooks and services observe the outputs of every layer.
+	deployResult := &provisioning.DeployResult{
+		Deployment:    &provisioning.Deployment{Outputs: map[string]provisioning.OutputParameter{}},
+		SkippedReason: provisioning.DeploymentStateSkipped,
+	}
+	deployPreviewResult := &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{Properties: &provisioning.DeploymentPreviewProperties{}},
+	}
 
 	projectEventArgs := project.ProjectLifecycleEventArgs{
 		Project: p.projectConfig,
 	}
 
 	err = p.projectConfig.Invoke(ctx, project.ProjectEventProvision, projectEventArgs, func() error {
-		var err error
-		if previewMode {
-			deployPreviewResult, err = p.provisionManager.Preview(ctx)
-		} else {
-			deployResult, err = p.provisionManager.Deploy(ctx)
+		for i, layer := range layers {
+			if i > 0 {
+				// Outputs of the layers deployed so far are the inputs of the next one.
+				layer.Inputs = maps.Clone(inputs)
+				if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layer); err != nil {
+					return fmt.Errorf("initializing provisioning manager: %w", err)
+				}
+			}
+
+			if layer.Name != "" {
+				p.console.Message(ctx, fmt.Sprintf(
+					"\nInfrastructure layer %s:", output.WithHighLightFormat(layer.Name)))
+			}
+
+			if previewMode {
+				layerResult, err := p.provisionManager.Preview(ctx)
+				if err != nil {
+					return err
+				}
+
+				if layerResult.Preview != nil && layerResult.Preview.Properties != nil {
+					deployPreviewResult.Preview.Properties.Changes = append(
+						deployPreviewResult.Preview.Properties.Changes, layerResult.Preview.Properties.Changes...)
+				}
+
+				if layerResult.Preview != nil {
+					for _, param := range layerResult.Preview.Parameters {
+						if layer.Name != "" {
+							param.Name = fmt.Sprintf("%s.%s", layer.Name, param.Name)
+						}
+						deployPreviewResult.Preview.Parameters = append(deployPreviewResult.Preview.Parameters, param)
+					}
+				}
+
+				// Nothing is deployed by a preview, so the next layer is previewed with the outputs of the last
+				// deployment of this one, when there is one.
+				if i < len(layers)-1 {
+					stateResult, err := p.provisionManager.State(ctx, nil)
+					if err != nil {
+						log.Printf("previewing layer without the outputs of the previous layer: %v", err)
+						continue
+					}
+
+					for key, output := range stateResult.State.Outputs {
+						inputs[key] = output
+					}
+				}
+
+				continue
+			}
+
+			layerResult, err := p.provisionManager.Deploy(ctx)
+			if err != nil {
+				return err
+			}
+
+			if layerResult.SkippedReason != provisioning.DeploymentStateSkipped {
+				deployResult.SkippedReason = layerResult.SkippedReason
+			}
+
+			if layerResult.Deployment != nil {
+				for key, output := range layerResult.Deployment.Outputs {
+					deployResult.Deployment.Outputs[key] = output
+					inputs[key] = output
+				}
+			}
 		}
-		return err
+
+		return nil
 	})
 
 	if err != nil {
-		if p.formatter.Kind() == output.JsonFormat {
+		if p.formatter.Kind().IsStructured() {
 			stateResult, err := p.provisionManager.State(ctx, nil)
 			if err != nil {
 				return nil, fmt.Errorf(
@@ -267,7 +431,7 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 			return nil, &azcli.ErrorWithSuggestion{
 				Suggestion: fmt.Sprintf("\nSuggested Action: The selected " +
 					"subscription has not been enabled for use of Azure AI service and does not have quota for " +
-					"any pricing tiers. Please visit " + output.WithLinkFormat(azurePortalURL) +
+					"any pricing tiers. Please visit " + output.WithLinkFormat(p.cloud.PortalUrlBase+"/") +
 					" and select 'Create' on specific services to request access."),
 				Err: err,
 			}
@@ -277,7 +441,7 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		if strings.Contains(errorMsg, responsibleAITerms) {
 			return nil, &azcli.ErrorWithSuggestion{
 				Suggestion: fmt.Sprintf("\nSuggested Action: Please visit azure portal in " +
-					output.WithLinkFormat(azurePortalURL) + ". Create the resource in azure portal " +
+					output.WithLinkFormat(p.cloud.PortalUrlBase+"/") + ". Create the resource in azure portal " +
 					"to go through Responsible AI terms, and then delete it. " +
 					"After that, run 'azd provision' again"),
 				Err: err,
@@ -295,7 +459,7 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 				Header: fmt.Sprintf(
 					"Generated provisioning preview in %s.", ux.DurationAsText(since(startTime))),
 				FollowUp: getResourceGroupFollowUp(
-					ctx, p.formatter, p.projectConfig, p.resourceManager, p.env, true),
+					ctx, p.formatter, p.projectConfig, p.resourceManager, p.env, p.cloud, true),
 			},
 		}, nil
 	}
@@ -308,11 +472,6 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}, nil
 	}
 
-	servicesStable, err := p.importManager.ServiceStable(ctx, p.projectConfig)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, svc := range servicesStable {
 		eventArgs := project.ServiceLifecycleEventArgs{
 			Project: p.projectConfig,
@@ -327,8 +486,8 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 	}
 
-	if p.formatter.Kind() == output.JsonFormat {
-		stateResult, err := p.provisionManager.State(ctx, nil)
+	if p.formatter.Kind().IsStructured() {
+		state, err := p.layersState(ctx, layers)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"deployment succeeded but the deployment result is unavailable: %w",
@@ -337,7 +496,7 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 
 		if err := p.formatter.Format(
-			provisioning.NewEnvRefreshResultFromState(stateResult.State), p.writer, nil); err != nil {
+			provisioning.NewEnvRefreshResultFromState(state), p.writer, nil); err != nil {
 			return nil, fmt.Errorf(
 				"deployment succeeded but the deployment result could not be displayed: %w",
 				multierr.Combine(err, err),
@@ -350,11 +509,263 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 			Header: fmt.Sprintf(
 				"Your application was provisioned in Azure in %s.", ux.DurationAsText(since(startTime))),
 			FollowUp: getResourceGroupFollowUp(
-				ctx, p.formatter, p.projectConfig, p.resourceManager, p.env, false),
+				ctx, p.formatter, p.projectConfig, p.resourceManager, p.env, p.cloud, false),
 		},
 	}, nil
 }
 
+// checkDrift checks the resources of every layer for changes made outside of azd, records the result in the environment
+// so that `azd show` reports it, and fails with provisioning.ErrDriftDetected when any resource drifted.
+func (p *provisionAction) checkDrift(
+	ctx context.Context,
+	layers []provisioning.Options,
+	inputs map[string]provisioning.OutputParameter,
+	startTime time.Time,
+) (*actions.ActionResult, error) {
+	driftResult := &provisioning.DriftResult{
+		CheckedAt: time.Now(),
+	}
+
+	for i, layer := range layers {
+		if i > 0 {
+			// Outputs of the layers deployed so far are the inputs of the next one.
+			layer.Inputs = maps.Clone(inputs)
+			if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layer); err != nil {
+				return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+			}
+		}
+
+		if layer.Name != "" {
+			p.console.Message(ctx, fmt.Sprintf(
+				"\nInfrastructure layer %s:", output.WithHighLightFormat(layer.Name)))
+		}
+
+		layerResult, err := p.provisionManager.CheckDrift(ctx)
+		if err != nil {
+			return nil, err
+		}
+		driftResult.Resources = append(driftResult.Resources, layerResult.Resources...)
+
+		if i < len(layers)-1 {
+			stateResult, err := p.provisionManager.State(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for key, output := range stateResult.State.Outputs {
+				inputs[key] = output
+			}
+		}
+	}
+
+	if err := p.env.Config.Set(provisioning.DriftConfigPath, driftResult); err != nil {
+		return nil, fmt.Errorf("recording drift check: %w", err)
+	}
+
+	if err := p.envManager.Save(ctx, p.env); err != nil {
+		return nil, fmt.Errorf("saving environment: %w", err)
+	}
+
+	if p.formatter.Kind().IsStructured() {
+		if err := p.formatter.Format(driftResult, p.writer, nil); err != nil {
+			return nil, fmt.Errorf("drift check result could not be displayed: %w", err)
+		}
+	} else {
+		p.console.MessageUxItem(ctx, driftResultToUx(driftResult))
+	}
+
+	if driftResult.HasDrift() {
+		return nil, &azcli.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"%d resource(s) changed outside of azd: %w", len(driftResult.Resources), provisioning.ErrDriftDetected),
+			Suggestion: fmt.Sprintf("\nSuggested Action: Run %s to bring the resources back in line with your "+
+				"infrastructure as code, or update your infrastructure as code to keep the changes.",
+				output.WithHighLightFormat("azd provision")),
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Checked your Azure resources for drift in %s.", ux.DurationAsText(since(startTime))),
+		},
+	}, nil
+}
+
+// layersState returns the state of all the given infrastructure layers, merged together. The provisioning manager is left
+// initialized for the last layer.
+func (p *provisionAction) layersState(
+	ctx context.Context, layers []provisioning.Options) (*provisioning.State, error) {
+	state := &provisioning.State{
+		Outputs: map[string]provisioning.OutputParameter{},
+	}
+
+	for _, layer := range layers {
+		if len(layers) > 1 {
+			if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layer); err != nil {
+				return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+			}
+		}
+
+		stateResult, err := p.provisionManager.State(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, output := range stateResult.State.Outputs {
+			state.Outputs[key] = output
+		}
+		state.Resources = append(state.Resources, stateResult.State.Resources...)
+	}
+
+	return state, nil
+}
+
+// precedingOutputs returns the outputs of the last deployment of the given layers, merged together. It fails when one of the
+// layers has not been provisioned yet.
+func (p *provisionAction) precedingOutputs(
+	ctx context.Context, layers []provisioning.Options) (map[string]provisioning.OutputParameter, error) {
+	outputs := map[string]provisioning.OutputParameter{}
+
+	for _, layer := range layers {
+		if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layer); err != nil {
+			return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+		}
+
+		stateResult, err := p.provisionManager.State(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"reading the outputs of infrastructure layer '%s', which must be provisioned first: %w", layer.Name, err)
+		}
+
+		for key, output := range stateResult.State.Outputs {
+			outputs[key] = output
+		}
+	}
+
+	return outputs, nil
+}
+
+// infraLayers returns the infrastructure layers to provision or destroy, in provisioning order. The infrastructure of the
+// project comes first, either as a single module or as its named layers, followed by the infrastructure owned by each
+// service. When targetServiceName is set, only the infrastructure owned by that service is returned. When targetLayerName
+// is set, only the named layer of the project with that name is returned.
+func infraLayers(
+	projectInfra provisioning.Options,
+	services []*project.ServiceConfig,
+	targetServiceName string,
+	targetLayerName string,
+) ([]provisioning.Options, error) {
+	if targetServiceName != "" && targetLayerName != "" {
+		return nil, errors.New("'--layer' cannot be specified when provisioning a service. Specify either one")
+	}
+
+	if targetServiceName != "" {
+		for _, svc := range services {
+			if svc.Name != targetServiceName {
+				continue
+			}
+
+			if !svc.HasInfra() {
+				return nil, fmt.Errorf(
+					"service '%s' does not define its own infrastructure. Set 'infra.path' for the service in azure.yaml",
+					targetServiceName)
+			}
+
+			return []provisioning.Options{svc.InfraOptions()}, nil
+		}
+
+		return nil, fmt.Errorf("service name '%s' doesn't exist", targetServiceName)
+	}
+
+	layers, err := projectInfraLayers(projectInfra)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetLayerName != "" {
+		for _, layer := range layers {
+			if layer.Name == targetLayerName {
+				return []provisioning.Options{layer}, nil
+			}
+		}
+
+		return nil, fmt.Errorf("infrastructure layer '%s' doesn't exist", targetLayerName)
+	}
+
+	for _, svc := range services {
+		if svc.HasInfra() {
+			layers = append(layers, svc.InfraOptions())
+		}
+	}
+
+	return layers, nil
+}
+
+// precedingLayers returns the infrastructure layers provisioned before the layer or service targeted by targetLayerName or
+// targetServiceName, in provisioning order. There are none when nothing is targeted, as every layer is provisioned.
+func precedingLayers(
+	projectInfra provisioning.Options,
+	services []*project.ServiceConfig,
+	targetServiceName string,
+	targetLayerName string,
+) ([]provisioning.Options, error) {
+	if targetServiceName == "" && targetLayerName == "" {
+		return nil, nil
+	}
+
+	layers, err := infraLayers(projectInfra, services, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	target := targetLayerName
+	if targetServiceName != "" {
+		target = targetServiceName
+	}
+
+	idx := slices.IndexFunc(layers, func(layer provisioning.Options) bool { return layer.Name == target })
+	if idx == -1 {
+		return nil, fmt.Errorf("infrastructure layer '%s' doesn't exist", target)
+	}
+
+	return layers[:idx], nil
+}
+
+// projectInfraLayers returns the layers the infrastructure of the project is made of. Named layers inherit the provider of
+// the infrastructure of the project, unless they set their own.
+func projectInfraLayers(projectInfra provisioning.Options) ([]provisioning.Options, error) {
+	if len(projectInfra.Layers) == 0 {
+		return []provisioning.Options{projectInfra}, nil
+	}
+
+	layers := make([]provisioning.Options, 0, len(projectInfra.Layers))
+	names := map[string]struct{}{}
+	for _, layer := range projectInfra.Layers {
+		if layer.Name == "" {
+			return nil, errors.New("infrastructure layers must have a name")
+		}
+
+		if layer.Path == "" {
+			return nil, fmt.Errorf("infrastructure layer '%s' must have a path", layer.Name)
+		}
+
+		if _, has := names[layer.Name]; has {
+			return nil, fmt.Errorf("infrastructure layer '%s' is defined more than once", layer.Name)
+		}
+		names[layer.Name] = struct{}{}
+
+		if layer.Provider == provisioning.NotSpecified {
+			layer.Provider = projectInfra.Provider
+		}
+		layer.IgnoreDeploymentState = projectInfra.IgnoreDeploymentState
+
+		layers = append(layers, layer)
+	}
+
+	return layers, nil
+}
+
 // deployResultToUx creates the ux element to display from a provision preview
 func deployResultToUx(previewResult *provisioning.DeployPreviewResult) ux.UxItem {
 	var operations []*ux.Resource
@@ -365,9 +776,125 @@ func deployResultToUx(previewResult *provisioning.DeployPreviewResult) ux.UxItem
 			Name:      change.Name,
 		})
 	}
+
+	var parameters []*ux.PreviewParameter
+	for _, param := range previewResult.Preview.Parameters {
+		value := "********"
+		if !param.Secure {
+			value = displayValue(param.Value)
+		}
+
+		parameters = append(parameters, &ux.PreviewParameter{
+			Name:  param.Name,
+			Value: value,
+		})
+	}
+
 	return &ux.PreviewProvision{
 		Operations: operations,
+		Parameters: parameters,
+	}
+}
+
+// parseParameters parses the values of the --parameters flag, which are key=value pairs.
+func parseParameters(values []string) (map[string]string, error) {
+	parameters := map[string]string{}
+	for _, value := range values {
+		key, paramValue, has := strings.Cut(value, "=")
+		if !has || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid parameter '%s', expected key=value", value)
+		}
+
+		parameters[strings.TrimSpace(key)] = paramValue
 	}
+
+	return parameters, nil
+}
+
+// assignParameterOverrides sets the one-off parameter values in the options of the layers they are for. A parameter is
+// for the named layer its key is prefixed with, as <layer>.<name>. Parameters without a layer prefix, and the parameters
+// file, are for the infrastructure of the project, or for the only layer being provisioned.
+func assignParameterOverrides(
+	layers []provisioning.Options,
+	parameters map[string]string,
+	parametersFile string,
+) error {
+	defaultLayer := slices.IndexFunc(layers, func(layer provisioning.Options) bool { return layer.Name == "" })
+	if defaultLayer == -1 && len(layers) == 1 {
+		defaultLayer = 0
+	}
+
+	for key, value := range parameters {
+		idx, name := defaultLayer, key
+		if layerName, paramName, has := strings.Cut(key, "."); has {
+			if layerIdx := slices.IndexFunc(layers, func(layer provisioning.Options) bool {
+				return layer.Name == layerName
+			}); layerIdx != -1 {
+				idx, name = layerIdx, paramName
+			}
+		}
+
+		if idx == -1 {
+			return fmt.Errorf(
+				"parameter '%s' must be prefixed with the name of its layer, as <layer>.%s, when several layers are "+
+					"provisioned", key, key)
+		}
+
+		if layers[idx].Parameters == nil {
+			layers[idx].Parameters = map[string]string{}
+		}
+		layers[idx].Parameters[name] = value
+	}
+
+	if parametersFile != "" {
+		if defaultLayer == -1 {
+			return errors.New(
+				"'--parameters-file' can only be used when a single layer is provisioned. Specify the layer with '--layer'")
+		}
+
+		layers[defaultLayer].ParametersFile = parametersFile
+	}
+
+	return nil
+}
+
+func driftResultToUx(driftResult *provisioning.DriftResult) *ux.DriftReport {
+	report := &ux.DriftReport{}
+	for _, res := range driftResult.Resources {
+		report.Resources = append(report.Resources, driftedResourceToUx(res))
+	}
+
+	return report
+}
+
+func driftedResourceToUx(res *provisioning.DriftedResource) *ux.DriftedResource {
+	drifted := &ux.DriftedResource{
+		Layer:   res.Layer,
+		Type:    res.Type,
+		Name:    res.Name,
+		Deleted: res.ChangeType == provisioning.ChangeTypeCreate,
+	}
+
+	for _, property := range res.Properties {
+		drifted.Properties = append(drifted.Properties, fmt.Sprintf(
+			"%s: expected %s, found %s", property.Path, displayValue(property.Expected), displayValue(property.Actual)))
+	}
+
+	return drifted
+}
+
+// displayValue formats the value of a parameter or of a property for display.
+func displayValue(value any) string {
+	if value == nil {
+		return "<none>"
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(bytes)
 }
 
 func getCmdProvisionHelpDescription(c *cobra.Command) string {
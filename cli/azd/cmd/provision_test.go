@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_infraLayers(t *testing.T) {
+	projectInfra := provisioning.Options{Path: "infra", Module: "main"}
+	services := []*project.ServiceConfig{
+		{Name: "api", RelativePath: "src/api", Infra: provisioning.Options{Path: "infra"}},
+		{Name: "web", RelativePath: "src/web"},
+	}
+
+	t.Run("All", func(t *testing.T) {
+		layers, err := infraLayers(projectInfra, services, "", "")
+		require.NoError(t, err)
+		require.Len(t, layers, 2)
+		require.Equal(t, "", layers[0].Name)
+		require.Equal(t, "api", layers[1].Name)
+	})
+
+	t.Run("Service", func(t *testing.T) {
+		layers, err := infraLayers(projectInfra, services, "api", "")
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+		require.Equal(t, "api", layers[0].Name)
+	})
+
+	t.Run("ServiceWithoutInfra", func(t *testing.T) {
+		_, err := infraLayers(projectInfra, services, "web", "")
+		require.Error(t, err)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		_, err := infraLayers(projectInfra, services, "worker", "")
+		require.Error(t, err)
+	})
+}
+
+func Test_infraLayers_Named(t *testing.T) {
+	projectInfra := provisioning.Options{
+		Provider: provisioning.Bicep,
+		Layers: []provisioning.Options{
+			{Name: "foundation", Path: "infra/foundation", Environment: "shared"},
+			{Name: "app", Path: "infra/app", Provider: provisioning.Terraform},
+		},
+	}
+	services := []*project.ServiceConfig{
+		{Name: "api", RelativePath: "src/api", Infra: provisioning.Options{Path: "infra"}},
+	}
+
+	t.Run("All", func(t *testing.T) {
+		layers, err := infraLayers(projectInfra, services, "", "")
+		require.NoError(t, err)
+		require.Len(t, layers, 3)
+
+		require.Equal(t, "foundation", layers[0].Name)
+		require.Equal(t, provisioning.Bicep, layers[0].Provider)
+		require.True(t, layers[0].IsShared())
+
+		require.Equal(t, "app", layers[1].Name)
+		require.Equal(t, provisioning.Terraform, layers[1].Provider)
+		require.False(t, layers[1].IsShared())
+
+		require.Equal(t, "api", layers[2].Name)
+	})
+
+	t.Run("Layer", func(t *testing.T) {
+		layers, err := infraLayers(projectInfra, services, "", "app")
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+		require.Equal(t, "app", layers[0].Name)
+	})
+
+	t.Run("UnknownLayer", func(t *testing.T) {
+		_, err := infraLayers(projectInfra, services, "", "network")
+		require.Error(t, err)
+	})
+
+	t.Run("LayerAndService", func(t *testing.T) {
+		_, err := infraLayers(projectInfra, services, "api", "app")
+		require.Error(t, err)
+	})
+
+	t.Run("DuplicateLayer", func(t *testing.T) {
+		duplicated := projectInfra
+		duplicated.Layers = append(duplicated.Layers, provisioning.Options{Name: "app", Path: "infra/other"})
+
+		_, err := infraLayers(duplicated, services, "", "")
+		require.Error(t, err)
+	})
+}
+
+func Test_precedingLayers(t *testing.T) {
+	projectInfra := provisioning.Options{
+		Provider: provisioning.Bicep,
+		Layers: []provisioning.Options{
+			{Name: "foundation", Path: "infra/foundation", Environment: "shared"},
+			{Name: "app", Path: "infra/app"},
+		},
+	}
+	services := []*project.ServiceConfig{
+		{Name: "api", RelativePath: "src/api", Infra: provisioning.Options{Path: "infra"}},
+		{Name: "worker", RelativePath: "src/worker", Infra: provisioning.Options{Path: "infra"}},
+	}
+
+	layerNames := func(layers []provisioning.Options) []string {
+		names := []string{}
+		for _, layer := range layers {
+			names = append(names, layer.Name)
+		}
+		return names
+	}
+
+	t.Run("All", func(t *testing.T) {
+		layers, err := precedingLayers(projectInfra, services, "", "")
+		require.NoError(t, err)
+		require.Empty(t, layers)
+	})
+
+	t.Run("Layer", func(t *testing.T) {
+		layers, err := precedingLayers(projectInfra, services, "", "app")
+		require.NoError(t, err)
+		require.Equal(t, []string{"foundation"}, layerNames(layers))
+
+		layers, err = precedingLayers(projectInfra, services, "", "foundation")
+		require.NoError(t, err)
+		require.Empty(t, layers)
+	})
+
+	t.Run("Service", func(t *testing.T) {
+		layers, err := precedingLayers(projectInfra, services, "worker", "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"foundation", "app", "api"}, layerNames(layers))
+	})
+}
+
+func Test_driftResultToUx(t *testing.T) {
+	report := driftResultToUx(&provisioning.DriftResult{
+		Resources: []*provisioning.DriftedResource{
+			{
+				Layer:      "network",
+				Type:       "Web App",
+				Name:       "app-123",
+				ChangeType: provisioning.ChangeTypeModify,
+				Properties: []provisioning.DriftedProperty{
+					{Path: "properties.siteConfig.alwaysOn", Expected: true, Actual: false},
+					{Path: "tags.owner", Actual: "someone"},
+				},
+			},
+			{
+				Type:       "Key Vault",
+				Name:       "kv-123",
+				ChangeType: provisioning.ChangeTypeCreate,
+			},
+		},
+	})
+
+	require.Len(t, report.Resources, 2)
+	require.Equal(t, &ux.DriftedResource{
+		Layer: "network",
+		Type:  "Web App",
+		Name:  "app-123",
+		Properties: []string{
+			"properties.siteConfig.alwaysOn: expected true, found false",
+			`tags.owner: expected <none>, found "someone"`,
+		},
+	}, report.Resources[0])
+	require.True(t, report.Resources[1].Deleted)
+}
+
+func Test_parseParameters(t *testing.T) {
+	parameters, err := parseParameters([]string{"sku=P1v3", "tags={\"team\":\"web\"}", "empty=", "connection=a=b"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"sku":        "P1v3",
+		"tags":       `{"team":"web"}`,
+		"empty":      "",
+		"connection": "a=b",
+	}, parameters)
+
+	_, err = parseParameters([]string{"sku"})
+	require.Error(t, err)
+
+	_, err = parseParameters([]string{"=value"})
+	require.Error(t, err)
+}
+
+func Test_assignParameterOverrides(t *testing.T) {
+	t.Run("ProjectInfrastructure", func(t *testing.T) {
+		layers := []provisioning.Options{{}, {Name: "app"}}
+		err := assignParameterOverrides(layers, map[string]string{"sku": "P1v3", "app.replicas": "2"}, "/overrides.json")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"sku": "P1v3"}, layers[0].Parameters)
+		require.Equal(t, "/overrides.json", layers[0].ParametersFile)
+		require.Equal(t, map[string]string{"replicas": "2"}, layers[1].Parameters)
+		require.Empty(t, layers[1].ParametersFile)
+	})
+
+	t.Run("SingleLayer", func(t *testing.T) {
+		layers := []provisioning.Options{{Name: "app"}}
+		err := assignParameterOverrides(layers, map[string]string{"sku": "P1v3"}, "/overrides.json")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"sku": "P1v3"}, layers[0].Parameters)
+		require.Equal(t, "/overrides.json", layers[0].ParametersFile)
+	})
+
+	t.Run("SeveralLayers", func(t *testing.T) {
+		layers := []provisioning.Options{{Name: "network"}, {Name: "app"}}
+		require.NoError(t, assignParameterOverrides(layers, map[string]string{"network.cidr": "10.0.0.0/16"}, ""))
+		require.Equal(t, map[string]string{"cidr": "10.0.0.0/16"}, layers[0].Parameters)
+		require.Nil(t, layers[1].Parameters)
+
+		require.Error(t, assignParameterOverrides(layers, map[string]string{"sku": "P1v3"}, ""))
+		require.Error(t, assignParameterOverrides(layers, nil, "/overrides.json"))
+	})
+}
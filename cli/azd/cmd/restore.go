@@ -189,7 +189,7 @@ func (ra *restoreAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		restoreResults[svc.Name] = restoreResult
 	}
 
-	if ra.formatter.Kind() == output.JsonFormat {
+	if ra.formatter.Kind().IsStructured() {
 		restoreResult := RestoreResult{
 			Timestamp: time.Now(),
 			Services:  restoreResults,
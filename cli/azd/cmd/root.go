@@ -119,6 +119,7 @@ func NewRootCmd(ctx context.Context, staticHelp bool, middlewareChain []*actions
 	templatesActions(root)
 	authActions(root)
 	hooksActions(root)
+	toolsActions(root)
 
 	root.Add("version", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
@@ -161,6 +162,7 @@ func NewRootCmd(ctx context.Context, staticHelp bool, middlewareChain []*actions
 	logout.Hidden = true
 	root.Add("logout", &actions.ActionDescriptorOptions{
 		Command:        logout,
+		FlagsResolver:  newLogoutFlags,
 		ActionResolver: newLogoutAction,
 	})
 
@@ -209,7 +211,7 @@ func NewRootCmd(ctx context.Context, staticHelp bool, middlewareChain []*actions
 			Command:        newProvisionCmd(),
 			FlagsResolver:  newProvisionFlags,
 			ActionResolver: newProvisionAction,
-			OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+			OutputFormats:  []output.Format{output.JsonFormat, output.JsonLinesFormat, output.NoneFormat},
 			DefaultFormat:  output.NoneFormat,
 			HelpOptions: actions.ActionHelpOptions{
 				Description: getCmdProvisionHelpDescription,
@@ -249,7 +251,7 @@ func NewRootCmd(ctx context.Context, staticHelp bool, middlewareChain []*actions
 			Command:        newDeployCmd(),
 			FlagsResolver:  newDeployFlags,
 			ActionResolver: newDeployAction,
-			OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+			OutputFormats:  []output.Format{output.JsonFormat, output.JsonLinesFormat, output.NoneFormat},
 			DefaultFormat:  output.NoneFormat,
 			HelpOptions: actions.ActionHelpOptions{
 				Description: getCmdDeployHelpDescription,
@@ -266,7 +268,7 @@ func NewRootCmd(ctx context.Context, staticHelp bool, middlewareChain []*actions
 			Command:        newUpCmd(),
 			FlagsResolver:  newUpFlags,
 			ActionResolver: newUpAction,
-			OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+			OutputFormats:  []output.Format{output.JsonFormat, output.JsonLinesFormat, output.NoneFormat},
 			DefaultFormat:  output.NoneFormat,
 			HelpOptions: actions.ActionHelpOptions{
 				Description: getCmdUpHelpDescription,
@@ -320,7 +322,8 @@ func NewRootCmd(ctx context.Context, staticHelp bool, middlewareChain []*actions
 		UseMiddleware("experimentation", middleware.NewExperimentationMiddleware).
 		UseMiddlewareWhen("telemetry", middleware.NewTelemetryMiddleware, func(descriptor *actions.ActionDescriptor) bool {
 			return !descriptor.Options.DisableTelemetry
-		})
+		}).
+		UseMiddleware("events", middleware.NewEventsMiddleware)
 
 	// Register common dependencies for the IoC container
 	ioc.RegisterInstance(ioc.Global, ctx)
@@ -13,10 +13,12 @@ import (
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -65,6 +67,7 @@ type showAction struct {
 	flags                *showFlags
 	lazyServiceManager   *lazy.Lazy[project.ServiceManager]
 	lazyResourceManager  *lazy.Lazy[project.ResourceManager]
+	cloud                *cloud.Cloud
 }
 
 func newShowAction(
@@ -80,6 +83,7 @@ func newShowAction(
 	flags *showFlags,
 	lazyServiceManager *lazy.Lazy[project.ServiceManager],
 	lazyResourceManager *lazy.Lazy[project.ResourceManager],
+	cloud *cloud.Cloud,
 ) actions.Action {
 	return &showAction{
 		projectConfig:        projectConfig,
@@ -94,6 +98,7 @@ func newShowAction(
 		flags:                flags,
 		lazyServiceManager:   lazyServiceManager,
 		lazyResourceManager:  lazyResourceManager,
+		cloud:                cloud,
 	}
 }
 
@@ -146,6 +151,7 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 
 	}
 	var subId, rgName string
+	var driftResult *provisioning.DriftResult
 	if env, err := s.envManager.Get(ctx, environmentName); err != nil {
 		if errors.Is(err, environment.ErrNotFound) && s.flags.environmentName != "" {
 			return nil, fmt.Errorf(
@@ -154,6 +160,9 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 		log.Printf("could not load environment: %s, resource ids will not be available", err)
 	} else {
+		driftResult = lastDriftCheck(env)
+		res.Drift = driftResultToContract(driftResult)
+
 		if subId = env.GetSubscriptionId(); subId == "" {
 			log.Printf("provision has not been run, resource ids will not be available")
 		} else {
@@ -191,7 +200,7 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 	}
 
-	if s.formatter.Kind() == output.JsonFormat {
+	if s.formatter.Kind().IsStructured() {
 		return nil, s.formatter.Format(res, s.writer, nil)
 	}
 
@@ -219,16 +228,67 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		index++
 	}
 
-	s.console.MessageUxItem(ctx, &ux.Show{
+	uxShow := &ux.Show{
 		AppName:         s.azdCtx.GetDefaultProjectName(),
 		Services:        uxServices,
 		Environments:    uxEnvironments,
-		AzurePortalLink: azurePortalLink(subId, rgName),
-	})
+		AzurePortalLink: azurePortalLink(s.cloud, subId, rgName),
+	}
+	if driftResult != nil {
+		uxShow.DriftCheckedAt = driftResult.CheckedAt
+		uxShow.DriftedResources = driftResultToUx(driftResult).Resources
+	}
+
+	s.console.MessageUxItem(ctx, uxShow)
 
 	return nil, nil
 }
 
+// lastDriftCheck returns the result of the last drift check of the environment, recorded by
+// `azd provision --check-drift`, or nil when it was never checked.
+func lastDriftCheck(env *environment.Environment) *provisioning.DriftResult {
+	var driftResult provisioning.DriftResult
+	has, err := env.Config.GetSection(provisioning.DriftConfigPath, &driftResult)
+	if err != nil {
+		log.Printf("ignoring invalid drift check result of environment %s: %v", env.Name(), err)
+		return nil
+	}
+
+	if !has {
+		return nil
+	}
+
+	return &driftResult
+}
+
+func driftResultToContract(driftResult *provisioning.DriftResult) *contracts.ShowDrift {
+	if driftResult == nil {
+		return nil
+	}
+
+	drift := &contracts.ShowDrift{
+		CheckedAt: driftResult.CheckedAt,
+		Resources: []contracts.ShowDriftedResource{},
+	}
+	for _, res := range driftResult.Resources {
+		properties := make([]string, len(res.Properties))
+		for index, property := range res.Properties {
+			properties[index] = property.Path
+		}
+
+		drift.Resources = append(drift.Resources, contracts.ShowDriftedResource{
+			Layer:      res.Layer,
+			Id:         res.Id,
+			Type:       res.Type,
+			Name:       res.Name,
+			Deleted:    res.ChangeType == provisioning.ChangeTypeCreate,
+			Properties: properties,
+		})
+	}
+
+	return drift
+}
+
 func (s *showAction) serviceEndpoint(
 	ctx context.Context, subId string, serviceConfig *project.ServiceConfig, env *environment.Environment) string {
 	resourceManager, err := s.lazyResourceManager.GetValue()
@@ -279,6 +339,14 @@ func showTypeFromLanguage(language project.ServiceLanguageKind) contracts.ShowTy
 		return contracts.ShowTypeNode
 	case project.ServiceLanguageJava:
 		return contracts.ShowTypeJava
+	case project.ServiceLanguageGo:
+		return contracts.ShowTypeGo
+	case project.ServiceLanguageRuby:
+		return contracts.ShowTypeRuby
+	case project.ServiceLanguagePhp:
+		return contracts.ShowTypePhp
+	case project.ServiceLanguageRust:
+		return contracts.ShowTypeRust
 	default:
 		panic(fmt.Sprintf("unknown language %s", language))
 	}
@@ -0,0 +1,231 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
+	"github.com/spf13/cobra"
+)
+
+func toolsActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
+	group := root.Add("tools", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "tools",
+			Short: "Manage the external tools azd installs on your behalf.",
+			Long: "Manage the external tools azd installs on your behalf.\n\n" +
+				"Tools are downloaded when they are missing or out of date.",
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupConfig,
+		},
+	})
+
+	group.Add("list", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Short: "List the tools azd can install and the versions in the tool cache.",
+		},
+		ActionResolver: newToolsListAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+	})
+
+	group.Add("install", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "install [<name>...]",
+			Short: "Install tools into the tool cache. Installs all tools when no name is given.",
+		},
+		ActionResolver: newToolsInstallAction,
+	})
+
+	group.Add("prune", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Short: "Remove versions of tools that azd no longer uses from the tool cache.",
+		},
+		ActionResolver: newToolsPruneAction,
+	})
+
+	return group
+}
+
+// toolListItem is a row in the output of `azd tools list`.
+type toolListItem struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Current   bool   `json:"current"`
+	Installed bool   `json:"installed"`
+	Path      string `json:"path,omitempty"`
+}
+
+type toolsListAction struct {
+	toolCache *toolcache.Cache
+	formatter output.Formatter
+	writer    io.Writer
+}
+
+func newToolsListAction(toolCache *toolcache.Cache, formatter output.Formatter, writer io.Writer) actions.Action {
+	return &toolsListAction{
+		toolCache: toolCache,
+		formatter: formatter,
+		writer:    writer,
+	}
+}
+
+func (a *toolsListAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	installed, err := a.toolCache.List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []toolListItem{}
+	for _, def := range toolcache.Catalog {
+		item := toolListItem{
+			Name:    def.Name,
+			Version: def.Version.String(),
+			Current: true,
+		}
+
+		for _, tool := range installed {
+			if tool.Name == def.Name && tool.Current {
+				item.Installed = true
+				item.Path = tool.Path
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	for _, tool := range installed {
+		if !tool.Current {
+			items = append(items, toolListItem{
+				Name:      tool.Name,
+				Version:   tool.Version,
+				Installed: true,
+				Path:      tool.Path,
+			})
+		}
+	}
+
+	if a.formatter.Kind() == output.TableFormat {
+		columns := []output.Column{
+			{
+				Heading:       "NAME",
+				ValueTemplate: "{{.Name}}",
+			},
+			{
+				Heading:       "VERSION",
+				ValueTemplate: "{{.Version}}",
+			},
+			{
+				Heading:       "CURRENT",
+				ValueTemplate: "{{.Current}}",
+			},
+			{
+				Heading:       "INSTALLED",
+				ValueTemplate: "{{.Installed}}",
+			},
+		}
+
+		err = a.formatter.Format(items, a.writer, output.TableFormatterOptions{
+			Columns: columns,
+		})
+	} else {
+		err = a.formatter.Format(items, a.writer, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+type toolsInstallAction struct {
+	toolCache *toolcache.Cache
+	console   input.Console
+	args      []string
+}
+
+func newToolsInstallAction(toolCache *toolcache.Cache, console input.Console, args []string) actions.Action {
+	return &toolsInstallAction{
+		toolCache: toolCache,
+		console:   console,
+		args:      args,
+	}
+}
+
+func (a *toolsInstallAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	defs := toolcache.Catalog
+	if len(a.args) > 0 {
+		defs = make([]toolcache.Definition, 0, len(a.args))
+		for _, name := range a.args {
+			def, ok := toolcache.Lookup(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown tool '%s', supported tools are: %s", name, catalogNames())
+			}
+
+			defs = append(defs, def)
+		}
+	}
+
+	for _, def := range defs {
+		title := fmt.Sprintf("Installing %s %s", def.Name, def.Version)
+		a.console.ShowSpinner(ctx, title, input.Step)
+		if _, err := a.toolCache.Install(ctx, def); err != nil {
+			a.console.StopSpinner(ctx, title, input.StepFailed)
+			return nil, err
+		}
+		a.console.StopSpinner(ctx, title, input.StepDone)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "Tools installed",
+		},
+	}, nil
+}
+
+func catalogNames() string {
+	names := make([]string, 0, len(toolcache.Catalog))
+	for _, def := range toolcache.Catalog {
+		names = append(names, def.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+type toolsPruneAction struct {
+	toolCache *toolcache.Cache
+	console   input.Console
+}
+
+func newToolsPruneAction(toolCache *toolcache.Cache, console input.Console) actions.Action {
+	return &toolsPruneAction{
+		toolCache: toolCache,
+		console:   console,
+	}
+}
+
+func (a *toolsPruneAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	pruned, err := a.toolCache.Prune()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tool := range pruned {
+		a.console.Message(ctx, fmt.Sprintf("Removed %s %s", tool.Name, tool.Version))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Removed %d tool version(s) from the tool cache", len(pruned)),
+		},
+	}, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	azdExec "github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -51,9 +52,10 @@ func getResourceGroupFollowUp(
 	projectConfig *project.ProjectConfig,
 	resourceManager project.ResourceManager,
 	env *environment.Environment,
+	cloud *cloud.Cloud,
 	whatIf bool,
 ) (followUp string) {
-	if formatter.Kind() == output.JsonFormat {
+	if formatter.Kind().IsStructured() {
 		return followUp
 	}
 
@@ -67,18 +69,19 @@ func getResourceGroupFollowUp(
 		}
 		followUp = fmt.Sprintf("%s\n%s",
 			defaultFollowUpText,
-			azurePortalLink(subscriptionId, resourceGroupName))
+			azurePortalLink(cloud, subscriptionId, resourceGroupName))
 	}
 
 	return followUp
 }
 
-func azurePortalLink(subscriptionId, resourceGroupName string) string {
+func azurePortalLink(cloud *cloud.Cloud, subscriptionId, resourceGroupName string) string {
 	if subscriptionId == "" || resourceGroupName == "" {
 		return ""
 	}
 	return output.WithLinkFormat(fmt.Sprintf(
-		"https://portal.azure.com/#@/resource/subscriptions/%s/resourceGroups/%s/overview",
+		"%s/#@/resource/subscriptions/%s/resourceGroups/%s/overview",
+		cloud.PortalUrlBase,
 		subscriptionId,
 		resourceGroupName))
 }
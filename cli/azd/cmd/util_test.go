@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -46,6 +47,7 @@ func Test_getResourceGroupFollowUp(t *testing.T) {
 		&project.ProjectConfig{},
 		project.NewResourceManager(env, azCli, depOpService),
 		env,
+		cloud.AzurePublic(),
 		false)
 
 	require.Contains(t, followUp, "You can view the resources created under the resource group Name in Azure Portal:")
@@ -81,6 +83,7 @@ func Test_getResourceGroupFollowUpPreview(t *testing.T) {
 		&project.ProjectConfig{},
 		project.NewResourceManager(env, azCli, depOpService),
 		env,
+		cloud.AzurePublic(),
 		true)
 
 	require.Contains(t, followUp, "You can view the current resources under the resource group Name in Azure Portal:")
@@ -26,6 +26,10 @@ const (
 	JavaScript    Language = "js"
 	TypeScript    Language = "ts"
 	Python        Language = "python"
+	Go            Language = "go"
+	Ruby          Language = "ruby"
+	Php           Language = "php"
+	Rust          Language = "rust"
 )
 
 func (pt Language) Display() string {
@@ -42,6 +46,14 @@ func (pt Language) Display() string {
 		return "TypeScript"
 	case Python:
 		return "Python"
+	case Go:
+		return "Go"
+	case Ruby:
+		return "Ruby"
+	case Php:
+		return "PHP"
+	case Rust:
+		return "Rust"
 	}
 
 	return ""
@@ -58,6 +70,20 @@ const (
 	PyFlask   Dependency = "flask"
 	PyDjango  Dependency = "django"
 	PyFastApi Dependency = "fastapi"
+
+	GoGin   Dependency = "gin"
+	GoEcho  Dependency = "echo"
+	GoFiber Dependency = "fiber"
+
+	RbRails   Dependency = "rails"
+	RbSinatra Dependency = "sinatra"
+
+	PhpLaravel Dependency = "laravel"
+	PhpSymfony Dependency = "symfony"
+
+	RsAxum     Dependency = "axum"
+	RsActixWeb Dependency = "actix-web"
+	RsRocket   Dependency = "rocket"
 )
 
 var WebUIFrameworks = map[Dependency]struct{}{
@@ -86,6 +112,26 @@ func (f Dependency) Display() string {
 		return "Vue.js"
 	case JsJQuery:
 		return "JQuery"
+	case GoGin:
+		return "Gin"
+	case GoEcho:
+		return "Echo"
+	case GoFiber:
+		return "Fiber"
+	case RbRails:
+		return "Ruby on Rails"
+	case RbSinatra:
+		return "Sinatra"
+	case PhpLaravel:
+		return "Laravel"
+	case PhpSymfony:
+		return "Symfony"
+	case RsAxum:
+		return "axum"
+	case RsActixWeb:
+		return "Actix Web"
+	case RsRocket:
+		return "Rocket"
 	}
 
 	return ""
@@ -146,6 +192,9 @@ type Project struct {
 
 	// If true, the project uses Docker for packaging. This is inferred through the presence of a Dockerfile.
 	Docker *Docker
+
+	// The workspace the project is a member of, if any. Members of a workspace are built from the root of the workspace.
+	Workspace *Workspace
 }
 
 func (p *Project) HasWebUIFramework() bool {
@@ -179,6 +228,11 @@ var allDetectors = []projectDetector{
 		dotnetCli: dotnet.NewDotNetCli(exec.NewCommandRunner(nil)),
 	},
 	&pythonDetector{},
+	&goDetector{},
+	// Rails and Laravel apps usually have a package.json for their assets, so Ruby and PHP come before JavaScript.
+	&rubyDetector{},
+	&phpDetector{},
+	&rustDetector{},
 	&javaScriptDetector{},
 }
 
@@ -201,6 +255,8 @@ func DetectDirectory(ctx context.Context, directory string, options ...DetectDir
 
 func detectUnder(ctx context.Context, root string, config detectConfig) ([]Project, error) {
 	projects := []Project{}
+	// the workspaces of the member directories of the workspaces found, which are detected as the members are walked
+	members := map[string]*Workspace{}
 
 	walkFunc := func(path string, entries []fs.DirEntry) error {
 		relativePath, err := filepath.Rel(root, path)
@@ -221,11 +277,52 @@ func detectUnder(ctx context.Context, root string, config detectConfig) ([]Proje
 			}
 		}
 
+		workspace, isMember := members[path]
+		if !isMember {
+			definition, err := readWorkspace(path, entries)
+			if err != nil {
+				return fmt.Errorf("reading workspace in %s: %w", path, err)
+			}
+
+			if definition != nil {
+				// The root of a workspace isn't a project itself. Its members are detected when they're walked. Members
+				// keep the outermost workspace they belong to, like the root of a Maven project with nested modules.
+				log.Printf("Found %s workspace at %s with %d members", definition.tool, path, len(definition.members))
+				for dir, member := range definition.members {
+					if _, has := members[dir]; has {
+						continue
+					}
+
+					members[dir] = &Workspace{
+						Tool:   definition.tool,
+						Path:   path,
+						Member: member,
+					}
+				}
+
+				return nil
+			}
+		}
+
 		project, err := detectAny(ctx, config.detectors, path, entries)
 		if err != nil {
 			return err
 		}
 
+		if project != nil && isMember {
+			isApp, err := isWorkspaceApp(project)
+			if err != nil {
+				return fmt.Errorf("detecting workspace member %s: %w", path, err)
+			}
+
+			if !isApp {
+				log.Printf("Skipping library %s of %s workspace at %s", path, workspace.Tool, workspace.Path)
+				return filepath.SkipDir
+			}
+
+			project.Workspace = workspace
+		}
+
 		if project != nil {
 			// Once a project is detected, we skip possible inner projects.
 			projects = append(projects, *project)
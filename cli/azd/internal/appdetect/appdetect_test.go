@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
@@ -36,6 +37,28 @@ func TestDetect(t *testing.T) {
 					Path:          "dotnet",
 					DetectionRule: "Inferred by presence of: dotnettestapp.csproj, Program.cs",
 				},
+				{
+					Language:      Go,
+					Path:          "go",
+					DetectionRule: "Inferred by presence of: go.mod",
+				},
+				{
+					Language:      Go,
+					Path:          "go-full",
+					DetectionRule: "Inferred by presence of: go.mod",
+					Dependencies: []Dependency{
+						GoEcho,
+						GoFiber,
+						GoGin,
+					},
+					DatabaseDeps: []DatabaseDep{
+						DbMongo,
+						DbMySql,
+						DbPostgres,
+						DbRedis,
+						DbSqlServer,
+					},
+				},
 				{
 					Language:      Java,
 					Path:          "java",
@@ -64,6 +87,18 @@ func TestDetect(t *testing.T) {
 						DbSqlServer,
 					},
 				},
+				{
+					Language:      Php,
+					Path:          "php",
+					DetectionRule: "Inferred by presence of: composer.json",
+					Dependencies: []Dependency{
+						PhpLaravel,
+					},
+					DatabaseDeps: []DatabaseDep{
+						DbMySql,
+						DbRedis,
+					},
+				},
 				{
 					Language:      Python,
 					Path:          "python",
@@ -85,6 +120,30 @@ func TestDetect(t *testing.T) {
 						DbRedis,
 					},
 				},
+				{
+					Language:      Ruby,
+					Path:          "ruby",
+					DetectionRule: "Inferred by presence of: Gemfile",
+					Dependencies: []Dependency{
+						RbRails,
+					},
+					DatabaseDeps: []DatabaseDep{
+						DbPostgres,
+						DbRedis,
+					},
+				},
+				{
+					Language:      Rust,
+					Path:          "rust",
+					DetectionRule: "Inferred by presence of: Cargo.toml",
+					Dependencies: []Dependency{
+						RsAxum,
+					},
+					DatabaseDeps: []DatabaseDep{
+						DbMongo,
+						DbPostgres,
+					},
+				},
 				{
 					Language:      TypeScript,
 					Path:          "typescript",
@@ -118,6 +177,10 @@ func TestDetect(t *testing.T) {
 			[]DetectOption{
 				WithoutJavaScript(),
 				WithoutPython(),
+				WithoutGo(),
+				WithoutRuby(),
+				WithoutPhp(),
+				WithoutRust(),
 			},
 			[]Project{
 				{
@@ -139,6 +202,10 @@ func TestDetect(t *testing.T) {
 					"**/*-full",
 					"**/javascript",
 					"typescript",
+					"go",
+					"php",
+					"ruby",
+					"rust",
 				}, false),
 			},
 			[]Project{
@@ -242,6 +309,11 @@ func copyTestDataDir(t *testing.T, glob string, dst string) error {
 
 		targetPath := filepath.Join(dst, rel)
 
+		// go.mod files can't be embedded, since their directory is a different module, and are stored as go.mod.txt
+		if d.Name() == "go.mod.txt" {
+			targetPath = strings.TrimSuffix(targetPath, ".txt")
+		}
+
 		if d.IsDir() {
 			return os.MkdirAll(targetPath, osutil.PermissionDirectory)
 		}
@@ -252,3 +252,139 @@ func (o *excludeJavaScript) applyLang(c languageConfig) languageConfig {
 func WithoutJavaScript() LanguageOption {
 	return &excludeJavaScript{}
 }
+
+type includeGo struct {
+}
+
+func (o *includeGo) apply(c detectConfig) detectConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Go)
+	return c
+}
+
+func (o *includeGo) applyLang(c languageConfig) languageConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Go)
+	return c
+}
+
+func WithGo() LanguageOption {
+	return &includeGo{}
+}
+
+type excludeGo struct {
+}
+
+func (o *excludeGo) apply(c detectConfig) detectConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Go)
+	return c
+}
+
+func (o *excludeGo) applyLang(c languageConfig) languageConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Go)
+	return c
+}
+
+func WithoutGo() LanguageOption {
+	return &excludeGo{}
+}
+
+type includeRuby struct {
+}
+
+func (o *includeRuby) apply(c detectConfig) detectConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Ruby)
+	return c
+}
+
+func (o *includeRuby) applyLang(c languageConfig) languageConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Ruby)
+	return c
+}
+
+func WithRuby() LanguageOption {
+	return &includeRuby{}
+}
+
+type excludeRuby struct {
+}
+
+func (o *excludeRuby) apply(c detectConfig) detectConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Ruby)
+	return c
+}
+
+func (o *excludeRuby) applyLang(c languageConfig) languageConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Ruby)
+	return c
+}
+
+func WithoutRuby() LanguageOption {
+	return &excludeRuby{}
+}
+
+type includePhp struct {
+}
+
+func (o *includePhp) apply(c detectConfig) detectConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Php)
+	return c
+}
+
+func (o *includePhp) applyLang(c languageConfig) languageConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Php)
+	return c
+}
+
+func WithPhp() LanguageOption {
+	return &includePhp{}
+}
+
+type excludePhp struct {
+}
+
+func (o *excludePhp) apply(c detectConfig) detectConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Php)
+	return c
+}
+
+func (o *excludePhp) applyLang(c languageConfig) languageConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Php)
+	return c
+}
+
+func WithoutPhp() LanguageOption {
+	return &excludePhp{}
+}
+
+type includeRust struct {
+}
+
+func (o *includeRust) apply(c detectConfig) detectConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Rust)
+	return c
+}
+
+func (o *includeRust) applyLang(c languageConfig) languageConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Rust)
+	return c
+}
+
+func WithRust() LanguageOption {
+	return &includeRust{}
+}
+
+type excludeRust struct {
+}
+
+func (o *excludeRust) apply(c detectConfig) detectConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Rust)
+	return c
+}
+
+func (o *excludeRust) applyLang(c languageConfig) languageConfig {
+	c.ExcludeLanguages = append(c.ExcludeLanguages, Rust)
+	return c
+}
+
+func WithoutRust() LanguageOption {
+	return &excludeRust{}
+}
@@ -0,0 +1,138 @@
+package appdetect
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type goDetector struct {
+}
+
+func (gd *goDetector) Language() Language {
+	return Go
+}
+
+func (gd *goDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
+	for _, entry := range entries {
+		if entry.Name() == "go.mod" {
+			project := &Project{
+				Language:      Go,
+				Path:          path,
+				DetectionRule: "Inferred by presence of: " + entry.Name(),
+			}
+
+			file, err := os.Open(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			modules, err := goRequiredModules(bufio.NewScanner(file))
+			if err != nil {
+				file.Close()
+				return nil, err
+			}
+
+			if err := file.Close(); err != nil {
+				return nil, err
+			}
+
+			dependencyMap := map[Dependency]struct{}{}
+			databaseDepMap := map[DatabaseDep]struct{}{}
+
+			for _, module := range modules {
+				switch {
+				case isGoModule(module, "github.com/gin-gonic/gin"):
+					dependencyMap[GoGin] = struct{}{}
+				case isGoModule(module, "github.com/labstack/echo"):
+					dependencyMap[GoEcho] = struct{}{}
+				case isGoModule(module, "github.com/gofiber/fiber"):
+					dependencyMap[GoFiber] = struct{}{}
+				}
+
+				switch {
+				case isGoModule(module, "github.com/go-sql-driver/mysql"):
+					databaseDepMap[DbMySql] = struct{}{}
+				case isGoModule(module, "github.com/lib/pq"),
+					isGoModule(module, "github.com/jackc/pgx"):
+					databaseDepMap[DbPostgres] = struct{}{}
+				case isGoModule(module, "go.mongodb.org/mongo-driver"):
+					databaseDepMap[DbMongo] = struct{}{}
+				case isGoModule(module, "github.com/microsoft/go-mssqldb"),
+					isGoModule(module, "github.com/denisenkom/go-mssqldb"):
+					databaseDepMap[DbSqlServer] = struct{}{}
+				case isGoModule(module, "github.com/redis/go-redis"),
+					isGoModule(module, "github.com/go-redis/redis"):
+					databaseDepMap[DbRedis] = struct{}{}
+				}
+			}
+
+			if len(dependencyMap) > 0 {
+				project.Dependencies = maps.Keys(dependencyMap)
+				slices.SortFunc(project.Dependencies, func(a, b Dependency) bool {
+					return string(a) < string(b)
+				})
+			}
+
+			if len(databaseDepMap) > 0 {
+				project.DatabaseDeps = maps.Keys(databaseDepMap)
+				slices.SortFunc(project.DatabaseDeps, func(a, b DatabaseDep) bool {
+					return string(a) < string(b)
+				})
+			}
+
+			return project, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// goRequiredModules returns the paths of the modules required by a go.mod file, in single line require directives and in
+// require blocks.
+func goRequiredModules(scanner *bufio.Scanner) ([]string, error) {
+	var modules []string
+	inRequireBlock := false
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if inRequireBlock {
+			if fields[0] == ")" {
+				inRequireBlock = false
+			} else if len(fields) == 2 {
+				modules = append(modules, fields[0])
+			}
+
+			continue
+		}
+
+		if fields[0] != "require" {
+			continue
+		}
+
+		switch {
+		case len(fields) == 2 && fields[1] == "(":
+			inRequireBlock = true
+		case len(fields) == 3:
+			modules = append(modules, fields[1])
+		}
+	}
+
+	return modules, scanner.Err()
+}
+
+// isGoModule returns true if module is the module at path, or one of its major versions like path/v2.
+func isGoModule(module string, path string) bool {
+	return module == path || strings.HasPrefix(module, path+"/v")
+}
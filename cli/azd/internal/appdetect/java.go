@@ -14,14 +14,28 @@ func (jd *javaDetector) Language() Language {
 }
 
 func (jd *javaDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
+	gradleBuild := ""
 	for _, entry := range entries {
-		if strings.ToLower(entry.Name()) == "pom.xml" {
+		name := strings.ToLower(entry.Name())
+		if name == "pom.xml" {
 			return &Project{
 				Language:      Java,
 				Path:          path,
 				DetectionRule: "Inferred by presence of: " + entry.Name(),
 			}, nil
 		}
+
+		if name == "build.gradle" || name == "build.gradle.kts" {
+			gradleBuild = entry.Name()
+		}
+	}
+
+	if gradleBuild != "" {
+		return &Project{
+			Language:      Java,
+			Path:          path,
+			DetectionRule: "Inferred by presence of: " + gradleBuild,
+		}, nil
 	}
 
 	return nil, nil
@@ -13,7 +13,10 @@ import (
 )
 
 type PackagesJson struct {
-	Dependencies map[string]string `json:"dependencies"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	Scripts              map[string]string `json:"scripts"`
 }
 
 type javaScriptDetector struct {
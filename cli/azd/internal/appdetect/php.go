@@ -0,0 +1,86 @@
+package appdetect
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type ComposerJson struct {
+	Require map[string]string `json:"require"`
+}
+
+type phpDetector struct {
+}
+
+func (pd *phpDetector) Language() Language {
+	return Php
+}
+
+func (pd *phpDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
+	for _, entry := range entries {
+		if entry.Name() == "composer.json" {
+			project := &Project{
+				Language:      Php,
+				Path:          path,
+				DetectionRule: "Inferred by presence of: " + entry.Name(),
+			}
+
+			contents, err := os.ReadFile(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			var composerJson ComposerJson
+			err = json.Unmarshal(contents, &composerJson)
+			if err != nil {
+				return nil, err
+			}
+
+			databaseDepMap := map[DatabaseDep]struct{}{}
+			for dep := range composerJson.Require {
+				switch dep {
+				case "laravel/framework":
+					project.Dependencies = append(project.Dependencies, PhpLaravel)
+				case "symfony/framework-bundle", "symfony/symfony":
+					if !slices.Contains(project.Dependencies, PhpSymfony) {
+						project.Dependencies = append(project.Dependencies, PhpSymfony)
+					}
+				}
+
+				switch dep {
+				case "ext-mysqli", "ext-pdo_mysql":
+					databaseDepMap[DbMySql] = struct{}{}
+				case "ext-pgsql", "ext-pdo_pgsql":
+					databaseDepMap[DbPostgres] = struct{}{}
+				case "ext-mongodb", "mongodb/mongodb":
+					databaseDepMap[DbMongo] = struct{}{}
+				case "ext-sqlsrv", "ext-pdo_sqlsrv":
+					databaseDepMap[DbSqlServer] = struct{}{}
+				case "ext-redis", "predis/predis":
+					databaseDepMap[DbRedis] = struct{}{}
+				}
+			}
+
+			if len(databaseDepMap) > 0 {
+				project.DatabaseDeps = maps.Keys(databaseDepMap)
+				slices.SortFunc(project.DatabaseDeps, func(a, b DatabaseDep) bool {
+					return string(a) < string(b)
+				})
+			}
+
+			slices.SortFunc(project.Dependencies, func(a, b Dependency) bool {
+				return string(a) < string(b)
+			})
+
+			return project, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,95 @@
+package appdetect
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type rubyDetector struct {
+}
+
+func (rd *rubyDetector) Language() Language {
+	return Ruby
+}
+
+// gemfileGem matches the gem declarations of a Gemfile, like: gem 'rails', '~> 7.1'
+var gemfileGem = regexp.MustCompile(`^\s*gem\s+['"]([^'"]+)['"]`)
+
+func (rd *rubyDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
+	for _, entry := range entries {
+		if entry.Name() == "Gemfile" {
+			project := &Project{
+				Language:      Ruby,
+				Path:          path,
+				DetectionRule: "Inferred by presence of: " + entry.Name(),
+			}
+
+			file, err := os.Open(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			scanner := bufio.NewScanner(file)
+			databaseDepMap := map[DatabaseDep]struct{}{}
+
+			for scanner.Scan() {
+				match := gemfileGem.FindStringSubmatch(scanner.Text())
+				if match == nil {
+					continue
+				}
+
+				gem := match[1]
+				switch gem {
+				case "rails":
+					project.Dependencies = append(project.Dependencies, RbRails)
+				case "sinatra":
+					project.Dependencies = append(project.Dependencies, RbSinatra)
+				}
+
+				switch gem {
+				case "mysql2", "trilogy":
+					databaseDepMap[DbMySql] = struct{}{}
+				case "pg":
+					databaseDepMap[DbPostgres] = struct{}{}
+				case "mongo", "mongoid":
+					databaseDepMap[DbMongo] = struct{}{}
+				case "tiny_tds", "activerecord-sqlserver-adapter":
+					databaseDepMap[DbSqlServer] = struct{}{}
+				case "redis", "redis-rails":
+					databaseDepMap[DbRedis] = struct{}{}
+				}
+			}
+
+			if err := scanner.Err(); err != nil {
+				file.Close()
+				return nil, err
+			}
+
+			if err := file.Close(); err != nil {
+				return nil, err
+			}
+
+			if len(databaseDepMap) > 0 {
+				project.DatabaseDeps = maps.Keys(databaseDepMap)
+				slices.SortFunc(project.DatabaseDeps, func(a, b DatabaseDep) bool {
+					return string(a) < string(b)
+				})
+			}
+
+			slices.SortFunc(project.Dependencies, func(a, b Dependency) bool {
+				return string(a) < string(b)
+			})
+
+			return project, nil
+		}
+	}
+
+	return nil, nil
+}
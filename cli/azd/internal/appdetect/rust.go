@@ -0,0 +1,129 @@
+package appdetect
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+type rustDetector struct {
+}
+
+func (rd *rustDetector) Language() Language {
+	return Rust
+}
+
+func (rd *rustDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
+	for _, entry := range entries {
+		if entry.Name() == "Cargo.toml" {
+			file, err := os.Open(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			isPackage, crates, err := cargoDependencies(bufio.NewScanner(file))
+			if err != nil {
+				file.Close()
+				return nil, err
+			}
+
+			if err := file.Close(); err != nil {
+				return nil, err
+			}
+
+			// The manifest of a workspace without a package of its own, whose members are detected instead
+			if !isPackage {
+				return nil, nil
+			}
+
+			project := &Project{
+				Language:      Rust,
+				Path:          path,
+				DetectionRule: "Inferred by presence of: " + entry.Name(),
+			}
+
+			databaseDepMap := map[DatabaseDep]struct{}{}
+			for _, crate := range crates {
+				switch crate {
+				case "axum":
+					project.Dependencies = append(project.Dependencies, RsAxum)
+				case "actix-web":
+					project.Dependencies = append(project.Dependencies, RsActixWeb)
+				case "rocket":
+					project.Dependencies = append(project.Dependencies, RsRocket)
+				}
+
+				switch crate {
+				case "mysql", "mysql_async":
+					databaseDepMap[DbMySql] = struct{}{}
+				case "postgres", "tokio-postgres":
+					databaseDepMap[DbPostgres] = struct{}{}
+				case "mongodb":
+					databaseDepMap[DbMongo] = struct{}{}
+				case "tiberius":
+					databaseDepMap[DbSqlServer] = struct{}{}
+				case "redis":
+					databaseDepMap[DbRedis] = struct{}{}
+				}
+			}
+
+			if len(databaseDepMap) > 0 {
+				project.DatabaseDeps = maps.Keys(databaseDepMap)
+				slices.SortFunc(project.DatabaseDeps, func(a, b DatabaseDep) bool {
+					return string(a) < string(b)
+				})
+			}
+
+			slices.SortFunc(project.Dependencies, func(a, b Dependency) bool {
+				return string(a) < string(b)
+			})
+
+			return project, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// cargoDependencies returns whether a Cargo.toml file declares a package, and the names of the crates in its
+// dependencies, declared either as keys of the [dependencies] table or as [dependencies.<name>] tables.
+func cargoDependencies(scanner *bufio.Scanner) (bool, []string, error) {
+	isPackage := false
+	var crates []string
+	table := ""
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			table = strings.TrimSpace(strings.Trim(line, "[]"))
+			if table == "package" {
+				isPackage = true
+			}
+
+			if crate, has := strings.CutPrefix(table, "dependencies."); has {
+				crates = append(crates, strings.Trim(crate, `"`))
+			}
+
+			continue
+		}
+
+		if table == "dependencies" {
+			if key, _, has := strings.Cut(line, "="); has {
+				crates = append(crates, strings.Trim(strings.TrimSpace(key), `"`))
+			}
+		}
+	}
+
+	return isPackage, crates, scanner.Err()
+}
@@ -0,0 +1,552 @@
+package appdetect
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceTool is the tool which builds the members of a workspace.
+type WorkspaceTool string
+
+const (
+	WorkspaceNpm    WorkspaceTool = "npm"
+	WorkspacePnpm   WorkspaceTool = "pnpm"
+	WorkspaceYarn   WorkspaceTool = "yarn"
+	WorkspaceMaven  WorkspaceTool = "maven"
+	WorkspaceGradle WorkspaceTool = "gradle"
+	WorkspaceDotNet WorkspaceTool = "dotnet"
+)
+
+// IsNode returns true for the workspaces of Node.js package managers.
+func (t WorkspaceTool) IsNode() bool {
+	return t == WorkspaceNpm || t == WorkspacePnpm || t == WorkspaceYarn
+}
+
+// Workspace is the workspace a project is a member of: an npm, pnpm or yarn workspace, a Maven multi-module project, a
+// Gradle multi-project build or a .NET solution.
+type Workspace struct {
+	Tool WorkspaceTool
+
+	// The path to the root directory of the workspace.
+	Path string
+
+	// The name the tool of the workspace knows the project by, to build it from the root of the workspace: the package
+	// name for Node.js workspaces, the module path for Maven, the project path for Gradle, like ':services:api', and the
+	// project file path for .NET solutions.
+	Member string
+}
+
+// MemberNames returns the names of the members of the workspace, the way Member names the member of a project.
+func (w *Workspace) MemberNames() ([]string, error) {
+	entries, err := os.ReadDir(w.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := readWorkspace(w.Path, entries)
+	if err != nil {
+		return nil, fmt.Errorf("reading workspace in %s: %w", w.Path, err)
+	}
+
+	if workspace == nil {
+		return nil, nil
+	}
+
+	names := maps.Values(workspace.members)
+	slices.Sort(names)
+	return names, nil
+}
+
+// workspaceDefinition is a workspace defined in a directory, with the directories of its members.
+type workspaceDefinition struct {
+	tool WorkspaceTool
+	path string
+	// members maps the directory of each member to its name.
+	members map[string]string
+}
+
+// readWorkspace reads the workspace defined in a directory, if any.
+func readWorkspace(path string, entries []fs.DirEntry) (*workspaceDefinition, error) {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	if slices.Contains(names, "pnpm-workspace.yaml") {
+		return readPnpmWorkspace(path)
+	}
+
+	if slices.Contains(names, "package.json") {
+		workspace, err := readNodeWorkspace(path, names)
+		if workspace != nil || err != nil {
+			return workspace, err
+		}
+	}
+
+	if slices.Contains(names, "pom.xml") {
+		workspace, err := readMavenWorkspace(path)
+		if workspace != nil || err != nil {
+			return workspace, err
+		}
+	}
+
+	for _, name := range []string{"settings.gradle", "settings.gradle.kts"} {
+		if slices.Contains(names, name) {
+			workspace, err := readGradleWorkspace(path, name)
+			if workspace != nil || err != nil {
+				return workspace, err
+			}
+		}
+	}
+
+	for _, name := range names {
+		if strings.EqualFold(filepath.Ext(name), ".sln") {
+			return readSolution(path, name)
+		}
+	}
+
+	return nil, nil
+}
+
+func readPnpmWorkspace(path string) (*workspaceDefinition, error) {
+	contents, err := os.ReadFile(filepath.Join(path, "pnpm-workspace.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pnpmWorkspace struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(contents, &pnpmWorkspace); err != nil {
+		return nil, fmt.Errorf("parsing pnpm-workspace.yaml: %w", err)
+	}
+
+	return nodeWorkspace(WorkspacePnpm, path, pnpmWorkspace.Packages)
+}
+
+func readNodeWorkspace(path string, names []string) (*workspaceDefinition, error) {
+	contents, err := os.ReadFile(filepath.Join(path, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var packageJson struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(contents, &packageJson); err != nil {
+		return nil, err
+	}
+
+	if len(packageJson.Workspaces) == 0 {
+		return nil, nil
+	}
+
+	// workspaces is either a list of patterns, or an object with the patterns in packages, for yarn
+	var patterns []string
+	if err := json.Unmarshal(packageJson.Workspaces, &patterns); err != nil {
+		var workspaces struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(packageJson.Workspaces, &workspaces); err != nil {
+			return nil, fmt.Errorf("parsing workspaces of package.json: %w", err)
+		}
+
+		patterns = workspaces.Packages
+	}
+
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	tool := WorkspaceNpm
+	if slices.Contains(names, "yarn.lock") {
+		tool = WorkspaceYarn
+	}
+
+	return nodeWorkspace(tool, path, patterns)
+}
+
+// nodeWorkspace returns the workspace with the packages matching the patterns. Patterns starting with '!' exclude
+// packages.
+func nodeWorkspace(tool WorkspaceTool, path string, patterns []string) (*workspaceDefinition, error) {
+	workspace := &workspaceDefinition{
+		tool:    tool,
+		path:    path,
+		members: map[string]string{},
+	}
+
+	var included []string
+	var excluded []string
+	for _, pattern := range patterns {
+		if exclude, has := strings.CutPrefix(pattern, "!"); has {
+			excluded = append(excluded, strings.TrimPrefix(exclude, "./"))
+		} else {
+			included = append(included, pattern)
+		}
+	}
+
+	for _, pattern := range included {
+		matches, err := doublestar.Glob(os.DirFS(path), strings.TrimPrefix(pattern, "./"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern '%s': %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			if slices.Contains(strings.Split(match, "/"), "node_modules") ||
+				slices.ContainsFunc(excluded, func(exclude string) bool {
+					matched, _ := doublestar.Match(exclude, match)
+					return matched
+				}) {
+				continue
+			}
+
+			dir := filepath.Join(path, filepath.FromSlash(match))
+			contents, err := os.ReadFile(filepath.Join(dir, "package.json"))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			var packageJson struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(contents, &packageJson); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", filepath.Join(dir, "package.json"), err)
+			}
+
+			name := packageJson.Name
+			if name == "" {
+				name = "./" + match
+			}
+
+			workspace.members[dir] = name
+		}
+	}
+
+	return workspace, nil
+}
+
+// mavenPom is the part of a pom.xml file which tells modules and apps apart.
+type mavenPom struct {
+	Packaging string   `xml:"packaging"`
+	Modules   []string `xml:"modules>module"`
+	Plugins   []struct {
+		ArtifactId string `xml:"artifactId"`
+	} `xml:"build>plugins>plugin"`
+}
+
+func readMavenPom(path string) (*mavenPom, error) {
+	contents, err := os.ReadFile(filepath.Join(path, "pom.xml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pom mavenPom
+	if err := xml.Unmarshal(contents, &pom); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Join(path, "pom.xml"), err)
+	}
+
+	return &pom, nil
+}
+
+func readMavenWorkspace(path string) (*workspaceDefinition, error) {
+	pom, err := readMavenPom(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pom.Modules) == 0 {
+		return nil, nil
+	}
+
+	workspace := &workspaceDefinition{
+		tool:    WorkspaceMaven,
+		path:    path,
+		members: map[string]string{},
+	}
+
+	if err := addMavenModules(workspace, path, pom.Modules); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+// addMavenModules adds the modules of a pom to the workspace. The modules of aggregator modules, which have modules of
+// their own, are added instead of the aggregator.
+func addMavenModules(workspace *workspaceDefinition, path string, modules []string) error {
+	for _, module := range modules {
+		dir := filepath.Join(path, filepath.FromSlash(module))
+		pom, err := readMavenPom(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("skipping maven module %s without pom.xml", dir)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if len(pom.Modules) > 0 {
+			if err := addMavenModules(workspace, dir, pom.Modules); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		rel, err := filepath.Rel(workspace.path, dir)
+		if err != nil {
+			return err
+		}
+
+		workspace.members[dir] = filepath.ToSlash(rel)
+	}
+
+	return nil
+}
+
+// gradleInclude matches the start of the include statements of Gradle settings, in the Groovy and Kotlin DSLs:
+// include 'api', 'web' or include(":services:api")
+var gradleInclude = regexp.MustCompile(`(?m)^\s*include\b\s*`)
+
+var gradleQuoted = regexp.MustCompile(`["']([^"']+)["']`)
+
+func readGradleWorkspace(path string, name string) (*workspaceDefinition, error) {
+	contents, err := os.ReadFile(filepath.Join(path, name))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := string(contents)
+	workspace := &workspaceDefinition{
+		tool:    WorkspaceGradle,
+		path:    path,
+		members: map[string]string{},
+	}
+
+	for _, match := range gradleInclude.FindAllStringIndex(settings, -1) {
+		// The projects are listed up to the end of the line, or the closing parenthesis of include(...)
+		statement := settings[match[1]:]
+		end := strings.IndexByte(statement, '\n')
+		if strings.HasPrefix(statement, "(") {
+			end = strings.IndexByte(statement, ')')
+		}
+
+		if end >= 0 {
+			statement = statement[:end]
+		}
+
+		for _, quoted := range gradleQuoted.FindAllStringSubmatch(statement, -1) {
+			projectPath := strings.TrimPrefix(quoted[1], ":")
+			dir := filepath.Join(path, filepath.FromSlash(strings.ReplaceAll(projectPath, ":", "/")))
+			if _, err := os.Stat(dir); err != nil {
+				log.Printf("skipping gradle project %s without directory %s", quoted[1], dir)
+				continue
+			}
+
+			workspace.members[dir] = ":" + projectPath
+		}
+	}
+
+	if len(workspace.members) == 0 {
+		return nil, nil
+	}
+
+	return workspace, nil
+}
+
+// gradlePlugin matches the plugins applied by Gradle build scripts, in the Groovy and Kotlin DSLs: id 'war',
+// id("org.springframework.boot") version "3.2.0", apply plugin: 'war' or the application shortcut of the plugins block.
+var gradlePlugin = regexp.MustCompile(
+	`(?m)\bid\s*\(?\s*["']([\w.-]+)["']|\bapply\s*\(?\s*plugin\s*[:=]\s*["']([\w.-]+)["']|^\s*(application|war)\s*$`)
+
+// isGradleApp returns true if the Gradle build script in path applies a plugin which packages an app.
+func isGradleApp(path string) (bool, error) {
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		contents, err := os.ReadFile(filepath.Join(path, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		for _, match := range gradlePlugin.FindAllStringSubmatch(string(contents), -1) {
+			switch match[1] + match[2] + match[3] {
+			case "org.springframework.boot",
+				"io.quarkus",
+				"io.micronaut.application",
+				"application",
+				"war",
+				"com.github.johnrengelman.shadow":
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// solutionProject matches the project lines of a solution file, like:
+// Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api", "src\Api\Api.csproj", "{A3D6...}"
+var solutionProject = regexp.MustCompile(`^Project\("\{[^}]+\}"\)\s*=\s*"[^"]*",\s*"([^"]+)"`)
+
+func readSolution(path string, name string) (*workspaceDefinition, error) {
+	contents, err := os.ReadFile(filepath.Join(path, name))
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &workspaceDefinition{
+		tool:    WorkspaceDotNet,
+		path:    path,
+		members: map[string]string{},
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		match := solutionProject.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+
+		// solution folders are listed as projects too, with their name as path
+		projectPath := filepath.FromSlash(strings.ReplaceAll(match[1], `\`, "/"))
+		switch strings.ToLower(filepath.Ext(projectPath)) {
+		case ".csproj", ".fsproj", ".vbproj":
+			workspace.members[filepath.Join(path, filepath.Dir(projectPath))] = filepath.ToSlash(projectPath)
+		}
+	}
+
+	return workspace, nil
+}
+
+// isWorkspaceApp returns true if a member of a workspace is a deployable app, rather than a library other members
+// depend on.
+func isWorkspaceApp(project *Project) (bool, error) {
+	switch project.Language {
+	case JavaScript, TypeScript:
+		contents, err := os.ReadFile(filepath.Join(project.Path, "package.json"))
+		if err != nil {
+			return false, err
+		}
+
+		var packageJson PackagesJson
+		if err := json.Unmarshal(contents, &packageJson); err != nil {
+			return false, err
+		}
+
+		// Apps are started, or built into a site for web UI frameworks.
+		_, hasStart := packageJson.Scripts["start"]
+		_, hasBuild := packageJson.Scripts["build"]
+		return hasStart || (project.HasWebUIFramework() && hasBuild), nil
+	case Java:
+		pom, err := readMavenPom(project.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			return isGradleApp(project.Path)
+		} else if err != nil {
+			return false, err
+		}
+
+		if pom.Packaging == "war" || pom.Packaging == "ear" {
+			return true, nil
+		}
+
+		// Apps are packaged as executable jars.
+		for _, plugin := range pom.Plugins {
+			switch plugin.ArtifactId {
+			case "spring-boot-maven-plugin",
+				"quarkus-maven-plugin",
+				"micronaut-maven-plugin",
+				"maven-shade-plugin",
+				"maven-assembly-plugin":
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case DotNet:
+		entries, err := os.ReadDir(project.Path)
+		if err != nil {
+			return false, err
+		}
+
+		// Test projects have a Program.cs generated for them.
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".csproj", ".fsproj", ".vbproj":
+				contents, err := os.ReadFile(filepath.Join(project.Path, entry.Name()))
+				if err != nil {
+					return false, err
+				}
+
+				if strings.Contains(string(contents), "Microsoft.NET.Test.Sdk") {
+					return false, nil
+				}
+			}
+		}
+
+		return true, nil
+	}
+
+	return true, nil
+}
+
+// FindWorkspace returns the workspace the project in dir is a member of, looking for the workspace definition in the
+// parent directories of dir up to root. The outermost workspace is returned when workspaces are nested, like the modules of
+// a Maven aggregator inside another one. nil is returned when the project isn't a member of a workspace.
+func FindWorkspace(dir string, root string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Workspace
+	for path := filepath.Dir(dir); ; path = filepath.Dir(path) {
+		if rel, err := filepath.Rel(root, path); err != nil || strings.HasPrefix(rel, "..") {
+			return found, nil
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		if err == nil {
+			workspace, err := readWorkspace(path, entries)
+			if err != nil {
+				return nil, fmt.Errorf("reading workspace in %s: %w", path, err)
+			}
+
+			if workspace != nil {
+				if member, has := workspace.members[dir]; has {
+					found = &Workspace{
+						Tool:   workspace.tool,
+						Path:   path,
+						Member: member,
+					}
+				}
+			}
+		}
+
+		if path == root || path == filepath.Dir(path) {
+			return found, nil
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package appdetect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+const solutionFile = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "src", "src", "{0C88DD14-F956-CE84-757C-A364CCF449FC}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api", "src\Api\Api.csproj", "{A3D6B0E6-7A3E}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api.Tests", "test\Api.Tests\Api.Tests.csproj", "{B3D6B0E6-7A3E}"
+EndProject
+`
+
+const testProjectFile = `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Microsoft.NET.Test.Sdk" Version="17.8.0" />
+  </ItemGroup>
+</Project>`
+
+// Verify the members of workspaces are detected, without the libraries of the workspace.
+func TestDetectWorkspaces(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []Project
+	}{
+		{
+			"Pnpm",
+			map[string]string{
+				"package.json":                 `{"name": "root", "private": true}`,
+				"pnpm-workspace.yaml":          "packages:\n  - 'apps/*'\n  - 'packages/*'\n  - '!packages/legacy'\n",
+				"apps/api/package.json":        `{"name": "@acme/api", "scripts": {"start": "node index.js"}}`,
+				"apps/web/package.json":        `{"name": "@acme/web", "dependencies": {"react": "18"}, "scripts": {"build": "vite"}}`,
+				"packages/ui/package.json":     `{"name": "@acme/ui", "dependencies": {"react": "18"}}`,
+				"packages/legacy/package.json": `{"name": "@acme/legacy", "scripts": {"start": "node index.js"}}`,
+			},
+			[]Project{
+				{
+					Language:      JavaScript,
+					Path:          "apps/api",
+					DetectionRule: "Inferred by presence of: package.json",
+					Workspace:     &Workspace{Tool: WorkspacePnpm, Member: "@acme/api"},
+				},
+				{
+					Language:      JavaScript,
+					Path:          "apps/web",
+					DetectionRule: "Inferred by presence of: package.json",
+					Dependencies:  []Dependency{JsReact},
+					Workspace:     &Workspace{Tool: WorkspacePnpm, Member: "@acme/web"},
+				},
+				{
+					// excluded from the workspace, and detected on its own
+					Language:      JavaScript,
+					Path:          "packages/legacy",
+					DetectionRule: "Inferred by presence of: package.json",
+				},
+			},
+		},
+		{
+			"Yarn",
+			map[string]string{
+				"package.json":              `{"name": "root", "workspaces": {"packages": ["services/*"]}}`,
+				"yarn.lock":                 "",
+				"services/api/package.json": `{"name": "api", "scripts": {"start": "node index.js"}}`,
+				"services/lib/package.json": `{"name": "lib"}`,
+			},
+			[]Project{
+				{
+					Language:      JavaScript,
+					Path:          "services/api",
+					DetectionRule: "Inferred by presence of: package.json",
+					Workspace:     &Workspace{Tool: WorkspaceYarn, Member: "api"},
+				},
+			},
+		},
+		{
+			"Maven",
+			map[string]string{
+				"pom.xml": "<project><packaging>pom</packaging>" +
+					"<modules><module>common</module><module>services</module></modules></project>",
+				"common/pom.xml": "<project><artifactId>common</artifactId></project>",
+				"services/pom.xml": "<project><packaging>pom</packaging>" +
+					"<modules><module>api</module></modules></project>",
+				"services/api/pom.xml": "<project><build><plugins><plugin>" +
+					"<artifactId>spring-boot-maven-plugin</artifactId></plugin></plugins></build></project>",
+			},
+			[]Project{
+				{
+					Language:      Java,
+					Path:          "services/api",
+					DetectionRule: "Inferred by presence of: pom.xml",
+					Workspace:     &Workspace{Tool: WorkspaceMaven, Member: "services/api"},
+				},
+			},
+		},
+		{
+			"Gradle",
+			map[string]string{
+				"settings.gradle.kts":     "rootProject.name = \"shop\"\ninclude(\n  \":common\",\n  \":services:api\"\n)\n",
+				"build.gradle.kts":        "",
+				"common/build.gradle.kts": "plugins {\n  `java-library`\n}\n",
+				"services/api/build.gradle.kts": "plugins {\n  java\n" +
+					"  id(\"org.springframework.boot\") version \"3.2.0\"\n}\n",
+			},
+			[]Project{
+				{
+					Language:      Java,
+					Path:          "services/api",
+					DetectionRule: "Inferred by presence of: build.gradle.kts",
+					Workspace:     &Workspace{Tool: WorkspaceGradle, Member: ":services:api"},
+				},
+			},
+		},
+		{
+			"GradleGroovy",
+			map[string]string{
+				"settings.gradle":  "rootProject.name = 'shop'\ninclude 'app', 'lib'\n",
+				"app/build.gradle": "apply plugin: 'war'\n",
+				"lib/build.gradle": "plugins {\n  id 'java-library'\n}\n",
+			},
+			[]Project{
+				{
+					Language:      Java,
+					Path:          "app",
+					DetectionRule: "Inferred by presence of: build.gradle",
+					Workspace:     &Workspace{Tool: WorkspaceGradle, Member: ":app"},
+				},
+			},
+		},
+		{
+			"Solution",
+			map[string]string{
+				"App.sln":                         solutionFile,
+				"src/Api/Api.csproj":              `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`,
+				"src/Api/Program.cs":              "",
+				"test/Api.Tests/Api.Tests.csproj": testProjectFile,
+				"test/Api.Tests/Program.cs":       "",
+			},
+			[]Project{
+				{
+					Language:      DotNet,
+					Path:          "src/Api",
+					DetectionRule: "Inferred by presence of: Api.csproj, Program.cs",
+					Workspace:     &Workspace{Tool: WorkspaceDotNet, Member: "src/Api/Api.csproj"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			projects, err := Detect(context.Background(), dir, WithDotNet(), WithJava(), WithJavaScript())
+			require.NoError(t, err)
+
+			for i := range tt.want {
+				tt.want[i].Path = filepath.Join(dir, filepath.FromSlash(tt.want[i].Path))
+				if tt.want[i].Workspace != nil {
+					tt.want[i].Workspace.Path = dir
+				}
+			}
+
+			require.Equal(t, tt.want, projects)
+		})
+	}
+}
+
+func TestFindWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json":              `{"name": "root", "workspaces": ["apps/*"]}`,
+		"apps/api/package.json":     `{"name": "api"}`,
+		"apps/web/package.json":     `{"name": "web"}`,
+		"tools/script/package.json": `{"name": "script"}`,
+	})
+
+	workspace, err := FindWorkspace(filepath.Join(dir, "apps", "api"), dir)
+	require.NoError(t, err)
+	require.Equal(t, &Workspace{Tool: WorkspaceNpm, Path: dir, Member: "api"}, workspace)
+
+	names, err := workspace.MemberNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "web"}, names)
+
+	workspace, err = FindWorkspace(filepath.Join(dir, "tools", "script"), dir)
+	require.NoError(t, err)
+	require.Nil(t, workspace)
+
+	// the workspace is above the root
+	workspace, err = FindWorkspace(filepath.Join(dir, "apps", "api"), filepath.Join(dir, "apps"))
+	require.NoError(t, err)
+	require.Nil(t, workspace)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, []byte(contents), osutil.PermissionFile))
+	}
+}
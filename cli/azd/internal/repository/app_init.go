@@ -30,6 +30,10 @@ var languageMap = map[appdetect.Language]project.ServiceLanguageKind{
 	appdetect.JavaScript: project.ServiceLanguageJavaScript,
 	appdetect.TypeScript: project.ServiceLanguageTypeScript,
 	appdetect.Python:     project.ServiceLanguagePython,
+	appdetect.Go:         project.ServiceLanguageGo,
+	appdetect.Ruby:       project.ServiceLanguageRuby,
+	appdetect.Php:        project.ServiceLanguagePhp,
+	appdetect.Rust:       project.ServiceLanguageRust,
 }
 
 var dbMap = map[appdetect.DatabaseDep]struct{}{
@@ -400,6 +404,16 @@ func prjConfigFromDetect(
 			svc.Docker = project.DockerProjectOptions{
 				Path: relDocker,
 			}
+
+			// Members of a workspace are built with the files of the whole workspace, like the libraries they use.
+			if prj.Workspace != nil {
+				relContext, err := filepath.Rel(prj.Path, prj.Workspace.Path)
+				if err != nil {
+					return project.ProjectConfig{}, err
+				}
+
+				svc.Docker.Context = relContext
+			}
 		}
 
 		if prj.HasWebUIFramework() {
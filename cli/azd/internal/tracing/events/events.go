@@ -21,6 +21,11 @@ const GitHubCliInstallEvent = "tools.gh.install"
 // PackCliInstallEvent is the name of the event which tracks the overall pack cli install operation.
 const PackCliInstallEvent = "tools.pack.install"
 
+// ToolCacheInstallEvent is the name of the event which tracks installing a tool into the azd tool cache, for the tools
+// which don't have an install event of their own.
+// See fields.ToolName and fields.ToolVersion for additional event fields.
+const ToolCacheInstallEvent = "tools.cache.install"
+
 // PackBuildEvent is the name of the event which tracks the overall pack build operation.
 const PackBuildEvent = "tools.pack.build"
 
@@ -161,6 +161,8 @@ const (
 	AccountTypeUser = "User"
 	// A service principal, typically an application.
 	AccountTypeServicePrincipal = "Service Principal"
+	// A managed identity of an Azure resource.
+	AccountTypeManagedIdentity = "Managed Identity"
 )
 
 // The value used for ServiceNameKey
@@ -184,6 +186,9 @@ const (
 
 	// The frame of the error.
 	ErrFrame = attribute.Key("error.frame")
+
+	// The category of the error in the error contract, like auth or quota.
+	ErrCategory = attribute.Key("error.category")
 )
 
 // Service related fields.
@@ -216,6 +221,9 @@ const (
 	// The name of the tool.
 	ToolName = attribute.Key("tool.name")
 
+	// The version of the tool.
+	ToolVersion = attribute.Key("tool.version")
+
 	// The exit code of the tool after invocation.
 	ToolExitCode = attribute.Key("tool.exitCode")
 )
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
@@ -44,6 +45,7 @@ func Test_GetAccountDefaults(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -69,6 +71,7 @@ func Test_GetAccountDefaults(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -103,6 +106,7 @@ func Test_GetAccountDefaults(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -137,6 +141,7 @@ func Test_GetAccountDefaults(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -159,6 +164,7 @@ func Test_GetSubscriptionsWithDefaultSet(t *testing.T) {
 			NewSubscriptionsService(
 				&mocks.MockMultiTenantCredentialProvider{},
 				mockHttp,
+				cloud.AzurePublic(),
 			),
 			NewBypassSubscriptionsCache()))
 		require.NoError(t, err)
@@ -194,6 +200,7 @@ func Test_GetSubscriptionsWithDefaultSet(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -223,6 +230,7 @@ func Test_GetSubscriptionsWithDefaultSet(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			))
@@ -261,6 +269,7 @@ func Test_GetLocations(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -282,6 +291,7 @@ func Test_GetLocations(t *testing.T) {
 			NewSubscriptionsService(
 				&mocks.MockMultiTenantCredentialProvider{},
 				mockHttp,
+				cloud.AzurePublic(),
 			),
 			NewBypassSubscriptionsCache()))
 		require.NoError(t, err)
@@ -302,6 +312,7 @@ func Test_GetLocations(t *testing.T) {
 			NewSubscriptionsService(
 				&mocks.MockMultiTenantCredentialProvider{},
 				mockHttp,
+				cloud.AzurePublic(),
 			),
 			NewBypassSubscriptionsCache()))
 		require.NoError(t, err)
@@ -330,6 +341,7 @@ func Test_SetDefaultSubscription(t *testing.T) {
 			NewSubscriptionsService(
 				&mocks.MockMultiTenantCredentialProvider{},
 				mockHttp,
+				cloud.AzurePublic(),
 			),
 			NewBypassSubscriptionsCache()))
 		require.NoError(t, err)
@@ -356,6 +368,7 @@ func Test_SetDefaultSubscription(t *testing.T) {
 			NewSubscriptionsService(
 				&mocks.MockMultiTenantCredentialProvider{},
 				mockHttp,
+				cloud.AzurePublic(),
 			),
 			NewBypassSubscriptionsCache()))
 		require.NoError(t, err)
@@ -395,6 +408,7 @@ func Test_SetDefaultLocation(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -419,6 +433,7 @@ func Test_SetDefaultLocation(t *testing.T) {
 			NewSubscriptionsService(
 				&mocks.MockMultiTenantCredentialProvider{},
 				mockHttp,
+				cloud.AzurePublic(),
 			),
 			NewBypassSubscriptionsCache()))
 		require.NoError(t, err)
@@ -446,6 +461,7 @@ func Test_Clear(t *testing.T) {
 		NewSubscriptionsService(
 			&mocks.MockMultiTenantCredentialProvider{},
 			mockHttp,
+			cloud.AzurePublic(),
 		),
 		NewBypassSubscriptionsCache()))
 	require.NoError(t, err)
@@ -497,6 +513,7 @@ func Test_HasDefaults(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -516,6 +533,7 @@ func Test_HasDefaults(t *testing.T) {
 				NewSubscriptionsService(
 					&mocks.MockMultiTenantCredentialProvider{},
 					mockHttp,
+					cloud.AzurePublic(),
 				),
 				NewBypassSubscriptionsCache(),
 			),
@@ -3,11 +3,14 @@ package apphost
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template"
@@ -20,6 +23,15 @@ import (
 )
 
 const RedisContainerAppService = "redis"
+const KafkaContainerAppService = "kafka"
+
+// DefaultExecutablePort is the port an executable.v0 resource is assumed to listen on when its bindings do not specify a
+// container port. It matches the port used by containers built from source with `pack`.
+const DefaultExecutablePort = 80
+
+// cnbLauncher is the launcher of the images built by buildpacks, which runs a command in the environment the buildpacks
+// set up for the app.
+const cnbLauncher = "/cnb/lifecycle/launcher"
 
 const DaprStateStoreComponentType = "state"
 const DaprPubSubComponentType = "pubsub"
@@ -83,6 +95,34 @@ func Dockerfiles(manifest *Manifest) map[string]genDockerfile {
 	return res
 }
 
+// Executables returns information about all executable.v0 resources from a manifest. These are applications written in
+// languages other than .NET (e.g. Node or Python apps added with AddNpmApp or AddPythonProject) which azd builds into
+// containers.
+func Executables(manifest *Manifest) map[string]genExecutable {
+	res := make(map[string]genExecutable)
+
+	for name, comp := range manifest.Resources {
+		switch comp.Type {
+		case "executable.v0":
+			exe := genExecutable{
+				Args:     comp.Args,
+				Env:      comp.Env,
+				Bindings: executableBindings(comp.Bindings),
+			}
+			if comp.WorkingDirectory != nil {
+				exe.WorkingDirectory = *comp.WorkingDirectory
+			}
+			if comp.Command != nil {
+				exe.Command = *comp.Command
+			}
+
+			res[name] = exe
+		}
+	}
+
+	return res
+}
+
 // ContainerAppManifestTemplateForProject returns the container app manifest template for a given project.
 // It can be used (after evaluation) to deploy the service to a container app environment.
 func ContainerAppManifestTemplateForProject(manifest *Manifest, projectName string) (string, error) {
@@ -133,6 +173,17 @@ func BicepTemplate(manifest *Manifest) (*memfs.FS, error) {
 		return nil, fmt.Errorf("generating infra/resources.bicep: %w", err)
 	}
 
+	for name, module := range generator.bicepContext.BicepModules {
+		contents, err := os.ReadFile(module.source)
+		if err != nil {
+			return nil, fmt.Errorf("reading bicep module for resource %s: %w", name, err)
+		}
+
+		if err := fs.WriteFile(module.Path, contents, osutil.PermissionFile); err != nil {
+			return nil, fmt.Errorf("writing infra/%s: %w", module.Path, err)
+		}
+	}
+
 	return fs, nil
 }
 
@@ -238,9 +289,11 @@ type infraGenerator struct {
 	containers        map[string]genContainer
 	dapr              map[string]genDapr
 	dockerfiles       map[string]genDockerfile
+	executables       map[string]genExecutable
 	projects          map[string]genProject
 	connectionStrings map[string]string
 	resourceTypes     map[string]string
+	parents           map[string]string
 	inputs            map[string]genInput
 
 	bicepContext                 genBicepTemplateContext
@@ -258,13 +311,22 @@ func newInfraGenerator() *infraGenerator {
 			ContainerApps:                   make(map[string]genContainerApp),
 			AppConfigs:                      make(map[string]genAppConfig),
 			DaprComponents:                  make(map[string]genDaprComponent),
+			EventHubs:                       make(map[string]genEventHubs),
+			SqlServers:                      make(map[string]genSqlServer),
+			CosmosDbAccounts:                make(map[string]genCosmosDbAccount),
+			RedisCaches:                     make(map[string]genRedisCache),
+			SignalRs:                        make(map[string]genSignalR),
+			OpenAIs:                         make(map[string]genOpenAI),
+			BicepModules:                    make(map[string]genBicepModule),
 		},
 		containers:                   make(map[string]genContainer),
 		dapr:                         make(map[string]genDapr),
 		dockerfiles:                  make(map[string]genDockerfile),
+		executables:                  make(map[string]genExecutable),
 		projects:                     make(map[string]genProject),
 		connectionStrings:            make(map[string]string),
 		resourceTypes:                make(map[string]string),
+		parents:                      make(map[string]string),
 		containerAppTemplateContexts: make(map[string]genContainerAppManifestTemplateContext),
 		inputs:                       make(map[string]genInput),
 	}
@@ -299,6 +361,10 @@ func (b *infraGenerator) LoadManifest(m *Manifest) error {
 
 		b.resourceTypes[name] = comp.Type
 
+		if comp.Parent != nil {
+			b.parents[name] = *comp.Parent
+		}
+
 		if comp.ConnectionString != nil {
 			b.connectionStrings[name] = *comp.ConnectionString
 		}
@@ -306,6 +372,8 @@ func (b *infraGenerator) LoadManifest(m *Manifest) error {
 		switch comp.Type {
 		case "azure.servicebus.v0":
 			b.addServiceBus(name, comp.Queues, comp.Topics)
+		case "azure.eventhubs.v0":
+			b.addEventHubs(name, comp.Hubs)
 		case "azure.appinsights.v0":
 			b.addAppInsights(name)
 		case "project.v0":
@@ -324,6 +392,11 @@ func (b *infraGenerator) LoadManifest(m *Manifest) error {
 			}
 		case "dockerfile.v0":
 			b.addDockerfile(name, *comp.Path, *comp.Context, comp.Env, comp.Bindings)
+		case "executable.v0":
+			if comp.WorkingDirectory == nil {
+				return fmt.Errorf("executable resource '%s' did not include a working directory", name)
+			}
+			b.addExecutable(name, comp)
 		case "redis.v0":
 			b.addContainerAppService(name, RedisContainerAppService)
 		case "azure.keyvault.v0":
@@ -338,6 +411,27 @@ func (b *infraGenerator) LoadManifest(m *Manifest) error {
 			b.addStorageQueue(*comp.Parent, name)
 		case "azure.storage.table.v0":
 			b.addStorageTable(*comp.Parent, name)
+		case "azure.sql.v0":
+			b.addSqlServer(name)
+		case "azure.sql.database.v0":
+			b.addSqlDatabase(*comp.Parent, name)
+		case "azure.cosmosdb.account.v0":
+			b.addCosmosDbAccount(name)
+		case "azure.cosmosdb.database.v0":
+			b.addCosmosDbDatabase(*comp.Parent, name)
+		case "azure.redis.v0":
+			b.addRedisCache(name)
+		case "azure.signalr.v0":
+			b.addSignalR(name)
+		case "azure.openai.account.v0":
+			b.addOpenAI(name, comp.Deployments)
+		case "kafka.server.v0":
+			b.addContainerAppService(name, KafkaContainerAppService)
+		case "bicep.v0":
+			if comp.Path == nil {
+				return fmt.Errorf("bicep resource '%s' did not include a path", name)
+			}
+			b.addBicepModule(name, *comp.Path, comp.Params)
 		case "postgres.server.v0":
 			// We currently use a ACA Postgres Service per database. Because of this, we don't need to retain any
 			// information from the server resource.
@@ -410,6 +504,90 @@ func (b *infraGenerator) addAppInsights(name string) {
 	b.bicepContext.AppInsights[name] = genAppInsight{}
 }
 
+func (b *infraGenerator) addEventHubs(name string, hubs *[]string) {
+	if hubs == nil {
+		hubs = &[]string{}
+	}
+
+	b.bicepContext.EventHubs[name] = genEventHubs{Hubs: *hubs}
+}
+
+func (b *infraGenerator) addSqlServer(name string) {
+	// the server can be added from addSqlDatabase, when a database is loaded before its server.
+	if _, exists := b.bicepContext.SqlServers[name]; !exists {
+		b.bicepContext.SqlServers[name] = genSqlServer{}
+	}
+}
+
+func (b *infraGenerator) addSqlDatabase(serverName, databaseName string) {
+	server := b.bicepContext.SqlServers[serverName]
+	server.Databases = append(server.Databases, databaseName)
+	b.bicepContext.SqlServers[serverName] = server
+}
+
+func (b *infraGenerator) addCosmosDbAccount(name string) {
+	// the account can be added from addCosmosDbDatabase, when a database is loaded before its account.
+	if _, exists := b.bicepContext.CosmosDbAccounts[name]; !exists {
+		b.bicepContext.CosmosDbAccounts[name] = genCosmosDbAccount{}
+	}
+}
+
+func (b *infraGenerator) addCosmosDbDatabase(accountName, databaseName string) {
+	account := b.bicepContext.CosmosDbAccounts[accountName]
+	account.Databases = append(account.Databases, databaseName)
+	b.bicepContext.CosmosDbAccounts[accountName] = account
+}
+
+func (b *infraGenerator) addRedisCache(name string) {
+	b.bicepContext.RedisCaches[name] = genRedisCache{}
+}
+
+func (b *infraGenerator) addSignalR(name string) {
+	b.bicepContext.SignalRs[name] = genSignalR{}
+}
+
+func (b *infraGenerator) addOpenAI(name string, deployments []OpenAIDeployment) {
+	openAI := genOpenAI{}
+
+	for _, deployment := range deployments {
+		genDeployment := genOpenAIDeployment{
+			Name:         deployment.Name,
+			ModelName:    deployment.ModelName,
+			ModelVersion: deployment.ModelVersion,
+			SkuName:      "Standard",
+			SkuCapacity:  1,
+		}
+
+		if deployment.Sku != nil {
+			if deployment.Sku.Name != "" {
+				genDeployment.SkuName = deployment.Sku.Name
+			}
+			if deployment.Sku.Capacity > 0 {
+				genDeployment.SkuCapacity = deployment.Sku.Capacity
+			}
+		}
+
+		openAI.Deployments = append(openAI.Deployments, genDeployment)
+	}
+
+	b.bicepContext.OpenAIs[name] = openAI
+}
+
+func (b *infraGenerator) addBicepModule(name string, path string, params map[string]any) {
+	b.bicepContext.BicepModules[name] = genBicepModule{
+		Path:    bicepModuleFileName(name),
+		Params:  make(map[string]string),
+		Outputs: make(map[string]string),
+		source:  path,
+		params:  params,
+	}
+}
+
+// bicepModuleFileName returns the name of the file a bicep.v0 module is copied to, alongside the generated main.bicep.
+func bicepModuleFileName(name string) string {
+	return fmt.Sprintf("%s.module.bicep", name)
+}
+
 func (b *infraGenerator) addProject(
 	name string, path string, env map[string]string, bindings map[string]*Binding,
 ) {
@@ -584,6 +762,55 @@ func (b *infraGenerator) addDockerfile(
 	}
 }
 
+func (b *infraGenerator) addExecutable(name string, comp *Resource) {
+	b.requireCluster()
+	b.requireContainerRegistry()
+
+	exe := genExecutable{
+		WorkingDirectory: *comp.WorkingDirectory,
+		Args:             comp.Args,
+		Env:              comp.Env,
+		Bindings:         executableBindings(comp.Bindings),
+	}
+	if comp.Command != nil {
+		exe.Command = *comp.Command
+	}
+
+	b.executables[name] = exe
+}
+
+// hasDockerfile returns true if the working directory of an executable has a Dockerfile, which azd builds its container
+// image from.
+func hasDockerfile(workingDirectory string) (bool, error) {
+	entries, err := os.ReadDir(workingDirectory)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(entries, func(entry fs.DirEntry) bool {
+		return strings.EqualFold(entry.Name(), "dockerfile")
+	}), nil
+}
+
+// executableBindings returns the bindings of an executable.v0 resource. Executables are built into containers by azd, so
+// the manifest does not know which port the container listens on. Bindings without a container port use the default
+// port, which is also what their "port" property evaluates to so the application can be told where to listen.
+func executableBindings(bindings map[string]*Binding) map[string]*Binding {
+	resolved := make(map[string]*Binding, len(bindings))
+	for bindingName, binding := range bindings {
+		withPort := *binding
+		if withPort.ContainerPort == nil {
+			port := DefaultExecutablePort
+			withPort.ContainerPort = &port
+		}
+		resolved[bindingName] = &withPort
+	}
+
+	return resolved
+}
+
 func validateAndMergeBindings(bindings map[string]*Binding) (*Binding, error) {
 	if len(bindings) == 0 {
 		return nil, nil
@@ -637,6 +864,17 @@ func validateAndMergeBindings(bindings map[string]*Binding) (*Binding, error) {
 // called the context objects on the infraGenerator can be passed to the text templates to generate the required
 // infrastructure.
 func (b *infraGenerator) Compile() error {
+	for name, module := range b.bicepContext.BicepModules {
+		for paramName, value := range module.params {
+			expr, err := b.bicepModuleParam(paramName, value)
+			if err != nil {
+				return fmt.Errorf("configuring parameter %s for module %s: %w", paramName, name, err)
+			}
+
+			module.Params[paramName] = expr
+		}
+	}
+
 	for name, container := range b.containers {
 		cs := genContainerApp{
 			Image: container.Image,
@@ -681,6 +919,40 @@ func (b *infraGenerator) Compile() error {
 		b.containerAppTemplateContexts[resourceName] = projectTemplateCtx
 	}
 
+	for resourceName, exe := range b.executables {
+		projectTemplateCtx := genContainerAppManifestTemplateContext{
+			Name: resourceName,
+			Env:  make(map[string]string),
+		}
+
+		ingress, err := buildIngress(exe.Bindings)
+		if err != nil {
+			return fmt.Errorf("configuring ingress for resource %s: %w", resourceName, err)
+		}
+
+		projectTemplateCtx.Ingress = ingress
+
+		if err := b.buildEnvBlock(exe.Env, &projectTemplateCtx); err != nil {
+			return fmt.Errorf("configuring environment for resource %s: %w", resourceName, err)
+		}
+
+		// Executables with a Dockerfile are started the way their Dockerfile says. The others are built with pack, and
+		// started with their command and arguments, through the launcher of the image.
+		hasDockerfile, err := hasDockerfile(exe.WorkingDirectory)
+		if err != nil {
+			return fmt.Errorf("configuring command for resource %s: %w", resourceName, err)
+		}
+
+		if !hasDockerfile {
+			if err := b.buildCommand(
+				append([]string{cnbLauncher, exe.Command}, exe.Args...), &projectTemplateCtx); err != nil {
+				return fmt.Errorf("configuring command for resource %s: %w", resourceName, err)
+			}
+		}
+
+		b.containerAppTemplateContexts[resourceName] = projectTemplateCtx
+	}
+
 	for resourceName, project := range b.projects {
 		projectTemplateCtx := genContainerAppManifestTemplateContext{
 			Name: resourceName,
@@ -746,6 +1018,70 @@ func (b *infraGenerator) Compile() error {
 	return nil
 }
 
+// bicepModuleParam returns the bicep expression to pass as the value of a parameter of a bicep.v0 module. Strings may
+// reference the outputs of other modules or inputs, and are emitted as interpolated bicep strings. Empty values for the
+// well-known principalId, principalType and location parameters are filled in by azd.
+func (b *infraGenerator) bicepModuleParam(name string, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			switch name {
+			case "principalId":
+				return "resources.outputs.MANAGED_IDENTITY_PRINCIPAL_ID", nil
+			case "principalType":
+				return "'ServicePrincipal'", nil
+			case "location":
+				return "location", nil
+			}
+		}
+
+		escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v)
+		res, err := evalString(escaped, b.evalBicepModuleRef)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("'%s'", res), nil
+	case bool, float64, nil:
+		res, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return string(res), nil
+	default:
+		res, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("json('%s')", strings.ReplaceAll(string(res), "'", `\'`)), nil
+	}
+}
+
+// evalBicepModuleRef evaluates an expression in a parameter of a bicep.v0 module. Only the outputs of other modules and
+// inputs may be referenced.
+func (b *infraGenerator) evalBicepModuleRef(v string) (string, error) {
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed expression, expected <resourceName>.[outputs|inputs].<name> but was: %s", v)
+	}
+
+	resource, kind, prop := parts[0], parts[1], parts[2]
+	if _, has := b.resourceTypes[resource]; !has {
+		return "", fmt.Errorf("unknown resource referenced in expression: %s", resource)
+	}
+
+	switch {
+	case kind == "inputs":
+		return fmt.Sprintf("${inputs['%s']['%s']}", resource, prop), nil
+	case kind == "outputs" && b.resourceTypes[resource] == "bicep.v0":
+		return fmt.Sprintf("${%s.outputs.%s}", scaffold.BicepName(resource), prop), nil
+	default:
+		return "", fmt.Errorf("unsupported expression: %s", v)
+	}
+}
+
 // buildIngress builds the ingress configuration for a given set of bindings. It returns nil, nil if no ingress should
 // be configured (i.e. the bindings are empty).
 func buildIngress(bindings map[string]*Binding) (*genContainerAppIngress, error) {
@@ -821,7 +1157,8 @@ func (b infraGenerator) evalBindingRef(v string, emitType inputEmitType) (string
 	}
 
 	switch {
-	case targetType == "project.v0" || targetType == "container.v0" || targetType == "dockerfile.v0":
+	case targetType == "project.v0" || targetType == "container.v0" || targetType == "dockerfile.v0" ||
+		targetType == "executable.v0":
 		if !strings.HasPrefix(prop, "bindings.") {
 			return "", fmt.Errorf("unsupported property referenced in binding expression: %s for %s", prop, targetType)
 		}
@@ -842,6 +1179,8 @@ func (b infraGenerator) evalBindingRef(v string, emitType inputEmitType) (string
 			binding, has = b.containers[resource].Bindings[parts[0]]
 		} else if targetType == "dockerfile.v0" {
 			binding, has = b.dockerfiles[resource].Bindings[parts[0]]
+		} else if targetType == "executable.v0" {
+			binding, has = b.executables[resource].Bindings[parts[0]]
 		}
 
 		if !has {
@@ -868,7 +1207,7 @@ func (b infraGenerator) evalBindingRef(v string, emitType inputEmitType) (string
 			return "",
 				fmt.Errorf("malformed binding expression, expected bindings.<binding-name>.[host|port|url] but was: %s", v)
 		}
-	case targetType == "postgres.database.v0" || targetType == "redis.v0":
+	case targetType == "postgres.database.v0" || targetType == "redis.v0" || targetType == "kafka.server.v0":
 		switch prop {
 		case "connectionString":
 			return fmt.Sprintf(`{{ connectionString "%s" }}`, resource), nil
@@ -882,6 +1221,76 @@ func (b infraGenerator) evalBindingRef(v string, emitType inputEmitType) (string
 		default:
 			return "", errUnsupportedProperty("azure.servicebus.v0", prop)
 		}
+	case targetType == "azure.eventhubs.v0":
+		switch prop {
+		case "connectionString":
+			return fmt.Sprintf("{{ urlHost .Env.SERVICE_BINDING_%s_ENDPOINT }}", scaffold.AlphaSnakeUpper(resource)), nil
+		default:
+			return "", errUnsupportedProperty("azure.eventhubs.v0", prop)
+		}
+	case targetType == "azure.sql.v0" || targetType == "azure.sql.database.v0":
+		switch prop {
+		case "connectionString":
+			server, database := resource, ""
+			if targetType == "azure.sql.database.v0" {
+				server, database = b.parentOf("azure.sql.v0", resource), fmt.Sprintf(";Database=%s", resource)
+			}
+
+			return fmt.Sprintf(
+				`Server=tcp:{{ .Env.SERVICE_BINDING_%s_ENDPOINT }},1433;Encrypt=True;Authentication="Active Directory Default"%s`,
+				scaffold.AlphaSnakeUpper(server),
+				database), nil
+		default:
+			return "", errUnsupportedProperty(targetType, prop)
+		}
+	case targetType == "azure.cosmosdb.account.v0" || targetType == "azure.cosmosdb.database.v0":
+		switch prop {
+		case "connectionString":
+			account := resource
+			if targetType == "azure.cosmosdb.database.v0" {
+				account = b.parentOf("azure.cosmosdb.account.v0", resource)
+			}
+
+			return fmt.Sprintf("AccountEndpoint={{ .Env.SERVICE_BINDING_%s_ENDPOINT }}", scaffold.AlphaSnakeUpper(account)), nil
+		default:
+			return "", errUnsupportedProperty(targetType, prop)
+		}
+	case targetType == "azure.redis.v0":
+		switch prop {
+		case "connectionString":
+			return fmt.Sprintf("{{ .Env.SERVICE_BINDING_%s_ENDPOINT }},ssl=true", scaffold.AlphaSnakeUpper(resource)), nil
+		default:
+			return "", errUnsupportedProperty("azure.redis.v0", prop)
+		}
+	case targetType == "azure.signalr.v0":
+		switch prop {
+		case "connectionString":
+			return fmt.Sprintf(
+				"Endpoint={{ .Env.SERVICE_BINDING_%s_ENDPOINT }};AuthType=azure.msi;"+
+					"ClientId={{ .Env.MANAGED_IDENTITY_CLIENT_ID }};Version=1.0;",
+				scaffold.AlphaSnakeUpper(resource)), nil
+		default:
+			return "", errUnsupportedProperty("azure.signalr.v0", prop)
+		}
+	case targetType == "azure.openai.account.v0":
+		switch prop {
+		case "connectionString":
+			return fmt.Sprintf("{{ .Env.SERVICE_BINDING_%s_ENDPOINT }}", scaffold.AlphaSnakeUpper(resource)), nil
+		default:
+			return "", errUnsupportedProperty("azure.openai.account.v0", prop)
+		}
+	case targetType == "bicep.v0":
+		if !strings.HasPrefix(prop, "outputs.") {
+			return "", errUnsupportedProperty("bicep.v0", prop)
+		}
+
+		output := prop[len("outputs."):]
+		envName := fmt.Sprintf("%s_%s", scaffold.AlphaSnakeUpper(resource), scaffold.AlphaSnakeUpper(output))
+
+		// Record the output as referenced, so it is exposed from main.bicep and ends up in the environment.
+		b.bicepContext.BicepModules[resource].Outputs[output] = envName
+
+		return fmt.Sprintf("{{ .Env.%s }}", envName), nil
 	case targetType == "azure.appinsights.v0":
 		switch prop {
 		case "connectionString":
@@ -922,6 +1331,16 @@ func (b infraGenerator) evalBindingRef(v string, emitType inputEmitType) (string
 	}
 }
 
+// parentOf returns the name of the parent resource of a child resource, which is expected to be of the given type. The
+// child itself is returned when the parent is unknown, so generated expressions remain stable.
+func (b infraGenerator) parentOf(parentType string, child string) string {
+	if parent, has := b.parents[child]; has && b.resourceTypes[parent] == parentType {
+		return parent
+	}
+
+	return child
+}
+
 // buildEnvBlock creates the environment map in the template context. It does this by copying the values from the given map,
 // evaluating any binding expressions that are present. It writes the result of the evaluation after calling json.Marshal
 // so the values may be emitted into YAML as is without worrying about escaping.
@@ -943,22 +1362,55 @@ func (b *infraGenerator) buildEnvBlock(env map[string]string, manifestCtx *genCo
 		//
 		// YAML marshalling the string value will give us something like `"true"` (with the quotes, and any escaping
 		// that needs to be done), which is what we want here.
-		// Do not use JSON marshall as it would escape the quotes within the string, breaking the meaning of the value.
-		// yaml marshall will use 'some text "quoted" more text' as a valid yaml string.
-		yamlString, err := yaml.Marshal(res)
+		yamlString, err := yamlValue(res)
 		if err != nil {
 			return fmt.Errorf("marshalling env value: %w", err)
 		}
 
-		// remove the trailing newline. yaml marshall will add a newline at the end of the string, as the new line is
-		// expected at the end of the yaml document. But we are getting a single value with valid yaml here, so we don't
-		// need the newline.
-		manifestCtx.Env[k] = string(yamlString[0 : len(yamlString)-1])
+		manifestCtx.Env[k] = yamlString
 	}
 
 	return nil
 }
 
+// buildCommand sets the entrypoint of the container in the template context to the first value of command, and its
+// arguments to the others. Binding expressions in the arguments are evaluated, and the values are emitted as YAML
+// strings, like buildEnvBlock does for the environment.
+func (b *infraGenerator) buildCommand(command []string, manifestCtx *genContainerAppManifestTemplateContext) error {
+	values := make([]string, 0, len(command))
+	for _, value := range command {
+		res, err := evalString(value, func(s string) (string, error) { return b.evalBindingRef(s, inputEmitTypeYaml) })
+		if err != nil {
+			return fmt.Errorf("evaluating argument %s: %w", value, err)
+		}
+
+		yamlString, err := yamlValue(res)
+		if err != nil {
+			return fmt.Errorf("marshalling argument: %w", err)
+		}
+
+		values = append(values, yamlString)
+	}
+
+	manifestCtx.Command = values[:1]
+	manifestCtx.Args = values[1:]
+	return nil
+}
+
+// yamlValue returns the YAML string for a value. Do not use JSON marshall as it would escape the quotes within the string,
+// breaking the meaning of the value. yaml marshall will use 'some text "quoted" more text' as a valid yaml string.
+func yamlValue(value string) (string, error) {
+	yamlString, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	// remove the trailing newline. yaml marshall will add a newline at the end of the string, as the new line is
+	// expected at the end of the yaml document. But we are getting a single value with valid yaml here, so we don't
+	// need the newline.
+	return string(yamlString[0 : len(yamlString)-1]), nil
+}
+
 // errUnsupportedProperty returns an error indicating that the given property is not supported for the given resource.
 func errUnsupportedProperty(resourceType, propertyName string) error {
 	return fmt.Errorf("unsupported property referenced in binding expression: %s for %s", propertyName, resourceType)
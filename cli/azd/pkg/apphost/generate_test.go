@@ -29,6 +29,12 @@ var aspireEscapingManifest []byte
 //go:embed testdata/aspire-container.json
 var aspireContainerManifest []byte
 
+//go:embed testdata/aspire-azure.json
+var aspireAzureManifest []byte
+
+//go:embed testdata/aspire-executable.json
+var aspireExecutableManifest []byte
+
 // mockPublishManifest mocks the dotnet run --publisher manifest command to return a fixed manifest.
 func mockPublishManifest(mockCtx *mocks.MockContext, manifest []byte) {
 	mockCtx.CommandRunner.When(func(args exec.RunArgs, command string) bool {
@@ -188,6 +194,124 @@ func TestAspireContainerGeneration(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAspireAzureGeneration(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping due to EOL issues on Windows with the baselines")
+	}
+
+	ctx := context.Background()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, aspireAzureManifest)
+	mockCli := dotnet.NewDotNetCli(mockCtx.CommandRunner)
+
+	m, err := ManifestFromAppHost(ctx, filepath.Join("testdata", "AspireDocker.AppHost.csproj"), mockCli)
+	require.NoError(t, err)
+
+	// The manifest is written to a temporary directory, so point the bicep module back at the copy in testdata.
+	modulePath, err := filepath.Abs(filepath.Join("testdata", "search.bicep"))
+	require.NoError(t, err)
+	m.Resources["search"].Path = &modulePath
+
+	for _, name := range []string{"api"} {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := ContainerAppManifestTemplateForProject(m, name)
+			require.NoError(t, err)
+			snapshot.SnapshotT(t, tmpl)
+		})
+	}
+
+	files, err := BicepTemplate(m)
+	require.NoError(t, err)
+
+	err = fs.WalkDir(files, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		contents, err := fs.ReadFile(files, path)
+		if err != nil {
+			return err
+		}
+		t.Run(path, func(t *testing.T) {
+			snapshot.SnapshotT(t, string(contents))
+		})
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestAspireExecutableGeneration(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping due to EOL issues on Windows with the baselines")
+	}
+
+	ctx := context.Background()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, aspireExecutableManifest)
+	mockCli := dotnet.NewDotNetCli(mockCtx.CommandRunner)
+
+	m, err := ManifestFromAppHost(ctx, filepath.Join("testdata", "AspireDocker.AppHost.csproj"), mockCli)
+	require.NoError(t, err)
+
+	executables := Executables(m)
+	require.Len(t, executables, 2)
+	require.True(t, filepath.IsAbs(executables["nodeapp"].WorkingDirectory))
+	require.Equal(t, "npm", executables["nodeapp"].Command)
+	require.Equal(t, []string{"main.py"}, executables["pyapp"].Args)
+	require.Equal(t, DefaultExecutablePort, *executables["nodeapp"].Bindings["http"].ContainerPort)
+	require.Nil(t, m.Resources["nodeapp"].Bindings["http"].ContainerPort)
+
+	for _, value := range m.Resources["nodeapp"].Bindings {
+		value.External = true
+	}
+
+	for _, name := range []string{"nodeapp", "pyapp"} {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := ContainerAppManifestTemplateForProject(m, name)
+			require.NoError(t, err)
+			snapshot.SnapshotT(t, tmpl)
+		})
+	}
+
+	t.Run("Dockerfile", func(t *testing.T) {
+		// Executables built from a Dockerfile are started the way their Dockerfile says.
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), nil, osutil.PermissionFile))
+
+		workingDirectory := *m.Resources["nodeapp"].WorkingDirectory
+		*m.Resources["nodeapp"].WorkingDirectory = dir
+		defer func() { *m.Resources["nodeapp"].WorkingDirectory = workingDirectory }()
+
+		tmpl, err := ContainerAppManifestTemplateForProject(m, "nodeapp")
+		require.NoError(t, err)
+		require.NotContains(t, tmpl, "command:")
+		require.NotContains(t, tmpl, "args:")
+	})
+
+	files, err := BicepTemplate(m)
+	require.NoError(t, err)
+
+	err = fs.WalkDir(files, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		contents, err := fs.ReadFile(files, path)
+		if err != nil {
+			return err
+		}
+		t.Run(path, func(t *testing.T) {
+			snapshot.SnapshotT(t, string(contents))
+		})
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func TestBuildEnvResolveServiceToConnectionString(t *testing.T) {
 	// Create a mock infraGenerator instance
 	mockGenerator := &infraGenerator{
@@ -13,6 +13,48 @@ type genServiceBus struct {
 	Topics []string
 }
 
+type genEventHubs struct {
+	Hubs []string
+}
+
+type genSqlServer struct {
+	Databases []string
+}
+
+type genCosmosDbAccount struct {
+	Databases []string
+}
+
+type genRedisCache struct{}
+
+type genSignalR struct{}
+
+type genOpenAI struct {
+	Deployments []genOpenAIDeployment
+}
+
+type genOpenAIDeployment struct {
+	Name         string
+	ModelName    string
+	ModelVersion string
+	SkuName      string
+	SkuCapacity  int
+}
+
+type genBicepModule struct {
+	// Path is the path of the module file, relative to the generated main.bicep.
+	Path string
+	// Params maps parameter names to the bicep expressions passed as their values.
+	Params map[string]string
+	// Outputs maps the outputs of the module referenced by other resources to the name of the output of main.bicep which
+	// exposes them.
+	Outputs map[string]string
+	// source is the path to the module file in the AppHost project.
+	source string
+	// params are the parameters from the manifest, before they have been compiled into bicep expressions.
+	params map[string]any
+}
+
 type genContainerAppEnvironmentServices struct {
 	Type string
 }
@@ -46,6 +88,14 @@ type genDockerfile struct {
 	Bindings map[string]*Binding
 }
 
+type genExecutable struct {
+	WorkingDirectory string
+	Command          string
+	Args             []string
+	Env              map[string]string
+	Bindings         map[string]*Binding
+}
+
 type genProject struct {
 	Path     string
 	Env      map[string]string
@@ -99,6 +149,13 @@ type genBicepTemplateContext struct {
 	ContainerApps                   map[string]genContainerApp
 	AppConfigs                      map[string]genAppConfig
 	DaprComponents                  map[string]genDaprComponent
+	EventHubs                       map[string]genEventHubs
+	SqlServers                      map[string]genSqlServer
+	CosmosDbAccounts                map[string]genCosmosDbAccount
+	RedisCaches                     map[string]genRedisCache
+	SignalRs                        map[string]genSignalR
+	OpenAIs                         map[string]genOpenAI
+	BicepModules                    map[string]genBicepModule
 }
 
 type genContainerAppManifestTemplateContext struct {
@@ -106,6 +163,10 @@ type genContainerAppManifestTemplateContext struct {
 	Ingress *genContainerAppIngress
 	Env     map[string]string
 	Dapr    *genContainerAppManifestTemplateContextDapr
+	// Command and Args override the entrypoint and the arguments of the container image, when set. Their values are
+	// YAML strings, like the values of Env.
+	Command []string
+	Args    []string
 }
 
 type genProjectFileContext struct {
@@ -20,13 +20,24 @@ type Resource struct {
 	// Type is present on all resource types
 	Type string `json:"type"`
 
-	// Path is present on a project.v0 resource and is the path to the project file, and on a dockerfile.v0
-	// resource and is the path to the Dockerfile (including the "Dockerfile" filename).
+	// Path is present on a project.v0 resource and is the path to the project file, on a dockerfile.v0
+	// resource and is the path to the Dockerfile (including the "Dockerfile" filename), and on a bicep.v0 resource and is
+	// the path to the bicep module.
 	Path *string `json:"path,omitempty"`
 
 	// Context is present on a dockerfile.v0 resource and is the path to the context directory.
 	Context *string `json:"context,omitempty"`
 
+	// Command is present on an executable.v0 resource and is the command used to start the executable (e.g. "npm").
+	Command *string `json:"command,omitempty"`
+
+	// Args is optionally present on an executable.v0 resource and is the list of arguments passed to the command.
+	Args []string `json:"args,omitempty"`
+
+	// WorkingDirectory is present on an executable.v0 resource and is the directory the command is run from. This is the
+	// root of the source code of the executable.
+	WorkingDirectory *string `json:"workingDirectory,omitempty"`
+
 	// Parent is present on a resource which is a child of another. It is the name of the parent resource. For example, a
 	// postgres.database.v0 is a child of a postgres.server.v0, and so it would have a parent of which is the name of
 	// the server resource.
@@ -35,12 +46,12 @@ type Resource struct {
 	// Image is present on a container.v0 resource and is the image to use for the container.
 	Image *string `json:"image,omitempty"`
 
-	// Bindings is present on container.v0, project.v0 and dockerfile.v0 resources, and is a map of binding names to
-	// binding details.
+	// Bindings is present on container.v0, project.v0, dockerfile.v0 and executable.v0 resources, and is a map of
+	// binding names to binding details.
 	Bindings map[string]*Binding `json:"bindings,omitempty"`
 
-	// Env is present on project.v0, container.v0 and dockerfile.v0 resources, and is a map of environment variable
-	// names to value  expressions. The value expressions are simple expressions like "{redis.connectionString}" or
+	// Env is present on project.v0, container.v0, dockerfile.v0 and executable.v0 resources, and is a map of environment
+	// variable names to value  expressions. The value expressions are simple expressions like "{redis.connectionString}" or
 	// "{postgres.port}" to allow referencing properties of other resources. The set of properties supported in these
 	// expressions depends on the type of resource you are referencing.
 	Env map[string]string `json:"env,omitempty"`
@@ -51,6 +62,16 @@ type Resource struct {
 	// Topics is optionally present on a azure.servicebus.v0 resource, and is a list of topic names to create.
 	Topics *[]string `json:"topics,omitempty"`
 
+	// Hubs is optionally present on a azure.eventhubs.v0 resource, and is a list of event hub names to create.
+	Hubs *[]string `json:"hubs,omitempty"`
+
+	// Deployments is optionally present on a azure.openai.account.v0 resource, and is a list of models to deploy.
+	Deployments []OpenAIDeployment `json:"deployments,omitempty"`
+
+	// Params is present on a bicep.v0 resource, and is a map of parameter names to the values passed to the module. String
+	// values may contain expressions like "{storage.outputs.blobEndpoint}" to reference the outputs of other modules.
+	Params map[string]any `json:"params,omitempty"`
+
 	// Some resources just represent connections to existing resources that need not be provisioned.  These resources have
 	// a "connectionString" property which is the connection string that should be used during binding.
 	ConnectionString *string `json:"connectionString,omitempty"`
@@ -66,6 +87,18 @@ type Resource struct {
 	Inputs map[string]Input `json:"inputs,omitempty"`
 }
 
+type OpenAIDeployment struct {
+	Name         string               `json:"name"`
+	ModelName    string               `json:"modelName"`
+	ModelVersion string               `json:"modelVersion"`
+	Sku          *OpenAIDeploymentSku `json:"sku,omitempty"`
+}
+
+type OpenAIDeploymentSku struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"`
+}
+
 type DaprResourceMetadata struct {
 	AppId                  *string `json:"appId,omitempty"`
 	Application            *string `json:"application,omitempty"`
@@ -152,6 +185,12 @@ func ManifestFromAppHost(ctx context.Context, appHostProject string, dotnetCli d
 				*res.Context = filepath.Join(manifestDir, *res.Context)
 			}
 		}
+
+		if res.WorkingDirectory != nil {
+			if !filepath.IsAbs(*res.WorkingDirectory) {
+				*res.WorkingDirectory = filepath.Join(manifestDir, *res.WorkingDirectory)
+			}
+		}
 	}
 
 	return &manifest, nil
@@ -0,0 +1,307 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/kballard/go-shellquote"
+)
+
+// federated auth token providers
+var (
+	gitHubFederatedAuth federatedTokenProvider = "github"
+	// fileFederatedAuth reads the federated token from the file named by AZURE_FEDERATED_TOKEN_FILE, like the one projected
+	// by AKS workload identity.
+	fileFederatedAuth federatedTokenProvider = "file"
+	// azurePipelinesFederatedAuth requests the federated token of a service connection from Azure Pipelines.
+	azurePipelinesFederatedAuth federatedTokenProvider = "azure-pipelines"
+	// gitLabFederatedAuth reads the ID token of a GitLab CI job from the environment.
+	gitLabFederatedAuth federatedTokenProvider = "gitlab"
+	// oidcFederatedAuth reads the federated token from an environment variable or the output of a command.
+	oidcFederatedAuth federatedTokenProvider = "oidc"
+)
+
+// FederatedTokenProviders lists the names of the supported federated token providers.
+var FederatedTokenProviders = []string{
+	string(gitHubFederatedAuth),
+	string(azurePipelinesFederatedAuth),
+	string(gitLabFederatedAuth),
+	string(fileFederatedAuth),
+	string(oidcFederatedAuth),
+}
+
+// cFederatedTokenAudience is the audience of the federated tokens exchanged with Microsoft Entra ID.
+const cFederatedTokenAudience = "api://AzureADTokenExchange"
+
+// cFederatedTokenFileEnvVarName is the environment variable holding the path of the federated token file.
+const cFederatedTokenFileEnvVarName = "AZURE_FEDERATED_TOKEN_FILE"
+
+// The environment variables of an Azure Pipelines job used to request the federated token of a service connection.
+// SYSTEM_ACCESSTOKEN must be mapped explicitly from $(System.AccessToken) by the pipeline.
+const (
+	cSystemOidcRequestUriEnvVarName = "SYSTEM_OIDCREQUESTURI"
+	cSystemAccessTokenEnvVarName    = "SYSTEM_ACCESSTOKEN"
+)
+
+// The environment variables holding the id of the Azure Pipelines service connection. The first one is set by the
+// AzureCLI@2 and AzurePowerShell@5 tasks, the second one can be set by the pipeline.
+var cServiceConnectionIdEnvVarNames = []string{
+	"AZURESUBSCRIPTION_SERVICE_CONNECTION_ID",
+	"AZURE_SERVICE_CONNECTION_ID",
+}
+
+// The environment variables holding the ID token of a GitLab CI job, in order of preference. GITLAB_OIDC_TOKEN is the name
+// used for the `id_tokens` of the job in the GitLab documentation, CI_JOB_JWT_V2 is the deprecated predefined token.
+var cGitLabTokenEnvVarNames = []string{
+	"GITLAB_OIDC_TOKEN",
+	"CI_JOB_JWT_V2",
+}
+
+// cDefaultOidcTokenEnvVarName is the environment variable read by the oidc token provider when neither an environment
+// variable nor a command is configured.
+const cDefaultOidcTokenEnvVarName = "AZURE_FEDERATED_TOKEN"
+
+// FederatedTokenProviderOptions configures the federated token providers which read the token from a configurable source.
+type FederatedTokenProviderOptions struct {
+	// The environment variable holding the token, for the oidc provider.
+	TokenEnvVar string
+	// The command printing the token, for the oidc provider. The command is split into arguments with the quoting rules of
+	// POSIX shells, and run without a shell.
+	TokenCommand string
+}
+
+// newFederatedAuth returns the federated auth information to persist for the given provider, resolving the settings
+// which are read from the environment at login.
+func newFederatedAuth(provider string, options *FederatedTokenProviderOptions) (*federatedAuth, error) {
+	tokenProvider := federatedTokenProvider(provider)
+	fedAuth := federatedAuth{
+		TokenProvider: &tokenProvider,
+	}
+
+	if options == nil {
+		options = &FederatedTokenProviderOptions{}
+	}
+
+	if tokenProvider != oidcFederatedAuth && (options.TokenEnvVar != "" || options.TokenCommand != "") {
+		return nil, fmt.Errorf(
+			"a token environment variable or command can only be set for the '%s' federated token provider",
+			oidcFederatedAuth)
+	}
+
+	switch tokenProvider {
+	case fileFederatedAuth:
+		// Store the absolute path of the token file, so the login keeps working from any directory.
+		if tokenFile := os.Getenv(cFederatedTokenFileEnvVarName); tokenFile != "" {
+			absTokenFile, err := filepath.Abs(tokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("resolving federated token file: %w", err)
+			}
+
+			fedAuth.TokenFile = &absTokenFile
+		}
+	case azurePipelinesFederatedAuth:
+		if serviceConnectionId := lookupFirstEnv(cServiceConnectionIdEnvVarNames); serviceConnectionId != "" {
+			fedAuth.ServiceConnectionID = &serviceConnectionId
+		}
+	case oidcFederatedAuth:
+		if options.TokenEnvVar != "" && options.TokenCommand != "" {
+			return nil, errors.New("only one of a token environment variable or a token command can be set")
+		}
+
+		if options.TokenCommand != "" {
+			tokenCommand, err := shellquote.Split(options.TokenCommand)
+			if err != nil {
+				return nil, fmt.Errorf("parsing federated token command: %w", err)
+			}
+
+			if len(tokenCommand) == 0 {
+				return nil, errors.New("the federated token command is empty")
+			}
+
+			fedAuth.TokenCommand = tokenCommand
+		} else {
+			tokenEnvVar := options.TokenEnvVar
+			if tokenEnvVar == "" {
+				tokenEnvVar = cDefaultOidcTokenEnvVarName
+			}
+
+			fedAuth.TokenEnvVar = &tokenEnvVar
+		}
+	}
+
+	return &fedAuth, nil
+}
+
+// federatedTokenFunc returns the function fetching the federated token of the given federated auth information.
+func (m *Manager) federatedTokenFunc(fedAuth federatedAuth) (func(ctx context.Context) (string, error), error) {
+	switch *fedAuth.TokenProvider {
+	case gitHubFederatedAuth:
+		return func(ctx context.Context) (string, error) {
+			federatedToken, err := m.ghClient.TokenForAudience(ctx, cFederatedTokenAudience)
+			if err != nil {
+				return "", fmt.Errorf("fetching federated token: %w", err)
+			}
+
+			return federatedToken, nil
+		}, nil
+	case fileFederatedAuth:
+		if fedAuth.TokenFile == nil || *fedAuth.TokenFile == "" {
+			return nil, fmt.Errorf(
+				"the '%s' federated token provider requires %s to be set", fileFederatedAuth, cFederatedTokenFileEnvVarName)
+		}
+
+		tokenFile := *fedAuth.TokenFile
+		return func(ctx context.Context) (string, error) {
+			// The token file is read on each request, since it is rotated by the platform (e.g. AKS workload identity).
+			federatedToken, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return "", fmt.Errorf("reading federated token file: %w", err)
+			}
+
+			return strings.TrimSpace(string(federatedToken)), nil
+		}, nil
+	case azurePipelinesFederatedAuth:
+		if fedAuth.ServiceConnectionID == nil || *fedAuth.ServiceConnectionID == "" {
+			return nil, fmt.Errorf(
+				"the '%s' federated token provider requires one of %s to be set",
+				azurePipelinesFederatedAuth,
+				strings.Join(cServiceConnectionIdEnvVarNames, ", "))
+		}
+
+		serviceConnectionId := *fedAuth.ServiceConnectionID
+		return func(ctx context.Context) (string, error) {
+			return m.azurePipelinesToken(ctx, serviceConnectionId)
+		}, nil
+	case gitLabFederatedAuth:
+		return func(ctx context.Context) (string, error) {
+			if token := lookupFirstEnv(cGitLabTokenEnvVarNames); token != "" {
+				return token, nil
+			}
+
+			return "", fmt.Errorf(
+				"no GitLab ID token found in %s, define %s in the `id_tokens` of the job",
+				strings.Join(cGitLabTokenEnvVarNames, ", "),
+				cGitLabTokenEnvVarNames[0])
+		}, nil
+	case oidcFederatedAuth:
+		if len(fedAuth.TokenCommand) > 0 {
+			tokenCommand := fedAuth.TokenCommand
+			return func(ctx context.Context) (string, error) {
+				return m.commandToken(ctx, tokenCommand)
+			}, nil
+		}
+
+		if fedAuth.TokenEnvVar == nil || *fedAuth.TokenEnvVar == "" {
+			return nil, fmt.Errorf(
+				"the '%s' federated token provider requires a token environment variable or command", oidcFederatedAuth)
+		}
+
+		tokenEnvVar := *fedAuth.TokenEnvVar
+		return func(ctx context.Context) (string, error) {
+			if token := os.Getenv(tokenEnvVar); token != "" {
+				return token, nil
+			}
+
+			return "", fmt.Errorf("no federated token set in %s", tokenEnvVar)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported federated token provider: '%s'", string(*fedAuth.TokenProvider))
+	}
+}
+
+// azurePipelinesToken requests the federated token of a service connection from the OIDC endpoint of the running Azure
+// Pipelines job, like the AzureCLI@2 task does.
+func (m *Manager) azurePipelinesToken(ctx context.Context, serviceConnectionId string) (string, error) {
+	requestUri := os.Getenv(cSystemOidcRequestUriEnvVarName)
+	if requestUri == "" {
+		return "", fmt.Errorf("no %s set in the environment", cSystemOidcRequestUriEnvVarName)
+	}
+
+	accessToken := os.Getenv(cSystemAccessTokenEnvVarName)
+	if accessToken == "" {
+		return "", fmt.Errorf(
+			"no %s set in the environment, map it from $(System.AccessToken) in the pipeline", cSystemAccessTokenEnvVarName)
+	}
+
+	query := url.Values{}
+	query.Set("api-version", "7.1")
+	query.Set("serviceConnectionId", serviceConnectionId)
+
+	separator := "?"
+	if strings.Contains(requestUri, "?") {
+		separator = "&"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestUri+separator+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := m.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("expected 200 response, got: %d, content: %s", res.StatusCode, body)
+	}
+
+	var tokenResponse struct {
+		OidcToken string `json:"oidcToken"`
+	}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", fmt.Errorf("parsing response: %w", err)
+	}
+
+	if tokenResponse.OidcToken == "" {
+		return "", errors.New("no token in response")
+	}
+
+	return tokenResponse.OidcToken, nil
+}
+
+// commandToken runs the given command, the program followed by its arguments, and returns its output as the federated token.
+func (m *Manager) commandToken(ctx context.Context, command []string) (string, error) {
+	res, err := m.commandRunner.Run(ctx, exec.NewRunArgs(command[0], command[1:]...))
+	if err != nil {
+		return "", fmt.Errorf("running federated token command: %w", err)
+	}
+
+	token := strings.TrimSpace(res.Stdout)
+	if token == "" {
+		return "", errors.New("the federated token command printed no token")
+	}
+
+	return token, nil
+}
+
+// lookupFirstEnv returns the value of the first of the given environment variables which is set, or the empty string.
+func lookupFirstEnv(names []string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
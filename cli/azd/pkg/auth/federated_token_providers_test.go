@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederatedTokenProviders(t *testing.T) {
+	federatedToken := func(t *testing.T, m *Manager, provider string, options *FederatedTokenProviderOptions) (string, error) {
+		fedAuth, err := newFederatedAuth(provider, options)
+		require.NoError(t, err)
+
+		getAssertion, err := m.federatedTokenFunc(*fedAuth)
+		if err != nil {
+			return "", err
+		}
+
+		return getAssertion(context.Background())
+	}
+
+	t.Run("AzurePipelines", func(t *testing.T) {
+		t.Setenv("SYSTEM_OIDCREQUESTURI", "https://dev.azure.com/org/00000000-0000-0000-0000-000000000000/_apis/oidctoken")
+		t.Setenv("SYSTEM_ACCESSTOKEN", "system-access-token")
+		t.Setenv("AZURESUBSCRIPTION_SERVICE_CONNECTION_ID", "service-connection-id")
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPost &&
+				strings.HasSuffix(request.URL.Path, "/_apis/oidctoken") &&
+				request.URL.Query().Get("serviceConnectionId") == "service-connection-id" &&
+				request.Header.Get("Authorization") == "Bearer system-access-token"
+		}).Respond(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{ "oidcToken": "azure-pipelines-token" }`)),
+		})
+
+		m := &Manager{httpClient: mockContext.HttpClient}
+
+		token, err := federatedToken(t, m, "azure-pipelines", nil)
+		require.NoError(t, err)
+		require.Equal(t, "azure-pipelines-token", token)
+
+		t.Setenv("SYSTEM_ACCESSTOKEN", "")
+		_, err = federatedToken(t, m, "azure-pipelines", nil)
+		require.ErrorContains(t, err, "System.AccessToken")
+	})
+
+	t.Run("AzurePipelinesNoServiceConnection", func(t *testing.T) {
+		t.Setenv("AZURESUBSCRIPTION_SERVICE_CONNECTION_ID", "")
+		t.Setenv("AZURE_SERVICE_CONNECTION_ID", "")
+
+		_, err := federatedToken(t, &Manager{}, "azure-pipelines", nil)
+		require.ErrorContains(t, err, "AZURESUBSCRIPTION_SERVICE_CONNECTION_ID")
+	})
+
+	t.Run("GitLab", func(t *testing.T) {
+		t.Setenv("GITLAB_OIDC_TOKEN", "")
+		t.Setenv("CI_JOB_JWT_V2", "")
+
+		_, err := federatedToken(t, &Manager{}, "gitlab", nil)
+		require.ErrorContains(t, err, "no GitLab ID token found")
+
+		t.Setenv("CI_JOB_JWT_V2", "legacy-token")
+		token, err := federatedToken(t, &Manager{}, "gitlab", nil)
+		require.NoError(t, err)
+		require.Equal(t, "legacy-token", token)
+
+		t.Setenv("GITLAB_OIDC_TOKEN", "id-token")
+		token, err = federatedToken(t, &Manager{}, "gitlab", nil)
+		require.NoError(t, err)
+		require.Equal(t, "id-token", token)
+	})
+
+	t.Run("OidcEnvVar", func(t *testing.T) {
+		t.Setenv("AZURE_FEDERATED_TOKEN", "default-token")
+		t.Setenv("MY_TOKEN", "my-token")
+
+		token, err := federatedToken(t, &Manager{}, "oidc", nil)
+		require.NoError(t, err)
+		require.Equal(t, "default-token", token)
+
+		token, err = federatedToken(t, &Manager{}, "oidc", &FederatedTokenProviderOptions{TokenEnvVar: "MY_TOKEN"})
+		require.NoError(t, err)
+		require.Equal(t, "my-token", token)
+	})
+
+	t.Run("OidcCommand", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return !args.UseShell &&
+				args.Cmd == "vault" &&
+				slices.Equal(args.Args, []string{"read", "-field=token", "secret/azure team"})
+		}).Respond(exec.NewRunResult(0, "command-token\n", ""))
+
+		m := &Manager{commandRunner: mockContext.CommandRunner}
+
+		token, err := federatedToken(t, m, "oidc", &FederatedTokenProviderOptions{
+			TokenCommand: `vault read -field=token "secret/azure team"`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "command-token", token)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := newFederatedAuth("github", &FederatedTokenProviderOptions{TokenEnvVar: "MY_TOKEN"})
+		require.Error(t, err)
+
+		_, err = newFederatedAuth("oidc", &FederatedTokenProviderOptions{TokenEnvVar: "MY_TOKEN", TokenCommand: "echo"})
+		require.Error(t, err)
+
+		_, err = newFederatedAuth("oidc", &FederatedTokenProviderOptions{TokenCommand: `echo "unterminated`})
+		require.ErrorContains(t, err, "parsing federated token command")
+
+		_, err = federatedToken(t, &Manager{}, "unknown", nil)
+		require.ErrorContains(t, err, "unsupported federated token provider")
+	})
+}
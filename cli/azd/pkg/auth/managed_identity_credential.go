@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// ManagedIdentityCredential acquires tokens for the system-assigned managed identity of the host, or for one of its
+// user-assigned managed identities. It wraps the managed identity credential of the Azure SDK, which finds the identity
+// endpoint of the host (IMDS, or the IDENTITY_ENDPOINT and IDENTITY_HEADER of App Service, Azure Arc and Service Fabric),
+// retries IMDS while it starts up and caches tokens until they expire.
+type ManagedIdentityCredential struct {
+	credential *azidentity.ManagedIdentityCredential
+}
+
+// NewManagedIdentityCredential creates a credential for the managed identity with the given client id, or for the
+// system-assigned managed identity when clientID is empty.
+func NewManagedIdentityCredential(clientID string, options policy.ClientOptions) (*ManagedIdentityCredential, error) {
+	credentialOptions := &azidentity.ManagedIdentityCredentialOptions{
+		ClientOptions: options,
+	}
+	if clientID != "" {
+		credentialOptions.ID = azidentity.ClientID(clientID)
+	}
+
+	credential, err := azidentity.NewManagedIdentityCredential(credentialOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating managed identity credential: %w", err)
+	}
+
+	return &ManagedIdentityCredential{
+		credential: credential,
+	}, nil
+}
+
+func (c *ManagedIdentityCredential) GetToken(
+	ctx context.Context,
+	options policy.TokenRequestOptions,
+) (azcore.AccessToken, error) {
+	return c.credential.GetToken(ctx, options)
+}
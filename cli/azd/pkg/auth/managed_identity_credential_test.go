@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/require"
+)
+
+// cManagedIdentityToken is an unsigned token with a single claim named tid with the value "test-tenant-id".
+//
+//nolint:gosec
+const cManagedIdentityToken = "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.eyJ0aWQiOiJ0ZXN0LXRlbmFudC1pZCJ9."
+
+const cIdentityHeader = "test-identity-header"
+
+// newIdentityServer starts a local stand-in of the identity endpoint of App Service, which issues tokens to the managed
+// identity with the given client id, and points IDENTITY_ENDPOINT and IDENTITY_HEADER to it. It returns the number of
+// token requests it served.
+func newIdentityServer(t *testing.T, clientID string) *atomic.Int32 {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query := r.URL.Query()
+
+		if r.Method != http.MethodGet ||
+			r.Header.Get("X-IDENTITY-HEADER") != cIdentityHeader ||
+			query.Get("api-version") != "2019-08-01" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if query.Get("client_id") != clientID {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"invalid_request","error_description":"Identity not found"}`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w,
+			`{"access_token":"%s","expires_on":"%d","resource":"%s","token_type":"Bearer"}`,
+			cManagedIdentityToken,
+			time.Now().Add(time.Hour).Unix(),
+			query.Get("resource"))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("IDENTITY_ENDPOINT", server.URL)
+	t.Setenv("IDENTITY_HEADER", cIdentityHeader)
+
+	return &requests
+}
+
+func TestManagedIdentityCredentialGetToken(t *testing.T) {
+	t.Run("SystemAssigned", func(t *testing.T) {
+		requests := newIdentityServer(t, "")
+		cred, err := NewManagedIdentityCredential("", policy.ClientOptions{})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{
+				Scopes: []string{"https://management.azure.com//.default"},
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, cManagedIdentityToken, token.Token)
+			require.True(t, token.ExpiresOn.After(time.Now()))
+		}
+
+		// The token is cached until it expires.
+		require.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("UserAssigned", func(t *testing.T) {
+		newIdentityServer(t, "user-assigned-client-id")
+		cred, err := NewManagedIdentityCredential("user-assigned-client-id", policy.ClientOptions{})
+		require.NoError(t, err)
+
+		token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{
+			Scopes: []string{"https://management.azure.com//.default"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, cManagedIdentityToken, token.Token)
+	})
+
+	t.Run("IdentityNotFound", func(t *testing.T) {
+		newIdentityServer(t, "user-assigned-client-id")
+		cred, err := NewManagedIdentityCredential("", policy.ClientOptions{})
+		require.NoError(t, err)
+
+		_, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{
+			Scopes: []string{"https://management.azure.com//.default"},
+		})
+
+		require.ErrorContains(t, err, "404")
+	})
+
+	t.Run("MultipleScopes", func(t *testing.T) {
+		cred, err := NewManagedIdentityCredential("", policy.ClientOptions{})
+		require.NoError(t, err)
+
+		_, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{
+			Scopes: []string{"one", "two"},
+		})
+
+		require.Error(t, err)
+	})
+}
@@ -20,8 +20,9 @@ import (
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/public"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
-	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/github"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -48,21 +49,39 @@ const cUseAzCliAuthKey = "auth.useAzCliAuth"
 // auth related configuration information (e.g. the home account id of the current user). This information is not secret.
 const cAuthConfigFileName = "auth.json"
 
-// cDefaultAuthority is the default authority to use when a specific tenant is not presented. We use "organizations" to
-// allow both work/school accounts and personal accounts (this matches the default authority the `az` CLI uses when logging
-// in).
-const cDefaultAuthority = "https://login.microsoftonline.com/organizations"
+// cDefaultAuthorityTenant is the tenant of the default authority to use when a specific tenant is not presented. We use
+// "organizations" to allow both work/school accounts and personal accounts (this matches the default authority the `az` CLI
+// uses when logging in).
+const cDefaultAuthorityTenant = "organizations"
+
+// cDefaultDeviceCodeUrl is the page to enter device codes, when the authority doesn't tell one.
+const cDefaultDeviceCodeUrl = "https://microsoft.com/devicelogin"
 
 const cUseCloudShellAuthEnvVar = "AZD_IN_CLOUDSHELL"
 
 const cExternalAuthEndpointEnvVarName = "AZD_AUTH_ENDPOINT"
 const cExternalAuthKeyEnvVarName = "AZD_AUTH_KEY"
 
-// The scopes to request when acquiring our token during the login flow or when requesting a token to validate if the client
-// is logged in.
-var LoginScopes = []string{azure.ManagementScope}
+// LoginScopes returns the scopes to request when acquiring our token during the login flow or when requesting a token to
+// validate if the client is logged in: the Resource Manager scope of the cloud.
+func LoginScopes(cloud *cloud.Cloud) []string {
+	return []string{cloud.ResourceManagerScope()}
+}
+
+// ResourceScopes returns the scopes to request to acquire a token for the given resource, like
+// https://ossrdbms-aad.database.windows.net. This matches the `--resource` argument of `az account get-access-token`.
+func ResourceScopes(resource string) []string {
+	return []string{resource + cDefaultSuffix}
+}
+
+// AzureDevOpsScopes are the scopes to request to acquire a token for Azure DevOps, like Azure Repos.
+var AzureDevOpsScopes = []string{"499b84ac-1321-427f-aa17-267ca6975798" + cDefaultSuffix}
+
+// loginScopesMap holds the login scopes of the known clouds.
 var loginScopesMap = map[string]struct{}{
-	azure.ManagementScope: {},
+	cloud.AzurePublic().ResourceManagerScope():     {},
+	cloud.AzureGovernment().ResourceManagerScope(): {},
+	cloud.AzureChina().ResourceManagerScope():      {},
 }
 
 // HttpClient interface as required by MSAL library.
@@ -84,6 +103,9 @@ type HttpClient interface {
 // When logged in as a service principal, the same cache strategy that backed the MSAL cache is used to store the private
 // key or secret and the public components (the client ID and tenant ID) are stored under  [cCurrentUserKey].
 //
+// Logins can be saved as named profiles, stored under [cProfilesKey]. The login of the current profile is also stored under
+// [cCurrentUserKey], and an environment can pin the profile it uses, which is returned by the [ProfileResolver].
+//
 // Logging out removes this cached authentication data.
 //
 // You can configure azd to ignore its native credential system and instead delegate to AZ CLI (useful for cases where azd
@@ -96,14 +118,22 @@ type Manager struct {
 	credentialCache     Cache
 	ghClient            *github.FederatedTokenClient
 	httpClient          HttpClient
+	commandRunner       exec.CommandRunner
 	console             input.Console
+	cloud               *cloud.Cloud
+	profileResolver     ProfileResolver
+	// The profile to use instead of the resolved profile, set by WithProfile.
+	profile string
 }
 
 func NewManager(
 	configManager config.FileConfigManager,
 	userConfigManager config.UserConfigManager,
+	cloud *cloud.Cloud,
 	httpClient HttpClient,
+	commandRunner exec.CommandRunner,
 	console input.Console,
+	profileResolver ProfileResolver,
 ) (*Manager, error) {
 	cfgRoot, err := config.GetUserConfigDir()
 	if err != nil {
@@ -122,7 +152,7 @@ func NewManager(
 
 	options := []public.Option{
 		public.WithCache(newCache(cacheRoot)),
-		public.WithAuthority(cDefaultAuthority),
+		public.WithAuthority(cloud.AuthorityHost() + cDefaultAuthorityTenant),
 		public.WithHTTPClient(httpClient),
 	}
 
@@ -141,15 +171,32 @@ func NewManager(
 		credentialCache:     newCredentialCache(authRoot),
 		ghClient:            ghClient,
 		httpClient:          httpClient,
+		commandRunner:       commandRunner,
 		console:             console,
+		cloud:               cloud,
+		profileResolver:     profileResolver,
 	}, nil
 }
 
-// EnsureLoggedInCredential uses the credential's GetToken method to ensure an access token can be fetched.
+// Cloud returns the cloud the manager logs in to.
+func (m *Manager) Cloud() *cloud.Cloud {
+	return m.cloud
+}
+
+// LoginScopes returns the scopes requested when logging in to the cloud of the manager.
+func (m *Manager) LoginScopes() []string {
+	return LoginScopes(m.cloud)
+}
+
+// EnsureLoggedInCredential uses the credential's GetToken method to ensure an access token can be fetched for the cloud.
 // On success, the token we fetched is returned.
-func EnsureLoggedInCredential(ctx context.Context, credential azcore.TokenCredential) (*azcore.AccessToken, error) {
+func EnsureLoggedInCredential(
+	ctx context.Context,
+	credential azcore.TokenCredential,
+	cloud *cloud.Cloud,
+) (*azcore.AccessToken, error) {
 	token, err := credential.GetToken(ctx, policy.TokenRequestOptions{
-		Scopes: LoginScopes,
+		Scopes: LoginScopes(cloud),
 	})
 	if err != nil {
 		return &azcore.AccessToken{}, err
@@ -199,7 +246,7 @@ func (m *Manager) CredentialForCurrentUser(
 		return nil, fmt.Errorf("reading auth config: %w", err)
 	}
 
-	currentUser, err := readUserProperties(authConfig)
+	currentUser, err := m.readCurrentUser(ctx, authConfig, options.Profile)
 	if errors.Is(err, ErrNoCurrentUser) {
 		// User is not logged in, not using az credentials, try CloudShell if possible
 		if ShouldUseCloudShellAuth() {
@@ -209,7 +256,9 @@ func (m *Manager) CredentialForCurrentUser(
 			}
 			return cloudShellCredential, nil
 		}
-		return nil, ErrNoCurrentUser
+		return nil, err
+	} else if err != nil {
+		return nil, err
 	}
 
 	if currentUser.HomeAccountID != nil {
@@ -222,7 +271,7 @@ func (m *Manager) CredentialForCurrentUser(
 				if options.TenantID == "" {
 					return newAzdCredential(m.publicClient, &accounts[i]), nil
 				} else {
-					newAuthority := "https://login.microsoftonline.com/" + options.TenantID
+					newAuthority := m.cloud.AuthorityHost() + options.TenantID
 
 					newOptions := make([]public.Option, 0, len(m.publicClientOptions)+1)
 					newOptions = append(newOptions, m.publicClientOptions...)
@@ -240,6 +289,14 @@ func (m *Manager) CredentialForCurrentUser(
 				}
 			}
 		}
+	} else if currentUser.ManagedIdentity {
+		// A managed identity belongs to a single tenant, the tenant override is not honored.
+		clientID := ""
+		if currentUser.ClientID != nil {
+			clientID = *currentUser.ClientID
+		}
+
+		return m.newCredentialFromManagedIdentity(clientID)
 	} else if currentUser.TenantID != nil && currentUser.ClientID != nil {
 		ps, err := m.loadSecret(*currentUser.TenantID, *currentUser.ClientID)
 		if err != nil {
@@ -260,8 +317,7 @@ func (m *Manager) CredentialForCurrentUser(
 		} else if ps.ClientCertificate != nil {
 			return m.newCredentialFromClientCertificate(tenantID, *currentUser.ClientID, *ps.ClientCertificate)
 		} else if ps.FederatedAuth != nil && ps.FederatedAuth.TokenProvider != nil {
-			return m.newCredentialFromFederatedTokenProvider(
-				tenantID, *currentUser.ClientID, *ps.FederatedAuth.TokenProvider)
+			return m.newCredentialFromFederatedTokenProvider(tenantID, *currentUser.ClientID, *ps.FederatedAuth)
 		}
 	}
 
@@ -319,8 +375,8 @@ func (m *Manager) GetLoggedInServicePrincipalTenantID(ctx context.Context) (*str
 		return nil, fmt.Errorf("fetching auth config: %w", err)
 	}
 
-	currentUser, err := readUserProperties(authCfg)
-	if err != nil {
+	currentUser, err := m.readCurrentUser(ctx, authCfg, "")
+	if errors.Is(err, ErrNoCurrentUser) {
 		// No user is logged in, if running in CloudShell use tenant id from
 		// CloudShell session (single tenant)
 		if ShouldUseCloudShellAuth() {
@@ -330,7 +386,7 @@ func (m *Manager) GetLoggedInServicePrincipalTenantID(ctx context.Context) (*str
 				return nil, err
 			}
 
-			token, err := EnsureLoggedInCredential(ctx, credential)
+			token, err := EnsureLoggedInCredential(ctx, credential, m.cloud)
 			if err != nil {
 				return nil, err
 			}
@@ -343,11 +399,15 @@ func (m *Manager) GetLoggedInServicePrincipalTenantID(ctx context.Context) (*str
 			return &tenantId, nil
 		}
 
-		return nil, ErrNoCurrentUser
+		return nil, err
+	} else if err != nil {
+		return nil, err
 	}
 
 	// Record type of account found
-	if currentUser.TenantID != nil {
+	if currentUser.ManagedIdentity {
+		tracing.SetGlobalAttributes(fields.AccountTypeKey.String(fields.AccountTypeManagedIdentity))
+	} else if currentUser.TenantID != nil {
 		tracing.SetGlobalAttributes(fields.AccountTypeKey.String(fields.AccountTypeServicePrincipal))
 	}
 
@@ -363,9 +423,7 @@ func (m *Manager) newCredentialFromClientSecret(
 	clientID string,
 	clientSecret string) (azcore.TokenCredential, error) {
 	options := &azidentity.ClientSecretCredentialOptions{
-		ClientOptions: policy.ClientOptions{
-			Transport: m.httpClient,
-		},
+		ClientOptions: m.clientOptions(),
 	}
 	cred, err := azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, options)
 	if err != nil {
@@ -391,9 +449,7 @@ func (m *Manager) newCredentialFromClientCertificate(
 	}
 
 	options := &azidentity.ClientCertificateCredentialOptions{
-		ClientOptions: policy.ClientOptions{
-			Transport: m.httpClient,
-		},
+		ClientOptions: m.clientOptions(),
 	}
 	cred, err := azidentity.NewClientCertificateCredential(
 		tenantID, clientID, certs, key, options)
@@ -408,28 +464,17 @@ func (m *Manager) newCredentialFromClientCertificate(
 func (m *Manager) newCredentialFromFederatedTokenProvider(
 	tenantID string,
 	clientID string,
-	provider federatedTokenProvider,
+	fedAuth federatedAuth,
 ) (azcore.TokenCredential, error) {
-	if provider != gitHubFederatedAuth {
-		return nil, fmt.Errorf("unsupported federated token provider: '%s'", string(provider))
+	getAssertion, err := m.federatedTokenFunc(fedAuth)
+	if err != nil {
+		return nil, err
 	}
+
 	options := &azidentity.ClientAssertionCredentialOptions{
-		ClientOptions: policy.ClientOptions{
-			Transport: m.httpClient,
-		},
+		ClientOptions: m.clientOptions(),
 	}
-	cred, err := azidentity.NewClientAssertionCredential(
-		tenantID,
-		clientID,
-		func(ctx context.Context) (string, error) {
-			federatedToken, err := m.ghClient.TokenForAudience(ctx, "api://AzureADTokenExchange")
-			if err != nil {
-				return "", fmt.Errorf("fetching federated token: %w", err)
-			}
-
-			return federatedToken, nil
-		},
-		options)
+	cred, err := azidentity.NewClientAssertionCredential(tenantID, clientID, getAssertion, options)
 	if err != nil {
 		return nil, fmt.Errorf("creating credential: %w", err)
 	}
@@ -437,6 +482,18 @@ func (m *Manager) newCredentialFromFederatedTokenProvider(
 	return cred, nil
 }
 
+// clientOptions returns the options of the azidentity credentials, which authenticate against the authority of the cloud.
+func (m *Manager) clientOptions() policy.ClientOptions {
+	return policy.ClientOptions{
+		Transport: m.httpClient,
+		Cloud:     m.cloud.Configuration,
+	}
+}
+
+func (m *Manager) newCredentialFromManagedIdentity(clientID string) (azcore.TokenCredential, error) {
+	return NewManagedIdentityCredential(clientID, m.clientOptions())
+}
+
 func (m *Manager) newCredentialFromCloudShell() (azcore.TokenCredential, error) {
 	return NewCloudShellCredential(m.httpClient), nil
 }
@@ -457,7 +514,7 @@ func (m *Manager) LoginInteractive(
 	scopes []string,
 	options *LoginInteractiveOptions) (azcore.TokenCredential, error) {
 	if scopes == nil {
-		scopes = LoginScopes
+		scopes = m.LoginScopes()
 	}
 	acquireTokenOptions := []public.AcquireInteractiveOption{}
 	if options == nil {
@@ -482,7 +539,7 @@ func (m *Manager) LoginInteractive(
 		return nil, err
 	}
 
-	if err := m.saveLoginForPublicClient(res); err != nil {
+	if err := m.saveLoginForPublicClient(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -492,7 +549,7 @@ func (m *Manager) LoginInteractive(
 func (m *Manager) LoginWithDeviceCode(
 	ctx context.Context, tenantID string, scopes []string, withOpenUrl WithOpenUrl) (azcore.TokenCredential, error) {
 	if scopes == nil {
-		scopes = LoginScopes
+		scopes = m.LoginScopes()
 	}
 	options := []public.AcquireByDeviceCodeOption{}
 	if tenantID != "" {
@@ -508,7 +565,10 @@ func (m *Manager) LoginWithDeviceCode(
 		return nil, err
 	}
 
-	url := "https://microsoft.com/devicelogin"
+	url := code.VerificationURL()
+	if url == "" {
+		url = cDefaultDeviceCodeUrl
+	}
 
 	if ShouldUseCloudShellAuth() {
 		m.console.MessageUxItem(ctx, &ux.MultilineMessage{
@@ -544,7 +604,7 @@ func (m *Manager) LoginWithDeviceCode(
 	}
 	m.console.Message(ctx, "Device code authentication completed.")
 
-	if err := m.saveLoginForPublicClient(res); err != nil {
+	if err := m.saveLoginForPublicClient(ctx, res); err != nil {
 		return nil, err
 	}
 
@@ -555,12 +615,14 @@ func (m *Manager) LoginWithDeviceCode(
 func (m *Manager) LoginWithServicePrincipalSecret(
 	ctx context.Context, tenantId, clientId, clientSecret string,
 ) (azcore.TokenCredential, error) {
-	cred, err := azidentity.NewClientSecretCredential(tenantId, clientId, clientSecret, nil)
+	cred, err := azidentity.NewClientSecretCredential(
+		tenantId, clientId, clientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: m.clientOptions()})
 	if err != nil {
 		return nil, fmt.Errorf("creating credential: %w", err)
 	}
 
 	if err := m.saveLoginForServicePrincipal(
+		ctx,
 		tenantId,
 		clientId,
 		&persistedSecret{
@@ -581,7 +643,8 @@ func (m *Manager) LoginWithServicePrincipalCertificate(
 		return nil, fmt.Errorf("parsing certificate: %w", err)
 	}
 
-	cred, err := azidentity.NewClientCertificateCredential(tenantId, clientId, certs, key, nil)
+	cred, err := azidentity.NewClientCertificateCredential(
+		tenantId, clientId, certs, key, &azidentity.ClientCertificateCredentialOptions{ClientOptions: m.clientOptions()})
 	if err != nil {
 		return nil, fmt.Errorf("creating credential: %w", err)
 	}
@@ -589,6 +652,7 @@ func (m *Manager) LoginWithServicePrincipalCertificate(
 	encodedCert := base64.StdEncoding.EncodeToString(certData)
 
 	if err := m.saveLoginForServicePrincipal(
+		ctx,
 		tenantId,
 		clientId,
 		&persistedSecret{
@@ -601,21 +665,27 @@ func (m *Manager) LoginWithServicePrincipalCertificate(
 	return cred, nil
 }
 
+// LoginWithServicePrincipalFederatedTokenProvider logs in as a service principal, presenting the federated tokens of the
+// given provider. options configures the providers which read the token from a configurable source, and may be nil.
 func (m *Manager) LoginWithServicePrincipalFederatedTokenProvider(
-	ctx context.Context, tenantId, clientId, provider string,
+	ctx context.Context, tenantId, clientId, provider string, options *FederatedTokenProviderOptions,
 ) (azcore.TokenCredential, error) {
-	cred, err := m.newCredentialFromFederatedTokenProvider(tenantId, clientId, federatedTokenProvider(provider))
+	fedAuth, err := newFederatedAuth(provider, options)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := m.newCredentialFromFederatedTokenProvider(tenantId, clientId, *fedAuth)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := m.saveLoginForServicePrincipal(
+		ctx,
 		tenantId,
 		clientId,
 		&persistedSecret{
-			FederatedAuth: &federatedAuth{
-				TokenProvider: &gitHubFederatedAuth,
-			},
+			FederatedAuth: fedAuth,
 		},
 	); err != nil {
 		return nil, err
@@ -624,38 +694,95 @@ func (m *Manager) LoginWithServicePrincipalFederatedTokenProvider(
 	return cred, nil
 }
 
-// Logout signs out the current user and removes any cached authentication information
-func (m *Manager) Logout(ctx context.Context) error {
-	act, err := m.getSignedInAccount(ctx)
-	if err != nil && !errors.Is(err, ErrNoCurrentUser) {
-		return fmt.Errorf("fetching current user: %w", err)
+// LoginWithManagedIdentity logs in with the managed identity of the host. The system-assigned managed identity is used when
+// clientId is empty, otherwise the user-assigned managed identity with the given client id.
+func (m *Manager) LoginWithManagedIdentity(ctx context.Context, clientId string) (azcore.TokenCredential, error) {
+	cred, err := m.newCredentialFromManagedIdentity(clientId)
+	if err != nil {
+		return nil, err
 	}
 
-	if act != nil {
-		if err := m.publicClient.RemoveAccount(ctx, *act); err != nil {
-			return fmt.Errorf("removing account from msal cache: %w", err)
-		}
+	// Managed identities are fixed to the tenant of the host, which is recorded from a token to avoid looking it up later.
+	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: m.LoginScopes()})
+	if err != nil {
+		return nil, fmt.Errorf("acquiring managed identity token: %w", err)
+	}
+
+	tenantId, err := GetTenantIdFromToken(token.Token)
+	if err != nil {
+		return nil, err
 	}
 
+	user := userProperties{
+		ManagedIdentity: true,
+		TenantID:        &tenantId,
+	}
+	if clientId != "" {
+		user.ClientID = &clientId
+	}
+
+	if err := m.saveUserProperties(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
+
+// Logout signs out of the profile returned by loginProfile and removes any cached authentication
+// information which is not used by another profile. When logging out of the current profile, the default profile becomes
+// the current profile.
+func (m *Manager) Logout(ctx context.Context) error {
 	cfg, err := m.readAuthConfig()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
-	// we are fine to ignore the error here, it just means there's nothing to clean up.
-	currentUser, _ := readUserProperties(cfg)
+	profiles, err := readProfiles(cfg)
+	if err != nil {
+		return fmt.Errorf("fetching current user: %w", err)
+	}
+
+	current := currentProfileName(cfg)
+	profile := m.loginProfile(cfg)
 
-	// When logged in as a service principal, remove the stored credential
-	if currentUser != nil && currentUser.TenantID != nil && currentUser.ClientID != nil {
-		if err := m.saveLoginForServicePrincipal(
-			*currentUser.TenantID, *currentUser.ClientID, &persistedSecret{},
-		); err != nil {
-			return fmt.Errorf("removing authentication secrets: %w", err)
+	if currentUser, has := profiles[profile]; has && !sharesIdentity(profiles, profile, currentUser) {
+		act, err := m.getAccount(ctx, currentUser)
+		if err != nil {
+			return fmt.Errorf("fetching current user: %w", err)
+		}
+
+		if act != nil {
+			if err := m.publicClient.RemoveAccount(ctx, *act); err != nil {
+				return fmt.Errorf("removing account from msal cache: %w", err)
+			}
+		}
+
+		// When logged in as a service principal, remove the stored credential
+		if !currentUser.ManagedIdentity && currentUser.TenantID != nil && currentUser.ClientID != nil {
+			if err := m.saveSecret(*currentUser.TenantID, *currentUser.ClientID, &persistedSecret{}); err != nil {
+				return fmt.Errorf("removing authentication secrets: %w", err)
+			}
 		}
 	}
 
-	if err := cfg.Unset(cCurrentUserKey); err != nil {
-		return fmt.Errorf("un-setting current user: %w", err)
+	if err := cfg.Unset(profileKey(profile)); err != nil {
+		return fmt.Errorf("un-setting profile: %w", err)
+	}
+
+	if profile == current {
+		if err := cfg.Unset(cCurrentUserKey); err != nil {
+			return fmt.Errorf("un-setting current user: %w", err)
+		}
+
+		if err := cfg.Unset(cCurrentProfileKey); err != nil {
+			return fmt.Errorf("un-setting current profile: %w", err)
+		}
+
+		if defaultUser, has := profiles[DefaultProfileName]; has && profile != DefaultProfileName {
+			if err := cfg.Set(cCurrentUserKey, *defaultUser); err != nil {
+				return fmt.Errorf("setting current user: %w", err)
+			}
+		}
 	}
 
 	if err := m.saveAuthConfig(cfg); err != nil {
@@ -672,46 +799,38 @@ func (m *Manager) UseExternalAuth() bool {
 	return hasEndpoint && hasKey
 }
 
-func (m *Manager) saveLoginForPublicClient(res public.AuthResult) error {
-	if err := m.saveUserProperties(&userProperties{HomeAccountID: &res.Account.HomeAccountID}); err != nil {
+func (m *Manager) saveLoginForPublicClient(ctx context.Context, res public.AuthResult) error {
+	if err := m.saveUserProperties(ctx, &userProperties{HomeAccountID: &res.Account.HomeAccountID}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (m *Manager) saveLoginForServicePrincipal(tenantId, clientId string, secret *persistedSecret) error {
+func (m *Manager) saveLoginForServicePrincipal(
+	ctx context.Context, tenantId, clientId string, secret *persistedSecret,
+) error {
 	if err := m.saveSecret(tenantId, clientId, secret); err != nil {
 		return err
 	}
 
-	if err := m.saveUserProperties(&userProperties{ClientID: &clientId, TenantID: &tenantId}); err != nil {
+	if err := m.saveUserProperties(ctx, &userProperties{ClientID: &clientId, TenantID: &tenantId}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// getSignedInAccount fetches the public.Account for the signed in user, or nil if one does not exist
+// getAccount fetches the public.Account of the given user, or nil if one does not exist
 // (e.g when logged in with a service principal).
-func (m *Manager) getSignedInAccount(ctx context.Context) (*public.Account, error) {
-	cfg, err := m.readAuthConfig()
-	if err != nil {
-		return nil, fmt.Errorf("fetching current user: %w", err)
-	}
-
-	currentUser, err := readUserProperties(cfg)
-	if err != nil {
-		return nil, ErrNoCurrentUser
-	}
-
-	if currentUser.HomeAccountID != nil {
+func (m *Manager) getAccount(ctx context.Context, user *userProperties) (*public.Account, error) {
+	if user.HomeAccountID != nil {
 		accounts, err := m.publicClient.Accounts(ctx)
 		if err != nil {
 			return nil, err
 		}
 		for _, account := range accounts {
-			if account.HomeAccountID == *currentUser.HomeAccountID {
+			if account.HomeAccountID == *user.HomeAccountID {
 				return &account, nil
 			}
 		}
@@ -720,15 +839,16 @@ func (m *Manager) getSignedInAccount(ctx context.Context) (*public.Account, erro
 	return nil, nil
 }
 
-// saveUserProperties writes the properties under [cCurrentUserKey], overwriting any existing value.
-func (m *Manager) saveUserProperties(user *userProperties) error {
+// saveUserProperties writes the properties as the login of the profile returned by loginProfile, overwriting any existing
+// value. The profile becomes the current profile.
+func (m *Manager) saveUserProperties(ctx context.Context, user *userProperties) error {
 	cfg, err := m.readAuthConfig()
 	if err != nil {
 		return fmt.Errorf("fetching current user: %w", err)
 	}
 
-	if err := cfg.Set(cCurrentUserKey, *user); err != nil {
-		return fmt.Errorf("setting account id in config: %w", err)
+	if err := setCurrentProfile(cfg, m.loginProfile(cfg), user); err != nil {
+		return err
 	}
 
 	return m.saveAuthConfig(cfg)
@@ -829,6 +949,9 @@ func (m *Manager) saveSecret(tenantId, clientId string, ps *persistedSecret) err
 type CredentialForCurrentUserOptions struct {
 	// The tenant ID to use when constructing the credential, instead of the default tenant.
 	TenantID string
+
+	// The profile to use, instead of the profile pinned by the environment or the current profile.
+	Profile string
 }
 
 // persistedSecret is the model type for the value we store in the credential cache. It is logically a discriminated union
@@ -845,11 +968,6 @@ type persistedSecret struct {
 	FederatedAuth *federatedAuth `json:"federatedAuth,omitempty"`
 }
 
-// federated auth token providers
-var (
-	gitHubFederatedAuth federatedTokenProvider = "github"
-)
-
 // token provider for federated auth
 type federatedTokenProvider string
 
@@ -857,15 +975,28 @@ type federatedTokenProvider string
 type federatedAuth struct {
 	// The auth token provider. Tokens are obtained by calling the provider as needed.
 	TokenProvider *federatedTokenProvider `json:"tokenProvider,omitempty"`
+
+	// The path of the file holding the federated token, for the file token provider.
+	TokenFile *string `json:"tokenFile,omitempty"`
+
+	// The id of the service connection to request tokens for, for the azure-pipelines token provider.
+	ServiceConnectionID *string `json:"serviceConnectionId,omitempty"`
+
+	// The environment variable holding the federated token, for the oidc token provider.
+	TokenEnvVar *string `json:"tokenEnvVar,omitempty"`
+
+	// The program and arguments of the command printing the federated token, for the oidc token provider.
+	TokenCommand []string `json:"tokenCommandArgs,omitempty"`
 }
 
 // userProperties is the model type for the value we store in the user's config. It is logically a discriminated union of
-// either an home account id (when logging in using a public client) or a client and tenant id (when using a confidential
-// client).
+// either an home account id (when logging in using a public client), a client and tenant id (when using a confidential
+// client) or a managed identity, with the client id of a user-assigned managed identity.
 type userProperties struct {
-	HomeAccountID *string `json:"homeAccountId,omitempty"`
-	ClientID      *string `json:"clientId,omitempty"`
-	TenantID      *string `json:"tenantId,omitempty"`
+	HomeAccountID   *string `json:"homeAccountId,omitempty"`
+	ClientID        *string `json:"clientId,omitempty"`
+	TenantID        *string `json:"tenantId,omitempty"`
+	ManagedIdentity bool    `json:"managedIdentity,omitempty"`
 }
 
 func readUserProperties(cfg config.Config) (*userProperties, error) {
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	_ "embed"
@@ -17,6 +18,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/public"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/github"
@@ -58,6 +60,7 @@ func TestServicePrincipalLoginClientSecret(t *testing.T) {
 	}
 
 	m := Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     newMemoryConfigManager(),
 		userConfigManager: newMemoryUserConfigManager(),
 		credentialCache:   credentialCache,
@@ -93,6 +96,7 @@ func TestServicePrincipalLoginClientCertificate(t *testing.T) {
 	}
 
 	m := Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     newMemoryConfigManager(),
 		userConfigManager: newMemoryUserConfigManager(),
 		credentialCache:   credentialCache,
@@ -136,6 +140,7 @@ func TestServicePrincipalLoginFederatedTokenProvider(t *testing.T) {
 	})
 
 	m := Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     newMemoryConfigManager(),
 		userConfigManager: newMemoryUserConfigManager(),
 		credentialCache:   credentialCache,
@@ -145,7 +150,7 @@ func TestServicePrincipalLoginFederatedTokenProvider(t *testing.T) {
 	}
 
 	cred, err := m.LoginWithServicePrincipalFederatedTokenProvider(
-		context.Background(), "testClientId", "testTenantId", "github",
+		context.Background(), "testClientId", "testTenantId", "github", nil,
 	)
 
 	require.NoError(t, err)
@@ -165,6 +170,107 @@ func TestServicePrincipalLoginFederatedTokenProvider(t *testing.T) {
 	require.True(t, errors.Is(err, ErrNoCurrentUser))
 }
 
+func TestServicePrincipalLoginFederatedTokenFile(t *testing.T) {
+	credentialCache := &memoryCache{
+		cache: make(map[string][]byte),
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("federated-token"), 0600))
+
+	m := Manager{
+		cloud:             cloud.AzurePublic(),
+		configManager:     newMemoryConfigManager(),
+		userConfigManager: newMemoryUserConfigManager(),
+		credentialCache:   credentialCache,
+	}
+
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", "")
+
+	_, err := m.LoginWithServicePrincipalFederatedTokenProvider(
+		context.Background(), "testTenantId", "testClientId", "file", nil,
+	)
+	require.ErrorContains(t, err, "AZURE_FEDERATED_TOKEN_FILE")
+
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+
+	cred, err := m.LoginWithServicePrincipalFederatedTokenProvider(
+		context.Background(), "testTenantId", "testClientId", "file", nil,
+	)
+
+	require.NoError(t, err)
+	require.IsType(t, new(azidentity.ClientAssertionCredential), cred)
+
+	ps, err := m.loadSecret("testTenantId", "testClientId")
+	require.NoError(t, err)
+	require.Equal(t, fileFederatedAuth, *ps.FederatedAuth.TokenProvider)
+	require.Equal(t, tokenFile, *ps.FederatedAuth.TokenFile)
+
+	// The stored path is used once the environment variable is gone, e.g. from another shell.
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", "")
+
+	cred, err = m.CredentialForCurrentUser(context.Background(), nil)
+
+	require.NoError(t, err)
+	require.IsType(t, new(azidentity.ClientAssertionCredential), cred)
+
+	require.NoError(t, m.Logout(context.Background()))
+}
+
+func TestLoginWithManagedIdentity(t *testing.T) {
+	for _, clientID := range []string{"", "user-assigned-client-id"} {
+		newIdentityServer(t, clientID)
+
+		m := Manager{
+			cloud:             cloud.AzurePublic(),
+			configManager:     newMemoryConfigManager(),
+			userConfigManager: newMemoryUserConfigManager(),
+			credentialCache:   &memoryCache{cache: make(map[string][]byte)},
+			httpClient:        http.DefaultClient,
+		}
+
+		cred, err := m.LoginWithManagedIdentity(context.Background(), clientID)
+
+		require.NoError(t, err)
+		require.IsType(t, new(ManagedIdentityCredential), cred)
+
+		tenantID, err := m.GetLoggedInServicePrincipalTenantID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "test-tenant-id", *tenantID)
+
+		cred, err = m.CredentialForCurrentUser(context.Background(), &CredentialForCurrentUserOptions{
+			TenantID: "other-tenant-id",
+		})
+		require.NoError(t, err)
+
+		token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: m.LoginScopes()})
+		require.NoError(t, err)
+		require.Equal(t, cManagedIdentityToken, token.Token)
+
+		require.NoError(t, m.Logout(context.Background()))
+
+		_, err = m.CredentialForCurrentUser(context.Background(), nil)
+		require.True(t, errors.Is(err, ErrNoCurrentUser))
+	}
+}
+
+func TestLoginWithManagedIdentityUnavailable(t *testing.T) {
+	newIdentityServer(t, "user-assigned-client-id")
+
+	m := Manager{
+		cloud:             cloud.AzurePublic(),
+		configManager:     newMemoryConfigManager(),
+		userConfigManager: newMemoryUserConfigManager(),
+		httpClient:        http.DefaultClient,
+	}
+
+	_, err := m.LoginWithManagedIdentity(context.Background(), "")
+	require.ErrorContains(t, err, "acquiring managed identity token")
+
+	_, err = m.CredentialForCurrentUser(context.Background(), nil)
+	require.True(t, errors.Is(err, ErrNoCurrentUser))
+}
+
 func TestLegacyAzCliCredentialSupport(t *testing.T) {
 	mgr := newMemoryUserConfigManager()
 
@@ -178,6 +284,7 @@ func TestLegacyAzCliCredentialSupport(t *testing.T) {
 	require.NoError(t, err)
 
 	m := Manager{
+		cloud:             cloud.AzurePublic(),
 		userConfigManager: mgr,
 	}
 
@@ -190,6 +297,7 @@ func TestLegacyAzCliCredentialSupport(t *testing.T) {
 func TestCloudShellCredentialSupport(t *testing.T) {
 	t.Setenv("AZD_IN_CLOUDSHELL", "1")
 	m := Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     newMemoryConfigManager(),
 		userConfigManager: newMemoryUserConfigManager(),
 	}
@@ -201,6 +309,7 @@ func TestCloudShellCredentialSupport(t *testing.T) {
 
 func TestLoginInteractive(t *testing.T) {
 	m := &Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     newMemoryConfigManager(),
 		userConfigManager: newMemoryUserConfigManager(),
 		publicClient:      &mockPublicClient{},
@@ -228,6 +337,7 @@ func TestLoginInteractive(t *testing.T) {
 func TestLoginDeviceCode(t *testing.T) {
 	console := mockinput.NewMockConsole()
 	m := &Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     newMemoryConfigManager(),
 		userConfigManager: newMemoryUserConfigManager(),
 		publicClient:      &mockPublicClient{},
@@ -269,6 +379,7 @@ func TestAuthFileConfigUpgrade(t *testing.T) {
 	require.NoError(t, err)
 
 	m := &Manager{
+		cloud:             cloud.AzurePublic(),
 		configManager:     cfgMgr,
 		userConfigManager: userCfgMgr,
 		publicClient:      &mockPublicClient{},
@@ -381,6 +492,10 @@ func (m *mockDeviceCode) UserCode() string {
 	return "123-456"
 }
 
+func (m *mockDeviceCode) VerificationURL() string {
+	return "https://microsoft.com/devicelogin"
+}
+
 func (m *mockDeviceCode) AuthenticationResult(ctx context.Context) (public.AuthResult, error) {
 	return public.AuthResult{
 		Account: public.Account{
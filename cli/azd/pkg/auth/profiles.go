@@ -0,0 +1,331 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/public"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// DefaultProfileName is the name of the profile used when no profile is selected, which holds the login of azd versions
+// without profiles.
+const DefaultProfileName = "default"
+
+// ProfileConfigPath is the path of the profile pinned by an environment in the config.json of the environment.
+const ProfileConfigPath = "auth.profile"
+
+// The keys of the profiles in the auth config. The identity of the current profile is also stored under [cCurrentUserKey].
+const (
+	cCurrentProfileKey = "auth.account.currentProfile"
+	cProfilesKey       = "auth.account.profiles"
+)
+
+var profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ProfileResolver returns the profile to use in the current context, like the profile pinned by the current environment, or
+// the empty string to use the current profile.
+type ProfileResolver func(ctx context.Context) (string, error)
+
+// ProfileNotFoundError indicates that a profile has no login. It wraps [ErrNoCurrentUser].
+type ProfileNotFoundError struct {
+	Profile string
+}
+
+func (e *ProfileNotFoundError) Error() string {
+	return fmt.Sprintf("no login for auth profile '%s', run `azd auth login --profile %s` to log in", e.Profile, e.Profile)
+}
+
+func (e *ProfileNotFoundError) Unwrap() error {
+	return ErrNoCurrentUser
+}
+
+// Profile describes a named login.
+type Profile struct {
+	// The name of the profile.
+	Name string `json:"name"`
+	// The type of account: User, Service Principal or Managed Identity.
+	Type string `json:"type"`
+	// The user name of a user, or the client id of a service principal or a user-assigned managed identity.
+	Account string `json:"account,omitempty"`
+	// The tenant of the account, when fixed.
+	TenantID string `json:"tenantId,omitempty"`
+	// Specifies when the profile is the current profile.
+	IsCurrent bool `json:"isCurrent"`
+}
+
+// ValidateProfileName returns an error when name can't be used as the name of a profile.
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf(
+			"invalid profile name '%s'. Profile names may only contain letters, digits, '-' and '_'", name)
+	}
+
+	return nil
+}
+
+// WithProfile returns a manager which logs in to, logs out of and gets credentials for the given profile, instead of the
+// profile pinned by the environment or the current profile. Logging in to a profile makes it the current profile.
+func (m *Manager) WithProfile(name string) *Manager {
+	profileManager := *m
+	profileManager.profile = name
+	return &profileManager
+}
+
+// CurrentProfile returns the name of the current profile.
+func (m *Manager) CurrentProfile() (string, error) {
+	cfg, err := m.readAuthConfig()
+	if err != nil {
+		return "", fmt.Errorf("reading auth config: %w", err)
+	}
+
+	return currentProfileName(cfg), nil
+}
+
+// SwitchProfile makes the profile with the given name the current profile.
+func (m *Manager) SwitchProfile(name string) error {
+	cfg, err := m.readAuthConfig()
+	if err != nil {
+		return fmt.Errorf("reading auth config: %w", err)
+	}
+
+	profiles, err := readProfiles(cfg)
+	if err != nil {
+		return err
+	}
+
+	user, has := profiles[name]
+	if !has {
+		return &ProfileNotFoundError{Profile: name}
+	}
+
+	if err := setCurrentProfile(cfg, name, user); err != nil {
+		return err
+	}
+
+	return m.saveAuthConfig(cfg)
+}
+
+// ListProfiles returns the profiles which have a login, sorted by name.
+func (m *Manager) ListProfiles(ctx context.Context) ([]Profile, error) {
+	cfg, err := m.readAuthConfig()
+	if err != nil {
+		return nil, fmt.Errorf("reading auth config: %w", err)
+	}
+
+	profiles, err := readProfiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []public.Account
+	current := currentProfileName(cfg)
+	result := make([]Profile, 0, len(profiles))
+
+	for name, user := range profiles {
+		profile := Profile{
+			Name:      name,
+			IsCurrent: name == current,
+		}
+
+		if user.TenantID != nil {
+			profile.TenantID = *user.TenantID
+		}
+
+		switch {
+		case user.HomeAccountID != nil:
+			profile.Type = fields.AccountTypeUser
+
+			if accounts == nil {
+				if accounts, err = m.publicClient.Accounts(ctx); err != nil {
+					return nil, fmt.Errorf("listing accounts: %w", err)
+				}
+			}
+
+			for _, account := range accounts {
+				if account.HomeAccountID == *user.HomeAccountID {
+					profile.Account = account.PreferredUsername
+					profile.TenantID = account.Realm
+				}
+			}
+		case user.ManagedIdentity:
+			profile.Type = fields.AccountTypeManagedIdentity
+			profile.Account = "system-assigned"
+			if user.ClientID != nil {
+				profile.Account = *user.ClientID
+			}
+		default:
+			profile.Type = fields.AccountTypeServicePrincipal
+			if user.ClientID != nil {
+				profile.Account = *user.ClientID
+			}
+		}
+
+		result = append(result, profile)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// resolveProfile returns the name of the profile to use: the given profile, the profile of the manager, the profile returned
+// by the profile resolver or the current profile, in that order.
+func (m *Manager) resolveProfile(ctx context.Context, cfg config.Config, profile string) (string, error) {
+	if profile == "" {
+		profile = m.profile
+	}
+
+	if profile == "" && m.profileResolver != nil {
+		resolved, err := m.profileResolver(ctx)
+		if err != nil {
+			return "", fmt.Errorf("resolving auth profile: %w", err)
+		}
+
+		profile = resolved
+	}
+
+	if profile == "" {
+		profile = currentProfileName(cfg)
+	}
+
+	return profile, nil
+}
+
+// loginProfile returns the name of the profile logins and logouts apply to: the profile of the manager, set with --profile, or
+// the current profile. The profile returned by the profile resolver, like the profile pinned by an environment, only selects
+// the credentials azd uses.
+func (m *Manager) loginProfile(cfg config.Config) string {
+	if m.profile != "" {
+		return m.profile
+	}
+
+	return currentProfileName(cfg)
+}
+
+// readCurrentUser reads the identity of the profile resolved by resolveProfile.
+func (m *Manager) readCurrentUser(ctx context.Context, cfg config.Config, profile string) (*userProperties, error) {
+	profile, err := m.resolveProfile(ctx, cfg, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == currentProfileName(cfg) {
+		return readUserProperties(cfg)
+	}
+
+	profiles, err := readProfiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	user, has := profiles[profile]
+	if !has {
+		return nil, &ProfileNotFoundError{Profile: profile}
+	}
+
+	return user, nil
+}
+
+// currentProfileName returns the name of the current profile, [DefaultProfileName] when none was selected.
+func currentProfileName(cfg config.Config) string {
+	if name, has := cfg.GetString(cCurrentProfileKey); has && name != "" {
+		return name
+	}
+
+	return DefaultProfileName
+}
+
+// readProfiles reads the profiles of the auth config. The login of the current profile is included when it was not saved
+// as a profile, like the login of azd versions without profiles.
+func readProfiles(cfg config.Config) (map[string]*userProperties, error) {
+	profiles := map[string]*userProperties{}
+
+	if data, has := cfg.Get(cProfilesKey); has {
+		jsonBytes, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(jsonBytes, &profiles); err != nil {
+			return nil, fmt.Errorf("reading auth profiles: %w", err)
+		}
+	}
+
+	current := currentProfileName(cfg)
+	if _, has := profiles[current]; !has {
+		user, err := readUserProperties(cfg)
+		if err != nil && !errors.Is(err, ErrNoCurrentUser) {
+			return nil, err
+		}
+
+		if user != nil {
+			profiles[current] = user
+		}
+	}
+
+	return profiles, nil
+}
+
+// setCurrentProfile saves the login of the given profile and makes it the current profile. The login of the previous current
+// profile is saved as a profile first, in case it was saved by a version of azd without profiles.
+func setCurrentProfile(cfg config.Config, name string, user *userProperties) error {
+	current := currentProfileName(cfg)
+	if _, has := cfg.Get(profileKey(current)); !has {
+		if currentUser, has := cfg.Get(cCurrentUserKey); has {
+			if err := cfg.Set(profileKey(current), currentUser); err != nil {
+				return fmt.Errorf("saving profile '%s': %w", current, err)
+			}
+		}
+	}
+
+	if err := cfg.Set(profileKey(name), *user); err != nil {
+		return fmt.Errorf("saving profile '%s': %w", name, err)
+	}
+
+	if err := cfg.Set(cCurrentProfileKey, name); err != nil {
+		return fmt.Errorf("setting current profile: %w", err)
+	}
+
+	if err := cfg.Set(cCurrentUserKey, *user); err != nil {
+		return fmt.Errorf("setting account id in config: %w", err)
+	}
+
+	return nil
+}
+
+// profileKey returns the key of the login of the given profile in the auth config.
+func profileKey(name string) string {
+	return cProfilesKey + "." + name
+}
+
+// sharesIdentity returns true when another profile than the given one logs in with the same identity.
+func sharesIdentity(profiles map[string]*userProperties, name string, user *userProperties) bool {
+	for otherName, other := range profiles {
+		if otherName == name {
+			continue
+		}
+
+		if user.HomeAccountID != nil && other.HomeAccountID != nil && *user.HomeAccountID == *other.HomeAccountID {
+			return true
+		}
+
+		if user.HomeAccountID == nil && !user.ManagedIdentity && !other.ManagedIdentity &&
+			user.TenantID != nil && other.TenantID != nil && *user.TenantID == *other.TenantID &&
+			user.ClientID != nil && other.ClientID != nil && *user.ClientID == *other.ClientID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	newManager := func() *Manager {
+		return &Manager{
+			cloud:             cloud.AzurePublic(),
+			configManager:     newMemoryConfigManager(),
+			userConfigManager: newMemoryUserConfigManager(),
+			credentialCache:   &memoryCache{cache: make(map[string][]byte)},
+			publicClient:      &mockPublicClient{},
+		}
+	}
+
+	t.Run("LoginToProfiles", func(t *testing.T) {
+		m := newManager()
+
+		_, err := m.LoginInteractive(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		_, err = m.WithProfile("customerA").LoginWithServicePrincipalSecret(
+			context.Background(), "testTenantId", "testClientId", "testClientSecret",
+		)
+		require.NoError(t, err)
+
+		profiles, err := m.ListProfiles(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []Profile{
+			{Name: "customerA", Type: "Service Principal", Account: "testClientId", TenantID: "testTenantId", IsCurrent: true},
+			{Name: "default", Type: "User", IsCurrent: false},
+		}, profiles)
+
+		// The last login is the current profile.
+		cred, err := m.CredentialForCurrentUser(context.Background(), nil)
+		require.NoError(t, err)
+		require.IsType(t, new(azidentity.ClientSecretCredential), cred)
+
+		cred, err = m.CredentialForCurrentUser(context.Background(), &CredentialForCurrentUserOptions{Profile: "default"})
+		require.NoError(t, err)
+		require.IsType(t, new(azdCredential), cred)
+
+		require.NoError(t, m.SwitchProfile("default"))
+
+		current, err := m.CurrentProfile()
+		require.NoError(t, err)
+		require.Equal(t, "default", current)
+
+		cred, err = m.CredentialForCurrentUser(context.Background(), nil)
+		require.NoError(t, err)
+		require.IsType(t, new(azdCredential), cred)
+
+		var notFoundErr *ProfileNotFoundError
+		err = m.SwitchProfile("customerB")
+		require.True(t, errors.As(err, &notFoundErr))
+		require.True(t, errors.Is(err, ErrNoCurrentUser))
+	})
+
+	t.Run("PinnedProfile", func(t *testing.T) {
+		m := newManager()
+
+		_, err := m.LoginInteractive(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		_, err = m.WithProfile("customerA").LoginWithServicePrincipalSecret(
+			context.Background(), "testTenantId", "testClientId", "testClientSecret",
+		)
+		require.NoError(t, err)
+		require.NoError(t, m.SwitchProfile("default"))
+
+		pinned := "customerA"
+		m.profileResolver = func(ctx context.Context) (string, error) {
+			return pinned, nil
+		}
+
+		cred, err := m.CredentialForCurrentUser(context.Background(), nil)
+		require.NoError(t, err)
+		require.IsType(t, new(azidentity.ClientSecretCredential), cred)
+
+		tenantID, err := m.GetLoggedInServicePrincipalTenantID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "testTenantId", *tenantID)
+
+		pinned = "customerB"
+		_, err = m.CredentialForCurrentUser(context.Background(), nil)
+		require.True(t, errors.Is(err, ErrNoCurrentUser))
+		require.ErrorContains(t, err, "azd auth login --profile customerB")
+
+		// Logins and logouts without a profile apply to the current profile, not to the pinned one.
+		pinned = "customerA"
+		_, err = m.LoginWithServicePrincipalSecret(
+			context.Background(), "otherTenantId", "otherClientId", "otherClientSecret",
+		)
+		require.NoError(t, err)
+
+		current, err := m.CurrentProfile()
+		require.NoError(t, err)
+		require.Equal(t, DefaultProfileName, current)
+
+		tenantID, err = m.GetLoggedInServicePrincipalTenantID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "testTenantId", *tenantID)
+
+		require.NoError(t, m.Logout(context.Background()))
+
+		profiles, err := m.ListProfiles(context.Background())
+		require.NoError(t, err)
+		require.Len(t, profiles, 1)
+		require.Equal(t, "customerA", profiles[0].Name)
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		m := newManager()
+
+		_, err := m.LoginInteractive(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		_, err = m.WithProfile("customerA").LoginWithServicePrincipalSecret(
+			context.Background(), "testTenantId", "testClientId", "testClientSecret",
+		)
+		require.NoError(t, err)
+
+		_, err = m.WithProfile("customerB").LoginWithServicePrincipalSecret(
+			context.Background(), "testTenantId", "testClientId", "testClientSecret",
+		)
+		require.NoError(t, err)
+
+		// The secret is kept while another profile uses the same service principal.
+		require.NoError(t, m.WithProfile("customerA").Logout(context.Background()))
+		_, err = m.loadSecret("testTenantId", "testClientId")
+		require.NoError(t, err)
+
+		// Logging out of the current profile falls back to the default profile.
+		require.NoError(t, m.Logout(context.Background()))
+
+		current, err := m.CurrentProfile()
+		require.NoError(t, err)
+		require.Equal(t, DefaultProfileName, current)
+
+		cred, err := m.CredentialForCurrentUser(context.Background(), nil)
+		require.NoError(t, err)
+		require.IsType(t, new(azdCredential), cred)
+
+		profiles, err := m.ListProfiles(context.Background())
+		require.NoError(t, err)
+		require.Len(t, profiles, 1)
+
+		require.NoError(t, m.Logout(context.Background()))
+
+		_, err = m.CredentialForCurrentUser(context.Background(), nil)
+		require.True(t, errors.Is(err, ErrNoCurrentUser))
+	})
+}
+
+func TestValidateProfileName(t *testing.T) {
+	require.NoError(t, ValidateProfileName("customer-A_1"))
+	require.Error(t, ValidateProfileName(""))
+	require.Error(t, ValidateProfileName("customer.A"))
+	require.Error(t, ValidateProfileName("customer A"))
+}
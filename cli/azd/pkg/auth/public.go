@@ -21,6 +21,7 @@ type publicClient interface {
 type deviceCodeResult interface {
 	Message() string
 	UserCode() string
+	VerificationURL() string
 	AuthenticationResult(context.Context) (public.AuthResult, error)
 }
 
@@ -80,6 +81,10 @@ func (m *msalDeviceCodeAdapter) UserCode() string {
 	return m.code.Result.UserCode
 }
 
+func (m *msalDeviceCodeAdapter) VerificationURL() string {
+	return m.code.Result.VerificationURL
+}
+
 func (m *msalDeviceCodeAdapter) AuthenticationResult(ctx context.Context) (public.AuthResult, error) {
 	res, err := m.code.AuthenticationResult(ctx)
 	if err != nil {
@@ -0,0 +1,330 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+)
+
+const cDeploymentStacksApiVersion = "2024-03-01"
+
+var (
+	ErrDeploymentStackNotFound = errors.New("deployment stack not found")
+)
+
+// DeploymentStacks manages Azure Deployment Stacks. A deployment stack is a deployment which keeps track of the resources
+// it manages, so they can be deleted together with the stack, and optionally protected from changes made outside of it.
+//
+// Stacks are identified by the resource id of the scope they are deployed at (a resource group, a subscription or a
+// management group) and their name. The subscription is used to get the credential for the request.
+type DeploymentStacks interface {
+	GetStack(
+		ctx context.Context,
+		subscriptionId string,
+		scopeId string,
+		stackName string,
+	) (*DeploymentStack, error)
+	DeployStack(
+		ctx context.Context,
+		subscriptionId string,
+		scopeId string,
+		stackName string,
+		stack *DeploymentStack,
+	) (*DeploymentStack, error)
+	DeleteStack(
+		ctx context.Context,
+		subscriptionId string,
+		scopeId string,
+		stackName string,
+		actionOnUnmanage ActionOnUnmanage,
+	) error
+}
+
+// DeploymentStack is the resource of a deployment stack.
+type DeploymentStack struct {
+	Id         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location,omitempty"`
+	Tags       map[string]*string        `json:"tags,omitempty"`
+	Properties DeploymentStackProperties `json:"properties"`
+}
+
+type DeploymentStackProperties struct {
+	Template          azure.RawArmTemplate         `json:"template,omitempty"`
+	Parameters        azure.ArmParameters          `json:"parameters,omitempty"`
+	ActionOnUnmanage  ActionOnUnmanage             `json:"actionOnUnmanage"`
+	DenySettings      DenySettings                 `json:"denySettings"`
+	ProvisioningState string                       `json:"provisioningState,omitempty"`
+	DeploymentId      string                       `json:"deploymentId,omitempty"`
+	Outputs           map[string]map[string]any    `json:"outputs,omitempty"`
+	Resources         []DeploymentStackManagedItem `json:"resources,omitempty"`
+}
+
+// ActionOnUnmanage sets what happens to the resources a stack stops managing, either because they were removed from the
+// template or because the stack is deleted. Values are "delete" or "detach".
+type ActionOnUnmanage struct {
+	Resources        string `json:"resources"`
+	ResourceGroups   string `json:"resourceGroups,omitempty"`
+	ManagementGroups string `json:"managementGroups,omitempty"`
+}
+
+// DenySettings protects the resources of a stack from changes made outside of the stack. Mode is "none", "denyDelete"
+// or "denyWriteAndDelete".
+type DenySettings struct {
+	Mode               string   `json:"mode"`
+	ApplyToChildScopes bool     `json:"applyToChildScopes,omitempty"`
+	ExcludedPrincipals []string `json:"excludedPrincipals,omitempty"`
+	ExcludedActions    []string `json:"excludedActions,omitempty"`
+}
+
+// DeploymentStackManagedItem is a resource managed by a stack.
+type DeploymentStackManagedItem struct {
+	Id         string `json:"id"`
+	Status     string `json:"status,omitempty"`
+	DenyStatus string `json:"denyStatus,omitempty"`
+}
+
+// CreateDeploymentStackOutput converts the outputs of a stack to the model used for deployment outputs. The type of an
+// output is inferred from its value when the stack doesn't report it.
+func CreateDeploymentStackOutput(outputs map[string]map[string]any) map[string]AzCliDeploymentOutput {
+	result := make(map[string]AzCliDeploymentOutput, len(outputs))
+	for key, output := range outputs {
+		outputType, _ := output["type"].(string)
+		if outputType == "" {
+			outputType = outputValueType(output["value"])
+		}
+
+		result[key] = AzCliDeploymentOutput{
+			Type:  outputType,
+			Value: output["value"],
+		}
+	}
+
+	return result
+}
+
+// outputValueType returns the ARM type of the JSON value of an output.
+func outputValueType(value any) string {
+	switch value.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		return "int"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+type deploymentStacks struct {
+	credentialProvider account.SubscriptionCredentialProvider
+	httpClient         httputil.HttpClient
+	userAgent          string
+	cloud              *cloud.Cloud
+}
+
+func NewDeploymentStacks(
+	credentialProvider account.SubscriptionCredentialProvider,
+	httpClient httputil.HttpClient,
+	cloud *cloud.Cloud,
+) DeploymentStacks {
+	return &deploymentStacks{
+		credentialProvider: credentialProvider,
+		httpClient:         httpClient,
+		cloud:              cloud,
+		userAgent:          azdinternal.UserAgent(),
+	}
+}
+
+func (ds *deploymentStacks) GetStack(
+	ctx context.Context,
+	subscriptionId string,
+	scopeId string,
+	stackName string,
+) (*DeploymentStack, error) {
+	pipeline, err := ds.createPipeline(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := runtime.NewRequest(ctx, http.MethodGet, ds.stackUrl(scopeId, stackName))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	request.Raw().Header.Set("Accept", "application/json")
+
+	response, err := pipeline.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("getting deployment stack: %w", err)
+	}
+
+	if runtime.HasStatusCode(response, http.StatusNotFound) {
+		return nil, ErrDeploymentStackNotFound
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		return nil, fmt.Errorf("getting deployment stack: %w", runtime.NewResponseError(response))
+	}
+
+	var stack DeploymentStack
+	if err := runtime.UnmarshalAsJSON(response, &stack); err != nil {
+		return nil, fmt.Errorf("reading deployment stack: %w", err)
+	}
+
+	return &stack, nil
+}
+
+func (ds *deploymentStacks) DeployStack(
+	ctx context.Context,
+	subscriptionId string,
+	scopeId string,
+	stackName string,
+	stack *DeploymentStack,
+) (*DeploymentStack, error) {
+	pipeline, err := ds.createPipeline(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := runtime.NewRequest(ctx, http.MethodPut, ds.stackUrl(scopeId, stackName))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	request.Raw().Header.Set("Accept", "application/json")
+
+	if err := runtime.MarshalAsJSON(request, stack); err != nil {
+		return nil, fmt.Errorf("writing deployment stack: %w", err)
+	}
+
+	response, err := pipeline.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment stack: %w", err)
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK, http.StatusCreated) {
+		return nil, fmt.Errorf(
+			"starting deployment stack:\n\nDeployment Error Details:\n%w",
+			createDeploymentError(runtime.NewResponseError(response)),
+		)
+	}
+
+	poller, err := runtime.NewPoller[DeploymentStack](response, pipeline, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment stack: %w", err)
+	}
+
+	result, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"deploying stack:\n\nDeployment Error Details:\n%w",
+			createDeploymentError(err),
+		)
+	}
+
+	return &result, nil
+}
+
+func (ds *deploymentStacks) DeleteStack(
+	ctx context.Context,
+	subscriptionId string,
+	scopeId string,
+	stackName string,
+	actionOnUnmanage ActionOnUnmanage,
+) error {
+	pipeline, err := ds.createPipeline(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	request, err := runtime.NewRequest(ctx, http.MethodDelete, ds.stackUrl(scopeId, stackName))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	query := request.Raw().URL.Query()
+	query.Set("api-version", cDeploymentStacksApiVersion)
+	if actionOnUnmanage.Resources != "" {
+		query.Set("unmanageAction.Resources", actionOnUnmanage.Resources)
+	}
+	if actionOnUnmanage.ResourceGroups != "" {
+		query.Set("unmanageAction.ResourceGroups", actionOnUnmanage.ResourceGroups)
+	}
+	if actionOnUnmanage.ManagementGroups != "" {
+		query.Set("unmanageAction.ManagementGroups", actionOnUnmanage.ManagementGroups)
+	}
+	request.Raw().URL.RawQuery = query.Encode()
+
+	response, err := pipeline.Do(request)
+	if err != nil {
+		return fmt.Errorf("starting to delete deployment stack: %w", err)
+	}
+
+	if runtime.HasStatusCode(response, http.StatusNoContent, http.StatusNotFound) {
+		return nil
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK, http.StatusAccepted) {
+		return fmt.Errorf("starting to delete deployment stack: %w", runtime.NewResponseError(response))
+	}
+
+	poller, err := runtime.NewPoller[struct{}](response, pipeline, nil)
+	if err != nil {
+		return fmt.Errorf("starting to delete deployment stack: %w", err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("deleting deployment stack: %w", err)
+	}
+
+	return nil
+}
+
+// createPipeline creates the HTTP pipeline for the requests made on behalf of the given subscription.
+func (ds *deploymentStacks) createPipeline(ctx context.Context, subscriptionId string) (runtime.Pipeline, error) {
+	credential, err := ds.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return runtime.Pipeline{}, err
+	}
+
+	options := azsdk.NewClientOptionsBuilder().
+		WithTransport(ds.httpClient).
+		WithCloud(ds.cloud.Configuration).
+		WithPerCallPolicy(azsdk.NewUserAgentPolicy(ds.userAgent)).
+		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx)).
+		BuildArmClientOptions()
+
+	pipeline, err := armruntime.NewPipeline("deploymentstacks", "1.0.0", credential, runtime.PipelineOptions{}, options)
+	if err != nil {
+		return runtime.Pipeline{}, fmt.Errorf("creating deployment stacks pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+// stackUrl returns the URL of the stack with the given name at the scope with the given resource id.
+func (ds *deploymentStacks) stackUrl(scopeId string, stackName string) string {
+	return fmt.Sprintf("%s?api-version=%s",
+		runtime.JoinPaths(
+			ds.cloud.ResourceManagerEndpoint(),
+			scopeId,
+			"providers/Microsoft.Resources/deploymentStacks",
+			url.PathEscape(stackName)),
+		cDeploymentStacksApiVersion)
+}
@@ -5,6 +5,7 @@ package azapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -17,10 +18,13 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 )
 
 type Deployments interface {
+	// PortalUrlBase returns the base URL of the Azure portal of the cloud the deployments are made in.
+	PortalUrlBase() string
 	ListSubscriptionDeployments(
 		ctx context.Context,
 		subscriptionId string,
@@ -41,6 +45,17 @@ type Deployments interface {
 		resourceGroupName string,
 		deploymentName string,
 	) (*armresources.DeploymentExtended, error)
+	ExportResourceGroupDeploymentTemplate(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		deploymentName string,
+	) (azure.RawArmTemplate, error)
+	ExportSubscriptionDeploymentTemplate(
+		ctx context.Context,
+		subscriptionId string,
+		deploymentName string,
+	) (azure.RawArmTemplate, error)
 	DeployToSubscription(
 		ctx context.Context,
 		subscriptionId string,
@@ -76,6 +91,75 @@ type Deployments interface {
 		parameters azure.ArmParameters,
 	) (*armresources.WhatIfOperationResult, error)
 	DeleteSubscriptionDeployment(ctx context.Context, subscriptionId string, deploymentName string) error
+	// Management group and tenant level operations use the credential of subscriptionId, which is in the tenant of the
+	// management group.
+	ListManagementGroupDeployments(
+		ctx context.Context,
+		subscriptionId string,
+		managementGroupId string,
+	) ([]*armresources.DeploymentExtended, error)
+	GetManagementGroupDeployment(
+		ctx context.Context,
+		subscriptionId string,
+		managementGroupId string,
+		deploymentName string,
+	) (*armresources.DeploymentExtended, error)
+	ExportManagementGroupDeploymentTemplate(
+		ctx context.Context,
+		subscriptionId string,
+		managementGroupId string,
+		deploymentName string,
+	) (azure.RawArmTemplate, error)
+	DeployToManagementGroup(
+		ctx context.Context,
+		subscriptionId string,
+		managementGroupId string,
+		location string,
+		deploymentName string,
+		armTemplate azure.RawArmTemplate,
+		parameters azure.ArmParameters,
+		tags map[string]*string,
+	) (*armresources.DeploymentExtended, error)
+	WhatIfDeployToManagementGroup(
+		ctx context.Context,
+		subscriptionId string,
+		managementGroupId string,
+		location string,
+		deploymentName string,
+		armTemplate azure.RawArmTemplate,
+		parameters azure.ArmParameters,
+	) (*armresources.WhatIfOperationResult, error)
+	ListTenantDeployments(
+		ctx context.Context,
+		subscriptionId string,
+	) ([]*armresources.DeploymentExtended, error)
+	GetTenantDeployment(
+		ctx context.Context,
+		subscriptionId string,
+		deploymentName string,
+	) (*armresources.DeploymentExtended, error)
+	ExportTenantDeploymentTemplate(
+		ctx context.Context,
+		subscriptionId string,
+		deploymentName string,
+	) (azure.RawArmTemplate, error)
+	DeployToTenant(
+		ctx context.Context,
+		subscriptionId string,
+		location string,
+		deploymentName string,
+		armTemplate azure.RawArmTemplate,
+		parameters azure.ArmParameters,
+		tags map[string]*string,
+	) (*armresources.DeploymentExtended, error)
+	WhatIfDeployToTenant(
+		ctx context.Context,
+		subscriptionId string,
+		location string,
+		deploymentName string,
+		armTemplate azure.RawArmTemplate,
+		parameters azure.ArmParameters,
+	) (*armresources.WhatIfOperationResult, error)
 	CalculateTemplateHash(
 		ctx context.Context,
 		subscriptionId string,
@@ -90,19 +174,26 @@ type deployments struct {
 	credentialProvider account.SubscriptionCredentialProvider
 	httpClient         httputil.HttpClient
 	userAgent          string
+	cloud              *cloud.Cloud
 }
 
 func NewDeployments(
 	credentialProvider account.SubscriptionCredentialProvider,
 	httpClient httputil.HttpClient,
+	cloud *cloud.Cloud,
 ) Deployments {
 	return &deployments{
 		credentialProvider: credentialProvider,
 		httpClient:         httpClient,
+		cloud:              cloud,
 		userAgent:          azdinternal.UserAgent(),
 	}
 }
 
+func (ds *deployments) PortalUrlBase() string {
+	return ds.cloud.PortalUrlBase
+}
+
 func (ds *deployments) CalculateTemplateHash(
 	ctx context.Context,
 	subscriptionId string,
@@ -209,6 +300,87 @@ func (ds *deployments) GetResourceGroupDeployment(
 	return &deployment.DeploymentExtended, nil
 }
 
+// ExportResourceGroupDeploymentTemplate gets the template used by a resource group deployment.
+func (ds *deployments) ExportResourceGroupDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	exportResult, err := deploymentClient.ExportTemplate(ctx, resourceGroupName, deploymentName, nil)
+	return exportedTemplate(exportResult.DeploymentExportResult, err)
+}
+
+// ExportSubscriptionDeploymentTemplate gets the template used by a subscription deployment.
+func (ds *deployments) ExportSubscriptionDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	exportResult, err := deploymentClient.ExportTemplateAtSubscriptionScope(ctx, deploymentName, nil)
+	return exportedTemplate(exportResult.DeploymentExportResult, err)
+}
+
+// ExportManagementGroupDeploymentTemplate gets the template used by a management group deployment.
+func (ds *deployments) ExportManagementGroupDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	managementGroupId string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	exportResult, err := deploymentClient.ExportTemplateAtManagementGroupScope(
+		ctx, managementGroupId, deploymentName, nil)
+	return exportedTemplate(exportResult.DeploymentExportResult, err)
+}
+
+// ExportTenantDeploymentTemplate gets the template used by a tenant deployment.
+func (ds *deployments) ExportTenantDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	exportResult, err := deploymentClient.ExportTemplateAtTenantScope(ctx, deploymentName, nil)
+	return exportedTemplate(exportResult.DeploymentExportResult, err)
+}
+
+// exportedTemplate returns the template of the result of exporting the template of a deployment.
+func exportedTemplate(exportResult armresources.DeploymentExportResult, err error) (azure.RawArmTemplate, error) {
+	if err != nil {
+		var errDetails *azcore.ResponseError
+		if errors.As(err, &errDetails) && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		return nil, fmt.Errorf("exporting deployment template: %w", err)
+	}
+
+	template, err := json.Marshal(exportResult.Template)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling deployment template: %w", err)
+	}
+
+	return template, nil
+}
+
 func (ds *deployments) createDeploymentsClient(
 	ctx context.Context,
 	subscriptionId string,
@@ -407,6 +579,268 @@ func (ds *deployments) DeleteSubscriptionDeployment(
 	return nil
 }
 
+func (ds *deployments) ListManagementGroupDeployments(
+	ctx context.Context,
+	subscriptionId string,
+	managementGroupId string,
+) ([]*armresources.DeploymentExtended, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	results := []*armresources.DeploymentExtended{}
+
+	pager := deploymentClient.NewListAtManagementGroupScopePager(managementGroupId, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, page.Value...)
+	}
+
+	return results, nil
+}
+
+func (ds *deployments) GetManagementGroupDeployment(
+	ctx context.Context,
+	subscriptionId string,
+	managementGroupId string,
+	deploymentName string,
+) (*armresources.DeploymentExtended, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	deployment, err := deploymentClient.GetAtManagementGroupScope(ctx, managementGroupId, deploymentName, nil)
+	if err != nil {
+		var errDetails *azcore.ResponseError
+		if errors.As(err, &errDetails) && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		return nil, fmt.Errorf("getting deployment from management group: %w", err)
+	}
+
+	return &deployment.DeploymentExtended, nil
+}
+
+func (ds *deployments) DeployToManagementGroup(
+	ctx context.Context,
+	subscriptionId string,
+	managementGroupId string,
+	location string,
+	deploymentName string,
+	armTemplate azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+	tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	createFromTemplateOperation, err := deploymentClient.BeginCreateOrUpdateAtManagementGroupScope(
+		ctx, managementGroupId, deploymentName,
+		armresources.ScopedDeployment{
+			Properties: &armresources.DeploymentProperties{
+				Template:   armTemplate,
+				Parameters: parameters,
+				Mode:       to.Ptr(armresources.DeploymentModeIncremental),
+			},
+			Location: to.Ptr(location),
+			Tags:     tags,
+		}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment to management group: %w", err)
+	}
+
+	// wait for deployment creation
+	deployResult, err := createFromTemplateOperation.PollUntilDone(ctx, nil)
+	if err != nil {
+		deploymentError := createDeploymentError(err)
+		return nil, fmt.Errorf(
+			"deploying to management group:\n\nDeployment Error Details:\n%w",
+			deploymentError,
+		)
+	}
+
+	return &deployResult.DeploymentExtended, nil
+}
+
+func (ds *deployments) WhatIfDeployToManagementGroup(
+	ctx context.Context,
+	subscriptionId string,
+	managementGroupId string,
+	location string,
+	deploymentName string,
+	armTemplate azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+) (*armresources.WhatIfOperationResult, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	createFromTemplateOperation, err := deploymentClient.BeginWhatIfAtManagementGroupScope(
+		ctx, managementGroupId, deploymentName,
+		armresources.ScopedDeploymentWhatIf{
+			Properties: &armresources.DeploymentWhatIfProperties{
+				Template:       armTemplate,
+				Parameters:     parameters,
+				Mode:           to.Ptr(armresources.DeploymentModeIncremental),
+				WhatIfSettings: &armresources.DeploymentWhatIfSettings{},
+			},
+			Location: to.Ptr(location),
+		}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment to management group: %w", err)
+	}
+
+	// wait for deployment creation
+	deployResult, err := createFromTemplateOperation.PollUntilDone(ctx, nil)
+	if err != nil {
+		deploymentError := createDeploymentError(err)
+		return nil, fmt.Errorf(
+			"deploying to management group:\n\nDeployment Error Details:\n%w",
+			deploymentError,
+		)
+	}
+
+	return &deployResult.WhatIfOperationResult, nil
+}
+
+func (ds *deployments) ListTenantDeployments(
+	ctx context.Context,
+	subscriptionId string,
+) ([]*armresources.DeploymentExtended, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	results := []*armresources.DeploymentExtended{}
+
+	pager := deploymentClient.NewListAtTenantScopePager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, page.Value...)
+	}
+
+	return results, nil
+}
+
+func (ds *deployments) GetTenantDeployment(
+	ctx context.Context,
+	subscriptionId string,
+	deploymentName string,
+) (*armresources.DeploymentExtended, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	deployment, err := deploymentClient.GetAtTenantScope(ctx, deploymentName, nil)
+	if err != nil {
+		var errDetails *azcore.ResponseError
+		if errors.As(err, &errDetails) && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		return nil, fmt.Errorf("getting deployment from tenant: %w", err)
+	}
+
+	return &deployment.DeploymentExtended, nil
+}
+
+func (ds *deployments) DeployToTenant(
+	ctx context.Context,
+	subscriptionId string,
+	location string,
+	deploymentName string,
+	armTemplate azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+	tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	createFromTemplateOperation, err := deploymentClient.BeginCreateOrUpdateAtTenantScope(
+		ctx, deploymentName,
+		armresources.ScopedDeployment{
+			Properties: &armresources.DeploymentProperties{
+				Template:   armTemplate,
+				Parameters: parameters,
+				Mode:       to.Ptr(armresources.DeploymentModeIncremental),
+			},
+			Location: to.Ptr(location),
+			Tags:     tags,
+		}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment to tenant: %w", err)
+	}
+
+	// wait for deployment creation
+	deployResult, err := createFromTemplateOperation.PollUntilDone(ctx, nil)
+	if err != nil {
+		deploymentError := createDeploymentError(err)
+		return nil, fmt.Errorf(
+			"deploying to tenant:\n\nDeployment Error Details:\n%w",
+			deploymentError,
+		)
+	}
+
+	return &deployResult.DeploymentExtended, nil
+}
+
+func (ds *deployments) WhatIfDeployToTenant(
+	ctx context.Context,
+	subscriptionId string,
+	location string,
+	deploymentName string,
+	armTemplate azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+) (*armresources.WhatIfOperationResult, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	createFromTemplateOperation, err := deploymentClient.BeginWhatIfAtTenantScope(
+		ctx, deploymentName,
+		armresources.ScopedDeploymentWhatIf{
+			Properties: &armresources.DeploymentWhatIfProperties{
+				Template:       armTemplate,
+				Parameters:     parameters,
+				Mode:           to.Ptr(armresources.DeploymentModeIncremental),
+				WhatIfSettings: &armresources.DeploymentWhatIfSettings{},
+			},
+			Location: to.Ptr(location),
+		}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment to tenant: %w", err)
+	}
+
+	// wait for deployment creation
+	deployResult, err := createFromTemplateOperation.PollUntilDone(ctx, nil)
+	if err != nil {
+		deploymentError := createDeploymentError(err)
+		return nil, fmt.Errorf(
+			"deploying to tenant:\n\nDeployment Error Details:\n%w",
+			deploymentError,
+		)
+	}
+
+	return &deployResult.WhatIfOperationResult, nil
+}
+
 type AzCliDeploymentPropertiesDependency struct {
 	AzCliDeploymentPropertiesBasicDependency
 	DependsOn []AzCliDeploymentPropertiesBasicDependency `json:"dependsOn"`
@@ -531,6 +965,7 @@ func createDeploymentError(err error) error {
 func (ds *deployments) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptionsBuilder {
 	return azsdk.NewClientOptionsBuilder().
 		WithTransport(ds.httpClient).
+		WithCloud(ds.cloud.Configuration).
 		WithPerCallPolicy(azsdk.NewUserAgentPolicy(ds.userAgent)).
 		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
 }
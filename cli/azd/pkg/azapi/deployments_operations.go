@@ -13,6 +13,7 @@ import (
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 )
 
@@ -28,15 +29,28 @@ type DeploymentOperations interface {
 		resourceGroupName string,
 		deploymentName string,
 	) ([]*armresources.DeploymentOperation, error)
+	ListManagementGroupDeploymentOperations(
+		ctx context.Context,
+		subscriptionId string,
+		managementGroupId string,
+		deploymentName string,
+	) ([]*armresources.DeploymentOperation, error)
+	ListTenantDeploymentOperations(
+		ctx context.Context,
+		subscriptionId string,
+		deploymentName string,
+	) ([]*armresources.DeploymentOperation, error)
 }
 
 func NewDeploymentOperations(
 	credentialProvider account.SubscriptionCredentialProvider,
 	httpClient httputil.HttpClient,
+	cloud *cloud.Cloud,
 ) DeploymentOperations {
 	return &deploymentOperations{
 		credentialProvider: credentialProvider,
 		httpClient:         httpClient,
+		cloud:              cloud,
 		userAgent:          azdinternal.UserAgent(),
 	}
 }
@@ -45,6 +59,7 @@ type deploymentOperations struct {
 	credentialProvider account.SubscriptionCredentialProvider
 	httpClient         httputil.HttpClient
 	userAgent          string
+	cloud              *cloud.Cloud
 }
 
 func (dp *deploymentOperations) createDeploymentsOperationsClient(
@@ -124,9 +139,70 @@ func (dp *deploymentOperations) ListResourceGroupDeploymentOperations(
 	return result, nil
 }
 
+func (dp *deploymentOperations) ListManagementGroupDeploymentOperations(
+	ctx context.Context,
+	subscriptionId string,
+	managementGroupId string,
+	deploymentName string,
+) ([]*armresources.DeploymentOperation, error) {
+	result := []*armresources.DeploymentOperation{}
+	deploymentOperationsClient, err := dp.createDeploymentsOperationsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	// Get all without any filter
+	getDeploymentsPager := deploymentOperationsClient.NewListAtManagementGroupScopePager(
+		managementGroupId, deploymentName, nil)
+
+	for getDeploymentsPager.More() {
+		page, err := getDeploymentsPager.NextPage(ctx)
+		var errDetails *azcore.ResponseError
+		if errors.As(err, &errDetails) && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed getting list of deployment operations from management group: %w", err)
+		}
+		result = append(result, page.Value...)
+	}
+
+	return result, nil
+}
+
+func (dp *deploymentOperations) ListTenantDeploymentOperations(
+	ctx context.Context,
+	subscriptionId string,
+	deploymentName string,
+) ([]*armresources.DeploymentOperation, error) {
+	result := []*armresources.DeploymentOperation{}
+	deploymentOperationsClient, err := dp.createDeploymentsOperationsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	// Get all without any filter
+	getDeploymentsPager := deploymentOperationsClient.NewListAtTenantScopePager(deploymentName, nil)
+
+	for getDeploymentsPager.More() {
+		page, err := getDeploymentsPager.NextPage(ctx)
+		var errDetails *azcore.ResponseError
+		if errors.As(err, &errDetails) && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed getting list of deployment operations from tenant: %w", err)
+		}
+		result = append(result, page.Value...)
+	}
+
+	return result, nil
+}
+
 func (dp *deploymentOperations) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptionsBuilder {
 	return azsdk.NewClientOptionsBuilder().
 		WithTransport(dp.httpClient).
+		WithCloud(dp.cloud.Configuration).
 		WithPerCallPolicy(azsdk.NewUserAgentPolicy(dp.userAgent)).
 		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
 }
@@ -0,0 +1,529 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+)
+
+const (
+	cComputeSkusApiVersion     = "2021-07-01"
+	cComputeUsagesApiVersion   = "2023-07-01"
+	cModelCapacitiesApiVersion = "2024-04-01-preview"
+)
+
+// ResourceAvailability reads the SKUs, quotas and model capacity a subscription can use in each Azure location. It is
+// used to check a template before deploying it, so a deployment doesn't fail after several minutes because a SKU is not
+// offered in the target location or the subscription is out of quota.
+type ResourceAvailability interface {
+	// ComputeSkus lists the compute SKUs (like virtual machine sizes) of a location, or of all locations when location
+	// is empty.
+	ComputeSkus(ctx context.Context, subscriptionId string, location string) ([]ResourceSku, error)
+	// ComputeUsages lists the compute quotas of a location, like the vCPUs of each virtual machine family.
+	ComputeUsages(ctx context.Context, subscriptionId string, location string) ([]ResourceUsage, error)
+	// CognitiveServicesSkus lists the SKUs of the Cognitive Services accounts of all locations.
+	CognitiveServicesSkus(ctx context.Context, subscriptionId string) ([]ResourceSku, error)
+	// ModelCapacities lists the capacity left for an AI model in each location, by deployment SKU.
+	ModelCapacities(ctx context.Context, subscriptionId string, model ModelReference) ([]ModelCapacity, error)
+	// AppServiceLocations lists the locations which offer the given App Service pricing tier, like PremiumV3.
+	AppServiceLocations(ctx context.Context, subscriptionId string, tier string) ([]string, error)
+	// ResourceTypeLocations lists the locations of a resource type, like Microsoft.App/managedEnvironments.
+	ResourceTypeLocations(ctx context.Context, subscriptionId string, resourceType string) ([]string, error)
+}
+
+// ResourceSku is a SKU of a resource type, with the locations where it is offered.
+type ResourceSku struct {
+	ResourceType string                   `json:"resourceType"`
+	Name         string                   `json:"name"`
+	Tier         string                   `json:"tier,omitempty"`
+	Kind         string                   `json:"kind,omitempty"`
+	Family       string                   `json:"family,omitempty"`
+	Locations    []string                 `json:"locations"`
+	Capabilities []ResourceSkuCapability  `json:"capabilities,omitempty"`
+	Restrictions []ResourceSkuRestriction `json:"restrictions,omitempty"`
+}
+
+type ResourceSkuCapability struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ResourceSkuRestriction is a restriction of a SKU for the subscription. A restriction of type "Location" makes the
+// SKU unavailable in the locations of the restriction.
+type ResourceSkuRestriction struct {
+	Type            string                     `json:"type"`
+	Values          []string                   `json:"values"`
+	ReasonCode      string                     `json:"reasonCode,omitempty"`
+	RestrictionInfo ResourceSkuRestrictionInfo `json:"restrictionInfo"`
+}
+
+type ResourceSkuRestrictionInfo struct {
+	Locations []string `json:"locations,omitempty"`
+	Zones     []string `json:"zones,omitempty"`
+}
+
+// Capability returns the value of a capability of the SKU, like "vCPUs".
+func (s *ResourceSku) Capability(name string) (string, bool) {
+	for _, capability := range s.Capabilities {
+		if strings.EqualFold(capability.Name, name) {
+			return capability.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// AvailableIn returns true when the SKU is offered in the location and is not restricted there for the subscription.
+func (s *ResourceSku) AvailableIn(location string) bool {
+	if !slices.ContainsFunc(s.Locations, func(l string) bool { return SameLocation(l, location) }) {
+		return false
+	}
+
+	for _, restriction := range s.Restrictions {
+		if !strings.EqualFold(restriction.Type, "Location") {
+			continue
+		}
+
+		locations := append(slices.Clone(restriction.Values), restriction.RestrictionInfo.Locations...)
+		if slices.ContainsFunc(locations, func(l string) bool { return SameLocation(l, location) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ResourceUsage is the usage of a quota in a location.
+type ResourceUsage struct {
+	Name         ResourceUsageName `json:"name"`
+	Unit         string            `json:"unit"`
+	CurrentValue int64             `json:"currentValue"`
+	Limit        int64             `json:"limit"`
+}
+
+type ResourceUsageName struct {
+	Value          string `json:"value"`
+	LocalizedValue string `json:"localizedValue"`
+}
+
+// ModelReference identifies an AI model, like the model of an Azure OpenAI deployment.
+type ModelReference struct {
+	Format  string `json:"format"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ModelCapacity is the capacity left for a model in a location, for a deployment SKU like "Standard".
+type ModelCapacity struct {
+	Location   string                  `json:"location"`
+	Properties ModelCapacityProperties `json:"properties"`
+}
+
+type ModelCapacityProperties struct {
+	Model             ModelReference `json:"model"`
+	SkuName           string         `json:"skuName"`
+	AvailableCapacity float64        `json:"availableCapacity"`
+}
+
+// ResourceAvailabilityError is returned when a template needs SKUs or quota which are not available in the location it
+// is deployed to.
+type ResourceAvailabilityError struct {
+	Location   string
+	Violations []ResourceAvailabilityViolation
+}
+
+// ResourceAvailabilityViolation is a resource of a template which can't be created in the target location.
+type ResourceAvailabilityViolation struct {
+	// Code is the code Azure would fail the deployment with, like SkuNotAvailable or QuotaExceeded.
+	Code         string
+	ResourceType string
+	ResourceName string
+	Message      string
+	// AlternativeLocations are the locations where the resource could be created instead, when they are known.
+	AlternativeLocations []string
+}
+
+func (e *ResourceAvailabilityError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("the template can't be deployed to location '%s':\n", e.Location))
+	for _, violation := range e.Violations {
+		resource := violation.ResourceType
+		if violation.ResourceName != "" {
+			resource = fmt.Sprintf("%s (%s)", violation.ResourceName, violation.ResourceType)
+		}
+
+		sb.WriteString(fmt.Sprintf("\n- %s: %s", resource, violation.Message))
+		if len(violation.AlternativeLocations) > 0 {
+			sb.WriteString(fmt.Sprintf("\n  Available in: %s", strings.Join(violation.AlternativeLocations, ", ")))
+		}
+	}
+
+	return sb.String()
+}
+
+// AlternativeLocations returns the locations where all the resources of the violations are available, when they are
+// known for every violation.
+func (e *ResourceAvailabilityError) AlternativeLocations() []string {
+	var result []string
+	for i, violation := range e.Violations {
+		if len(violation.AlternativeLocations) == 0 {
+			return nil
+		}
+
+		if i == 0 {
+			result = slices.Clone(violation.AlternativeLocations)
+			continue
+		}
+
+		result = slices.DeleteFunc(result, func(location string) bool {
+			return !slices.ContainsFunc(violation.AlternativeLocations, func(l string) bool {
+				return SameLocation(l, location)
+			})
+		})
+	}
+
+	return result
+}
+
+// SameLocation returns true when two location names refer to the same location. ARM uses both names ("eastus") and
+// display names ("East US") depending on the API.
+func SameLocation(a string, b string) bool {
+	return NormalizeLocation(a) == NormalizeLocation(b)
+}
+
+// NormalizeLocation returns the name of a location from its name or display name.
+func NormalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+type resourceAvailability struct {
+	credentialProvider account.SubscriptionCredentialProvider
+	httpClient         httputil.HttpClient
+	userAgent          string
+	cloud              *cloud.Cloud
+}
+
+func NewResourceAvailability(
+	credentialProvider account.SubscriptionCredentialProvider,
+	httpClient httputil.HttpClient,
+	cloud *cloud.Cloud,
+) ResourceAvailability {
+	return &resourceAvailability{
+		credentialProvider: credentialProvider,
+		httpClient:         httpClient,
+		cloud:              cloud,
+		userAgent:          azdinternal.UserAgent(),
+	}
+}
+
+func (ra *resourceAvailability) ComputeSkus(
+	ctx context.Context,
+	subscriptionId string,
+	location string,
+) ([]ResourceSku, error) {
+	query := url.Values{"api-version": []string{cComputeSkusApiVersion}}
+	if location != "" {
+		query.Set("$filter", fmt.Sprintf("location eq '%s'", NormalizeLocation(location)))
+	}
+
+	skus, err := listAll[ResourceSku](
+		ctx, ra, subscriptionId, ra.subscriptionUrl(subscriptionId, query, "providers/Microsoft.Compute/skus"))
+	if err != nil {
+		return nil, fmt.Errorf("listing compute skus: %w", err)
+	}
+
+	return skus, nil
+}
+
+func (ra *resourceAvailability) ComputeUsages(
+	ctx context.Context,
+	subscriptionId string,
+	location string,
+) ([]ResourceUsage, error) {
+	query := url.Values{"api-version": []string{cComputeUsagesApiVersion}}
+	usages, err := listAll[ResourceUsage](
+		ctx,
+		ra,
+		subscriptionId,
+		ra.subscriptionUrl(
+			subscriptionId, query, "providers/Microsoft.Compute/locations", NormalizeLocation(location), "usages"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing compute usages: %w", err)
+	}
+
+	return usages, nil
+}
+
+func (ra *resourceAvailability) CognitiveServicesSkus(
+	ctx context.Context,
+	subscriptionId string,
+) ([]ResourceSku, error) {
+	credential, err := ra.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armcognitiveservices.NewResourceSKUsClient(subscriptionId, credential, ra.clientOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("creating cognitive services skus client: %w", err)
+	}
+
+	skus := []ResourceSku{}
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing cognitive services skus: %w", err)
+		}
+
+		for _, sku := range page.Value {
+			skus = append(skus, cognitiveServicesSku(sku))
+		}
+	}
+
+	return skus, nil
+}
+
+// cognitiveServicesSku converts a SKU of the Cognitive Services API to a ResourceSku.
+func cognitiveServicesSku(sku *armcognitiveservices.ResourceSKU) ResourceSku {
+	result := ResourceSku{
+		ResourceType: convert.ToValueWithDefault(sku.ResourceType, ""),
+		Name:         convert.ToValueWithDefault(sku.Name, ""),
+		Tier:         convert.ToValueWithDefault(sku.Tier, ""),
+		Kind:         convert.ToValueWithDefault(sku.Kind, ""),
+		Locations:    stringValues(sku.Locations),
+	}
+
+	for _, restriction := range sku.Restrictions {
+		converted := ResourceSkuRestriction{
+			Type:       string(convert.ToValueWithDefault(restriction.Type, "")),
+			Values:     stringValues(restriction.Values),
+			ReasonCode: string(convert.ToValueWithDefault(restriction.ReasonCode, "")),
+		}
+		if restriction.RestrictionInfo != nil {
+			converted.RestrictionInfo = ResourceSkuRestrictionInfo{
+				Locations: stringValues(restriction.RestrictionInfo.Locations),
+				Zones:     stringValues(restriction.RestrictionInfo.Zones),
+			}
+		}
+
+		result.Restrictions = append(result.Restrictions, converted)
+	}
+
+	return result
+}
+
+func (ra *resourceAvailability) ModelCapacities(
+	ctx context.Context,
+	subscriptionId string,
+	model ModelReference,
+) ([]ModelCapacity, error) {
+	query := url.Values{
+		"api-version": []string{cModelCapacitiesApiVersion},
+		"modelFormat": []string{model.Format},
+		"modelName":   []string{model.Name},
+	}
+	if model.Version != "" {
+		query.Set("modelVersion", model.Version)
+	}
+
+	capacities, err := listAll[ModelCapacity](
+		ctx,
+		ra,
+		subscriptionId,
+		ra.subscriptionUrl(subscriptionId, query, "providers/Microsoft.CognitiveServices/modelCapacities"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing capacity of model %s: %w", model.Name, err)
+	}
+
+	return capacities, nil
+}
+
+func (ra *resourceAvailability) AppServiceLocations(
+	ctx context.Context,
+	subscriptionId string,
+	tier string,
+) ([]string, error) {
+	credential, err := ra.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armappservice.NewWebSiteManagementClient(subscriptionId, credential, ra.clientOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("creating app service client: %w", err)
+	}
+
+	locations := []string{}
+	pager := client.NewListGeoRegionsPager(&armappservice.WebSiteManagementClientListGeoRegionsOptions{
+		SKU: to.Ptr(armappservice.SKUName(tier)),
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing locations of app service tier %s: %w", tier, err)
+		}
+
+		for _, region := range page.Value {
+			if region.Name != nil {
+				locations = append(locations, NormalizeLocation(*region.Name))
+			}
+		}
+	}
+
+	return locations, nil
+}
+
+func (ra *resourceAvailability) ResourceTypeLocations(
+	ctx context.Context,
+	subscriptionId string,
+	resourceType string,
+) ([]string, error) {
+	namespace, typeName, found := strings.Cut(resourceType, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid resource type '%s'", resourceType)
+	}
+
+	credential, err := ra.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armresources.NewProvidersClient(subscriptionId, credential, ra.clientOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("creating providers client: %w", err)
+	}
+
+	provider, err := client.Get(ctx, namespace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource provider %s: %w", namespace, err)
+	}
+
+	for _, rt := range provider.ResourceTypes {
+		if rt.ResourceType != nil && strings.EqualFold(*rt.ResourceType, typeName) {
+			locations := make([]string, 0, len(rt.Locations))
+			for _, location := range rt.Locations {
+				if location != nil {
+					locations = append(locations, NormalizeLocation(*location))
+				}
+			}
+
+			return locations, nil
+		}
+	}
+
+	return nil, fmt.Errorf("resource type '%s' not found", resourceType)
+}
+
+// stringValues returns the values of a list of strings of an Azure SDK model.
+func stringValues(values []*string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			result = append(result, *value)
+		}
+	}
+
+	return result
+}
+
+// listAll reads all the pages of an ARM list operation, following the next links. It is used for the operations which
+// don't have a client in the Azure SDK versions azd uses: the compute SKUs and usages, and the model capacities.
+func listAll[T any](
+	ctx context.Context,
+	ra *resourceAvailability,
+	subscriptionId string,
+	requestUrl string,
+) ([]T, error) {
+	pipeline, err := ra.createPipeline(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []T{}
+	for requestUrl != "" {
+		request, err := runtime.NewRequest(ctx, http.MethodGet, requestUrl)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		response, err := pipeline.Do(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if !runtime.HasStatusCode(response, http.StatusOK) {
+			return nil, runtime.NewResponseError(response)
+		}
+
+		var page struct {
+			Value    []T    `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := runtime.UnmarshalAsJSON(response, &page); err != nil {
+			return nil, err
+		}
+
+		result = append(result, page.Value...)
+		requestUrl = page.NextLink
+	}
+
+	return result, nil
+}
+
+// createPipeline creates the HTTP pipeline for the requests made on behalf of the given subscription.
+func (ra *resourceAvailability) createPipeline(ctx context.Context, subscriptionId string) (runtime.Pipeline, error) {
+	credential, err := ra.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return runtime.Pipeline{}, err
+	}
+
+	pipeline, err := armruntime.NewPipeline(
+		"resourceavailability", "1.0.0", credential, runtime.PipelineOptions{}, ra.clientOptions(ctx))
+	if err != nil {
+		return runtime.Pipeline{}, fmt.Errorf("creating resource availability pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+// clientOptions returns the options of the ARM clients and pipelines of the resource availability requests.
+func (ra *resourceAvailability) clientOptions(ctx context.Context) *arm.ClientOptions {
+	return azsdk.NewClientOptionsBuilder().
+		WithTransport(ra.httpClient).
+		WithCloud(ra.cloud.Configuration).
+		WithPerCallPolicy(azsdk.NewUserAgentPolicy(ra.userAgent)).
+		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx)).
+		BuildArmClientOptions()
+}
+
+// subscriptionUrl returns the URL of a path of the given subscription, with the given query.
+func (ra *resourceAvailability) subscriptionUrl(subscriptionId string, query url.Values, paths ...string) string {
+	return fmt.Sprintf("%s?%s",
+		runtime.JoinPaths(
+			ra.cloud.ResourceManagerEndpoint(),
+			append([]string{"subscriptions", url.PathEscape(subscriptionId)}, paths...)...),
+		query.Encode())
+}
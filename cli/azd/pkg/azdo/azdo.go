@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/location"
 )
 
 var (
@@ -34,8 +35,6 @@ var (
 	AzurePipelineName = "Azure Dev Deploy"
 	// path to the azure pipeline yaml
 	AzurePipelineYamlPath = ".azdo/pipelines/azure-dev.yml"
-	// target Azure Cloud
-	CloudEnvironment = "AzureCloud"
 	// default branch for pipeline and branch policy
 	DefaultBranch = "main"
 	// azure devops project description
@@ -60,3 +59,19 @@ func GetConnection(
 
 	return connection, nil
 }
+
+// GetOrganizationId returns the id of the organization of the connection, which identifies the organization as the issuer
+// of the federated tokens of its service connections.
+func GetOrganizationId(ctx context.Context, connection *azuredevops.Connection) (string, error) {
+	client := location.NewClient(ctx, connection)
+	connectionData, err := client.GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return "", fmt.Errorf("getting organization connection data: %w", err)
+	}
+
+	if connectionData.InstanceId == nil {
+		return "", fmt.Errorf("organization id not found in connection data")
+	}
+
+	return connectionData.InstanceId.String(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
@@ -77,53 +78,67 @@ func serviceConnectionExists(ctx context.Context,
 	return nil, nil
 }
 
-// create a new service connection that will be used in the deployment pipeline
+// ServiceConnectionSubject returns the subject of the federated tokens issued to the service connection of the project.
+func ServiceConnectionSubject(orgName string, projectName string) string {
+	return fmt.Sprintf("sc://%s/%s/%s", orgName, projectName, ServiceConnectionName)
+}
+
+// ServiceConnectionIssuer returns the issuer of the federated tokens of the service connections of the organization.
+func ServiceConnectionIssuer(organizationId string) string {
+	return fmt.Sprintf("https://vstoken.%s/%s", AzDoHostName, organizationId)
+}
+
+// create a new service connection that will be used in the deployment pipeline. When useWorkloadIdentity is true, the
+// service connection authenticates with workload identity federation instead of the client secret of the credentials.
 func CreateServiceConnection(
 	ctx context.Context,
 	connection *azuredevops.Connection,
 	projectId string,
 	azdEnvironment environment.Environment,
 	credentials *azcli.AzureCredentials,
-	console input.Console) error {
+	useWorkloadIdentity bool,
+	cloud *cloud.Cloud,
+	console input.Console) (*serviceendpoint.ServiceEndpoint, error) {
 
 	client, err := serviceendpoint.NewClient(ctx, connection)
 	if err != nil {
-		return fmt.Errorf("creating new azdo client: %w", err)
+		return nil, fmt.Errorf("creating new azdo client: %w", err)
 	}
 
 	foundServiceConnection, err := serviceConnectionExists(ctx, &client, &projectId, &ServiceConnectionName)
 	if err != nil {
-		return fmt.Errorf("creating service connection: looking for existing connection: %w", err)
+		return nil, fmt.Errorf("creating service connection: looking for existing connection: %w", err)
 	}
 
 	// endpoint contains the Azure credentials
-	createServiceEndpointArgs, err := createAzureRMServiceEndPointArgs(ctx, &projectId, credentials)
+	createServiceEndpointArgs, err := createAzureRMServiceEndPointArgs(
+		ctx, &projectId, credentials, useWorkloadIdentity, cloud)
 	if err != nil {
-		return fmt.Errorf("creating Azure DevOps endpoint: %w", err)
+		return nil, fmt.Errorf("creating Azure DevOps endpoint: %w", err)
 	}
 
 	// if a service connection exists, skip creating a new Service connection. But update the current connection only
 	if foundServiceConnection != nil {
 		// After updating the endpoint with credentials, we no longer need it
-		_, err := client.UpdateServiceEndpoint(ctx, serviceendpoint.UpdateServiceEndpointArgs{
+		endpoint, err := client.UpdateServiceEndpoint(ctx, serviceendpoint.UpdateServiceEndpointArgs{
 			Endpoint:   createServiceEndpointArgs.Endpoint,
 			Project:    createServiceEndpointArgs.Project,
 			EndpointId: foundServiceConnection.Id,
 		})
 		if err != nil {
-			return fmt.Errorf("updating service connection: %w", err)
+			return nil, fmt.Errorf("updating service connection: %w", err)
 		}
 		console.MessageUxItem(ctx, &ux.DisplayedResource{
 			Type: "Azure DevOps",
 			Name: "Updated service connection",
 		})
-		return nil
+		return endpoint, nil
 	}
 
 	// Service connection not found. Creating a new one and authorizing.
 	endpoint, err := client.CreateServiceEndpoint(ctx, createServiceEndpointArgs)
 	if err != nil {
-		return fmt.Errorf("Creating new service connection: %w", err)
+		return nil, fmt.Errorf("Creating new service connection: %w", err)
 	}
 	console.MessageUxItem(ctx, &ux.DisplayedResource{
 		Type: "Azure DevOps",
@@ -132,10 +147,10 @@ func CreateServiceConnection(
 
 	err = authorizeServiceConnectionToAllPipelines(ctx, projectId, endpoint, connection)
 	if err != nil {
-		return fmt.Errorf("authorizing service connection: %w", err)
+		return nil, fmt.Errorf("authorizing service connection: %w", err)
 	}
 
-	return nil
+	return endpoint, nil
 }
 
 // creates input parameter needed to create the azure rm service connection
@@ -143,10 +158,12 @@ func createAzureRMServiceEndPointArgs(
 	ctx context.Context,
 	projectId *string,
 	credentials *azcli.AzureCredentials,
+	useWorkloadIdentity bool,
+	cloud *cloud.Cloud,
 ) (serviceendpoint.CreateServiceEndpointArgs, error) {
 	endpointType := "azurerm"
 	endpointOwner := "library"
-	endpointUrl := "https://management.azure.com/"
+	endpointUrl := cloud.ResourceManagerEndpoint() + "/"
 	endpointName := ServiceConnectionName
 	endpointIsShared := false
 	endpointScheme := "ServicePrincipal"
@@ -158,8 +175,18 @@ func createAzureRMServiceEndPointArgs(
 		"tenantid":            credentials.TenantId,
 	}
 
+	if useWorkloadIdentity {
+		// The federated credential of the service principal is created by azd, the issuer and subject are the ones
+		// Azure DevOps uses for the service connection.
+		endpointScheme = "WorkloadIdentityFederation"
+		endpointAuthorizationParameters = map[string]string{
+			"serviceprincipalid": credentials.ClientId,
+			"tenantid":           credentials.TenantId,
+		}
+	}
+
 	endpointData := map[string]string{
-		"environment":      CloudEnvironment,
+		"environment":      cloud.Name,
 		"subscriptionId":   credentials.SubscriptionId,
 		"subscriptionName": "azure subscription",
 		"scopeLevel":       "Subscription",
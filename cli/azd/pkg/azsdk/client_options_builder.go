@@ -3,11 +3,13 @@ package azsdk
 import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 )
 
 type ClientOptionsBuilder struct {
 	transport        policy.Transporter
+	cloud            cloud.Configuration
 	perCallPolicies  []policy.Policy
 	perRetryPolicies []policy.Policy
 }
@@ -22,6 +24,12 @@ func (b *ClientOptionsBuilder) WithTransport(transport policy.Transporter) *Clie
 	return b
 }
 
+// Sets the cloud the clients connect to. Clients connect to the public Azure cloud when no cloud is set.
+func (b *ClientOptionsBuilder) WithCloud(cloud cloud.Configuration) *ClientOptionsBuilder {
+	b.cloud = cloud
+	return b
+}
+
 // Appends per-call policies into the HTTP pipeline
 func (b *ClientOptionsBuilder) WithPerCallPolicy(policy policy.Policy) *ClientOptionsBuilder {
 	b.perCallPolicies = append(b.perCallPolicies, policy)
@@ -40,6 +48,7 @@ func (b *ClientOptionsBuilder) BuildCoreClientOptions() *azcore.ClientOptions {
 	return &azcore.ClientOptions{
 		// Supports mocking for unit tests
 		Transport: b.transport,
+		Cloud:     b.cloud,
 		// Per request policies to inject into HTTP pipeline
 		PerCallPolicies: b.perCallPolicies,
 		// Per retry policies to inject into HTTP pipeline
@@ -54,6 +63,7 @@ func (b *ClientOptionsBuilder) BuildArmClientOptions() *arm.ClientOptions {
 		ClientOptions: policy.ClientOptions{
 			// Supports mocking for unit tests
 			Transport: b.transport,
+			Cloud:     b.cloud,
 			// Per request policies to inject into HTTP pipeline
 			PerCallPolicies: b.perCallPolicies,
 			// Per retry policies to inject into HTTP pipeline
@@ -11,6 +11,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 )
 
@@ -21,10 +22,6 @@ type AccountConfig struct {
 	Endpoint      string
 }
 
-const (
-	DefaultBlobEndpoint = "blob.core.windows.net"
-)
-
 var (
 	ErrContainerNotFound = errors.New("container not found")
 )
@@ -174,9 +171,11 @@ func NewBlobSdkClient(
 	accountConfig *AccountConfig,
 	httpClient httputil.HttpClient,
 	userAgent httputil.UserAgent,
+	cloud *cloud.Cloud,
 ) (*azblob.Client, error) {
 	coreOptions := azsdk.
 		DefaultClientOptionsBuilder(ctx, httpClient, string(userAgent)).
+		WithCloud(cloud.Configuration).
 		BuildCoreClientOptions()
 
 	blobOptions := &azblob.ClientOptions{
@@ -184,7 +183,7 @@ func NewBlobSdkClient(
 	}
 
 	if accountConfig.Endpoint == "" {
-		accountConfig.Endpoint = DefaultBlobEndpoint
+		accountConfig.Endpoint = "blob." + cloud.StorageEndpointSuffix
 	}
 
 	serviceUrl := fmt.Sprintf("https://%s.%s", accountConfig.AccountName, accountConfig.Endpoint)
@@ -2,6 +2,7 @@ package azsdk
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,8 +26,9 @@ const (
 // https://github.com/MicrosoftDocs/azure-docs/blob/main/includes/app-service-deploy-zip-push-rest.md
 // https://github.com/projectkudu/kudu/wiki/REST-API
 type ZipDeployClient struct {
-	subscriptionId string
-	pipeline       runtime.Pipeline
+	subscriptionId    string
+	scmEndpointSuffix string
+	pipeline          runtime.Pipeline
 }
 
 type DeployResponse struct {
@@ -52,12 +54,17 @@ type DeployStatus struct {
 	SiteName     string     `json:"site_name"`
 }
 
-// Creates a new ZipDeployClient instance
+// Creates a new ZipDeployClient instance, deploying to the SCM sites with the given DNS suffix, like scm.azurewebsites.net
 func NewZipDeployClient(
 	subscriptionId string,
+	scmEndpointSuffix string,
 	credential azcore.TokenCredential,
 	options *arm.ClientOptions,
 ) (*ZipDeployClient, error) {
+	if scmEndpointSuffix == "" {
+		return nil, errors.New("the cloud doesn't define the DNS suffix of App Service SCM sites")
+	}
+
 	if options == nil {
 		options = &arm.ClientOptions{}
 	}
@@ -77,8 +84,9 @@ func NewZipDeployClient(
 	}
 
 	return &ZipDeployClient{
-		subscriptionId: subscriptionId,
-		pipeline:       pipeline,
+		subscriptionId:    subscriptionId,
+		scmEndpointSuffix: scmEndpointSuffix,
+		pipeline:          pipeline,
 	}, nil
 }
 
@@ -137,7 +145,7 @@ func (c *ZipDeployClient) createDeployRequest(
 	appName string,
 	zipFile io.Reader,
 ) (*policy.Request, error) {
-	endpoint := fmt.Sprintf("https://%s.scm.azurewebsites.net/api/zipdeploy", appName)
+	endpoint := fmt.Sprintf("https://%s.%s/api/zipdeploy", appName, c.scmEndpointSuffix)
 	req, err := runtime.NewRequest(ctx, http.MethodPost, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("creating deploy request: %w", err)
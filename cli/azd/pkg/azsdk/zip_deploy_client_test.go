@@ -24,7 +24,7 @@ func TestZipDeploy(t *testing.T) {
 			WithTransport(mockContext.HttpClient).
 			BuildArmClientOptions()
 
-		client, err := NewZipDeployClient("SUBSCRIPTION_ID", &mocks.MockCredentials{}, options)
+		client, err := NewZipDeployClient("SUBSCRIPTION_ID", "scm.azurewebsites.net", &mocks.MockCredentials{}, options)
 		require.NoError(t, err)
 
 		zipFile := bytes.NewBuffer([]byte{})
@@ -49,7 +49,7 @@ func TestZipDeploy(t *testing.T) {
 			WithTransport(mockContext.HttpClient).
 			BuildArmClientOptions()
 
-		client, err := NewZipDeployClient("SUBSCRIPTION_ID", &mocks.MockCredentials{}, options)
+		client, err := NewZipDeployClient("SUBSCRIPTION_ID", "scm.azurewebsites.net", &mocks.MockCredentials{}, options)
 		require.NoError(t, err)
 
 		zipFile := bytes.NewBuffer([]byte{})
@@ -73,7 +73,7 @@ func TestZipDeploy(t *testing.T) {
 			WithTransport(mockContext.HttpClient).
 			BuildArmClientOptions()
 
-		client, err := NewZipDeployClient("SUBSCRIPTION_ID", &mocks.MockCredentials{}, options)
+		client, err := NewZipDeployClient("SUBSCRIPTION_ID", "scm.azurewebsites.net", &mocks.MockCredentials{}, options)
 		require.NoError(t, err)
 
 		zipFile := bytes.NewBuffer([]byte{})
@@ -81,6 +81,28 @@ func TestZipDeploy(t *testing.T) {
 		require.Nil(t, poller)
 		require.Error(t, err)
 	})
+
+	t.Run("SovereignCloud", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		var host string
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPost && strings.Contains(request.URL.Path, "/api/zipdeploy")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			host = request.URL.Host
+			return mocks.CreateEmptyHttpResponse(request, http.StatusConflict)
+		})
+
+		options := NewClientOptionsBuilder().
+			WithTransport(mockContext.HttpClient).
+			BuildArmClientOptions()
+
+		client, err := NewZipDeployClient("SUBSCRIPTION_ID", "scm.azurewebsites.us", &mocks.MockCredentials{}, options)
+		require.NoError(t, err)
+
+		_, err = client.BeginDeploy(*mockContext.Context, "APP_NAME", bytes.NewBuffer([]byte{}))
+		require.Error(t, err)
+		require.Equal(t, "APP_NAME.scm.azurewebsites.us", host)
+	})
 }
 
 func registerConflictMocks(mockContext *mocks.MockContext) {
@@ -16,6 +16,8 @@ type DeploymentScope string
 
 const DeploymentScopeSubscription DeploymentScope = "subscription"
 const DeploymentScopeResourceGroup DeploymentScope = "resourceGroup"
+const DeploymentScopeManagementGroup DeploymentScope = "managementGroup"
+const DeploymentScopeTenant DeploymentScope = "tenant"
 
 // RawArmTemplate is a JSON encoded ARM template.
 type RawArmTemplate = json.RawMessage
@@ -33,6 +35,8 @@ type ArmTemplate struct {
 
 var cResourceDeploymentTemplateSchemaLower = strings.ToLower("deploymentTemplate.json")
 var cSubscriptionDeploymentTemplateSchemaLower = strings.ToLower("subscriptionDeploymentTemplate.json")
+var cManagementGroupDeploymentTemplateSchemaLower = strings.ToLower("managementGroupDeploymentTemplate.json")
+var cTenantDeploymentTemplateSchemaLower = strings.ToLower("tenantDeploymentTemplate.json")
 
 // TargetScope uses the $schema property of the template to determine what scope this template should be deployed
 // at or an error if the scope could not be determined.
@@ -51,6 +55,10 @@ func (t ArmTemplate) TargetScope() (DeploymentScope, error) {
 		return DeploymentScopeSubscription, nil
 	case cResourceDeploymentTemplateSchemaLower:
 		return DeploymentScopeResourceGroup, nil
+	case cManagementGroupDeploymentTemplateSchemaLower:
+		return DeploymentScopeManagementGroup, nil
+	case cTenantDeploymentTemplateSchemaLower:
+		return DeploymentScopeTenant, nil
 	default:
 		return DeploymentScope(""), fmt.Errorf("unknown schema: %s", t.Schema)
 	}
@@ -84,7 +92,8 @@ type ArmTemplateParameterDefinition struct {
 }
 
 func (d *ArmTemplateParameterDefinition) Secure() bool {
-	return d.Type == "secureObject" || d.Type == "secureString"
+	// Types are case insensitive, and bicep compiles secure parameters to "securestring" and "secureobject".
+	return strings.EqualFold(d.Type, "secureObject") || strings.EqualFold(d.Type, "secureString")
 }
 
 type AzdMetadata struct {
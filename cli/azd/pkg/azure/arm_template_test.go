@@ -0,0 +1,29 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArmTemplateTargetScope(t *testing.T) {
+	const schemas = "https://schema.management.azure.com/schemas"
+	tests := map[string]DeploymentScope{
+		schemas + "/2019-04-01/deploymentTemplate.json#":                DeploymentScopeResourceGroup,
+		schemas + "/2018-05-01/subscriptionDeploymentTemplate.json#":    DeploymentScopeSubscription,
+		schemas + "/2019-08-01/managementGroupDeploymentTemplate.json#": DeploymentScopeManagementGroup,
+		schemas + "/2019-08-01/tenantDeploymentTemplate.json#":          DeploymentScopeTenant,
+	}
+
+	for schema, expected := range tests {
+		scope, err := ArmTemplate{Schema: schema}.TargetScope()
+		require.NoError(t, err)
+		require.Equal(t, expected, scope)
+	}
+
+	_, err := ArmTemplate{Schema: schemas + "/unknown.json#"}.TargetScope()
+	require.Error(t, err)
+}
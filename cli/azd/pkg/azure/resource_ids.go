@@ -50,6 +50,22 @@ func ResourceGroupDeploymentRID(subscriptionId string, resourceGroupName string,
 	return returnValue
 }
 
+// Creates management group level deployment resource ID
+func ManagementGroupDeploymentRID(managementGroupId string, deploymentId string) string {
+	returnValue := fmt.Sprintf(
+		"/providers/Microsoft.Management/managementGroups/%s/providers/Microsoft.Resources/deployments/%s",
+		managementGroupId,
+		deploymentId,
+	)
+	return returnValue
+}
+
+// Creates tenant level deployment resource ID
+func TenantDeploymentRID(deploymentId string) string {
+	returnValue := fmt.Sprintf("/providers/Microsoft.Resources/deployments/%s", deploymentId)
+	return returnValue
+}
+
 // Creates resource ID for an Azure resource group
 func ResourceGroupRID(subscriptionId, resourceGroupName string) string {
 	returnValue := fmt.Sprintf("%s/resourceGroups/%s", SubscriptionRID(subscriptionId), resourceGroupName)
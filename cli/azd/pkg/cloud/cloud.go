@@ -0,0 +1,298 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package cloud describes the Azure clouds azd can target: the public Azure cloud, the sovereign clouds and custom clouds
+// described by an endpoint metadata file.
+package cloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+const (
+	AzurePublicName       = "AzureCloud"
+	AzureUSGovernmentName = "AzureUSGovernment"
+	AzureChinaCloudName   = "AzureChinaCloud"
+)
+
+// MicrosoftGraph is the service name of Microsoft Graph in the cloud configuration.
+const MicrosoftGraph cloud.ServiceName = "microsoftGraph"
+
+// Cloud is the set of endpoints of an Azure cloud.
+type Cloud struct {
+	Name string
+
+	// The authority host and the Resource Manager and Microsoft Graph endpoints and audiences of the cloud, used by the Azure
+	// SDK clients.
+	Configuration cloud.Configuration
+
+	// The base URL of the Azure portal, without trailing slash, like https://portal.azure.com
+	PortalUrlBase string
+
+	// The endpoint of Microsoft Graph, without trailing slash, like https://graph.microsoft.com
+	GraphEndpoint string
+
+	// The DNS suffix of storage accounts, like core.windows.net
+	StorageEndpointSuffix string
+
+	// The DNS suffix of the SCM (Kudu) sites of App Service apps, like scm.azurewebsites.net. Empty for custom clouds, whose
+	// metadata doesn't describe it.
+	AppServiceScmEndpointSuffix string
+
+	// The DNS suffix of Key Vault vaults, like vault.azure.net
+	KeyVaultEndpointSuffix string
+
+	// The DNS suffix of the login servers of container registries, like azurecr.io
+	ContainerRegistryEndpointSuffix string
+}
+
+// Config is the cloud configuration of the `cloud` section of the user configuration or azure.yaml.
+type Config struct {
+	// The name of the cloud: AzureCloud, AzureUSGovernment or AzureChinaCloud. Custom clouds are selected by the name
+	// of the cloud in the metadata file, when the file describes several clouds.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// The path to the endpoint metadata file of a custom cloud, in the format of the Resource Manager
+	// /metadata/endpoints API, like the one of Azure Stack Hub.
+	MetadataFile string `json:"metadataFile,omitempty" yaml:"metadataFile,omitempty"`
+}
+
+// ParseConfig attempts to parse a partial JSON configuration into a cloud configuration
+func ParseConfig(partialConfig any) (*Config, error) {
+	var config *Config
+
+	jsonBytes, err := json.Marshal(partialConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cloud configuration: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonBytes, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cloud configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// NewCloud returns the cloud selected by the configuration. The public Azure cloud is returned when the configuration is
+// nil or empty.
+func NewCloud(config *Config) (*Cloud, error) {
+	if config == nil || (config.Name == "" && config.MetadataFile == "") {
+		return AzurePublic(), nil
+	}
+
+	if config.MetadataFile != "" {
+		return readMetadataFile(config.MetadataFile, config.Name)
+	}
+
+	switch strings.ToLower(config.Name) {
+	case strings.ToLower(AzurePublicName), "azurepublic":
+		return AzurePublic(), nil
+	case strings.ToLower(AzureUSGovernmentName), "azuregovernment":
+		return AzureGovernment(), nil
+	case strings.ToLower(AzureChinaCloudName), "azurechina":
+		return AzureChina(), nil
+	}
+
+	return nil, fmt.Errorf(
+		"unsupported cloud '%s'. Valid values are '%s', '%s' and '%s', or a custom cloud with 'metadataFile'",
+		config.Name,
+		AzurePublicName,
+		AzureUSGovernmentName,
+		AzureChinaCloudName,
+	)
+}
+
+func AzurePublic() *Cloud {
+	return newCloud(
+		AzurePublicName,
+		cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+		cloud.ServiceConfiguration{
+			Audience: "https://management.core.windows.net/",
+			Endpoint: "https://management.azure.com",
+		},
+		"https://portal.azure.com",
+		"https://graph.microsoft.com",
+		"core.windows.net",
+		"scm.azurewebsites.net",
+		"vault.azure.net",
+		"azurecr.io",
+	)
+}
+
+func AzureGovernment() *Cloud {
+	return newCloud(
+		AzureUSGovernmentName,
+		cloud.AzureGovernment.ActiveDirectoryAuthorityHost,
+		cloud.ServiceConfiguration{
+			Audience: "https://management.core.usgovcloudapi.net",
+			Endpoint: "https://management.usgovcloudapi.net",
+		},
+		"https://portal.azure.us",
+		"https://graph.microsoft.us",
+		"core.usgovcloudapi.net",
+		"scm.azurewebsites.us",
+		"vault.usgovcloudapi.net",
+		"azurecr.us",
+	)
+}
+
+func AzureChina() *Cloud {
+	return newCloud(
+		AzureChinaCloudName,
+		cloud.AzureChina.ActiveDirectoryAuthorityHost,
+		cloud.ServiceConfiguration{
+			Audience: "https://management.core.chinacloudapi.cn",
+			Endpoint: "https://management.chinacloudapi.cn",
+		},
+		"https://portal.azure.cn",
+		"https://microsoftgraph.chinacloudapi.cn",
+		"core.chinacloudapi.cn",
+		"scm.chinacloudsites.cn",
+		"vault.azure.cn",
+		"azurecr.cn",
+	)
+}
+
+// newCloud returns a cloud with the given endpoints. The Resource Manager endpoints are set here rather than taken from the
+// configurations of the Azure SDK, which only define them once the arm package is initialized.
+func newCloud(
+	name string,
+	authorityHost string,
+	resourceManager cloud.ServiceConfiguration,
+	portalUrlBase string,
+	graphEndpoint string,
+	storageEndpointSuffix string,
+	appServiceScmEndpointSuffix string,
+	keyVaultEndpointSuffix string,
+	containerRegistryEndpointSuffix string,
+) *Cloud {
+	services := map[cloud.ServiceName]cloud.ServiceConfiguration{
+		cloud.ResourceManager: resourceManager,
+	}
+
+	if graphEndpoint != "" {
+		services[MicrosoftGraph] = cloud.ServiceConfiguration{
+			Audience: graphEndpoint,
+			Endpoint: graphEndpoint + "/v1.0",
+		}
+	}
+
+	return &Cloud{
+		Name: name,
+		Configuration: cloud.Configuration{
+			ActiveDirectoryAuthorityHost: authorityHost,
+			Services:                     services,
+		},
+		PortalUrlBase:                   portalUrlBase,
+		GraphEndpoint:                   graphEndpoint,
+		StorageEndpointSuffix:           storageEndpointSuffix,
+		AppServiceScmEndpointSuffix:     appServiceScmEndpointSuffix,
+		KeyVaultEndpointSuffix:          keyVaultEndpointSuffix,
+		ContainerRegistryEndpointSuffix: containerRegistryEndpointSuffix,
+	}
+}
+
+// ResourceManagerEndpoint returns the endpoint of Resource Manager, without trailing slash, like
+// https://management.azure.com
+func (c *Cloud) ResourceManagerEndpoint() string {
+	return strings.TrimSuffix(c.Configuration.Services[cloud.ResourceManager].Endpoint, "/")
+}
+
+// ResourceManagerScope returns the scope of the tokens for Resource Manager, like https://management.azure.com//.default
+func (c *Cloud) ResourceManagerScope() string {
+	return c.ResourceManagerEndpoint() + "//.default"
+}
+
+// GraphScope returns the scope of the tokens for Microsoft Graph, like https://graph.microsoft.com/.default
+func (c *Cloud) GraphScope() string {
+	return c.GraphEndpoint + "/.default"
+}
+
+// AuthorityHost returns the authority host of Microsoft Entra ID, with trailing slash, like
+// https://login.microsoftonline.com/
+func (c *Cloud) AuthorityHost() string {
+	host := c.Configuration.ActiveDirectoryAuthorityHost
+	if !strings.HasSuffix(host, "/") {
+		host += "/"
+	}
+
+	return host
+}
+
+// metadataEndpoints is the format of the Resource Manager /metadata/endpoints API.
+type metadataEndpoints struct {
+	Name            string `json:"name"`
+	Portal          string `json:"portal"`
+	ResourceManager string `json:"resourceManager"`
+	Authentication  struct {
+		LoginEndpoint string   `json:"loginEndpoint"`
+		Audiences     []string `json:"audiences"`
+	} `json:"authentication"`
+	MicrosoftGraphResourceId string `json:"microsoftGraphResourceId"`
+	Suffixes                 struct {
+		Storage        string `json:"storage"`
+		KeyVaultDns    string `json:"keyVaultDns"`
+		AcrLoginServer string `json:"acrLoginServer"`
+	} `json:"suffixes"`
+}
+
+// readMetadataFile reads a custom cloud from an endpoint metadata file. The file holds either a single cloud, or a list of
+// clouds (api-version 2022-09-01 and later) from which the cloud with the given name, or the only cloud, is read.
+func readMetadataFile(path string, name string) (*Cloud, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cloud metadata file: %w", err)
+	}
+
+	var clouds []metadataEndpoints
+	if err := json.Unmarshal(contents, &clouds); err != nil {
+		var single metadataEndpoints
+		if err := json.Unmarshal(contents, &single); err != nil {
+			return nil, fmt.Errorf("parsing cloud metadata file %s: %w", path, err)
+		}
+
+		clouds = []metadataEndpoints{single}
+	}
+
+	var metadata *metadataEndpoints
+	for i := range clouds {
+		if strings.EqualFold(clouds[i].Name, name) || (name == "" && len(clouds) == 1) {
+			metadata = &clouds[i]
+			break
+		}
+	}
+
+	if metadata == nil {
+		return nil, fmt.Errorf("cloud '%s' not found in cloud metadata file %s", name, path)
+	}
+
+	if metadata.ResourceManager == "" || metadata.Authentication.LoginEndpoint == "" {
+		return nil, errors.New("cloud metadata file must define resourceManager and authentication.loginEndpoint")
+	}
+
+	audience := metadata.ResourceManager
+	if len(metadata.Authentication.Audiences) > 0 {
+		audience = metadata.Authentication.Audiences[0]
+	}
+
+	return newCloud(
+		metadata.Name,
+		metadata.Authentication.LoginEndpoint,
+		cloud.ServiceConfiguration{
+			Audience: audience,
+			Endpoint: metadata.ResourceManager,
+		},
+		strings.TrimSuffix(metadata.Portal, "/"),
+		strings.TrimSuffix(metadata.MicrosoftGraphResourceId, "/"),
+		metadata.Suffixes.Storage,
+		"",
+		strings.TrimPrefix(metadata.Suffixes.KeyVaultDns, "."),
+		strings.TrimPrefix(metadata.Suffixes.AcrLoginServer, "."),
+	), nil
+}
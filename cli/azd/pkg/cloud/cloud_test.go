@@ -0,0 +1,142 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cloud
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewCloud(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		for _, config := range []*Config{nil, {}} {
+			azureCloud, err := NewCloud(config)
+			require.NoError(t, err)
+			require.Equal(t, AzurePublicName, azureCloud.Name)
+		}
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		tests := map[string]string{
+			"AzureCloud":        AzurePublicName,
+			"AzureUSGovernment": AzureUSGovernmentName,
+			"azuregovernment":   AzureUSGovernmentName,
+			"AzureChinaCloud":   AzureChinaCloudName,
+			"AzureChina":        AzureChinaCloudName,
+		}
+
+		for name, expected := range tests {
+			azureCloud, err := NewCloud(&Config{Name: name})
+			require.NoError(t, err)
+			require.Equal(t, expected, azureCloud.Name)
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := NewCloud(&Config{Name: "AzureGermanCloud"})
+		require.ErrorContains(t, err, "unsupported cloud 'AzureGermanCloud'")
+	})
+}
+
+func Test_Cloud_Endpoints(t *testing.T) {
+	public := AzurePublic()
+	require.Equal(t, azure.ManagementScope, public.ResourceManagerScope())
+	require.Equal(t, "https://login.microsoftonline.com/", public.AuthorityHost())
+	require.Equal(t, "https://graph.microsoft.com/.default", public.GraphScope())
+
+	government := AzureGovernment()
+	require.Equal(t, "https://management.usgovcloudapi.net", government.ResourceManagerEndpoint())
+	require.Equal(t, "https://management.usgovcloudapi.net//.default", government.ResourceManagerScope())
+	require.Equal(t, "https://login.microsoftonline.us/", government.AuthorityHost())
+	require.Equal(t, "https://portal.azure.us", government.PortalUrlBase)
+	require.Equal(t, "scm.azurewebsites.us", government.AppServiceScmEndpointSuffix)
+	require.Equal(t, "vault.usgovcloudapi.net", government.KeyVaultEndpointSuffix)
+	require.Equal(t, "azurecr.us", government.ContainerRegistryEndpointSuffix)
+	require.Equal(
+		t,
+		"https://graph.microsoft.us/v1.0",
+		government.Configuration.Services[MicrosoftGraph].Endpoint,
+	)
+
+	// The configurations of the Azure SDK are left untouched
+	require.NotContains(t, cloud.AzureGovernment.Services, MicrosoftGraph)
+}
+
+func Test_NewCloud_MetadataFile(t *testing.T) {
+	single := `{
+		"name": "AzureStack",
+		"portal": "https://portal.local.azurestack.external/",
+		"resourceManager": "https://management.local.azurestack.external/",
+		"authentication": {
+			"loginEndpoint": "https://login.local.azurestack.external/adfs",
+			"audiences": ["https://management.adfs.azurestack.local/4de154de-f8a8-4017-af41-df619da68155"]
+		},
+		"suffixes": {
+			"storage": "local.azurestack.external",
+			"keyVaultDns": ".vault.local.azurestack.external",
+			"acrLoginServer": ".azurecr.local.azurestack.external"
+		}
+	}`
+
+	t.Run("Single", func(t *testing.T) {
+		path := writeMetadataFile(t, single)
+
+		azureCloud, err := NewCloud(&Config{MetadataFile: path})
+		require.NoError(t, err)
+		require.Equal(t, "AzureStack", azureCloud.Name)
+		require.Equal(t, "https://management.local.azurestack.external", azureCloud.ResourceManagerEndpoint())
+		require.Equal(t, "https://login.local.azurestack.external/adfs/", azureCloud.AuthorityHost())
+		require.Equal(t, "https://portal.local.azurestack.external", azureCloud.PortalUrlBase)
+		require.Equal(t, "local.azurestack.external", azureCloud.StorageEndpointSuffix)
+		require.Equal(t, "vault.local.azurestack.external", azureCloud.KeyVaultEndpointSuffix)
+		require.Equal(t, "azurecr.local.azurestack.external", azureCloud.ContainerRegistryEndpointSuffix)
+		require.Empty(t, azureCloud.AppServiceScmEndpointSuffix)
+		require.Equal(
+			t,
+			"https://management.adfs.azurestack.local/4de154de-f8a8-4017-af41-df619da68155",
+			azureCloud.Configuration.Services[cloud.ResourceManager].Audience,
+		)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		path := writeMetadataFile(t, `[
+			{
+				"name": "First",
+				"resourceManager": "https://management.first",
+				"authentication": { "loginEndpoint": "https://login.first" }
+			},
+			{
+				"name": "Second",
+				"resourceManager": "https://management.second",
+				"authentication": { "loginEndpoint": "https://login.second" }
+			}
+		]`)
+
+		azureCloud, err := NewCloud(&Config{Name: "second", MetadataFile: path})
+		require.NoError(t, err)
+		require.Equal(t, "https://management.second", azureCloud.ResourceManagerEndpoint())
+
+		_, err = NewCloud(&Config{MetadataFile: path})
+		require.ErrorContains(t, err, "not found in cloud metadata file")
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		path := writeMetadataFile(t, `{ "name": "Incomplete", "resourceManager": "https://management.incomplete" }`)
+
+		_, err := NewCloud(&Config{MetadataFile: path})
+		require.ErrorContains(t, err, "must define resourceManager and authentication.loginEndpoint")
+	})
+}
+
+func writeMetadataFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "cloud.json")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	return path
+}
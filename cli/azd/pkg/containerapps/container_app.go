@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v2"
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/benbjohnson/clock"
@@ -38,6 +41,7 @@ type ContainerAppService interface {
 		resourceGroupName string,
 		appName string,
 		imageName string,
+		options *AddRevisionOptions,
 	) error
 	ListSecrets(ctx context.Context,
 		subscriptionId string,
@@ -51,9 +55,11 @@ func NewContainerAppService(
 	credentialProvider account.SubscriptionCredentialProvider,
 	httpClient httputil.HttpClient,
 	clock clock.Clock,
+	cloud *cloud.Cloud,
 ) ContainerAppService {
 	return &containerAppService{
 		credentialProvider: credentialProvider,
+		cloud:              cloud,
 		httpClient:         httpClient,
 		userAgent:          azdinternal.UserAgent(),
 		clock:              clock,
@@ -65,12 +71,29 @@ type containerAppService struct {
 	httpClient         httputil.HttpClient
 	userAgent          string
 	clock              clock.Clock
+	cloud              *cloud.Cloud
 }
 
 type ContainerAppIngressConfiguration struct {
 	HostNames []string
 }
 
+// AddRevisionOptions are the changes made by the revision which deploys a new image to a container app, besides the image.
+type AddRevisionOptions struct {
+	// EnvVars are set on the container the image is deployed to.
+	EnvVars []ContainerAppEnvVar
+	// RemovedEnvVars are the names of the environment variables removed from the container the image is deployed to.
+	RemovedEnvVars []string
+}
+
+// ContainerAppEnvVar is an environment variable of a container app. The value of a secret environment variable is stored
+// as a secret of the container app, which the environment variable references.
+type ContainerAppEnvVar struct {
+	Name   string
+	Value  string
+	Secret bool
+}
+
 // Gets the ingress configuration for the specified container app
 func (cas *containerAppService) GetIngressConfiguration(
 	ctx context.Context,
@@ -145,6 +168,7 @@ func (cas *containerAppService) AddRevision(
 	resourceGroupName string,
 	appName string,
 	imageName string,
+	options *AddRevisionOptions,
 ) error {
 	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName)
 	if err != nil {
@@ -175,6 +199,10 @@ func (cas *containerAppService) AddRevision(
 		return fmt.Errorf("syncing secrets: %w", err)
 	}
 
+	if options != nil && (len(options.EnvVars) > 0 || len(options.RemovedEnvVars) > 0) {
+		applyEnvVars(containerApp, options.EnvVars, options.RemovedEnvVars)
+	}
+
 	// Update the container app
 	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp)
 	if err != nil {
@@ -212,6 +240,85 @@ func (cas *containerAppService) ListSecrets(
 	return secretsResponse.Value, nil
 }
 
+// applyEnvVars sets the given environment variables on the first container of the app, which is the container azd deploys
+// images to, and removes the environment variables named in removed. The values of secret environment variables are
+// stored in secrets azd creates for them. Secrets azd created which are no longer referenced are removed, while the
+// secrets of the infrastructure are kept.
+func applyEnvVars(containerApp *armappcontainers.ContainerApp, envVars []ContainerAppEnvVar, removed []string) {
+	if containerApp.Properties.Configuration == nil {
+		containerApp.Properties.Configuration = &armappcontainers.Configuration{}
+	}
+
+	container := containerApp.Properties.Template.Containers[0]
+	configuration := containerApp.Properties.Configuration
+
+	for _, envVar := range envVars {
+		desired := &armappcontainers.EnvironmentVar{
+			Name: convert.RefOf(envVar.Name),
+		}
+
+		if envVar.Secret {
+			secretName := containerAppSecretName(envVar.Name)
+			desired.SecretRef = convert.RefOf(secretName)
+
+			secretIndex := slices.IndexFunc(configuration.Secrets, func(secret *armappcontainers.Secret) bool {
+				return secret.Name != nil && *secret.Name == secretName
+			})
+			if secretIndex < 0 {
+				configuration.Secrets = append(configuration.Secrets, &armappcontainers.Secret{
+					Name:  convert.RefOf(secretName),
+					Value: convert.RefOf(envVar.Value),
+				})
+			} else {
+				configuration.Secrets[secretIndex].Value = convert.RefOf(envVar.Value)
+			}
+		} else {
+			desired.Value = convert.RefOf(envVar.Value)
+		}
+
+		envIndex := slices.IndexFunc(container.Env, func(env *armappcontainers.EnvironmentVar) bool {
+			return env.Name != nil && *env.Name == envVar.Name
+		})
+		if envIndex < 0 {
+			container.Env = append(container.Env, desired)
+		} else {
+			container.Env[envIndex] = desired
+		}
+	}
+
+	container.Env = slices.DeleteFunc(container.Env, func(env *armappcontainers.EnvironmentVar) bool {
+		return env.Name != nil && slices.Contains(removed, *env.Name)
+	})
+
+	referenced := map[string]struct{}{}
+	for _, container := range containerApp.Properties.Template.Containers {
+		for _, env := range container.Env {
+			if env.SecretRef != nil {
+				referenced[*env.SecretRef] = struct{}{}
+			}
+		}
+	}
+
+	configuration.Secrets = slices.DeleteFunc(configuration.Secrets, func(secret *armappcontainers.Secret) bool {
+		if secret.Name == nil || !strings.HasPrefix(*secret.Name, cEnvVarSecretPrefix) {
+			return false
+		}
+
+		_, has := referenced[*secret.Name]
+		return !has
+	})
+}
+
+// cEnvVarSecretPrefix is the prefix of the names of the secrets azd creates for secret environment variables, which tells
+// them apart from the secrets of the infrastructure.
+const cEnvVarSecretPrefix = "azd-env-"
+
+// containerAppSecretName returns the name of the secret azd stores the value of a secret environment variable in.
+// Secret names can only contain lower case alphanumeric characters and '-'.
+func containerAppSecretName(envVarName string) string {
+	return cEnvVarSecretPrefix + strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(envVarName))
+}
+
 func (cas *containerAppService) syncSecrets(
 	ctx context.Context,
 	subscriptionId string,
@@ -326,7 +433,9 @@ func (cas *containerAppService) createContainerAppsClient(
 		return nil, err
 	}
 
-	options := azsdk.DefaultClientOptionsBuilder(ctx, cas.httpClient, cas.userAgent).BuildArmClientOptions()
+	options := azsdk.DefaultClientOptionsBuilder(ctx, cas.httpClient, cas.userAgent).
+		WithCloud(cas.cloud.Configuration).
+		BuildArmClientOptions()
 	client, err := armappcontainers.NewContainerAppsClient(subscriptionId, credential, options)
 	if err != nil {
 		return nil, fmt.Errorf("creating ContainerApps client: %w", err)
@@ -344,7 +453,9 @@ func (cas *containerAppService) createRevisionsClient(
 		return nil, err
 	}
 
-	options := azsdk.DefaultClientOptionsBuilder(ctx, cas.httpClient, cas.userAgent).BuildArmClientOptions()
+	options := azsdk.DefaultClientOptionsBuilder(ctx, cas.httpClient, cas.userAgent).
+		WithCloud(cas.cloud.Configuration).
+		BuildArmClientOptions()
 	client, err := armappcontainers.NewContainerAppsRevisionsClient(subscriptionId, credential, options)
 	if err != nil {
 		return nil, fmt.Errorf("creating ContainerApps client: %w", err)
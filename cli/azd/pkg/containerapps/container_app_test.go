@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazsdk"
@@ -37,7 +38,12 @@ func Test_ContainerApp_GetIngressConfiguration(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	mockRequest := mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
 
-	cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		mockContext.HttpClient,
+		clock.NewMock(),
+		cloud.AzurePublic(),
+	)
 	ingressConfig, err := cas.GetIngressConfiguration(*mockContext.Context, subscriptionId, resourceGroup, appName)
 	require.NoError(t, err)
 	require.NotNil(t, ingressConfig)
@@ -127,8 +133,13 @@ func Test_ContainerApp_AddRevision(t *testing.T) {
 		containerApp,
 	)
 
-	cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
-	err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName)
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		mockContext.HttpClient,
+		clock.NewMock(),
+		cloud.AzurePublic(),
+	)
+	err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName, nil)
 	require.NoError(t, err)
 
 	// Verify lastest revision is read
@@ -150,3 +161,113 @@ func Test_ContainerApp_AddRevision(t *testing.T) {
 	require.Equal(t, updatedImageName, *updatedContainerApp.Properties.Template.Containers[0].Image)
 	require.Equal(t, "azd-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
 }
+
+func Test_ContainerApp_AddRevision_EnvVars(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	revisionName := "REVISION_NAME"
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName: &revisionName,
+			Configuration: &armappcontainers.Configuration{
+				ActiveRevisionsMode: convert.RefOf(armappcontainers.ActiveRevisionsModeSingle),
+				Secrets: []*armappcontainers.Secret{
+					{Name: convert.RefOf("old-password")},
+					{Name: convert.RefOf("azd-env-old-password")},
+					{Name: convert.RefOf("azd-env-api-key")},
+				},
+			},
+		},
+	}
+
+	revision := &armappcontainers.Revision{
+		Properties: &armappcontainers.RevisionProperties{
+			Template: &armappcontainers.Template{
+				Containers: []*armappcontainers.Container{
+					{
+						Image: convert.RefOf("ORIGINAL_IMAGE_NAME"),
+						Env: []*armappcontainers.EnvironmentVar{
+							{Name: convert.RefOf("INFRA_SETTING"), Value: convert.RefOf("infra")},
+							{Name: convert.RefOf("INFRA_PASSWORD"), SecretRef: convert.RefOf("old-password")},
+							{Name: convert.RefOf("LOG_LEVEL"), Value: convert.RefOf("info")},
+							{Name: convert.RefOf("OLD_PASSWORD"), SecretRef: convert.RefOf("azd-env-old-password")},
+							{Name: convert.RefOf("API_KEY"), SecretRef: convert.RefOf("azd-env-api-key")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	secrets := &armappcontainers.SecretsCollection{
+		Value: []*armappcontainers.ContainerAppSecret{
+			{Name: convert.RefOf("old-password"), Value: convert.RefOf("infra-value")},
+			{Name: convert.RefOf("azd-env-old-password"), Value: convert.RefOf("old-value")},
+			{Name: convert.RefOf("azd-env-api-key"), Value: convert.RefOf("key")},
+		},
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+	_ = mockazsdk.MockContainerAppRevisionGet(mockContext, subscriptionId, resourceGroup, appName, revisionName, revision)
+	_ = mockazsdk.MockContainerAppSecretsList(mockContext, subscriptionId, resourceGroup, appName, secrets)
+	updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+		mockContext,
+		subscriptionId,
+		resourceGroup,
+		appName,
+		containerApp,
+	)
+
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		mockContext.HttpClient,
+		clock.NewMock(),
+		cloud.AzurePublic(),
+	)
+	err := cas.AddRevision(
+		*mockContext.Context,
+		subscriptionId,
+		resourceGroup,
+		appName,
+		"UPDATED_IMAGE_NAME",
+		&AddRevisionOptions{
+			EnvVars: []ContainerAppEnvVar{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "DB_PASSWORD", Value: "password", Secret: true},
+				// A secret setting which is no longer secret.
+				{Name: "API_KEY", Value: "public"},
+			},
+			RemovedEnvVars: []string{"OLD_PASSWORD"},
+		},
+	)
+	require.NoError(t, err)
+
+	var updatedContainerApp *armappcontainers.ContainerApp
+	jsonDecoder := json.NewDecoder(updateContainerAppRequest.Body)
+	err = jsonDecoder.Decode(&updatedContainerApp)
+	require.NoError(t, err)
+
+	// The environment variables are set by the revision which deploys the image.
+	container := updatedContainerApp.Properties.Template.Containers[0]
+	require.Equal(t, "UPDATED_IMAGE_NAME", *container.Image)
+	require.Equal(t, "azd-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
+	require.Equal(t, []*armappcontainers.EnvironmentVar{
+		{Name: convert.RefOf("INFRA_SETTING"), Value: convert.RefOf("infra")},
+		{Name: convert.RefOf("INFRA_PASSWORD"), SecretRef: convert.RefOf("old-password")},
+		{Name: convert.RefOf("LOG_LEVEL"), Value: convert.RefOf("debug")},
+		{Name: convert.RefOf("API_KEY"), Value: convert.RefOf("public")},
+		{Name: convert.RefOf("DB_PASSWORD"), SecretRef: convert.RefOf("azd-env-db-password")},
+	}, container.Env)
+
+	// Only the secrets azd created which are no longer referenced are removed.
+	require.Equal(t, []*armappcontainers.Secret{
+		{Name: convert.RefOf("old-password"), Value: convert.RefOf("infra-value")},
+		{Name: convert.RefOf("azd-env-db-password"), Value: convert.RefOf("password")},
+	}, updatedContainerApp.Properties.Configuration.Secrets)
+}
@@ -9,10 +9,25 @@ type EventDataType string
 
 const (
 	ConsoleMessageEventDataType EventDataType = "consoleMessage"
+	// The type of the events with the [ErrorResult] of a failed command.
+	ErrorEventDataType EventDataType = "error"
+
+	// The types of the events streamed with the JSON Lines output format.
+	CommandStartEventDataType         EventDataType = "commandStart"
+	CommandEndEventDataType           EventDataType = "commandEnd"
+	StepStartEventDataType            EventDataType = "stepStart"
+	StepEndEventDataType              EventDataType = "stepEnd"
+	ProvisioningProgressEventDataType EventDataType = "provisioningProgress"
+	ServiceProgressEventDataType      EventDataType = "serviceProgress"
+	HookStartEventDataType            EventDataType = "hookStart"
+	HookEndEventDataType              EventDataType = "hookEnd"
+	ResultEventDataType               EventDataType = "result"
 )
 
 type EventEnvelope struct {
 	Type      EventDataType `json:"type"`
 	Timestamp time.Time     `json:"timestamp"`
-	Data      any           `json:"data"`
+	// CorrelationId identifies the invocation of azd which streamed the event.
+	CorrelationId string `json:"correlationId,omitempty"`
+	Data          any    `json:"data"`
 }
@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+// ErrorCategory groups the errors by the action needed to resolve them.
+type ErrorCategory string
+
+const (
+	// The account is not logged in, or its login is rejected.
+	ErrorCategoryAuth ErrorCategory = "auth"
+	// A quota or the capacity of a SKU in a region is exceeded, or a SKU is not available in a region.
+	ErrorCategoryQuota ErrorCategory = "quota"
+	// A request, like an Azure deployment, is not valid.
+	ErrorCategoryValidation ErrorCategory = "validation"
+	// A required external tool is not installed, or its version is not supported.
+	ErrorCategoryToolMissing ErrorCategory = "tool-missing"
+	// The user cancelled the command.
+	ErrorCategoryUserCancelled ErrorCategory = "user-cancelled"
+	// An Azure service failed.
+	ErrorCategoryService ErrorCategory = "service"
+	// An external tool failed.
+	ErrorCategoryTool ErrorCategory = "tool"
+	// Any other error.
+	ErrorCategoryUnknown ErrorCategory = "unknown"
+)
+
+// ErrorCode identifies an error. The codes are stable: new codes may be added, but existing codes are not changed.
+type ErrorCode string
+
+const (
+	ErrorCodeNotLoggedIn                ErrorCode = "NotLoggedIn"
+	ErrorCodeReLoginRequired            ErrorCode = "ReLoginRequired"
+	ErrorCodeAuthFailed                 ErrorCode = "AuthFailed"
+	ErrorCodeQuotaExceeded              ErrorCode = "QuotaExceeded"
+	ErrorCodeSkuNotAvailable            ErrorCode = "SkuNotAvailable"
+	ErrorCodeDeploymentValidationFailed ErrorCode = "DeploymentValidationFailed"
+	ErrorCodeDeploymentFailed           ErrorCode = "DeploymentFailed"
+	ErrorCodeServiceRequestFailed       ErrorCode = "ServiceRequestFailed"
+	ErrorCodeToolMissing                ErrorCode = "ToolMissing"
+	ErrorCodeToolFailed                 ErrorCode = "ToolFailed"
+	ErrorCodeUserCancelled              ErrorCode = "UserCancelled"
+	ErrorCodeUnknown                    ErrorCode = "Unknown"
+)
+
+// ErrorResult is the contract for the error of a failed command, written to stderr with the JSON output format.
+type ErrorResult struct {
+	Code     ErrorCode     `json:"code"`
+	Category ErrorCategory `json:"category"`
+	// Message is the error message, as displayed without the JSON output format.
+	Message string `json:"message"`
+	// Suggestion is the action suggested to resolve the error, when there is one.
+	Suggestion string `json:"suggestion,omitempty"`
+	// Details are the inner errors, like the errors of an Azure deployment or the missing tools.
+	Details []ErrorDetail `json:"details,omitempty"`
+	// TraceId is the id of the telemetry trace of the command, when telemetry is enabled.
+	TraceId string `json:"traceId,omitempty"`
+}
+
+// ErrorDetail is an inner error of an [ErrorResult].
+type ErrorDetail struct {
+	// Code is the code of the error returned by a service or a tool, like the code of an ARM error.
+	Code    string        `json:"code,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Details []ErrorDetail `json:"details,omitempty"`
+}
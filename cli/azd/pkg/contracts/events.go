@@ -0,0 +1,81 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+// CommandStartEvent is the data of the event streamed when a command starts. Composite commands like `azd up` stream the
+// events of the commands they run.
+type CommandStartEvent struct {
+	Command string `json:"command"`
+	// TraceId is the id of the telemetry trace of the command, when telemetry is enabled.
+	TraceId string `json:"traceId,omitempty"`
+}
+
+// CommandEndEvent is the data of the event streamed when a command ends.
+type CommandEndEvent struct {
+	Command    string `json:"command"`
+	TraceId    string `json:"traceId,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	// Error is the error message of a failed command.
+	Error string `json:"error,omitempty"`
+}
+
+// StepStatus is the outcome of a step.
+type StepStatus string
+
+const (
+	StepStatusDone    StepStatus = "done"
+	StepStatusFailed  StepStatus = "failed"
+	StepStatusWarning StepStatus = "warning"
+	StepStatusSkipped StepStatus = "skipped"
+	// StepStatusStopped is the status of a step stopped without an outcome.
+	StepStatusStopped StepStatus = "stopped"
+)
+
+// StepStartEvent is the data of the event streamed when a step of a command starts, like "Deploying service api".
+type StepStartEvent struct {
+	// StepId correlates the start and the end events of the step.
+	StepId string `json:"stepId"`
+	Title  string `json:"title"`
+}
+
+// StepEndEvent is the data of the event streamed when a step of a command ends.
+type StepEndEvent struct {
+	StepId string     `json:"stepId"`
+	Title  string     `json:"title"`
+	Status StepStatus `json:"status"`
+}
+
+// ProvisioningProgressEvent is the data of the event streamed when the state of a resource of an Azure deployment changes.
+type ProvisioningProgressEvent struct {
+	ResourceId   string `json:"resourceId"`
+	ResourceName string `json:"resourceName"`
+	ResourceType string `json:"resourceType"`
+	// DisplayName is the display name of the resource type, like "Container App".
+	DisplayName string `json:"displayName,omitempty"`
+	// State is the provisioning state of the resource operation: Running, Succeeded or Failed.
+	State string `json:"state"`
+}
+
+// ServiceProgressEvent is the data of the event streamed when a service reports progress while it is packaged or deployed.
+type ServiceProgressEvent struct {
+	Service string `json:"service"`
+	// Operation is the operation running on the service, like package or deploy.
+	Operation string `json:"operation"`
+	Message   string `json:"message"`
+}
+
+// HookStartEvent is the data of the event streamed when a hook starts.
+type HookStartEvent struct {
+	// Name is the name of the hook, like preprovision.
+	Name  string `json:"name"`
+	Shell string `json:"shell"`
+}
+
+// HookEndEvent is the data of the event streamed when a hook ends.
+type HookEndEvent struct {
+	Name     string `json:"name"`
+	ExitCode int    `json:"exitCode"`
+	// Error is the error message of a failed hook.
+	Error string `json:"error,omitempty"`
+}
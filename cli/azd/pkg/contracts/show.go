@@ -2,6 +2,8 @@
 // Licensed under the MIT License.
 package contracts
 
+import "time"
+
 // ShowType are the values for the language property of a ShowServiceProject
 type ShowType string
 
@@ -10,12 +12,18 @@ const (
 	ShowTypePython ShowType = "python"
 	ShowTypeNode   ShowType = "node"
 	ShowTypeJava   ShowType = "java"
+	ShowTypeGo     ShowType = "go"
+	ShowTypeRuby   ShowType = "ruby"
+	ShowTypePhp    ShowType = "php"
+	ShowTypeRust   ShowType = "rust"
 )
 
 // ShowResult is the contract for the output of `azd show`
 type ShowResult struct {
 	Name     string                 `json:"name"`
 	Services map[string]ShowService `json:"services"`
+	// Drift is the result of the last infrastructure drift check of the environment, when it was checked.
+	Drift *ShowDrift `json:"drift,omitempty"`
 }
 
 // ShowService is the contract for a service returned by `azd show`
@@ -42,3 +50,22 @@ type ShowServiceProject struct {
 type ShowTargetArm struct {
 	ResourceIds []string `json:"resourceIds"`
 }
+
+// ShowDrift is the contract for the result of the last infrastructure drift check, as returned by `azd show`
+type ShowDrift struct {
+	CheckedAt time.Time             `json:"checkedAt"`
+	Resources []ShowDriftedResource `json:"resources"`
+}
+
+// ShowDriftedResource is the contract for a resource which was changed or deleted outside of azd.
+type ShowDriftedResource struct {
+	// Layer is the infrastructure layer of the resource, empty for the infrastructure of the project.
+	Layer string `json:"layer,omitempty"`
+	Id    string `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	// Deleted is true when the resource was deleted, false when some of its properties were changed.
+	Deleted bool `json:"deleted"`
+	// Properties are the paths of the changed properties.
+	Properties []string `json:"properties,omitempty"`
+}
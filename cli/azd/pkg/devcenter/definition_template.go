@@ -0,0 +1,214 @@
+package devcenter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/devcentersdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+)
+
+// The API version of the dev center and project catalogs of the Microsoft.DevCenter resource provider
+const cCatalogApiVersion = "2023-04-01"
+
+// catalogGitSource is the git repository a catalog is synced from
+type catalogGitSource struct {
+	Uri    string `json:"uri"`
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+	// SecretIdentifier is the URL of the Key Vault secret holding the personal access token of private repositories
+	SecretIdentifier string `json:"secretIdentifier"`
+}
+
+type catalogProperties struct {
+	GitHub *catalogGitSource `json:"gitHub"`
+	AdoGit *catalogGitSource `json:"adoGit"`
+}
+
+// DefinitionTemplate gets the ARM template of the specified environment definition from the git repository of its catalog.
+// Bicep templates are compiled to ARM.
+func (m *manager) DefinitionTemplate(
+	ctx context.Context,
+	envDef *devcentersdk.EnvironmentDefinition,
+) (azure.RawArmTemplate, error) {
+	project, err := m.client.
+		DevCenterByName(m.config.Name).
+		ProjectByName(m.config.Project).
+		Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting project: %w", err)
+	}
+
+	source, err := m.catalogSource(ctx, project, envDef.CatalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	cloneDir, err := os.MkdirTemp("", "azd-devcenter-catalog")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(cloneDir)
+
+	token, err := m.catalogAccessToken(ctx, project.SubscriptionId, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		err = m.gitCli.ShallowCloneWithToken(ctx, source.Uri, source.Branch, cloneDir, token)
+	} else {
+		err = m.gitCli.ShallowClone(ctx, source.Uri, source.Branch, cloneDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed cloning catalog '%s': %w", envDef.CatalogName, err)
+	}
+
+	templatePath, err := definitionTemplatePath(cloneDir, source.Path, envDef.TemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(templatePath), ".bicep") {
+		var bicepCli bicep.BicepCli
+		if err := m.serviceLocator.Resolve(&bicepCli); err != nil {
+			return nil, fmt.Errorf("resolving bicep: %w", err)
+		}
+
+		compiled, err := bicepCli.Build(ctx, templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed compiling template of environment definition '%s': %w", envDef.Name, err)
+		}
+
+		return azure.RawArmTemplate(compiled.Compiled), nil
+	}
+
+	template, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading template of environment definition '%s': %w", envDef.Name, err)
+	}
+
+	return azure.RawArmTemplate(template), nil
+}
+
+// catalogSource gets the git repository of the catalog with the given name, which is either a catalog of the dev center
+// or of the project
+func (m *manager) catalogSource(
+	ctx context.Context,
+	project *devcentersdk.Project,
+	catalogName string,
+) (*catalogGitSource, error) {
+	credential, err := m.credentialProvider.CredentialForSubscription(ctx, project.DevCenter.SubscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armresources.NewClient(project.DevCenter.SubscriptionId, credential, m.armClientOptions(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("creating Resource client: %w", err)
+	}
+
+	var errs []error
+	for _, parentId := range []string{project.DevCenter.Id, project.Id} {
+		res, err := client.GetByID(ctx, fmt.Sprintf("%s/catalogs/%s", parentId, catalogName), cCatalogApiVersion, nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		propertiesJson, err := json.Marshal(res.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading catalog '%s': %w", catalogName, err)
+		}
+
+		var properties catalogProperties
+		if err := json.Unmarshal(propertiesJson, &properties); err != nil {
+			return nil, fmt.Errorf("failed reading catalog '%s': %w", catalogName, err)
+		}
+
+		if properties.GitHub != nil {
+			return properties.GitHub, nil
+		} else if properties.AdoGit != nil {
+			return properties.AdoGit, nil
+		}
+
+		return nil, fmt.Errorf("catalog '%s' is not synced from a git repository", catalogName)
+	}
+
+	return nil, fmt.Errorf("failed getting catalog '%s': %w", catalogName, errors.Join(errs...))
+}
+
+// catalogAccessToken returns the personal access token to clone the repository of a catalog with, or an empty string
+// for public repositories
+func (m *manager) catalogAccessToken(
+	ctx context.Context,
+	subscriptionId string,
+	source *catalogGitSource,
+) (string, error) {
+	if source.SecretIdentifier == "" {
+		return "", nil
+	}
+
+	// The secret identifier is https://<vault>/secrets/<name>[/<version>]
+	secretUrl, err := url.Parse(source.SecretIdentifier)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing catalog secret identifier: %w", err)
+	}
+
+	segments := strings.Split(strings.Trim(secretUrl.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "secrets" {
+		return "", fmt.Errorf("invalid catalog secret identifier '%s'", source.SecretIdentifier)
+	}
+
+	vaultUrl := fmt.Sprintf("%s://%s", secretUrl.Scheme, secretUrl.Host)
+	secret, err := m.azCli.GetKeyVaultSecret(ctx, subscriptionId, vaultUrl, segments[1])
+	if err != nil {
+		return "", fmt.Errorf("failed getting the access token of the catalog: %w", err)
+	}
+
+	return secret.Value, nil
+}
+
+// definitionTemplatePath returns the path of the template of an environment definition in the clone of its catalog.
+// The template path is relative to the root of the repository, or to the folder of the catalog within the repository.
+func definitionTemplatePath(cloneDir string, catalogPath string, templatePath string) (string, error) {
+	candidates := []string{
+		filepath.Join(cloneDir, filepath.FromSlash(templatePath)),
+		filepath.Join(cloneDir, filepath.FromSlash(catalogPath), filepath.FromSlash(templatePath)),
+	}
+
+	for _, candidate := range candidates {
+		// Paths are kept within the clone, since the template path is not trusted.
+		if rel, err := filepath.Rel(cloneDir, candidate); err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	log.Printf("template '%s' not found in catalog, looked in %v", templatePath, candidates)
+	return "", fmt.Errorf("template '%s' of the environment definition not found in its catalog", templatePath)
+}
+
+func (m *manager) armClientOptions(ctx context.Context) *arm.ClientOptions {
+	return azsdk.NewClientOptionsBuilder().
+		WithTransport(m.httpClient).
+		WithCloud(m.cloud.Configuration).
+		WithPerCallPolicy(azsdk.NewUserAgentPolicy(internal.UserAgent())).
+		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx)).
+		BuildArmClientOptions()
+}
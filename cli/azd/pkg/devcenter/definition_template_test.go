@@ -0,0 +1,33 @@
+package devcenter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_definitionTemplatePath(t *testing.T) {
+	cloneDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(cloneDir, "Environments", "WebApp"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cloneDir, "Environments", "WebApp", "main.bicep"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(cloneDir, "secret.json"), nil, 0600))
+
+	t.Run("RelativeToRepository", func(t *testing.T) {
+		path, err := definitionTemplatePath(cloneDir, "Environments", "Environments/WebApp/main.bicep")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(cloneDir, "Environments", "WebApp", "main.bicep"), path)
+	})
+
+	t.Run("RelativeToCatalog", func(t *testing.T) {
+		path, err := definitionTemplatePath(cloneDir, "Environments", "WebApp/main.bicep")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(cloneDir, "Environments", "WebApp", "main.bicep"), path)
+	})
+
+	t.Run("OutsideClone", func(t *testing.T) {
+		_, err := definitionTemplatePath(filepath.Join(cloneDir, "Environments"), "", "../secret.json")
+		require.Error(t, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/devcentersdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
@@ -127,7 +128,13 @@ func (m *mockDevCenterManager) Deployment(
 	filter DeploymentFilterPredicate,
 ) (infra.Deployment, error) {
 	args := m.Called(ctx, env, filter)
-	return args.Get(0).(infra.Deployment), args.Error(1)
+
+	deployment, ok := args.Get(0).(infra.Deployment)
+	if ok {
+		return deployment, args.Error(1)
+	}
+
+	return nil, args.Error(1)
 }
 
 func (m *mockDevCenterManager) LatestArmDeployment(
@@ -139,6 +146,29 @@ func (m *mockDevCenterManager) LatestArmDeployment(
 	return args.Get(0).(*armresources.DeploymentExtended), args.Error(1)
 }
 
+func (m *mockDevCenterManager) DefinitionTemplate(
+	ctx context.Context,
+	envDef *devcentersdk.EnvironmentDefinition,
+) (azure.RawArmTemplate, error) {
+	args := m.Called(ctx, envDef)
+
+	template, ok := args.Get(0).(azure.RawArmTemplate)
+	if ok {
+		return template, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *mockDevCenterManager) SubscriptionDeployment(
+	subscriptionId string,
+	location string,
+	deploymentName string,
+) infra.Deployment {
+	args := m.Called(subscriptionId, location, deploymentName)
+	return args.Get(0).(infra.Deployment)
+}
+
 func (m *mockDevCenterManager) Outputs(
 	ctx context.Context,
 	env *devcentersdk.Environment,
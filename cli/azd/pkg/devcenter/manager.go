@@ -6,11 +6,18 @@ import (
 	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/devcentersdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"go.uber.org/multierr"
 	"golang.org/x/exp/slices"
 )
@@ -48,6 +55,13 @@ type Manager interface {
 		env *devcentersdk.Environment,
 		filter DeploymentFilterPredicate,
 	) (*armresources.DeploymentExtended, error)
+	// DefinitionTemplate gets the ARM template of the specified environment definition from the repository of its catalog
+	DefinitionTemplate(
+		ctx context.Context,
+		envDef *devcentersdk.EnvironmentDefinition,
+	) (azure.RawArmTemplate, error)
+	// SubscriptionDeployment gets a subscription scoped deployment, used to preview environments which don't exist yet
+	SubscriptionDeployment(subscriptionId string, location string, deploymentName string) infra.Deployment
 	// Outputs gets the outputs for the specified devcenter environment
 	Outputs(
 		ctx context.Context,
@@ -61,6 +75,12 @@ type manager struct {
 	client               devcentersdk.DevCenterClient
 	deploymentsService   azapi.Deployments
 	deploymentOperations azapi.DeploymentOperations
+	credentialProvider   account.SubscriptionCredentialProvider
+	httpClient           httputil.HttpClient
+	cloud                *cloud.Cloud
+	azCli                azcli.AzCli
+	gitCli               git.GitCli
+	serviceLocator       ioc.ServiceLocator
 }
 
 // NewManager creates a new devcenter manager
@@ -69,12 +89,24 @@ func NewManager(
 	client devcentersdk.DevCenterClient,
 	deploymentsService azapi.Deployments,
 	deploymentOperations azapi.DeploymentOperations,
+	credentialProvider account.SubscriptionCredentialProvider,
+	httpClient httputil.HttpClient,
+	cloud *cloud.Cloud,
+	azCli azcli.AzCli,
+	gitCli git.GitCli,
+	serviceLocator ioc.ServiceLocator,
 ) Manager {
 	return &manager{
 		config:               config,
 		client:               client,
 		deploymentsService:   deploymentsService,
 		deploymentOperations: deploymentOperations,
+		credentialProvider:   credentialProvider,
+		httpClient:           httpClient,
+		cloud:                cloud,
+		azCli:                azCli,
+		gitCli:               gitCli,
+		serviceLocator:       serviceLocator,
 	}
 }
 
@@ -284,6 +316,12 @@ func (m *manager) LatestArmDeployment(
 	return deployments[latestDeploymentIndex], nil
 }
 
+// SubscriptionDeployment gets a subscription scoped deployment, used to preview environments which don't exist yet
+func (m *manager) SubscriptionDeployment(subscriptionId string, location string, deploymentName string) infra.Deployment {
+	return infra.NewSubscriptionDeployment(
+		m.deploymentsService, m.deploymentOperations, location, subscriptionId, deploymentName)
+}
+
 // Outputs gets the outputs for the latest deployment of the specified environment
 // Right now this will retrieve the outputs from the latest azure deployment
 // Long term this will call into ADE Outputs API
@@ -7,6 +7,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcegraph/armresourcegraph"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/devcentersdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -174,10 +175,12 @@ func (p *Platform) ConfigureContainer(container *ioc.NestedContainer) error {
 		ctx context.Context,
 		credential azcore.TokenCredential,
 		httpClient httputil.HttpClient,
+		azureCloud *cloud.Cloud,
 		resourceGraphClient *armresourcegraph.Client,
 	) (devcentersdk.DevCenterClient, error) {
 		options := azsdk.
 			DefaultClientOptionsBuilder(ctx, httpClient, "azd").
+			WithCloud(azureCloud.Configuration).
 			BuildCoreClientOptions()
 
 		return devcentersdk.NewDevCenterClient(credential, options, resourceGraphClient)
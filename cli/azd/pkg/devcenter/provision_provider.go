@@ -2,16 +2,21 @@ package devcenter
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/devcentersdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
@@ -25,6 +30,9 @@ const (
 	ProvisionParametersConfigPath string                    = "provision.parameters"
 	ProvisionKindDevCenter        provisioning.ProviderKind = "devcenter"
 
+	// EnvironmentResourceType is the type of the Azure resource of an ADE environment
+	EnvironmentResourceType = "Microsoft.DevCenter/projects/environments"
+
 	// ADE environment ARM deployment tags
 	DeploymentTagDevCenterName    = "AdeDevCenterName"
 	DeploymentTagDevCenterProject = "AdeProjectName"
@@ -242,9 +250,303 @@ func (p *ProvisionProvider) Deploy(ctx context.Context) (*provisioning.DeployRes
 	return result, nil
 }
 
-// Preview previews the deployment of the environment from the configured environment definition
+// Preview previews the deployment of the environment from the configured environment definition.
+// The template of the environment definition is read from its catalog and evaluated with ARM what-if, against the resource
+// group of the environment when it already exists, or along with the resource group ADE would create for it otherwise.
+// When what-if isn't possible, the parameters are compared with the ones of the environment instead.
 func (p *ProvisionProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
-	return nil, fmt.Errorf("preview is not supported for devcenter")
+	if err := p.config.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid devcenter configuration, %w", err)
+	}
+
+	envDef, err := p.devCenterClient.
+		DevCenterByName(p.config.Name).
+		ProjectByName(p.config.Project).
+		CatalogByName(p.config.Catalog).
+		EnvironmentDefinitionByName(p.config.EnvironmentDefinition).
+		Get(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed getting environment definition: %w", err)
+	}
+
+	paramValues, err := p.prompter.PromptParameters(ctx, p.env, envDef)
+	if err != nil {
+		return nil, fmt.Errorf("failed prompting for parameters: %w", err)
+	}
+
+	envName := p.env.Name()
+	existingEnv, _ := p.devCenterClient.
+		DevCenterByName(p.config.Name).
+		ProjectByName(p.config.Project).
+		EnvironmentsByUser(p.config.User).
+		EnvironmentByName(envName).
+		Get(ctx)
+
+	p.console.ShowSpinner(ctx, "Generating infrastructure preview", input.Step)
+
+	var preview *provisioning.DeploymentPreview
+	if existingEnv == nil {
+		preview, err = p.newEnvironmentPreview(ctx, envDef, paramValues)
+		if err != nil {
+			log.Printf("what-if is not available for new environment '%s': %v", envName, err)
+			preview = &provisioning.DeploymentPreview{
+				Status: "Succeeded",
+				Properties: &provisioning.DeploymentPreviewProperties{
+					Changes: []*provisioning.DeploymentPreviewChange{
+						{
+							ChangeType:   provisioning.ChangeTypeCreate,
+							ResourceType: EnvironmentResourceType,
+							Name:         envName,
+						},
+					},
+				},
+			}
+		}
+	} else {
+		preview, err = p.whatIfPreview(ctx, existingEnv, envDef, paramValues)
+		if err != nil {
+			log.Printf("what-if is not available for environment '%s', comparing parameters instead: %v", envName, err)
+			preview = parametersPreview(existingEnv, paramValues)
+		}
+	}
+
+	p.console.StopSpinner(ctx, "", input.StepDone)
+
+	return &provisioning.DeployPreviewResult{
+		Preview: preview,
+	}, nil
+}
+
+// whatIfPreview runs ARM what-if for the template of the environment definition, read from its catalog, against the
+// resource group of the environment, with the given parameters.
+func (p *ProvisionProvider) whatIfPreview(
+	ctx context.Context,
+	env *devcentersdk.Environment,
+	envDef *devcentersdk.EnvironmentDefinition,
+	paramValues map[string]any,
+) (*provisioning.DeploymentPreview, error) {
+	deployment, err := p.manager.Deployment(ctx, env, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := p.manager.DefinitionTemplate(ctx, envDef)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting environment definition template: %w", err)
+	}
+
+	parameters := azure.ArmParameters{}
+	for key, value := range paramValues {
+		parameters[key] = azure.ArmParameterValue{Value: value}
+	}
+
+	return whatIf(ctx, deployment, template, parameters)
+}
+
+// newEnvironmentPreview runs ARM what-if for the template of the environment definition, read from its catalog, for an
+// environment which doesn't exist yet. The template is nested in a subscription scoped template which also creates the
+// resource group ADE creates for the environment, in the deployment target of the environment type.
+func (p *ProvisionProvider) newEnvironmentPreview(
+	ctx context.Context,
+	envDef *devcentersdk.EnvironmentDefinition,
+	paramValues map[string]any,
+) (*provisioning.DeploymentPreview, error) {
+	envTypes, err := p.devCenterClient.
+		DevCenterByName(p.config.Name).
+		ProjectByName(p.config.Project).
+		EnvironmentTypes().
+		Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting environment types: %w", err)
+	}
+
+	envTypeIndex := slices.IndexFunc(envTypes.Value, func(envType *devcentersdk.EnvironmentType) bool {
+		return envType.Name == p.config.EnvironmentType
+	})
+	if envTypeIndex == -1 {
+		return nil, fmt.Errorf("environment type '%s' not found", p.config.EnvironmentType)
+	}
+
+	targetId, err := arm.ParseResourceID(strings.TrimSuffix(envTypes.Value[envTypeIndex].DeploymentTargetId, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing deployment target of environment type: %w", err)
+	}
+
+	location := p.env.GetLocation()
+	if location == "" {
+		location, _ = paramValues["location"].(string)
+	}
+	if location == "" {
+		return nil, errors.New("the location of the environment is unknown")
+	}
+
+	template, err := p.manager.DefinitionTemplate(ctx, envDef)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting environment definition template: %w", err)
+	}
+
+	resourceGroupName := fmt.Sprintf("%s-%s", p.config.Project, p.env.Name())
+	wrapper, err := newEnvironmentTemplate(resourceGroupName, location, template, paramValues)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := p.manager.SubscriptionDeployment(targetId.SubscriptionID, location, resourceGroupName)
+	return whatIf(ctx, deployment, wrapper, azure.ArmParameters{})
+}
+
+// newEnvironmentTemplate returns a subscription scoped template which creates a resource group and deploys the given
+// template, with the given parameter values, to it.
+func newEnvironmentTemplate(
+	resourceGroupName string,
+	location string,
+	template azure.RawArmTemplate,
+	paramValues map[string]any,
+) (azure.RawArmTemplate, error) {
+	parameters := map[string]any{}
+	for key, value := range paramValues {
+		parameters[key] = map[string]any{"value": value}
+	}
+
+	wrapper := map[string]any{
+		"$schema":        "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#",
+		"contentVersion": "1.0.0.0",
+		"resources": []any{
+			map[string]any{
+				"type":       "Microsoft.Resources/resourceGroups",
+				"apiVersion": "2021-04-01",
+				"name":       resourceGroupName,
+				"location":   location,
+			},
+			map[string]any{
+				"type":          "Microsoft.Resources/deployments",
+				"apiVersion":    "2021-04-01",
+				"name":          resourceGroupName,
+				"resourceGroup": resourceGroupName,
+				"dependsOn": []string{
+					fmt.Sprintf("[resourceId('Microsoft.Resources/resourceGroups', '%s')]", resourceGroupName),
+				},
+				"properties": map[string]any{
+					"mode":                        "Incremental",
+					"expressionEvaluationOptions": map[string]any{"scope": "inner"},
+					"template":                    json.RawMessage(template),
+					"parameters":                  parameters,
+				},
+			},
+		},
+	}
+
+	raw, err := json.Marshal(wrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating preview template: %w", err)
+	}
+
+	return raw, nil
+}
+
+// whatIf runs ARM what-if for the given deployment and converts its result to a preview.
+func whatIf(
+	ctx context.Context,
+	deployment infra.Deployment,
+	template azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+) (*provisioning.DeploymentPreview, error) {
+	whatIfResult, err := deployment.DeployPreview(ctx, template, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if whatIfResult.Error != nil {
+		return nil, fmt.Errorf(
+			"error code: %s, message: %s",
+			convert.ToValueWithDefault(whatIfResult.Error.Code, ""),
+			convert.ToValueWithDefault(whatIfResult.Error.Message, ""),
+		)
+	}
+
+	var changes []*provisioning.DeploymentPreviewChange
+	for _, change := range whatIfResult.Properties.Changes {
+		resource, ok := change.After.(map[string]any)
+		if !ok {
+			// Deleted resources only have a state before the change
+			resource, _ = change.Before.(map[string]any)
+		}
+
+		resourceType, _ := resource["type"].(string)
+		name, _ := resource["name"].(string)
+
+		changes = append(changes, &provisioning.DeploymentPreviewChange{
+			ChangeType: provisioning.ChangeType(convert.ToValueWithDefault(change.ChangeType, "")),
+			ResourceId: provisioning.Resource{
+				Id: convert.ToValueWithDefault(change.ResourceID, ""),
+			},
+			ResourceType: resourceType,
+			Name:         name,
+		})
+	}
+
+	return &provisioning.DeploymentPreview{
+		Status: convert.ToValueWithDefault(whatIfResult.Status, ""),
+		Properties: &provisioning.DeploymentPreviewProperties{
+			Changes: changes,
+		},
+	}, nil
+}
+
+// parametersPreview compares the given parameters with the ones the environment was deployed with. The environment is
+// modified when any of its parameters changes.
+func parametersPreview(env *devcentersdk.Environment, paramValues map[string]any) *provisioning.DeploymentPreview {
+	var delta []provisioning.DeploymentPreviewPropertyChange
+	keys := maps.Keys(paramValues)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		after := paramValues[key]
+		before, has := env.Parameters[key]
+
+		switch {
+		case !has:
+			delta = append(delta, provisioning.DeploymentPreviewPropertyChange{
+				ChangeType: provisioning.PropertyChangeTypeCreate,
+				Path:       fmt.Sprintf("parameters.%s", key),
+				After:      after,
+			})
+		case fmt.Sprint(before) != fmt.Sprint(after):
+			delta = append(delta, provisioning.DeploymentPreviewPropertyChange{
+				ChangeType: provisioning.PropertyChangeTypeModify,
+				Path:       fmt.Sprintf("parameters.%s", key),
+				Before:     before,
+				After:      after,
+			})
+		}
+	}
+
+	changeType := provisioning.ChangeTypeNoChange
+	if len(delta) > 0 {
+		changeType = provisioning.ChangeTypeModify
+	}
+
+	return &provisioning.DeploymentPreview{
+		Status: "Succeeded",
+		Properties: &provisioning.DeploymentPreviewProperties{
+			Changes: []*provisioning.DeploymentPreviewChange{
+				{
+					ChangeType:   changeType,
+					ResourceType: EnvironmentResourceType,
+					Name:         env.Name,
+					Before:       env.Parameters,
+					After:        paramValues,
+					Delta:        delta,
+				},
+			},
+		},
+	}
+}
+
+// CheckDrift is not supported for dev center environments, whose resources are deployed by the dev center.
+func (p *ProvisionProvider) CheckDrift(ctx context.Context) (*provisioning.DriftResult, error) {
+	return nil, errors.New("checking infrastructure drift is not supported for dev center environments")
 }
 
 // Destroy destroys the environment by deleting the ADE environment
@@ -2,14 +2,20 @@ package devcenter
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcegraph/armresourcegraph"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/devcentersdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -410,7 +416,6 @@ func Test_ProvisionProvider_Destroy(t *testing.T) {
 }
 
 func Test_ProvisionProvider_Preview(t *testing.T) {
-	mockContext := mocks.NewMockContext(context.Background())
 	config := &Config{
 		Name:                  "DEV_CENTER_01",
 		Catalog:               "SampleCatalog",
@@ -419,14 +424,223 @@ func Test_ProvisionProvider_Preview(t *testing.T) {
 		EnvironmentDefinition: "WebApp",
 		User:                  "me",
 	}
-	env := environment.New("test")
 
-	provider := newProvisionProviderForTest(t, mockContext, config, env, nil)
+	t.Run("NewEnvironment", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := environment.New("test")
+
+		mockdevcentersdk.MockDevCenterGraphQuery(mockContext, mockDevCenterList)
+		mockdevcentersdk.MockGetEnvironmentDefinition(
+			mockContext,
+			config.Project,
+			config.Catalog,
+			config.EnvironmentDefinition,
+			mockEnvDefinitions[0],
+		)
+		mockdevcentersdk.MockGetEnvironment(mockContext, config.Project, config.User, env.Name(), nil)
+		mockdevcentersdk.MockListEnvironmentTypes(mockContext, config.Project, mockEnvironmentTypes)
+
+		// The environment type of the configuration doesn't exist, so what-if can't run.
+		provider := newProvisionProviderForTest(t, mockContext, config, env, nil)
+		result, err := provider.Preview(*mockContext.Context)
+		require.NoError(t, err)
+		require.Len(t, result.Preview.Properties.Changes, 1)
+		require.Equal(t, provisioning.ChangeTypeCreate, result.Preview.Properties.Changes[0].ChangeType)
+		require.Equal(t, EnvironmentResourceType, result.Preview.Properties.Changes[0].ResourceType)
+		require.Equal(t, env.Name(), result.Preview.Properties.Changes[0].Name)
+	})
+
+	t.Run("NewEnvironmentWhatIf", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := environment.NewWithValues("test", map[string]string{
+			environment.LocationEnvVarName: "eastus2",
+		})
+
+		deploymentsService := azapi.NewDeployments(
+			mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, cloud.AzurePublic())
+		deploymentOperations := azapi.NewDeploymentOperations(
+			mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, cloud.AzurePublic())
+
+		manager := &mockDevCenterManager{}
+		manager.
+			On("DefinitionTemplate", *mockContext.Context, mock.AnythingOfType("*devcentersdk.EnvironmentDefinition")).
+			Return(azure.RawArmTemplate(`{"resources": []}`), nil)
+		manager.
+			On("SubscriptionDeployment", "SUBSCRIPTION_01", "eastus2", "Project1-test").
+			Return(infra.NewSubscriptionDeployment(
+				deploymentsService, deploymentOperations, "eastus2", "SUBSCRIPTION_01", "Project1-test"))
+
+		mockdevcentersdk.MockDevCenterGraphQuery(mockContext, mockDevCenterList)
+		mockdevcentersdk.MockGetEnvironmentDefinition(
+			mockContext,
+			config.Project,
+			config.Catalog,
+			config.EnvironmentDefinition,
+			mockEnvDefinitions[0],
+		)
+		mockdevcentersdk.MockGetEnvironment(mockContext, config.Project, config.User, env.Name(), nil)
+		mockdevcentersdk.MockListEnvironmentTypes(mockContext, config.Project, []*devcentersdk.EnvironmentType{
+			{Name: "Dev", DeploymentTargetId: "/subscriptions/SUBSCRIPTION_01/", Status: "Enabled"},
+		})
+
+		var whatIfRequest armresources.DeploymentWhatIf
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPost &&
+				request.URL.Path == "/subscriptions/SUBSCRIPTION_01/providers/Microsoft.Resources/deployments/"+
+					"Project1-test/whatIf"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			require.NoError(t, json.NewDecoder(request.Body).Decode(&whatIfRequest))
+
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.WhatIfOperationResult{
+				Status: to.Ptr("Succeeded"),
+				Properties: &armresources.WhatIfOperationProperties{
+					Changes: []*armresources.WhatIfChange{
+						{
+							ChangeType: to.Ptr(armresources.ChangeTypeCreate),
+							ResourceID: to.Ptr("/subscriptions/SUBSCRIPTION_01/resourceGroups/Project1-test"),
+							After:      map[string]any{"type": "Microsoft.Resources/resourceGroups", "name": "Project1-test"},
+						},
+						{
+							ChangeType: to.Ptr(armresources.ChangeTypeCreate),
+							ResourceID: to.Ptr("/subscriptions/SUBSCRIPTION_01/resourceGroups/Project1-test/" +
+								"providers/Microsoft.Web/sites/app"),
+							After: map[string]any{"type": "Microsoft.Web/sites", "name": "app"},
+						},
+					},
+				},
+			})
+		})
+
+		provider := newProvisionProviderForTest(t, mockContext, config, env, manager)
+		result, err := provider.Preview(*mockContext.Context)
+		require.NoError(t, err)
+		require.Len(t, result.Preview.Properties.Changes, 2)
+		require.Equal(t, "Project1-test", result.Preview.Properties.Changes[0].Name)
+		require.Equal(t, "Microsoft.Web/sites", result.Preview.Properties.Changes[1].ResourceType)
+
+		// The template of the definition is nested in a template creating the resource group of the environment.
+		template := whatIfRequest.Properties.Template.(map[string]any)
+		resources := template["resources"].([]any)
+		require.Len(t, resources, 2)
+		require.Equal(t, "Microsoft.Resources/resourceGroups", resources[0].(map[string]any)["type"])
+
+		nested := resources[1].(map[string]any)
+		require.Equal(t, "Project1-test", nested["resourceGroup"])
+		properties := nested["properties"].(map[string]any)
+		require.Equal(t, map[string]any{"resources": []any{}}, properties["template"])
+		require.Equal(t,
+			map[string]any{"value": mockEnvDefinitions[0].Parameters[0].Default},
+			properties["parameters"].(map[string]any)["repoUrl"])
+	})
+
+	t.Run("WhatIf", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := environment.New("test")
 
-	// Preview is not supported in ADE - expected to fail
-	result, err := provider.Preview(*mockContext.Context)
-	require.Error(t, err)
-	require.Nil(t, result)
+		deployment := infra.NewResourceGroupDeployment(
+			azapi.NewDeployments(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, cloud.AzurePublic()),
+			azapi.NewDeploymentOperations(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, cloud.AzurePublic()),
+			"SUBSCRIPTION_ID",
+			"RESOURCE_GROUP_NAME",
+			"DEPLOYMENT_NAME",
+		)
+
+		manager := &mockDevCenterManager{}
+		manager.
+			On("Deployment", *mockContext.Context, mock.AnythingOfType("*devcentersdk.Environment"), mock.Anything).
+			Return(deployment, nil)
+		manager.
+			On("DefinitionTemplate", *mockContext.Context, mock.AnythingOfType("*devcentersdk.EnvironmentDefinition")).
+			Return(azure.RawArmTemplate(`{"resources": []}`), nil)
+
+		mockdevcentersdk.MockDevCenterGraphQuery(mockContext, mockDevCenterList)
+		mockdevcentersdk.MockGetEnvironmentDefinition(
+			mockContext,
+			config.Project,
+			config.Catalog,
+			config.EnvironmentDefinition,
+			mockEnvDefinitions[0],
+		)
+		mockdevcentersdk.MockGetEnvironment(mockContext, config.Project, config.User, env.Name(), mockEnvironments[0])
+
+		var whatIfRequest armresources.DeploymentWhatIf
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPost &&
+				strings.HasSuffix(request.URL.Path, "/deployments/DEPLOYMENT_NAME/whatIf")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			require.NoError(t, json.NewDecoder(request.Body).Decode(&whatIfRequest))
+
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.WhatIfOperationResult{
+				Status: to.Ptr("Succeeded"),
+				Properties: &armresources.WhatIfOperationProperties{
+					Changes: []*armresources.WhatIfChange{
+						{
+							ChangeType: to.Ptr(armresources.ChangeTypeModify),
+							ResourceID: to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP_NAME/" +
+								"providers/Microsoft.Web/sites/app"),
+							After: map[string]any{"type": "Microsoft.Web/sites", "name": "app"},
+						},
+						{
+							ChangeType: to.Ptr(armresources.ChangeTypeDelete),
+							ResourceID: to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP_NAME/" +
+								"providers/Microsoft.Storage/storageAccounts/storage"),
+							Before: map[string]any{"type": "Microsoft.Storage/storageAccounts", "name": "storage"},
+						},
+					},
+				},
+			})
+		})
+
+		provider := newProvisionProviderForTest(t, mockContext, config, env, manager)
+		result, err := provider.Preview(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, "Succeeded", result.Preview.Status)
+		require.Len(t, result.Preview.Properties.Changes, 2)
+		require.Equal(t, provisioning.ChangeTypeModify, result.Preview.Properties.Changes[0].ChangeType)
+		require.Equal(t, "Microsoft.Web/sites", result.Preview.Properties.Changes[0].ResourceType)
+		require.Equal(t, "app", result.Preview.Properties.Changes[0].Name)
+		require.Equal(t, provisioning.ChangeTypeDelete, result.Preview.Properties.Changes[1].ChangeType)
+		require.Equal(t, "storage", result.Preview.Properties.Changes[1].Name)
+
+		parameters := whatIfRequest.Properties.Parameters.(map[string]any)
+		require.Equal(t, map[string]any{"value": mockEnvDefinitions[0].Parameters[0].Default}, parameters["repoUrl"])
+	})
+
+	t.Run("ParametersWhenWhatIfUnavailable", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := environment.New("test")
+
+		manager := &mockDevCenterManager{}
+		manager.
+			On("Deployment", *mockContext.Context, mock.AnythingOfType("*devcentersdk.Environment"), mock.Anything).
+			Return(nil, errors.New("failed to find latest deployment"))
+
+		mockdevcentersdk.MockDevCenterGraphQuery(mockContext, mockDevCenterList)
+		mockdevcentersdk.MockGetEnvironmentDefinition(
+			mockContext,
+			config.Project,
+			config.Catalog,
+			config.EnvironmentDefinition,
+			mockEnvDefinitions[0],
+		)
+		mockdevcentersdk.MockGetEnvironment(mockContext, config.Project, config.User, env.Name(), mockEnvironments[0])
+
+		provider := newProvisionProviderForTest(t, mockContext, config, env, manager)
+		result, err := provider.Preview(*mockContext.Context)
+		require.NoError(t, err)
+		require.Len(t, result.Preview.Properties.Changes, 1)
+
+		change := result.Preview.Properties.Changes[0]
+		require.Equal(t, provisioning.ChangeTypeModify, change.ChangeType)
+		require.Equal(t, EnvironmentResourceType, change.ResourceType)
+		require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+			{
+				ChangeType: provisioning.PropertyChangeTypeCreate,
+				Path:       "parameters.repoUrl",
+				After:      mockEnvDefinitions[0].Parameters[0].Default,
+			},
+		}, change.Delta)
+	})
 }
 
 func newProvisionProviderForTest(
@@ -458,7 +672,7 @@ func newProvisionProviderForTest(
 	azCli := azcli.NewAzCli(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, azcli.NewAzCliArgs{})
 	resourceManager := infra.NewAzureResourceManager(
 		azCli,
-		azapi.NewDeploymentOperations(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient),
+		azapi.NewDeploymentOperations(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, cloud.AzurePublic()),
 	)
 
 	if manager == nil {
@@ -43,6 +43,14 @@ const AksClusterEnvVarName = "AZURE_AKS_CLUSTER_NAME"
 // ResourceGroupEnvVarName is the name of the azure resource group that should be used for deployments
 const ResourceGroupEnvVarName = "AZURE_RESOURCE_GROUP"
 
+// ManagementGroupIdEnvVarName is the id of the azure management group that should be used for management group scoped
+// deployments
+const ManagementGroupIdEnvVarName = "AZURE_MANAGEMENT_GROUP_ID"
+
+// DeploymentScopeEnvVarName is the scope of the deployments of the environment, when it can't be inferred from the other
+// values of the environment. It is only set to "tenant", for tenant scoped deployments.
+const DeploymentScopeEnvVarName = "AZURE_DEPLOYMENT_SCOPE"
+
 // The zero value of an Environment is not valid. Use [New] to create one. When writing tests,
 // [Ephemeral] and [EphemeralWithValues] are useful to create environments which are not persisted to disk.
 type Environment struct {
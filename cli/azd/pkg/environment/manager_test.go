@@ -10,6 +10,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
@@ -363,6 +364,7 @@ func registerContainerComponents(t *testing.T, mockContext *mocks.MockContext) {
 	_ = mockContext.Container.RegisterNamedSingleton(string(RemoteKindAzureBlobStorage), NewStorageBlobDataStore)
 
 	mockContext.Container.RegisterSingleton(storage.NewBlobSdkClient)
+	mockContext.Container.RegisterSingleton(cloud.AzurePublic)
 	mockContext.Container.RegisterSingleton(config.NewManager)
 	mockContext.Container.RegisterSingleton(storage.NewBlobClient)
 
@@ -92,7 +92,18 @@ func (r *commandRunner) Run(ctx context.Context, args RunArgs) (RunResult, error
 	// use the shell on Windows since most commands are actually just batch files wrapping
 	// real commands. And even if they're not, this will work fine without having to do any
 	// probing or checking.
-	cmd, err := newCmdTree(ctx, args.Cmd, args.Args, args.UseShell || runtime.GOOS == "windows", args.Interactive)
+	useShell := args.UseShell || runtime.GOOS == "windows"
+
+	// Executables in the search path of the context take precedence over the PATH of the current process. When a shell
+	// is used, the shell finds them through the PATH of the command.
+	cmdName := args.Cmd
+	if !useShell && len(searchPathDirs(ctx)) > 0 {
+		if resolved, err := LookPath(ctx, args.Cmd); err == nil {
+			cmdName = resolved
+		}
+	}
+
+	cmd, err := newCmdTree(ctx, cmdName, args.Args, useShell, args.Interactive)
 
 	if err != nil {
 		return RunResult{}, err
@@ -109,7 +120,7 @@ func (r *commandRunner) Run(ctx context.Context, args RunArgs) (RunResult, error
 
 	var stdout, stderr bytes.Buffer
 
-	cmd.Env = appendEnv(args.Env)
+	cmd.Env = withSearchPath(ctx, appendEnv(args.Env))
 
 	if args.Interactive {
 		cmd.Stdin = r.stdin
@@ -196,7 +207,7 @@ func (r *commandRunner) RunList(ctx context.Context, commands []string, args Run
 	}
 
 	process.Cmd.Dir = args.Cwd
-	process.Env = appendEnv(args.Env)
+	process.Env = withSearchPath(ctx, appendEnv(args.Env))
 
 	var stdOutBuf bytes.Buffer
 	var stdErrBuf bytes.Buffer
@@ -281,7 +292,7 @@ func (l *logBuilder) Write(debug bool, sensitiveArgsData []string) {
 
 	if debug && len(l.env) > 0 {
 		msg.WriteString("Additional env:\n")
-		for _, kv := range l.env {
+		for _, kv := range RedactSensitiveArgs(l.env, sensitiveArgsData) {
 			msg.WriteString(fmt.Sprintf("   %s\n", RedactSensitiveData(kv)))
 		}
 	}
@@ -8,7 +8,7 @@ import (
 type RunArgs struct {
 	Cmd  string
 	Args []string
-	// Any string from SensitiveData will be redacted as *** if found in Args
+	// Any string from SensitiveData will be redacted as *** if found in Args or Env
 	SensitiveData []string
 	Cwd           string
 	Env           []string
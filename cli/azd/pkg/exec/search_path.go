@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package exec
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SearchPath is a list of directories searched for executables before the PATH of the current process. Commands run
+// with a context carrying a SearchPath resolve their executable from it and have it prepended to their PATH, which lets
+// azd use the copies of tools it manages without modifying the environment of its own process.
+type SearchPath struct {
+	mu   sync.RWMutex
+	dirs []string
+}
+
+// NewSearchPath creates an empty SearchPath.
+func NewSearchPath() *SearchPath {
+	return &SearchPath{}
+}
+
+// Prepend adds dir to the front of the search path, so it is searched before every other directory.
+func (p *SearchPath) Prepend(dir string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.dirs = slices.DeleteFunc(p.dirs, func(existing string) bool { return existing == dir })
+	p.dirs = append([]string{dir}, p.dirs...)
+}
+
+// Dirs returns the directories of the search path, in the order they are searched.
+func (p *SearchPath) Dirs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return slices.Clone(p.dirs)
+}
+
+type searchPathKey struct{}
+
+// WithSearchPath returns a context which causes commands to be resolved from searchPath before the PATH of the current
+// process.
+func WithSearchPath(ctx context.Context, searchPath *SearchPath) context.Context {
+	return context.WithValue(ctx, searchPathKey{}, searchPath)
+}
+
+// SearchPathFromContext returns the SearchPath of the context, or nil when there is none.
+func SearchPathFromContext(ctx context.Context) *SearchPath {
+	searchPath, _ := ctx.Value(searchPathKey{}).(*SearchPath)
+	return searchPath
+}
+
+// LookPath searches for an executable named name in the search path of the context and then in the PATH of the current
+// process, returning the path to the executable. Like exec.LookPath, exec.ErrNotFound is returned when it is not found.
+func LookPath(ctx context.Context, name string) (string, error) {
+	if !strings.ContainsAny(name, `/\`) {
+		for _, dir := range searchPathDirs(ctx) {
+			// LookPath checks the file directly, appending the extensions in PATHEXT on Windows, when given a path.
+			if found, err := exec.LookPath(filepath.Join(dir, name)); err == nil {
+				return found, nil
+			}
+		}
+	}
+
+	return exec.LookPath(name)
+}
+
+func searchPathDirs(ctx context.Context) []string {
+	if searchPath := SearchPathFromContext(ctx); searchPath != nil {
+		return searchPath.Dirs()
+	}
+
+	return nil
+}
+
+// withSearchPath returns env, or the environment of the current process when env is nil, with the directories of the
+// search path of the context prepended to PATH. env is returned unchanged when the search path is empty.
+func withSearchPath(ctx context.Context, env []string) []string {
+	dirs := searchPathDirs(ctx)
+	if len(dirs) == 0 {
+		return env
+	}
+
+	if env == nil {
+		env = os.Environ()
+	}
+
+	pathKey := "PATH"
+	current := ""
+	result := make([]string, 0, len(env)+1)
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		if key == "PATH" || (runtime.GOOS == "windows" && strings.EqualFold(key, "PATH")) {
+			// When the variable is set more than once the last value wins, matching how it is passed to the process.
+			pathKey = key
+			current = value
+			continue
+		}
+
+		result = append(result, kv)
+	}
+
+	searched := strings.Join(dirs, string(os.PathListSeparator))
+	if current != "" {
+		searched += string(os.PathListSeparator) + current
+	}
+
+	return append(result, pathKey+"="+searched)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package exec
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the executable")
+	}
+
+	dir := t.TempDir()
+	toolPath := filepath.Join(dir, "azd-search-path-tool")
+	require.NoError(t, os.WriteFile(toolPath, []byte("#!/bin/sh\necho \"$PATH\"\n"), 0700))
+
+	_, err := LookPath(context.Background(), "azd-search-path-tool")
+	require.ErrorIs(t, err, exec.ErrNotFound)
+
+	searchPath := NewSearchPath()
+	searchPath.Prepend("/other")
+	searchPath.Prepend(dir)
+	searchPath.Prepend("/other")
+	require.Equal(t, []string{"/other", dir}, searchPath.Dirs())
+
+	ctx := WithSearchPath(context.Background(), searchPath)
+	found, err := LookPath(ctx, "azd-search-path-tool")
+	require.NoError(t, err)
+	require.Equal(t, toolPath, found)
+
+	res, err := NewCommandRunner(nil).Run(ctx, NewRunArgs("azd-search-path-tool"))
+	require.NoError(t, err)
+	require.Equal(t, "/other:"+dir+":"+os.Getenv("PATH")+"\n", res.Stdout)
+}
+
+func Test_withSearchPath(t *testing.T) {
+	searchPath := NewSearchPath()
+	ctx := WithSearchPath(context.Background(), searchPath)
+
+	env := []string{"A=1", "PATH=/usr/bin"}
+	require.Equal(t, env, withSearchPath(ctx, env))
+
+	searchPath.Prepend("/tools")
+	sep := string(os.PathListSeparator)
+	require.Equal(t, []string{"A=1", "PATH=/tools" + sep + "/usr/bin"}, withSearchPath(ctx, env))
+	require.Equal(t, []string{"A=1", "PATH=/tools"}, withSearchPath(ctx, []string{"A=1"}))
+}
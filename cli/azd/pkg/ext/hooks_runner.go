@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -159,8 +160,23 @@ func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, opti
 		defer h.console.StopPreviewer(ctx, false)
 	}
 
+	h.console.Event(ctx, contracts.HookStartEventDataType, contracts.HookStartEvent{
+		Name:  hookConfig.Name,
+		Shell: string(hookConfig.Shell),
+	})
+
 	log.Printf("Executing script '%s'\n", hookConfig.path)
 	res, err := script.Execute(ctx, hookConfig.path, *options)
+
+	hookEnd := contracts.HookEndEvent{
+		Name:     hookConfig.Name,
+		ExitCode: res.ExitCode,
+	}
+	if err != nil {
+		hookEnd.Error = err.Error()
+	}
+
+	h.console.Event(ctx, contracts.HookEndEventDataType, hookEnd)
 	if err != nil {
 		execErr := fmt.Errorf(
 			"'%s' hook failed with exit code: '%d', Path: '%s'. : %w",
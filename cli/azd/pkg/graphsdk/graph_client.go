@@ -3,6 +3,7 @@ package graphsdk
 import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 )
 
 type GraphClient struct {
@@ -19,11 +20,17 @@ func NewGraphClient(
 		options = &azcore.ClientOptions{}
 	}
 
-	pipeline := NewPipeline(credential, ServiceConfig, options)
+	// Sovereign and custom clouds have their own Microsoft Graph endpoint
+	serviceConfig := ServiceConfig
+	if cloudServiceConfig, has := options.Cloud.Services[cloud.MicrosoftGraph]; has {
+		serviceConfig = cloudServiceConfig
+	}
+
+	pipeline := NewPipeline(credential, serviceConfig, options)
 
 	return &GraphClient{
 		pipeline: pipeline,
-		host:     ServiceConfig.Endpoint,
+		host:     serviceConfig.Endpoint,
 	}, nil
 }
 
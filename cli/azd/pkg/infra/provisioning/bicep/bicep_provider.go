@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -69,11 +70,16 @@ type BicepProvider struct {
 	azCli                 azcli.AzCli
 	deploymentsService    azapi.Deployments
 	deploymentOperations  azapi.DeploymentOperations
+	deploymentStacks      azapi.DeploymentStacks
+	resourceAvailability  azapi.ResourceAvailability
 	prompters             prompt.Prompter
 	curPrincipal          CurrentPrincipalIdProvider
 	alphaFeatureManager   *alpha.FeatureManager
 	clock                 clock.Clock
 	ignoreDeploymentState bool
+	// ownerEnv is the environment that owns a shared layer provisioned from another environment. It is nil for layers
+	// owned by the current environment.
+	ownerEnv *environment.Environment
 }
 
 var ErrResourceGroupScopeNotSupported = fmt.Errorf(
@@ -104,6 +110,20 @@ func (p *BicepProvider) Initialize(ctx context.Context, projectPath string, opti
 	}
 	p.ignoreDeploymentState = options.IgnoreDeploymentState
 
+	p.ownerEnv = nil
+	if options.IsShared() && options.Environment != p.env.Name() {
+		ownerEnv, err := p.envManager.Get(ctx, options.Environment)
+		if errors.Is(err, environment.ErrNotFound) {
+			return fmt.Errorf(
+				"layer '%s' is shared from environment '%s', which does not exist. Create it with 'azd env new %s'",
+				options.Name, options.Environment, options.Environment)
+		} else if err != nil {
+			return fmt.Errorf("loading environment '%s' of shared layer '%s': %w", options.Environment, options.Name, err)
+		}
+
+		p.ownerEnv = ownerEnv
+	}
+
 	p.console.ShowSpinner(ctx, "Initialize bicep provider", input.Step)
 	err := p.EnsureEnv(ctx)
 	p.console.StopSpinner(ctx, "", input.Step)
@@ -123,7 +143,7 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 		log.Printf("Initializing environment w/o arm template info.")
 	}
 
-	if err := EnsureSubscriptionAndLocation(ctx, p.envManager, p.env, p.prompters, func(loc account.Location) bool {
+	locationFilter := func(loc account.Location) bool {
 		// compileResult can be nil if the infra folder is missing and azd couldn't get a template information.
 		// A template information can be used to apply filters to the initial values (like location).
 		// But if there's not template, azd will continue with azd env init.
@@ -139,10 +159,28 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 			}
 		}
 		return true
-	}); err != nil {
+	}
+
+	// The scope of a shared layer comes from the environment owning it, which is never prompted for or modified while
+	// provisioning from another environment.
+	promptLocation := p.ownerEnv == nil && p.env.GetLocation() == ""
+	if p.ownerEnv != nil {
+		if p.ownerEnv.GetSubscriptionId() == "" || p.ownerEnv.GetLocation() == "" {
+			return fmt.Errorf(
+				"layer '%s' is shared from environment '%s', which has no %s or %s set. Provision it from that "+
+					"environment first", p.options.Name, p.ownerEnv.Name(),
+				environment.SubscriptionIdEnvVarName, environment.LocationEnvVarName)
+		}
+	} else if err := EnsureSubscriptionAndLocation(ctx, p.envManager, p.env, p.prompters, locationFilter); err != nil {
 		return err
 	}
 
+	if promptLocation && compileResult != nil {
+		if err := p.ensureLocationAvailability(ctx, compileResult, locationFilter); err != nil {
+			return err
+		}
+	}
+
 	// If there's not template, just behave as if we are in a subscription scope (and don't ask about
 	// AZURE_RESOURCE_GROUP). Future operations which try to use the infrastructure may fail, but that's ok. These
 	// failures will have reasonable error messages.
@@ -165,7 +203,14 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 
 		p.console.WarnForFeature(ctx, ResourceGroupDeploymentFeature)
 
-		if p.env.Getenv(environment.ResourceGroupEnvVarName) == "" {
+		if p.options.Scope.ResourceGroup == "" && p.scopeEnv().Getenv(environment.ResourceGroupEnvVarName) == "" {
+			if p.ownerEnv != nil {
+				return fmt.Errorf(
+					"layer '%s' is shared from environment '%s', which has no %s set. Set the resource group of the "+
+						"layer with 'scope.resourceGroup' in azure.yaml", p.options.Name, p.ownerEnv.Name(),
+					environment.ResourceGroupEnvVarName)
+			}
+
 			rgName, err := p.prompters.PromptResourceGroup(ctx)
 			if err != nil {
 				return err
@@ -178,6 +223,36 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 		}
 	}
 
+	if scope == azure.DeploymentScopeManagementGroup &&
+		p.scopeEnv().Getenv(environment.ManagementGroupIdEnvVarName) == "" {
+		if p.ownerEnv != nil {
+			return fmt.Errorf(
+				"layer '%s' is shared from environment '%s', which has no %s set. Provision it from that environment "+
+					"first", p.options.Name, p.ownerEnv.Name(), environment.ManagementGroupIdEnvVarName)
+		}
+
+		managementGroupId, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message: "Enter the id of the management group to deploy to:",
+		})
+		if err != nil {
+			return fmt.Errorf("prompting for management group: %w", err)
+		}
+
+		p.env.DotenvSet(environment.ManagementGroupIdEnvVarName, strings.TrimSpace(managementGroupId))
+		if err := p.envManager.Save(ctx, p.env); err != nil {
+			return fmt.Errorf("saving management group id: %w", err)
+		}
+	}
+
+	// Recorded so the scope of the deployments can be inferred when the template is not available, like on down.
+	if scope == azure.DeploymentScopeTenant && p.ownerEnv == nil &&
+		p.env.Getenv(environment.DeploymentScopeEnvVarName) != string(azure.DeploymentScopeTenant) {
+		p.env.DotenvSet(environment.DeploymentScopeEnvVarName, string(azure.DeploymentScopeTenant))
+		if err := p.envManager.Save(ctx, p.env); err != nil {
+			return fmt.Errorf("saving deployment scope: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -225,13 +300,17 @@ func (p *BicepProvider) State(ctx context.Context, options *StateOptions) (*Stat
 		outputs = azure.ArmTemplateOutputs{}
 	}
 
+	if p.useDeploymentStacks() {
+		return p.stackState(ctx, scope, outputs)
+	}
+
 	// TODO: Report progress, "Retrieving Azure deployment"
 	spinnerMessage = "Retrieving Azure deployment"
 	p.console.ShowSpinner(ctx, spinnerMessage, input.Step)
 
 	var deployment *armresources.DeploymentExtended
 
-	deployments, err := p.findCompletedDeployments(ctx, p.env.Name(), scope, options.Hint())
+	deployments, err := p.findCompletedDeployments(ctx, p.layerEnvName(), scope, options.Hint())
 	p.console.StopSpinner(ctx, "", input.StepDone)
 
 	if err != nil {
@@ -303,21 +382,38 @@ func (p *BicepProvider) createDeploymentFromArmDeployment(
 	scope infra.Scope,
 	deploymentName string,
 ) (infra.Deployment, error) {
-	switch scope.(type) {
+	switch scope := scope.(type) {
 	case *infra.ResourceGroupScope:
 		return infra.NewResourceGroupDeployment(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetSubscriptionId(),
-			p.env.Getenv(environment.ResourceGroupEnvVarName),
+			p.scopeEnv().GetSubscriptionId(),
+			scope.ResourceGroupName(),
 			deploymentName,
 		), nil
 	case *infra.SubscriptionScope:
 		return infra.NewSubscriptionDeployment(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetLocation(),
-			p.env.GetSubscriptionId(),
+			p.scopeEnv().GetLocation(),
+			p.scopeEnv().GetSubscriptionId(),
+			deploymentName,
+		), nil
+	case *infra.ManagementGroupScope:
+		return infra.NewManagementGroupDeployment(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetLocation(),
+			p.scopeEnv().GetSubscriptionId(),
+			scope.ManagementGroupId(),
+			deploymentName,
+		), nil
+	case *infra.TenantScope:
+		return infra.NewTenantDeployment(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetLocation(),
+			p.scopeEnv().GetSubscriptionId(),
 			deploymentName,
 		), nil
 	default:
@@ -349,18 +445,29 @@ func (p *BicepProvider) plan(ctx context.Context) (*deploymentDetails, error) {
 		return nil, fmt.Errorf("creating template: %w", err)
 	}
 
+	overrides, err := p.parameterOverrides(compileResult.Template)
+	if err != nil {
+		return nil, err
+	}
+
 	// for .bicep, azd must load a parameters.json file and create the ArmParameters
 	if isBicepFile(modulePath) {
 		parameters, err := p.loadParameters(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("resolving bicep parameters file: %w", err)
 		}
+		maps.Copy(parameters, overrides)
 
 		configuredParameters, err := p.ensureParameters(ctx, compileResult.Template, parameters)
 		if err != nil {
 			return nil, err
 		}
 		compileResult.Parameters = configuredParameters
+	} else if len(overrides) > 0 {
+		if compileResult.Parameters == nil {
+			compileResult.Parameters = azure.ArmParameters{}
+		}
+		maps.Copy(compileResult.Parameters, overrides)
 	}
 
 	deploymentScope, err := compileResult.Template.TargetScope()
@@ -384,22 +491,111 @@ func (p *BicepProvider) deploymentScope(deploymentScope azure.DeploymentScope) (
 		return infra.NewSubscriptionDeployment(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetLocation(),
-			p.env.GetSubscriptionId(),
-			deploymentNameForEnv(p.env.Name(), p.clock),
+			p.scopeEnv().GetLocation(),
+			p.scopeEnv().GetSubscriptionId(),
+			deploymentNameForEnv(p.deploymentPrefix(), p.clock),
 		), nil
 	} else if deploymentScope == azure.DeploymentScopeResourceGroup {
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return nil, err
+		}
+
 		return infra.NewResourceGroupDeployment(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetSubscriptionId(),
-			p.env.Getenv(environment.ResourceGroupEnvVarName),
-			deploymentNameForEnv(p.env.Name(), p.clock),
+			p.scopeEnv().GetSubscriptionId(),
+			resourceGroup,
+			deploymentNameForEnv(p.deploymentPrefix(), p.clock),
+		), nil
+	} else if deploymentScope == azure.DeploymentScopeManagementGroup {
+		return infra.NewManagementGroupDeployment(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetLocation(),
+			p.scopeEnv().GetSubscriptionId(),
+			p.scopeEnv().Getenv(environment.ManagementGroupIdEnvVarName),
+			deploymentNameForEnv(p.deploymentPrefix(), p.clock),
+		), nil
+	} else if deploymentScope == azure.DeploymentScopeTenant {
+		return infra.NewTenantDeployment(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetLocation(),
+			p.scopeEnv().GetSubscriptionId(),
+			deploymentNameForEnv(p.deploymentPrefix(), p.clock),
 		), nil
 	}
 	return nil, fmt.Errorf("unsupported scope: %s", deploymentScope)
 }
 
+// deploymentPrefix returns the prefix of the names of the deployments of the layer being provisioned. It is the name of the
+// environment, followed by the name of the layer for layers other than the infrastructure of the project.
+func (p *BicepProvider) deploymentPrefix() string {
+	if p.options.Name == "" {
+		return p.layerEnvName()
+	}
+
+	return fmt.Sprintf("%s-%s", p.layerEnvName(), p.options.Name)
+}
+
+// layerEnvName returns the name of the environment the layer being provisioned belongs to. It is the shared environment of
+// the layer, when set, and the current environment otherwise.
+func (p *BicepProvider) layerEnvName() string {
+	if p.options.IsShared() {
+		return p.options.Environment
+	}
+
+	return p.env.Name()
+}
+
+// scopeEnv returns the environment the subscription, location and resource group of the layer are read from. This is the
+// environment owning the layer, which differs from the current environment for shared layers.
+func (p *BicepProvider) scopeEnv() *environment.Environment {
+	if p.ownerEnv != nil {
+		return p.ownerEnv
+	}
+
+	return p.env
+}
+
+// resourceGroupName returns the resource group resource group scoped deployments of the layer target. It is the resource
+// group of the scope of the layer, when set, and AZURE_RESOURCE_GROUP otherwise.
+func (p *BicepProvider) resourceGroupName() (string, error) {
+	if p.options.Scope.ResourceGroup == "" {
+		return p.scopeEnv().Getenv(environment.ResourceGroupEnvVarName), nil
+	}
+
+	resourceGroup, err := envsubst.Eval(p.options.Scope.ResourceGroup, p.scopeEnv().Getenv)
+	if err != nil {
+		return "", fmt.Errorf("expanding the resource group of the layer: %w", err)
+	}
+
+	return resourceGroup, nil
+}
+
+// deploymentTags returns the tags applied to the deployments of the layer being provisioned.
+func (p *BicepProvider) deploymentTags() map[string]*string {
+	tags := map[string]*string{
+		azure.TagKeyAzdEnvName: to.Ptr(p.layerEnvName()),
+	}
+	if p.options.Name != "" {
+		tags[azure.TagKeyAzdLayerName] = to.Ptr(p.options.Name)
+	}
+
+	return tags
+}
+
+// deploymentLayer returns the name of the layer a deployment provisions, which is empty for the infrastructure of the
+// project.
+func deploymentLayer(deployment *armresources.DeploymentExtended) string {
+	if v, has := deployment.Tags[azure.TagKeyAzdLayerName]; has && v != nil {
+		return *v
+	}
+
+	return ""
+}
+
 // cArmDeploymentNameLengthMax is the maximum length of the name of a deployment in ARM.
 const cArmDeploymentNameLengthMax = 64
 
@@ -437,7 +633,7 @@ func (p *BicepProvider) deploymentState(
 
 	var templateHash string
 	createHashResult, err := p.deploymentsService.CalculateTemplateHash(
-		ctx, p.env.GetSubscriptionId(), deploymentData.CompiledBicep.RawArmTemplate)
+		ctx, p.scopeEnv().GetSubscriptionId(), deploymentData.CompiledBicep.RawArmTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("can't get hash from current template: %w", err)
 	}
@@ -458,7 +654,7 @@ func (p *BicepProvider) latestDeploymentResult(
 	ctx context.Context,
 	scope infra.Scope,
 ) (*armresources.DeploymentExtended, error) {
-	deployments, err := p.findCompletedDeployments(ctx, p.env.Name(), scope, "")
+	deployments, err := p.findCompletedDeployments(ctx, p.layerEnvName(), scope, "")
 	// findCompletedDeployments returns error if no deployments are found
 	// No need to check for empty list
 	if err != nil {
@@ -554,6 +750,16 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		return nil, err
 	}
 
+	// The stack keeps track of the resources it manages, so there is no deployment state to compare with.
+	if p.useDeploymentStacks() {
+		if err := p.checkResourceAvailability(
+			ctx, bicepDeploymentData.CompiledBicep, p.scopeEnv().GetLocation(), bicepDeploymentData.Target); err != nil {
+			return nil, err
+		}
+
+		return p.deployStack(ctx, bicepDeploymentData, deployment)
+	}
+
 	// parameters hash is required for doing deployment state validation check but also to set the hash
 	// after a successful deployment.
 	currentParamsHash, parametersHashErr := parametersHash(
@@ -579,6 +785,11 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		logDS(err.Error())
 	}
 
+	if err := p.checkResourceAvailability(
+		ctx, bicepDeploymentData.CompiledBicep, p.scopeEnv().GetLocation(), bicepDeploymentData.Target); err != nil {
+		return nil, err
+	}
+
 	cancelProgress := make(chan bool)
 	defer func() { cancelProgress <- true }()
 	go func() {
@@ -617,9 +828,7 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 	// Start the deployment
 	p.console.ShowSpinner(ctx, "Creating/Updating resources", input.Step)
 
-	deploymentTags := map[string]*string{
-		azure.TagKeyAzdEnvName: to.Ptr(p.env.Name()),
-	}
+	deploymentTags := p.deploymentTags()
 	if parametersHashErr == nil {
 		deploymentTags[azure.TagKeyAzdDeploymentStateParamHashName] = to.Ptr(currentParamsHash)
 	}
@@ -653,11 +862,46 @@ func (p *BicepProvider) Preview(ctx context.Context) (*DeployPreviewResult, erro
 
 	p.console.ShowSpinner(ctx, "Generating infrastructure preview", input.Step)
 
-	targetScope := bicepDeploymentData.Target
-	deployPreviewResult, err := targetScope.DeployPreview(
+	deployPreviewResult, err := p.whatIf(ctx, bicepDeploymentData)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*DeploymentPreviewChange
+	for _, change := range deployPreviewResult.Properties.Changes {
+		resourceAfter := change.After.(map[string]interface{})
+
+		changes = append(changes, &DeploymentPreviewChange{
+			ChangeType: ChangeType(*change.ChangeType),
+			ResourceId: Resource{
+				Id: *change.ResourceID,
+			},
+			ResourceType: resourceAfter["type"].(string),
+			Name:         resourceAfter["name"].(string),
+		})
+	}
+
+	return &DeployPreviewResult{
+		Preview: &DeploymentPreview{
+			Status: *deployPreviewResult.Status,
+			Properties: &DeploymentPreviewProperties{
+				Changes: changes,
+			},
+			Parameters: previewParameters(
+				bicepDeploymentData.CompiledBicep.Template, bicepDeploymentData.CompiledBicep.Parameters),
+		},
+	}, nil
+}
+
+// whatIf evaluates the compiled template with ARM what-if against the target of the deployment.
+func (p *BicepProvider) whatIf(
+	ctx context.Context,
+	deploymentData *deploymentDetails,
+) (*armresources.WhatIfOperationResult, error) {
+	deployPreviewResult, err := deploymentData.Target.DeployPreview(
 		ctx,
-		bicepDeploymentData.CompiledBicep.RawArmTemplate,
-		bicepDeploymentData.CompiledBicep.Parameters,
+		deploymentData.CompiledBicep.RawArmTemplate,
+		deploymentData.CompiledBicep.Parameters,
 	)
 	if err != nil {
 		return nil, err
@@ -686,28 +930,7 @@ func (p *BicepProvider) Preview(ctx context.Context) (*DeployPreviewResult, erro
 		)
 	}
 
-	var changes []*DeploymentPreviewChange
-	for _, change := range deployPreviewResult.Properties.Changes {
-		resourceAfter := change.After.(map[string]interface{})
-
-		changes = append(changes, &DeploymentPreviewChange{
-			ChangeType: ChangeType(*change.ChangeType),
-			ResourceId: Resource{
-				Id: *change.ResourceID,
-			},
-			ResourceType: resourceAfter["type"].(string),
-			Name:         resourceAfter["name"].(string),
-		})
-	}
-
-	return &DeployPreviewResult{
-		Preview: &DeploymentPreview{
-			Status: *deployPreviewResult.Status,
-			Properties: &DeploymentPreviewProperties{
-				Changes: changes,
-			},
-		},
-	}, nil
+	return deployPreviewResult, nil
 }
 
 type itemToPurge struct {
@@ -725,32 +948,74 @@ func (p *BicepProvider) scopeForTemplate(ctx context.Context, t azure.ArmTemplat
 
 	if deploymentScope == azure.DeploymentScopeSubscription {
 		return infra.NewSubscriptionScope(
-			p.deploymentsService, p.deploymentOperations, p.env.GetSubscriptionId()), nil
+			p.deploymentsService, p.deploymentOperations, p.scopeEnv().GetSubscriptionId()), nil
 	} else if deploymentScope == azure.DeploymentScopeResourceGroup {
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return nil, err
+		}
+
 		return infra.NewResourceGroupScope(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetSubscriptionId(),
-			p.env.Getenv(environment.ResourceGroupEnvVarName),
+			p.scopeEnv().GetSubscriptionId(),
+			resourceGroup,
+		), nil
+	} else if deploymentScope == azure.DeploymentScopeManagementGroup {
+		return infra.NewManagementGroupScope(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetSubscriptionId(),
+			p.scopeEnv().Getenv(environment.ManagementGroupIdEnvVarName),
 		), nil
+	} else if deploymentScope == azure.DeploymentScopeTenant {
+		return infra.NewTenantScope(p.deploymentsService, p.deploymentOperations, p.scopeEnv().GetSubscriptionId()), nil
 	} else {
 		return nil, fmt.Errorf("unsupported deployment scope: %s", deploymentScope)
 	}
 }
 
 func (p *BicepProvider) inferScopeFromEnv(ctx context.Context) (infra.Scope, error) {
-	if resourceGroup, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+	if p.scopeEnv().Getenv(environment.DeploymentScopeEnvVarName) == string(azure.DeploymentScopeTenant) {
+		return infra.NewTenantScope(
+			p.deploymentsService, p.deploymentOperations, p.scopeEnv().GetSubscriptionId()), nil
+	}
+
+	if managementGroupId := p.scopeEnv().Getenv(environment.ManagementGroupIdEnvVarName); managementGroupId != "" {
+		return infra.NewManagementGroupScope(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetSubscriptionId(),
+			managementGroupId,
+		), nil
+	}
+
+	if p.options.Scope.ResourceGroup != "" {
+		resourceGroup, err := p.resourceGroupName()
+		if err != nil {
+			return nil, err
+		}
+
+		return infra.NewResourceGroupScope(
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.scopeEnv().GetSubscriptionId(),
+			resourceGroup,
+		), nil
+	}
+
+	if resourceGroup, has := p.scopeEnv().LookupEnv(environment.ResourceGroupEnvVarName); has {
 		return infra.NewResourceGroupScope(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetSubscriptionId(),
+			p.scopeEnv().GetSubscriptionId(),
 			resourceGroup,
 		), nil
 	} else {
 		return infra.NewSubscriptionScope(
 			p.deploymentsService,
 			p.deploymentOperations,
-			p.env.GetSubscriptionId(),
+			p.scopeEnv().GetSubscriptionId(),
 		), nil
 	}
 }
@@ -764,6 +1029,24 @@ const cEmptySubDeployTemplate = `{
 	"outputs": {}
   }`
 
+const cEmptyManagementGroupDeployTemplate = `{
+	"$schema": "https://schema.management.azure.com/schemas/2019-08-01/managementGroupDeploymentTemplate.json#",
+	"contentVersion": "1.0.0.0",
+	"parameters": {},
+	"variables": {},
+	"resources": [],
+	"outputs": {}
+  }`
+
+const cEmptyTenantDeployTemplate = `{
+	"$schema": "https://schema.management.azure.com/schemas/2019-08-01/tenantDeploymentTemplate.json#",
+	"contentVersion": "1.0.0.0",
+	"parameters": {},
+	"variables": {},
+	"resources": [],
+	"outputs": {}
+  }`
+
 const cEmptyResourceGroupDeployTemplate = `{
 	"$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
 	"contentVersion": "1.0.0.0",
@@ -787,6 +1070,10 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 		return nil, fmt.Errorf("computing deployment scope: %w", err)
 	}
 
+	if p.useDeploymentStacks() {
+		return p.destroyStack(ctx, options, compileResult.Template.Outputs, scope)
+	}
+
 	targetScope, err := compileResult.Template.TargetScope()
 	if err != nil {
 		return nil, err
@@ -797,13 +1084,42 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 	}
 
 	// TODO: Report progress, "Fetching resource groups"
-	deployments, err := p.findCompletedDeployments(ctx, p.env.Name(), scope, "")
+	deployments, err := p.findCompletedDeployments(ctx, p.layerEnvName(), scope, "")
 	if err != nil {
 		return nil, err
 	}
 
 	rgsFromDeployment := resourceGroupsToDelete(deployments[0])
 
+	// A layer only deletes the resource groups it deploys into alone. Resource groups which other layers deploy into as
+	// well are left in place, and only the resources the deployment of the layer created in them are deleted, so
+	// destroying a layer never removes the resources of another.
+	var sharedResourceGroups []string
+	var layerResources []string
+	if p.options.Name != "" {
+		otherResourceGroups, err := p.otherLayersResourceGroups(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		rgsFromDeployment, sharedResourceGroups = partitionResourceGroups(rgsFromDeployment, otherResourceGroups)
+		if len(sharedResourceGroups) > 0 {
+			layerResources, err = p.deploymentResources(ctx, scope, deployments[0])
+			if err != nil {
+				return nil, fmt.Errorf("getting resources of the layer: %w", err)
+			}
+
+			layerResources = resourcesInResourceGroups(layerResources, sharedResourceGroups)
+		}
+	}
+
+	// Resources deployed directly at a management group or tenant (e.g. policy assignments or subscription aliases) are
+	// not in a resource group, so they are deleted one by one, after the resource groups of the deployment.
+	var scopeResources []string
+	if targetScope == azure.DeploymentScopeManagementGroup || targetScope == azure.DeploymentScopeTenant {
+		scopeResources = resourcesOutsideResourceGroups(deployments[0])
+	}
+
 	// TODO: Report progress, "Fetching resources"
 	groupedResources, err := p.getAllResourcesToDelete(ctx, rgsFromDeployment)
 	if err != nil {
@@ -815,6 +1131,87 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 		allResources = append(allResources, groupResources...)
 	}
 
+	// The soft-deleted resources of the layer in shared resource groups are purged as well.
+	purgeResources, err := p.layerResourcesToPurge(ctx, groupedResources, sharedResourceGroups, layerResources)
+	if err != nil {
+		return nil, fmt.Errorf("getting resources to delete: %w", err)
+	}
+
+	purgeItem, err := p.itemsToPurge(ctx, purgeResources, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is nothing to confirm or delete when a layer has no resources left.
+	if p.options.Name == "" || len(groupedResources) > 0 || len(scopeResources) > 0 || len(layerResources) > 0 {
+		err := p.destroyResourceGroups(
+			ctx,
+			options,
+			groupedResources,
+			scopeResources,
+			layerResources,
+			len(allResources)+len(scopeResources)+len(layerResources),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("deleting resource groups: %w", err)
+		}
+	}
+
+	if err := p.purgeItems(ctx, purgeItem, options); err != nil {
+		return nil, fmt.Errorf("purging resources: %w", err)
+	}
+
+	destroyResult := &DestroyResult{
+		InvalidatedEnvKeys: maps.Keys(p.createOutputParameters(
+			compileResult.Template.Outputs,
+			azapi.CreateDeploymentOutput(deployments[0].Properties.Outputs),
+		)),
+		SharedResourceGroups: sharedResourceGroups,
+	}
+
+	// Since we have deleted the resource group, add AZURE_RESOURCE_GROUP to the list of invalidated env vars
+	// so it will be removed from the .env file.
+	if _, ok := scope.(*infra.ResourceGroupScope); ok && len(sharedResourceGroups) == 0 &&
+		p.options.Scope.ResourceGroup == "" {
+		destroyResult.InvalidatedEnvKeys = append(
+			destroyResult.InvalidatedEnvKeys, environment.ResourceGroupEnvVarName,
+		)
+	}
+
+	downTags := p.deploymentTags()
+	downTags["azd-deploy-reason"] = to.Ptr("down")
+
+	var emptyTemplate json.RawMessage
+	switch targetScope {
+	case azure.DeploymentScopeSubscription:
+		emptyTemplate = []byte(cEmptySubDeployTemplate)
+	case azure.DeploymentScopeManagementGroup:
+		emptyTemplate = []byte(cEmptyManagementGroupDeployTemplate)
+	case azure.DeploymentScopeTenant:
+		emptyTemplate = []byte(cEmptyTenantDeployTemplate)
+	default:
+		emptyTemplate = []byte(cEmptyResourceGroupDeployTemplate)
+	}
+
+	// create empty deployment to void provision state
+	// We want to keep the deployment history, that's why it's not just deleted
+	if _, err := p.deployModule(ctx,
+		deployScope,
+		emptyTemplate,
+		azure.ArmParameters{},
+		downTags); err != nil {
+		log.Println("failed creating new empty deployment after destroy")
+	}
+
+	return destroyResult, nil
+}
+
+// itemsToPurge returns the soft-deleted resources to purge, once the given resources are deleted.
+func (p *BicepProvider) itemsToPurge(
+	ctx context.Context,
+	groupedResources map[string][]azcli.AzCliResource,
+	options DestroyOptions,
+) ([]itemToPurge, error) {
 	// TODO: Report progress, "Getting Key Vaults to purge"
 	keyVaults, err := p.getKeyVaultsToPurge(ctx, groupedResources)
 	if err != nil {
@@ -845,10 +1242,6 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 		return nil, fmt.Errorf("getting cognitive accounts to purge: %w", err)
 	}
 
-	if err := p.destroyResourceGroups(ctx, options, groupedResources, len(allResources)); err != nil {
-		return nil, fmt.Errorf("deleting resource groups: %w", err)
-	}
-
 	keyVaultsPurge := itemToPurge{
 		resourceType: "Key Vault",
 		count:        len(keyVaults),
@@ -899,46 +1292,7 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 		purgeItem = append(purgeItem, addPurgeItem)
 	}
 
-	if err := p.purgeItems(ctx, purgeItem, options); err != nil {
-		return nil, fmt.Errorf("purging resources: %w", err)
-	}
-
-	destroyResult := &DestroyResult{
-		InvalidatedEnvKeys: maps.Keys(p.createOutputParameters(
-			compileResult.Template.Outputs,
-			azapi.CreateDeploymentOutput(deployments[0].Properties.Outputs),
-		)),
-	}
-
-	// Since we have deleted the resource group, add AZURE_RESOURCE_GROUP to the list of invalidated env vars
-	// so it will be removed from the .env file.
-	if _, ok := scope.(*infra.ResourceGroupScope); ok {
-		destroyResult.InvalidatedEnvKeys = append(
-			destroyResult.InvalidatedEnvKeys, environment.ResourceGroupEnvVarName,
-		)
-	}
-
-	var emptyTemplate json.RawMessage
-	if targetScope == azure.DeploymentScopeSubscription {
-		emptyTemplate = []byte(cEmptySubDeployTemplate)
-	} else {
-		emptyTemplate = []byte(cEmptyResourceGroupDeployTemplate)
-	}
-
-	// create empty deployment to void provision state
-	// We want to keep the deployment history, that's why it's not just deleted
-	if _, err := p.deployModule(ctx,
-		deployScope,
-		emptyTemplate,
-		azure.ArmParameters{},
-		map[string]*string{
-			azure.TagKeyAzdEnvName: to.Ptr(p.env.Name()),
-			"azd-deploy-reason":    to.Ptr("down"),
-		}); err != nil {
-		log.Println("failed creating new empty deployment after destroy")
-	}
-
-	return destroyResult, nil
+	return purgeItem, nil
 }
 
 // A local type for adding the resource group to a cognitive account as it is required for purging
@@ -987,9 +1341,12 @@ func (p *BicepProvider) findCompletedDeployments(
 		return y.Properties.Timestamp.Compare(*x.Properties.Timestamp)
 	})
 
-	// If hint is not provided, use the environment name as the hint
+	// If hint is not provided, use the deployment name prefix of the layer as the hint
 	if hint == "" {
 		hint = envName
+		if p.options.Name != "" {
+			hint = fmt.Sprintf("%s-%s", envName, p.options.Name)
+		}
 	}
 
 	// Environment matching strategy
@@ -1005,6 +1362,11 @@ func (p *BicepProvider) findCompletedDeployments(
 			continue
 		}
 
+		// Deployments of other infrastructure layers are never a match.
+		if deploymentLayer(deployment) != p.options.Name {
+			continue
+		}
+
 		// Match on current azd strategy (tags) or old azd strategy (deployment name)
 		if v, has := deployment.Tags[azure.TagKeyAzdEnvName]; has && *v == envName || *deployment.Name == envName {
 			return []*armresources.DeploymentExtended{deployment}, nil
@@ -1075,6 +1437,181 @@ func resourceGroupsToDelete(deployment *armresources.DeploymentExtended) []strin
 	return maps.Keys(resourceGroups)
 }
 
+// resourcesOutsideResourceGroups returns the ids of the resources of a deployment which are not in a resource group, like
+// the resources of management group or tenant scoped deployments.
+func resourcesOutsideResourceGroups(deployment *armresources.DeploymentExtended) []string {
+	var resources []string
+	for _, resource := range deployment.Properties.OutputResources {
+		if resource == nil || resource.ID == nil {
+			continue
+		}
+
+		resId, err := arm.ParseResourceID(*resource.ID)
+		if err == nil && resId.ResourceGroupName == "" {
+			resources = append(resources, *resource.ID)
+		}
+	}
+
+	return resources
+}
+
+// otherLayersResourceGroups returns the resource groups the latest deployments of the other infrastructure layers deploy
+// into. Layers of every environment are considered, since shared layers are tagged with the environment they belong to.
+func (p *BicepProvider) otherLayersResourceGroups(ctx context.Context, scope infra.Scope) (map[string]struct{}, error) {
+	deployments, err := scope.ListDeployments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(deployments, func(x, y *armresources.DeploymentExtended) int {
+		return y.Properties.Timestamp.Compare(*x.Properties.Timestamp)
+	})
+
+	seenLayers := map[string]struct{}{}
+	resourceGroups := map[string]struct{}{}
+	for _, deployment := range deployments {
+		envName, has := deployment.Tags[azure.TagKeyAzdEnvName]
+		if !has || envName == nil {
+			continue
+		}
+
+		if *deployment.Properties.ProvisioningState != armresources.ProvisioningStateSucceeded &&
+			*deployment.Properties.ProvisioningState != armresources.ProvisioningStateFailed {
+			continue
+		}
+
+		layer := deploymentLayer(deployment)
+		if *envName == p.layerEnvName() && layer == p.options.Name {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", *envName, layer)
+		if _, seen := seenLayers[key]; seen {
+			continue
+		}
+		seenLayers[key] = struct{}{}
+
+		for _, resourceGroup := range resourceGroupsToDelete(deployment) {
+			resourceGroups[resourceGroup] = struct{}{}
+		}
+	}
+
+	return resourceGroups, nil
+}
+
+// partitionResourceGroups splits resourceGroups into the ones which are not in shared, and the ones which are.
+func partitionResourceGroups(
+	resourceGroups []string, shared map[string]struct{},
+) (exclusive []string, inShared []string) {
+	for _, resourceGroup := range resourceGroups {
+		if _, has := shared[resourceGroup]; has {
+			inShared = append(inShared, resourceGroup)
+		} else {
+			exclusive = append(exclusive, resourceGroup)
+		}
+	}
+
+	return exclusive, inShared
+}
+
+// deploymentResources returns the ids of the resources a deployment created. They are the output resources of a
+// succeeded deployment, and the resources of the create operations of a failed one, whose output resources are not set.
+func (p *BicepProvider) deploymentResources(
+	ctx context.Context,
+	scope infra.Scope,
+	deployment *armresources.DeploymentExtended,
+) ([]string, error) {
+	var resourceIds []string
+	if *deployment.Properties.ProvisioningState == armresources.ProvisioningStateSucceeded {
+		for _, resource := range deployment.Properties.OutputResources {
+			if resource != nil && resource.ID != nil {
+				resourceIds = append(resourceIds, *resource.ID)
+			}
+		}
+
+		return resourceIds, nil
+	}
+
+	armDeployment, err := p.createDeploymentFromArmDeployment(scope, *deployment.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceManager := infra.NewAzureResourceManager(p.azCli, p.deploymentOperations)
+	operations, err := resourceManager.GetDeploymentResourceOperations(ctx, armDeployment, &time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations {
+		if operation.Properties.TargetResource != nil && operation.Properties.TargetResource.ID != nil &&
+			operation.Properties.ProvisioningOperation != nil &&
+			*operation.Properties.ProvisioningOperation == armresources.ProvisioningOperationCreate {
+			resourceIds = append(resourceIds, *operation.Properties.TargetResource.ID)
+		}
+	}
+
+	return resourceIds, nil
+}
+
+// resourcesInResourceGroups returns the resources of resourceIds which are in one of the given resource groups. The
+// resource groups themselves, nested deployments, and child resources of resources in the result are left out, since
+// deleting a resource deletes its child resources.
+func resourcesInResourceGroups(resourceIds []string, resourceGroups []string) []string {
+	var resources []string
+	for _, resourceId := range resourceIds {
+		resId, err := arm.ParseResourceID(resourceId)
+		if err != nil || resId.ResourceGroupName == "" ||
+			strings.EqualFold(resId.ResourceType.String(), arm.ResourceGroupResourceType.String()) ||
+			strings.EqualFold(resId.ResourceType.String(), string(infra.AzureResourceTypeDeployment)) {
+			continue
+		}
+
+		if slices.ContainsFunc(resourceGroups, func(rg string) bool { return strings.EqualFold(rg, resId.ResourceGroupName) }) {
+			resources = append(resources, resourceId)
+		}
+	}
+
+	slices.SortFunc(resources, func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) })
+	resources = slices.CompactFunc(resources, strings.EqualFold)
+
+	return slices.DeleteFunc(resources, func(resourceId string) bool {
+		return slices.ContainsFunc(resources, func(parentId string) bool {
+			return len(resourceId) > len(parentId) && strings.EqualFold(resourceId[:len(parentId)+1], parentId+"/")
+		})
+	})
+}
+
+// layerResourcesToPurge returns the resources whose soft-deleted copies are purged once they are deleted: the resources
+// of the resource groups deleted with a layer, and the resources of the layer in the resource groups it shares with
+// other layers.
+func (p *BicepProvider) layerResourcesToPurge(
+	ctx context.Context,
+	groupedResources map[string][]azcli.AzCliResource,
+	sharedResourceGroups []string,
+	layerResources []string,
+) (map[string][]azcli.AzCliResource, error) {
+	if len(layerResources) == 0 {
+		return groupedResources, nil
+	}
+
+	sharedResources, err := p.getAllResourcesToDelete(ctx, sharedResourceGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	result := maps.Clone(groupedResources)
+	for resourceGroup, resources := range sharedResources {
+		for _, resource := range resources {
+			if slices.ContainsFunc(layerResources, func(id string) bool { return strings.EqualFold(id, resource.Id) }) {
+				result[resourceGroup] = append(result[resourceGroup], resource)
+			}
+		}
+	}
+
+	return result, nil
+}
+
 func (p *BicepProvider) getAllResourcesToDelete(
 	ctx context.Context,
 	resourceGroups []string,
@@ -1082,7 +1619,7 @@ func (p *BicepProvider) getAllResourcesToDelete(
 	allResources := map[string][]azcli.AzCliResource{}
 
 	for _, resourceGroup := range resourceGroups {
-		groupResources, err := p.azCli.ListResourceGroupResources(ctx, p.env.GetSubscriptionId(), resourceGroup, nil)
+		groupResources, err := p.azCli.ListResourceGroupResources(ctx, p.scopeEnv().GetSubscriptionId(), resourceGroup, nil)
 		var errDetails *azcore.ResponseError
 		if errors.As(err, &errDetails) && errDetails.StatusCode == 404 {
 			// Resource group not found and already deleted, skip grouping for deletion
@@ -1099,14 +1636,19 @@ func (p *BicepProvider) getAllResourcesToDelete(
 	return allResources, nil
 }
 
-func generateResourceGroupsToDelete(groupedResources map[string][]azcli.AzCliResource, subId string) []string {
+func generateResourceGroupsToDelete(
+	groupedResources map[string][]azcli.AzCliResource,
+	portalUrlBase string,
+	subId string,
+) []string {
 	lines := []string{"Resource group(s) to be deleted:", ""}
 
 	for rg := range groupedResources {
 		lines = append(lines, fmt.Sprintf(
 			"  • %s: %s",
 			rg,
-			output.WithLinkFormat("https://portal.azure.com/#@/resource/subscriptions/%s/resourceGroups/%s/overview",
+			output.WithLinkFormat("%s/#@/resource/subscriptions/%s/resourceGroups/%s/overview",
+				portalUrlBase,
 				subId,
 				rg,
 			),
@@ -1120,12 +1662,30 @@ func (p *BicepProvider) destroyResourceGroups(
 	ctx context.Context,
 	options DestroyOptions,
 	groupedResources map[string][]azcli.AzCliResource,
+	scopeResources []string,
+	layerResources []string,
 	resourceCount int,
 ) error {
 	if !options.Force() {
-		p.console.MessageUxItem(ctx, &ux.MultilineMessage{
-			Lines: generateResourceGroupsToDelete(groupedResources, p.env.GetSubscriptionId())},
-		)
+		lines := generateResourceGroupsToDelete(
+			groupedResources, p.deploymentsService.PortalUrlBase(), p.scopeEnv().GetSubscriptionId())
+		if len(scopeResources) > 0 {
+			lines = append(lines, "Resource(s) outside of resource groups to be deleted:", "")
+			for _, resourceId := range scopeResources {
+				lines = append(lines, fmt.Sprintf("  • %s", resourceId))
+			}
+			lines = append(lines, "")
+		}
+
+		if len(layerResources) > 0 {
+			lines = append(lines, "Resource(s) in resource groups shared with other layers to be deleted:", "")
+			for _, resourceId := range layerResources {
+				lines = append(lines, fmt.Sprintf("  • %s", resourceId))
+			}
+			lines = append(lines, "")
+		}
+
+		p.console.MessageUxItem(ctx, &ux.MultilineMessage{Lines: lines})
 		confirmDestroy, err := p.console.Confirm(ctx, input.ConsoleOptions{
 			Message: fmt.Sprintf(
 				"Total resources to %s: %d, are you sure you want to continue?",
@@ -1151,18 +1711,59 @@ func (p *BicepProvider) destroyResourceGroups(
 			output.WithHighLightFormat(resourceGroup),
 		)
 		p.console.ShowSpinner(ctx, message, input.Step)
-		err := p.azCli.DeleteResourceGroup(ctx, p.env.GetSubscriptionId(), resourceGroup)
+		err := p.azCli.DeleteResourceGroup(ctx, p.scopeEnv().GetSubscriptionId(), resourceGroup)
 
 		p.console.StopSpinner(ctx, message, input.GetStepResultFormat(err))
 		if err != nil {
 			return err
 		}
 	}
+
+	if err := p.deleteResources(ctx, append(slices.Clone(layerResources), scopeResources...)); err != nil {
+		return err
+	}
+
 	// empty line at the end of all resource group deletion
 	p.console.Message(ctx, "")
 	return nil
 }
 
+// deleteResources deletes resources one by one: the resources which are not in a resource group, and the resources of a
+// layer in resource groups shared with other layers. Resources can depend on each other, like a policy assignment on its
+// policy definition or a web app on its plan, so resources which fail to delete are retried for as long as others are
+// deleted.
+func (p *BicepProvider) deleteResources(ctx context.Context, resourceIds []string) error {
+	remaining := resourceIds
+	for len(remaining) > 0 {
+		var failed []string
+		var errs []error
+		for _, resourceId := range remaining {
+			message := fmt.Sprintf("Deleting resource: %s", output.WithHighLightFormat(resourceId))
+			p.console.ShowSpinner(ctx, message, input.Step)
+			err := p.azCli.DeleteResource(ctx, p.scopeEnv().GetSubscriptionId(), resourceId)
+			if err != nil {
+				// Reported as a warning, since the resource is retried after the others.
+				p.console.StopSpinner(ctx, message, input.StepWarning)
+				log.Printf("deleting resource %s: %v", resourceId, err)
+				failed = append(failed, resourceId)
+				errs = append(errs, fmt.Errorf("deleting resource %s: %w", resourceId, err))
+				continue
+			}
+
+			p.console.StopSpinner(ctx, message, input.StepDone)
+		}
+
+		// Stop once no resource could be deleted, since retrying won't make progress.
+		if len(failed) == len(remaining) {
+			return errors.Join(errs...)
+		}
+
+		remaining = failed
+	}
+
+	return nil
+}
+
 func itemsCountAsText(items []itemToPurge) string {
 	count := len(items)
 	if count < 1 {
@@ -1595,22 +2196,53 @@ func (p *BicepProvider) createOutputParameters(
 	return outputParams
 }
 
-// loadParameters reads the parameters file template for environment/module specified by Options,
-// doing environment and command substitutions, and returns the values.
+// loadParameters reads the parameters file template for environment/module specified by Options, doing environment and
+// command substitutions, and returns the values. The values of the <module>.parameters.json file are overridden by the
+// values of the <module>.<envName>.parameters.json file of the environment, when there is one. The base file is optional
+// when the environment has its own file.
 func (p *BicepProvider) loadParameters(ctx context.Context) (map[string]azure.ArmParameterValue, error) {
-	parametersFilename := fmt.Sprintf("%s.parameters.json", p.options.Module)
 	parametersRoot := p.options.Path
 
 	if !filepath.IsAbs(parametersRoot) {
 		parametersRoot = filepath.Join(p.projectPath, parametersRoot)
 	}
 
-	paramFilePath := filepath.Join(parametersRoot, parametersFilename)
+	envParamFilePath := filepath.Join(
+		parametersRoot, fmt.Sprintf("%s.%s.parameters.json", p.options.Module, p.layerEnvName()))
+	envParametersBytes, err := os.ReadFile(envParamFilePath)
+	hasEnvParameters := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(envParamFilePath), err)
+	}
+
+	parameters := azure.ArmParameters{}
+
+	paramFilePath := filepath.Join(parametersRoot, fmt.Sprintf("%s.parameters.json", p.options.Module))
 	parametersBytes, err := os.ReadFile(paramFilePath)
-	if err != nil {
+	if err == nil {
+		baseParameters, err := p.evalParametersFile(ctx, parametersBytes)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(parameters, baseParameters)
+	} else if !hasEnvParameters || !errors.Is(err, os.ErrNotExist) {
 		return nil, fmt.Errorf("reading parameters.json: %w", err)
 	}
 
+	if hasEnvParameters {
+		envParameters, err := p.evalParametersFile(ctx, envParametersBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(envParamFilePath), err)
+		}
+		maps.Copy(parameters, envParameters)
+	}
+
+	return parameters, nil
+}
+
+// evalParametersFile does the environment and command substitutions in the contents of a parameters file and returns
+// its values.
+func (p *BicepProvider) evalParametersFile(ctx context.Context, parametersBytes []byte) (azure.ArmParameters, error) {
 	principalId, err := p.curPrincipal.CurrentPrincipalId(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetching current principal id: %w", err)
@@ -1621,14 +2253,14 @@ func (p *BicepProvider) loadParameters(ctx context.Context) (map[string]azure.Ar
 			return principalId
 		}
 
-		return p.env.Getenv(name)
+		return p.scopeEnv().Getenv(name)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("substituting environment variables inside parameter file: %w", err)
 	}
 
 	if cmdsubst.ContainsCommandInvocation(replaced, cmdsubst.SecretOrRandomPasswordCommandName) {
-		cmdExecutor := cmdsubst.NewSecretOrRandomPasswordExecutor(p.azCli, p.env.GetSubscriptionId())
+		cmdExecutor := cmdsubst.NewSecretOrRandomPasswordExecutor(p.azCli, p.scopeEnv().GetSubscriptionId())
 		replaced, err = cmdsubst.Eval(ctx, replaced, cmdExecutor)
 		if err != nil {
 			return nil, fmt.Errorf("substituting command output inside parameter file: %w", err)
@@ -1643,6 +2275,97 @@ func (p *BicepProvider) loadParameters(ctx context.Context) (map[string]azure.Ar
 	return armParameters.Parameters, nil
 }
 
+// compileBicepParam compiles a .bicepparam file, returning the template it uses and the values of its parameters.
+func (p *BicepProvider) compileBicepParam(
+	ctx context.Context, paramPath string, azdEnv []string,
+) (string, azure.ArmParameters, error) {
+	compiledResult, err := p.bicepCli.BuildBicepParam(ctx, paramPath, azdEnv)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to compile bicepparam template: %w", err)
+	}
+	compiled := compiledResult.Compiled
+
+	var bicepParamOutput compiledBicepParamResult
+	if err := json.Unmarshal([]byte(compiled), &bicepParamOutput); err != nil {
+		log.Printf("failed unmarshalling compiled bicepparam (err: %v), template contents:\n%s", err, compiled)
+		return "", nil, fmt.Errorf("failed unmarshalling arm template from json: %w", err)
+	}
+	compiled = bicepParamOutput.TemplateJson
+	var params azure.ArmParameterFile
+	if err := json.Unmarshal([]byte(bicepParamOutput.ParametersJson), &params); err != nil {
+		log.Printf("failed unmarshalling compiled bicepparam parameters(err: %v), template contents:\n%s", err, compiled)
+		return "", nil, fmt.Errorf("failed unmarshalling arm parameters template from json: %w", err)
+	}
+
+	return compiled, params.Parameters, nil
+}
+
+// bicepParamAssignmentRegex matches the `param <name> =` assignments of a .bicepparam file.
+var bicepParamAssignmentRegex = regexp.MustCompile(`(?m)^\s*param\s+([A-Za-z_][A-Za-z0-9_]*)\s*=`)
+
+// compileEnvironmentBicepParam compiles the .bicepparam file of an environment, which only has to assign the parameters
+// it overrides. Bicep rejects a .bicepparam file which does not assign every required parameter, so the parameters of
+// baseParameters which the file does not assign are compiled with a placeholder value, and left out of the result.
+func (p *BicepProvider) compileEnvironmentBicepParam(
+	ctx context.Context, paramPath string, baseParameters azure.ArmParameters, azdEnv []string,
+) (string, azure.ArmParameters, error) {
+	contents, err := os.ReadFile(paramPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading bicepparam file: %w", err)
+	}
+
+	assigned := map[string]bool{}
+	for _, match := range bicepParamAssignmentRegex.FindAllStringSubmatch(string(contents), -1) {
+		assigned[match[1]] = true
+	}
+
+	var unassigned []string
+	for name := range baseParameters {
+		if !assigned[name] {
+			unassigned = append(unassigned, name)
+		}
+	}
+
+	if len(unassigned) == 0 {
+		return p.compileBicepParam(ctx, paramPath, azdEnv)
+	}
+
+	slices.Sort(unassigned)
+	overlay := strings.Builder{}
+	overlay.Write(contents)
+	overlay.WriteString("\n")
+	for _, name := range unassigned {
+		overlay.WriteString(fmt.Sprintf("param %s = any(null)\n", name))
+	}
+
+	// The file is written next to the original one, so the paths of its `using` and `import` statements still resolve.
+	fileName := strings.TrimSuffix(filepath.Base(paramPath), bicepparamFileExtension)
+	overlayFile, err := os.CreateTemp(filepath.Dir(paramPath), "."+fileName+"-*"+bicepparamFileExtension)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating bicepparam file: %w", err)
+	}
+	defer os.Remove(overlayFile.Name())
+
+	_, err = overlayFile.WriteString(overlay.String())
+	if closeErr := overlayFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("writing bicepparam file: %w", err)
+	}
+
+	template, parameters, err := p.compileBicepParam(ctx, overlayFile.Name(), azdEnv)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, name := range unassigned {
+		delete(parameters, name)
+	}
+
+	return template, parameters, nil
+}
+
 type compiledBicepParamResult struct {
 	TemplateJson   string `json:"templateJson"`
 	ParametersJson string `json:"parametersJson"`
@@ -1672,24 +2395,28 @@ func (p *BicepProvider) compileBicep(
 			}
 			azdEnv = append(azdEnv, fmt.Sprintf("%s=%s", environment.PrincipalIdEnvVarName, currentPrincipalId))
 		}
-		compiledResult, err := p.bicepCli.BuildBicepParam(ctx, modulePath, azdEnv)
-		if err != nil {
-			return nil, fmt.Errorf("failed to compile bicepparam template: %w", err)
-		}
-		compiled = compiledResult.Compiled
 
-		var bicepParamOutput compiledBicepParamResult
-		if err := json.Unmarshal([]byte(compiled), &bicepParamOutput); err != nil {
-			log.Printf("failed unmarshalling compiled bicepparam (err: %v), template contents:\n%s", err, compiled)
-			return nil, fmt.Errorf("failed unmarshalling arm template from json: %w", err)
+		// The values of the <module>.<envName>.bicepparam file of the environment override the values of the
+		// <module>.bicepparam file, parameter by parameter.
+		var baseParameters azure.ArmParameters
+		basePath := filepath.Join(filepath.Dir(modulePath), p.options.Module+bicepparamFileExtension)
+		if basePath != modulePath {
+			if _, err := os.Stat(basePath); err == nil {
+				_, baseParameters, err = p.compileBicepParam(ctx, basePath, azdEnv)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
-		compiled = bicepParamOutput.TemplateJson
-		var params azure.ArmParameterFile
-		if err := json.Unmarshal([]byte(bicepParamOutput.ParametersJson), &params); err != nil {
-			log.Printf("failed unmarshalling compiled bicepparam parameters(err: %v), template contents:\n%s", err, compiled)
-			return nil, fmt.Errorf("failed unmarshalling arm parameters template from json: %w", err)
+
+		template, envParameters, err := p.compileEnvironmentBicepParam(ctx, modulePath, baseParameters, azdEnv)
+		if err != nil {
+			return nil, err
 		}
-		parameters = params.Parameters
+		compiled = template
+		parameters = azure.ArmParameters{}
+		maps.Copy(parameters, baseParameters)
+		maps.Copy(parameters, envParameters)
 	} else {
 		res, err := p.bicepCli.Build(ctx, modulePath)
 		if err != nil {
@@ -1844,15 +2571,20 @@ func (p *BicepProvider) modulePath() string {
 		infraRoot = filepath.Join(p.projectPath, infraRoot)
 	}
 
-	// Check if there's a <moduleName>.bicepparam first. It will be preferred over a <moduleName>.bicep
-	moduleFilename := moduleName + bicepparamFileExtension
-	moduleFilePath := filepath.Join(infraRoot, moduleFilename)
-	if _, err := os.Stat(moduleFilePath); err == nil {
-		return moduleFilePath
+	// Check if there's a <moduleName>.<envName>.bicepparam or a <moduleName>.bicepparam first. They will be preferred over
+	// a <moduleName>.bicep
+	for _, moduleFilename := range []string{
+		fmt.Sprintf("%s.%s%s", moduleName, p.layerEnvName(), bicepparamFileExtension),
+		moduleName + bicepparamFileExtension,
+	} {
+		moduleFilePath := filepath.Join(infraRoot, moduleFilename)
+		if _, err := os.Stat(moduleFilePath); err == nil {
+			return moduleFilePath
+		}
 	}
 
 	// fallback to .bicep
-	moduleFilename = moduleName + bicepFileExtension
+	moduleFilename := moduleName + bicepFileExtension
 	return filepath.Join(infraRoot, moduleFilename)
 }
 
@@ -1887,6 +2619,14 @@ func (p *BicepProvider) ensureParameters(
 			}
 		}
 
+		// Parameters are bound to the outputs of the layers provisioned before this one, by name.
+		if v, has := layerInput(p.options.Inputs, key); has {
+			configuredParameters[key] = azure.ArmParameterValue{
+				Value: v.Value,
+			}
+			continue
+		}
+
 		// If this parameter has a default, then there is no need for us to configure it.
 		if param.DefaultValue != nil {
 			continue
@@ -1967,6 +2707,22 @@ func (p *BicepProvider) ensureParameters(
 	return configuredParameters, nil
 }
 
+// layerInput returns the input named after the given parameter. Names are compared ignoring case, since bicep parameters
+// are usually camel cased while outputs stored in the environment are upper cased.
+func layerInput(inputs map[string]OutputParameter, name string) (OutputParameter, bool) {
+	if v, has := inputs[name]; has {
+		return v, true
+	}
+
+	for key, v := range inputs {
+		if strings.EqualFold(key, name) {
+			return v, true
+		}
+	}
+
+	return OutputParameter{}, false
+}
+
 // Convert the ARM parameters file value into a value suitable for deployment
 func armParameterFileValue(paramType ParameterType, value any, defaultValue any) any {
 	// Quick return if the value being converted is not a string
@@ -2050,6 +2806,8 @@ func NewBicepProvider(
 	azCli azcli.AzCli,
 	deploymentsService azapi.Deployments,
 	deploymentOperations azapi.DeploymentOperations,
+	deploymentStacks azapi.DeploymentStacks,
+	resourceAvailability azapi.ResourceAvailability,
 	envManager environment.Manager,
 	env *environment.Environment,
 	console input.Console,
@@ -2066,6 +2824,8 @@ func NewBicepProvider(
 		azCli:                azCli,
 		deploymentsService:   deploymentsService,
 		deploymentOperations: deploymentOperations,
+		deploymentStacks:     deploymentStacks,
+		resourceAvailability: resourceAvailability,
 		prompters:            prompters,
 		curPrincipal:         curPrincipal,
 		alphaFeatureManager:  alphaFeatureManager,
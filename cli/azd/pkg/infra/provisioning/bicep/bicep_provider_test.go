@@ -24,6 +24,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -32,6 +33,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
@@ -352,11 +354,14 @@ func createBicepProvider(t *testing.T, mockContext *mocks.MockContext) *BicepPro
 	envManager := &mockenv.MockEnvManager{}
 	envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
 
-	bicepCli, err := bicep.NewBicepCli(*mockContext.Context, mockContext.Console, mockContext.CommandRunner)
+	bicepCli, err := bicep.NewBicepCli(
+		*mockContext.Context, mockContext.Console, mockContext.CommandRunner, newToolCache(mockContext))
 	require.NoError(t, err)
 	azCli := mockazcli.NewAzCliFromMockContext(mockContext)
 	depOpService := mockazcli.NewDeploymentOperationsServiceFromMockContext(mockContext)
 	depService := mockazcli.NewDeploymentsServiceFromMockContext(mockContext)
+	stacksService := mockazcli.NewDeploymentStacksFromMockContext(mockContext)
+	availabilityService := mockazcli.NewResourceAvailabilityFromMockContext(mockContext)
 	accountManager := &mockaccount.MockAccountManager{
 		Subscriptions: []account.Subscription{
 			{
@@ -378,10 +383,12 @@ func createBicepProvider(t *testing.T, mockContext *mocks.MockContext) *BicepPro
 		azCli,
 		depService,
 		depOpService,
+		stacksService,
+		availabilityService,
 		envManager,
 		env,
 		mockContext.Console,
-		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, azCli),
+		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, azCli, cloud.AzurePublic()),
 		&mockCurrentPrincipal{},
 		mockContext.AlphaFeaturesManager,
 		clock.NewMock(),
@@ -899,7 +906,8 @@ func TestUserDefinedTypes(t *testing.T) {
 		Stderr: "",
 	})
 
-	bicepCli, err := bicep.NewBicepCli(*mockContext.Context, mockContext.Console, mockContext.CommandRunner)
+	bicepCli, err := bicep.NewBicepCli(
+		*mockContext.Context, mockContext.Console, mockContext.CommandRunner, newToolCache(mockContext))
 	require.NoError(t, err)
 	env := environment.NewWithValues("test-env", map[string]string{})
 
@@ -916,10 +924,12 @@ func TestUserDefinedTypes(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		&mockenv.MockEnvManager{},
 		env,
 		mockContext.Console,
-		prompt.NewDefaultPrompter(env, mockContext.Console, nil, nil),
+		prompt.NewDefaultPrompter(env, mockContext.Console, nil, nil, cloud.AzurePublic()),
 		&mockCurrentPrincipal{},
 		mockContext.AlphaFeaturesManager,
 		clock.NewMock(),
@@ -1212,3 +1222,73 @@ const userDefinedParamsSample = `{
 		}
 	}
 }`
+
+func TestPartitionResourceGroups(t *testing.T) {
+	exclusive, inShared := partitionResourceGroups(
+		[]string{"rg-api", "rg-shared", "rg-web"},
+		map[string]struct{}{"rg-shared": {}},
+	)
+
+	require.Equal(t, []string{"rg-api", "rg-web"}, exclusive)
+	require.Equal(t, []string{"rg-shared"}, inShared)
+}
+
+func TestResourcesInResourceGroups(t *testing.T) {
+	const rg = "/subscriptions/SUBSCRIPTION_ID/resourceGroups/"
+	resources := resourcesInResourceGroups(
+		[]string{
+			rg + "rg-shared/providers/Microsoft.Web/sites/api",
+			rg + "rg-shared/providers/Microsoft.Web/sites/api/config/appsettings",
+			rg + "RG-SHARED/providers/Microsoft.Web/serverfarms/plan",
+			rg + "rg-shared/providers/Microsoft.Web/sites/api",
+			rg + "rg-shared/providers/Microsoft.Resources/deployments/api",
+			rg + "rg-shared",
+			rg + "rg-api/providers/Microsoft.KeyVault/vaults/kv",
+			"/subscriptions/SUBSCRIPTION_ID/providers/Microsoft.Authorization/roleAssignments/ra",
+		},
+		[]string{"rg-shared"},
+	)
+
+	require.Equal(t, []string{
+		rg + "RG-SHARED/providers/Microsoft.Web/serverfarms/plan",
+		rg + "rg-shared/providers/Microsoft.Web/sites/api",
+	}, resources)
+}
+
+func TestLayerInput(t *testing.T) {
+	inputs := map[string]OutputParameter{
+		"AZURE_CONTAINER_REGISTRY_ENDPOINT": {Type: ParameterTypeString, Value: "acr.azurecr.io"},
+		"logAnalyticsWorkspaceId":           {Type: ParameterTypeString, Value: "workspace"},
+	}
+
+	v, has := layerInput(inputs, "logAnalyticsWorkspaceId")
+	require.True(t, has)
+	require.Equal(t, "workspace", v.Value)
+
+	v, has = layerInput(inputs, "azure_container_registry_endpoint")
+	require.True(t, has)
+	require.Equal(t, "acr.azurecr.io", v.Value)
+
+	_, has = layerInput(inputs, "vnetId")
+	require.False(t, has)
+}
+
+func TestResourcesOutsideResourceGroups(t *testing.T) {
+	policyAssignment := "/providers/Microsoft.Management/managementGroups/mg/providers/" +
+		"Microsoft.Authorization/policyAssignments/audit"
+	deployment := &armresources.DeploymentExtended{
+		Properties: &armresources.DeploymentPropertiesExtended{
+			OutputResources: []*armresources.ResourceReference{
+				{ID: to.Ptr(policyAssignment)},
+				{ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-app")},
+				{ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-app/providers/Microsoft.Web/sites/app")},
+			},
+		},
+	}
+
+	require.Equal(t, []string{policyAssignment}, resourcesOutsideResourceGroups(deployment))
+}
+
+func newToolCache(mockContext *mocks.MockContext) *toolcache.Cache {
+	return toolcache.NewCache(mockContext.Console, mockContext.CommandRunner)
+}
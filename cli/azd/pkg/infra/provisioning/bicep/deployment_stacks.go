@@ -0,0 +1,317 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"golang.org/x/exp/maps"
+)
+
+const cActionOnUnmanageDelete = "delete"
+
+// useDeploymentStacks returns true when the infrastructure is deployed as a deployment stack.
+func (p *BicepProvider) useDeploymentStacks() bool {
+	return p.options.DeploymentStacks != nil
+}
+
+// stackScopeId returns the resource id of the scope the deployment stack of a scope, or of a deployment, is created at.
+func stackScopeId(scope infra.Scope) (string, error) {
+	switch scope := scope.(type) {
+	case *infra.ResourceGroupScope:
+		return azure.ResourceGroupRID(scope.SubscriptionId(), scope.ResourceGroupName()), nil
+	case *infra.ResourceGroupDeployment:
+		return azure.ResourceGroupRID(scope.SubscriptionId(), scope.ResourceGroupName()), nil
+	case *infra.ManagementGroupScope:
+		return managementGroupRID(scope.ManagementGroupId()), nil
+	case *infra.ManagementGroupDeployment:
+		return managementGroupRID(scope.ManagementGroupId()), nil
+	case *infra.SubscriptionScope, *infra.SubscriptionDeployment:
+		return azure.SubscriptionRID(scope.SubscriptionId()), nil
+	default:
+		return "", errors.New("deployment stacks are not supported for tenant scoped deployments")
+	}
+}
+
+func managementGroupRID(managementGroupId string) string {
+	return fmt.Sprintf("/providers/Microsoft.Management/managementGroups/%s", managementGroupId)
+}
+
+// newDeploymentStack creates the deployment stack which deploys the compiled template to the target of the deployment.
+func (p *BicepProvider) newDeploymentStack(deploymentData *deploymentDetails) *azapi.DeploymentStack {
+	stackOptions := p.options.DeploymentStacks
+
+	denySettings := azapi.DenySettings{
+		Mode:               stackOptions.DenySettings.Mode,
+		ApplyToChildScopes: stackOptions.DenySettings.ApplyToChildScopes,
+		ExcludedPrincipals: stackOptions.DenySettings.ExcludedPrincipals,
+		ExcludedActions:    stackOptions.DenySettings.ExcludedActions,
+	}
+	if denySettings.Mode == "" {
+		denySettings.Mode = "none"
+	}
+
+	stack := &azapi.DeploymentStack{
+		Tags: p.deploymentTags(),
+		Properties: azapi.DeploymentStackProperties{
+			Template:         deploymentData.CompiledBicep.RawArmTemplate,
+			Parameters:       deploymentData.CompiledBicep.Parameters,
+			ActionOnUnmanage: p.actionOnUnmanage(),
+			DenySettings:     denySettings,
+		},
+	}
+
+	// Stacks at the scope of a resource group are created in the location of the resource group.
+	if _, isResourceGroup := deploymentData.Target.(*infra.ResourceGroupDeployment); !isResourceGroup {
+		stack.Location = p.scopeEnv().GetLocation()
+	}
+
+	return stack
+}
+
+// actionOnUnmanage returns what happens to the resources the stack stops managing. Unless configured otherwise, resources
+// and resource groups are deleted, so that deleting the stack removes everything it deployed.
+func (p *BicepProvider) actionOnUnmanage() azapi.ActionOnUnmanage {
+	configured := p.options.DeploymentStacks.ActionOnUnmanage
+	action := azapi.ActionOnUnmanage{
+		Resources:        configured.Resources,
+		ResourceGroups:   configured.ResourceGroups,
+		ManagementGroups: configured.ManagementGroups,
+	}
+
+	if action.Resources == "" {
+		action.Resources = cActionOnUnmanageDelete
+	}
+	if action.ResourceGroups == "" {
+		action.ResourceGroups = cActionOnUnmanageDelete
+	}
+
+	return action
+}
+
+// deployStack creates or updates the deployment stack of the layer being provisioned.
+func (p *BicepProvider) deployStack(
+	ctx context.Context,
+	deploymentData *deploymentDetails,
+	deployment *Deployment,
+) (*DeployResult, error) {
+	scopeId, err := stackScopeId(deploymentData.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	p.console.ShowSpinner(ctx, "Creating/Updating resources", input.Step)
+
+	stack, err := p.deploymentStacks.DeployStack(
+		ctx,
+		deploymentData.Target.SubscriptionId(),
+		scopeId,
+		p.deploymentPrefix(),
+		p.newDeploymentStack(deploymentData),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.Outputs = p.createOutputParameters(
+		deploymentData.CompiledBicep.Template.Outputs,
+		azapi.CreateDeploymentStackOutput(stack.Properties.Outputs),
+	)
+
+	return &DeployResult{
+		Deployment: deployment,
+	}, nil
+}
+
+// stackState returns the state of the deployment stack of the layer being provisioned.
+func (p *BicepProvider) stackState(
+	ctx context.Context,
+	scope infra.Scope,
+	outputs azure.ArmTemplateOutputs,
+) (*StateResult, error) {
+	stack, err := p.getStack(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	p.console.MessageUxItem(ctx, &ux.DoneMessage{
+		Message: fmt.Sprintf("Retrieving Azure deployment stack (%s)", output.WithHighLightFormat(stack.Name)),
+	})
+
+	state := State{}
+	state.Resources = make([]Resource, len(stack.Properties.Resources))
+	for idx, res := range stack.Properties.Resources {
+		state.Resources[idx] = Resource{
+			Id: res.Id,
+		}
+	}
+
+	state.Outputs = p.createOutputParameters(outputs, azapi.CreateDeploymentStackOutput(stack.Properties.Outputs))
+
+	p.console.MessageUxItem(ctx, &ux.DoneMessage{
+		Message: fmt.Sprintf("Updated %d environment variables", len(state.Outputs)),
+	})
+
+	return &StateResult{
+		State: &state,
+	}, nil
+}
+
+// getStack returns the deployment stack of the layer being provisioned, at the given scope.
+func (p *BicepProvider) getStack(ctx context.Context, scope infra.Scope) (*azapi.DeploymentStack, error) {
+	scopeId, err := stackScopeId(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	stackName := p.deploymentPrefix()
+	stack, err := p.deploymentStacks.GetStack(ctx, scope.SubscriptionId(), scopeId, stackName)
+	if errors.Is(err, azapi.ErrDeploymentStackNotFound) {
+		return nil, fmt.Errorf("no deployment stack '%s' found for environment '%s'", stackName, p.layerEnvName())
+	} else if err != nil {
+		return nil, fmt.Errorf("retrieving deployment stack: %w", err)
+	}
+
+	return stack, nil
+}
+
+// destroyStack deletes the deployment stack of the layer being provisioned, which deletes exactly the resources it
+// manages, and purges the soft-deleted resources among them.
+func (p *BicepProvider) destroyStack(
+	ctx context.Context,
+	options DestroyOptions,
+	templateOutputs azure.ArmTemplateOutputs,
+	scope infra.Scope,
+) (*DestroyResult, error) {
+	stack, err := p.getStack(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeId, err := stackScopeId(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Soft-deleted resources are looked up before the stack is deleted, while their resource groups still exist. Resource
+	// group scoped stacks deploy into a resource group they don't manage, so only the resources they manage are kept.
+	resourceGroups := stackResourceGroups(stack)
+	if rgScope, ok := scope.(*infra.ResourceGroupScope); ok {
+		resourceGroups = append(resourceGroups, rgScope.ResourceGroupName())
+	}
+
+	groupedResources, err := p.getAllResourcesToDelete(ctx, resourceGroups)
+	if err != nil {
+		return nil, fmt.Errorf("getting resources to delete: %w", err)
+	}
+	groupedResources = managedResources(groupedResources, stack)
+
+	purgeItem, err := p.itemsToPurge(ctx, groupedResources, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.Force() {
+		lines := []string{"Resource(s) managed by the deployment stack:", ""}
+		for _, res := range stack.Properties.Resources {
+			lines = append(lines, fmt.Sprintf("  • %s", res.Id))
+		}
+
+		p.console.MessageUxItem(ctx, &ux.MultilineMessage{Lines: append(lines, "")})
+		confirmDestroy, err := p.console.Confirm(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf(
+				"Total resources to %s: %d, are you sure you want to continue?",
+				output.WithErrorFormat("delete"),
+				len(stack.Properties.Resources),
+			),
+			DefaultValue: false,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for delete confirmation: %w", err)
+		}
+
+		if !confirmDestroy {
+			return nil, errors.New("user denied delete confirmation")
+		}
+	}
+
+	p.console.Message(ctx, output.WithGrayFormat("Deleting your resources can take some time.\n"))
+
+	message := fmt.Sprintf("Deleting deployment stack: %s", output.WithHighLightFormat(stack.Name))
+	p.console.ShowSpinner(ctx, message, input.Step)
+	// Down deletes everything the stack manages, whatever the stack does with resources it stops managing on provision.
+	deleteAll := azapi.ActionOnUnmanage{
+		Resources:        cActionOnUnmanageDelete,
+		ResourceGroups:   cActionOnUnmanageDelete,
+		ManagementGroups: cActionOnUnmanageDelete,
+	}
+	err = p.deploymentStacks.DeleteStack(ctx, scope.SubscriptionId(), scopeId, stack.Name, deleteAll)
+	p.console.StopSpinner(ctx, message, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf("deleting deployment stack: %w", err)
+	}
+	p.console.Message(ctx, "")
+
+	if err := p.purgeItems(ctx, purgeItem, options); err != nil {
+		return nil, fmt.Errorf("purging resources: %w", err)
+	}
+
+	return &DestroyResult{
+		InvalidatedEnvKeys: maps.Keys(p.createOutputParameters(
+			templateOutputs,
+			azapi.CreateDeploymentStackOutput(stack.Properties.Outputs),
+		)),
+	}, nil
+}
+
+// managedResources filters the given resources, grouped by resource group, to the ones managed by a deployment stack.
+func managedResources(
+	groupedResources map[string][]azcli.AzCliResource,
+	stack *azapi.DeploymentStack,
+) map[string][]azcli.AzCliResource {
+	managed := map[string]struct{}{}
+	for _, res := range stack.Properties.Resources {
+		managed[strings.ToLower(res.Id)] = struct{}{}
+	}
+
+	result := map[string][]azcli.AzCliResource{}
+	for resourceGroup, resources := range groupedResources {
+		for _, res := range resources {
+			if _, has := managed[strings.ToLower(res.Id)]; has {
+				result[resourceGroup] = append(result[resourceGroup], res)
+			}
+		}
+	}
+
+	return result
+}
+
+// stackResourceGroups returns the names of the resource groups managed by a deployment stack.
+func stackResourceGroups(stack *azapi.DeploymentStack) []string {
+	var resourceGroups []string
+	for _, res := range stack.Properties.Resources {
+		resourceId, err := arm.ParseResourceID(res.Id)
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(resourceId.ResourceType.String(), arm.ResourceGroupResourceType.String()) {
+			resourceGroups = append(resourceGroups, resourceId.Name)
+		}
+	}
+
+	return resourceGroups
+}
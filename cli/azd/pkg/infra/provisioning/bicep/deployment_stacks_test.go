@@ -0,0 +1,186 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const cTestStackPath = "/subscriptions/SUBSCRIPTION_ID/providers/Microsoft.Resources/deploymentStacks/test-env"
+
+var cTestStack = azapi.DeploymentStack{
+	Id:   cTestStackPath,
+	Name: "test-env",
+	Properties: azapi.DeploymentStackProperties{
+		ProvisioningState: "succeeded",
+		Outputs: map[string]map[string]any{
+			"websitE_URL": {"value": "http://myapp.azurewebsites.net"},
+		},
+		Resources: []azapi.DeploymentStackManagedItem{
+			{Id: "/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP"},
+			{Id: "subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP/Microsoft.Web/sites/app-123"},
+			{Id: "subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP/Microsoft.KeyVault/vaults/kv-123"},
+		},
+	},
+}
+
+func TestBicepDeployStack(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+
+	var deployedStack azapi.DeploymentStack
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPut && strings.HasSuffix(request.URL.Path, cTestStackPath)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &deployedStack))
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, cTestStack)
+	})
+
+	infraProvider := createBicepProvider(t, mockContext)
+	infraProvider.options.DeploymentStacks = &DeploymentStacksOptions{
+		DenySettings: DenySettingsOptions{
+			Mode:               "denyDelete",
+			ExcludedPrincipals: []string{"PRINCIPAL_ID"},
+		},
+		ActionOnUnmanage: ActionOnUnmanageOptions{
+			ResourceGroups: "detach",
+		},
+	}
+
+	deployResult, err := infraProvider.Deploy(*mockContext.Context)
+	require.NoError(t, err)
+	require.Equal(t, "http://myapp.azurewebsites.net", deployResult.Deployment.Outputs["WEBSITE_URL"].Value)
+	require.Equal(t, ParameterTypeString, deployResult.Deployment.Outputs["WEBSITE_URL"].Type)
+
+	require.Equal(t, "westus2", deployedStack.Location)
+	require.Equal(t, "test-env", *deployedStack.Tags["azd-env-name"])
+	require.Equal(t, azapi.ActionOnUnmanage{
+		Resources:      "delete",
+		ResourceGroups: "detach",
+	}, deployedStack.Properties.ActionOnUnmanage)
+	require.Equal(t, "denyDelete", deployedStack.Properties.DenySettings.Mode)
+	require.Equal(t, []string{"PRINCIPAL_ID"}, deployedStack.Properties.DenySettings.ExcludedPrincipals)
+	require.Equal(t, "test-env", deployedStack.Properties.Parameters["environmentName"].Value)
+}
+
+func TestBicepStackState(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	prepareStackMocks(mockContext)
+
+	infraProvider := createBicepProvider(t, mockContext)
+	infraProvider.options.DeploymentStacks = &DeploymentStacksOptions{}
+
+	stateResult, err := infraProvider.State(*mockContext.Context, nil)
+	require.NoError(t, err)
+	require.Len(t, stateResult.State.Resources, 3)
+	require.Equal(t, "http://myapp.azurewebsites.net", stateResult.State.Outputs["WEBSITE_URL"].Value)
+}
+
+func TestBicepDestroyStack(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	prepareDestroyMocks(mockContext)
+	prepareStackMocks(mockContext)
+
+	var deleteQuery string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodDelete && strings.HasSuffix(request.URL.Path, cTestStackPath)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		deleteQuery = request.URL.RawQuery
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	deletedResourceGroup := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodDelete && strings.HasSuffix(request.URL.Path, "resourcegroups/RESOURCE_GROUP")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		deletedResourceGroup = true
+		return httpRespondFn(request)
+	})
+
+	var purged []string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "/purge")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		purged = append(purged, request.URL.Path)
+		return httpRespondFn(request)
+	})
+
+	infraProvider := createBicepProvider(t, mockContext)
+	// Resources the stack stops managing are detached on provision, but down still deletes everything.
+	infraProvider.options.DeploymentStacks = &DeploymentStacksOptions{
+		ActionOnUnmanage: ActionOnUnmanageOptions{
+			Resources:      "detach",
+			ResourceGroups: "detach",
+		},
+	}
+
+	destroyResult, err := infraProvider.Destroy(*mockContext.Context, NewDestroyOptions(true, true))
+	require.NoError(t, err)
+	require.Equal(t, []string{"WEBSITE_URL"}, destroyResult.InvalidatedEnvKeys)
+
+	require.Contains(t, deleteQuery, "unmanageAction.Resources=delete")
+	require.Contains(t, deleteQuery, "unmanageAction.ResourceGroups=delete")
+	require.Contains(t, deleteQuery, "unmanageAction.ManagementGroups=delete")
+
+	// The resource groups are deleted by the stack, not one by one, and only the key vault managed by the stack is purged.
+	require.False(t, deletedResourceGroup)
+	require.Len(t, purged, 1)
+	require.Contains(t, purged[0], "deletedVaults/kv-123/purge")
+}
+
+func TestStackScopeId(t *testing.T) {
+	tests := []struct {
+		scope    infra.Scope
+		expected string
+	}{
+		{
+			scope:    infra.NewSubscriptionScope(nil, nil, "SUBSCRIPTION_ID"),
+			expected: "/subscriptions/SUBSCRIPTION_ID",
+		},
+		{
+			scope:    infra.NewResourceGroupScope(nil, nil, "SUBSCRIPTION_ID", "RESOURCE_GROUP"),
+			expected: "/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP",
+		},
+		{
+			scope:    infra.NewManagementGroupScope(nil, nil, "SUBSCRIPTION_ID", "MANAGEMENT_GROUP"),
+			expected: "/providers/Microsoft.Management/managementGroups/MANAGEMENT_GROUP",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T", test.scope), func(t *testing.T) {
+			scopeId, err := stackScopeId(test.scope)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, scopeId)
+		})
+	}
+
+	_, err := stackScopeId(infra.NewTenantScope(nil, nil, "SUBSCRIPTION_ID"))
+	require.Error(t, err)
+}
+
+func prepareStackMocks(mockContext *mocks.MockContext) {
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, cTestStackPath)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, cTestStack)
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+)
+
+// CheckDrift evaluates the template and parameters of the last completed deployment of the layer with ARM what-if. Since
+// they are the ones the resources were provisioned from, a resource what-if would modify was changed outside of azd, and a
+// resource what-if would create was deleted outside of azd. Changes made to the local template since are not drift.
+func (p *BicepProvider) CheckDrift(ctx context.Context) (*DriftResult, error) {
+	scope, err := p.layerScope(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("computing deployment scope: %w", err)
+	}
+
+	p.console.ShowSpinner(ctx, "Checking infrastructure drift", input.Step)
+
+	deployments, err := p.findCompletedDeployments(ctx, p.layerEnvName(), scope, "")
+	if err != nil {
+		return nil, fmt.Errorf("finding the last deployment: %w", err)
+	}
+
+	// Deployments are sorted from the most recent one.
+	lastDeployment := deployments[0]
+	target, err := p.createDeploymentFromArmDeployment(scope, *lastDeployment.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := target.ExportTemplate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting the template of deployment '%s': %w", *lastDeployment.Name, err)
+	}
+
+	parameters, missing := deployedParameters(lastDeployment.Properties.Parameters)
+	if len(missing) > 0 {
+		// ARM doesn't return the values of secure parameters, or Key Vault references, so these are resolved locally.
+		deploymentData, err := p.plan(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range missing {
+			if value, has := deploymentData.CompiledBicep.Parameters[name]; has {
+				parameters[name] = value
+			}
+		}
+
+		p.console.ShowSpinner(ctx, "Checking infrastructure drift", input.Step)
+	}
+
+	whatIfResult, err := p.whatIf(ctx, &deploymentDetails{
+		CompiledBicep: &compileBicepResult{
+			RawArmTemplate: template,
+			Parameters:     parameters,
+		},
+		Target: target,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DriftResult{}
+	for _, change := range whatIfResult.Properties.Changes {
+		changeType := ChangeType(convert.ToValueWithDefault((*string)(change.ChangeType), ""))
+		if changeType != ChangeTypeModify && changeType != ChangeTypeCreate {
+			continue
+		}
+
+		drifted := &DriftedResource{
+			Id:         convert.ToValueWithDefault(change.ResourceID, ""),
+			ChangeType: changeType,
+		}
+		if resourceAfter, ok := change.After.(map[string]any); ok {
+			drifted.Type, _ = resourceAfter["type"].(string)
+			drifted.Name, _ = resourceAfter["name"].(string)
+		}
+
+		if changeType == ChangeTypeModify {
+			drifted.Properties = driftedProperties("", change.Delta)
+			// Changes what-if reports as having no effect, like read-only properties, are not drift.
+			if len(drifted.Properties) == 0 {
+				continue
+			}
+		}
+
+		result.Resources = append(result.Resources, drifted)
+	}
+
+	return result, nil
+}
+
+// driftedProperties flattens the property changes reported by what-if to the properties whose deployed value differs
+// from the value in the template. The deployed value is the value before the change.
+func driftedProperties(parentPath string, delta []*armresources.WhatIfPropertyChange) []DriftedProperty {
+	var properties []DriftedProperty
+	for _, change := range delta {
+		if change == nil || change.PropertyChangeType == nil {
+			continue
+		}
+
+		path := convert.ToValueWithDefault(change.Path, "")
+		if parentPath != "" {
+			path = fmt.Sprintf("%s.%s", parentPath, path)
+		}
+
+		changeType := *change.PropertyChangeType
+		if changeType == armresources.PropertyChangeTypeNoEffect {
+			continue
+		}
+
+		if len(change.Children) > 0 &&
+			(changeType == armresources.PropertyChangeTypeModify || changeType == armresources.PropertyChangeTypeArray) {
+			properties = append(properties, driftedProperties(path, change.Children)...)
+			continue
+		}
+
+		properties = append(properties, DriftedProperty{
+			Path:     path,
+			Expected: change.After,
+			Actual:   change.Before,
+		})
+	}
+
+	return properties
+}
+
+// deployedParameters returns the parameters a deployment was deployed with, along with the names of the parameters whose
+// value ARM doesn't return.
+func deployedParameters(deployed any) (azure.ArmParameters, []string) {
+	parameters := azure.ArmParameters{}
+	var missing []string
+
+	deployedParameters, _ := deployed.(map[string]any)
+	for name, parameter := range deployedParameters {
+		parameter, _ := parameter.(map[string]any)
+		if value, has := parameter["value"]; has {
+			parameters[name] = azure.ArmParameterValue{Value: value}
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	slices.Sort(missing)
+	return parameters, missing
+}
+
+// layerScope returns the scope the layer is deployed to, which is the target scope of its template, or the scope
+// inferred from the environment when the layer has no template.
+func (p *BicepProvider) layerScope(ctx context.Context) (infra.Scope, error) {
+	modulePath := p.modulePath()
+	if _, err := os.Stat(modulePath); errors.Is(err, os.ErrNotExist) {
+		return p.inferScopeFromEnv(ctx)
+	}
+
+	compileResult, err := p.compileBicep(ctx, modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("compiling bicep template: %w", err)
+	}
+
+	return p.scopeForTemplate(ctx, compileResult.Template)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftedProperties(t *testing.T) {
+	delta := []*armresources.WhatIfPropertyChange{
+		{
+			Path:               to.Ptr("properties"),
+			PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeModify),
+			Children: []*armresources.WhatIfPropertyChange{
+				{
+					Path:               to.Ptr("siteConfig.alwaysOn"),
+					PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeModify),
+					Before:             false,
+					After:              true,
+				},
+				{
+					Path:               to.Ptr("provisioningState"),
+					PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeNoEffect),
+					Before:             "Succeeded",
+				},
+			},
+		},
+		{
+			Path:               to.Ptr("tags.owner"),
+			PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeDelete),
+			Before:             "someone",
+		},
+	}
+
+	require.Equal(t, []DriftedProperty{
+		{Path: "properties.siteConfig.alwaysOn", Expected: true, Actual: false},
+		{Path: "tags.owner", Actual: "someone"},
+	}, driftedProperties("", delta))
+
+	require.Empty(t, driftedProperties("", []*armresources.WhatIfPropertyChange{
+		{
+			Path:               to.Ptr("properties.provisioningState"),
+			PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeNoEffect),
+		},
+	}))
+}
+
+func TestDeployedParameters(t *testing.T) {
+	parameters, missing := deployedParameters(map[string]any{
+		"location":      map[string]any{"type": "String", "value": "eastus2"},
+		"replicas":      map[string]any{"type": "Int", "value": float64(2)},
+		"adminPassword": map[string]any{"type": "SecureString"},
+		"apiKey":        map[string]any{"type": "SecureString"},
+	})
+
+	require.Equal(t, azure.ArmParameters{
+		"location": {Value: "eastus2"},
+		"replicas": {Value: float64(2)},
+	}, parameters)
+	require.Equal(t, []string{"adminPassword", "apiKey"}, missing)
+
+	parameters, missing = deployedParameters(nil)
+	require.Empty(t, parameters)
+	require.Empty(t, missing)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"golang.org/x/exp/maps"
+)
+
+// parameterOverrides returns the one-off values set for the parameters of the template, which take precedence over the
+// values from the parameter files of the layer. The values of the parameters file set in the options are overridden by
+// the values of the parameters set in the options. Parameters the template doesn't declare are an error.
+func (p *BicepProvider) parameterOverrides(template azure.ArmTemplate) (azure.ArmParameters, error) {
+	overrides := azure.ArmParameters{}
+
+	if p.options.ParametersFile != "" {
+		parametersBytes, err := os.ReadFile(p.options.ParametersFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading parameters file: %w", err)
+		}
+
+		var parametersFile azure.ArmParameterFile
+		if err := json.Unmarshal(parametersBytes, &parametersFile); err != nil {
+			return nil, fmt.Errorf("parsing parameters file %s: %w", p.options.ParametersFile, err)
+		}
+
+		for key, value := range parametersFile.Parameters {
+			name, has := templateParameterName(template, key)
+			if !has {
+				return nil, fmt.Errorf(
+					"parameter '%s' of the parameters file %s is not declared by the template", key, p.options.ParametersFile)
+			}
+
+			overrides[name] = value
+		}
+	}
+
+	for key, value := range p.options.Parameters {
+		name, has := templateParameterName(template, key)
+		if !has {
+			return nil, fmt.Errorf("parameter '%s' is not declared by the template", key)
+		}
+
+		paramValue, err := parameterOverrideValue(p.mapBicepTypeToInterfaceType(template.Parameters[name].Type), value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter '%s': %w", key, err)
+		}
+
+		overrides[name] = azure.ArmParameterValue{
+			Value: paramValue,
+		}
+	}
+
+	return overrides, nil
+}
+
+// templateParameterName returns the name a parameter is declared with in the template. Like in ARM, names are compared
+// ignoring case.
+func templateParameterName(template azure.ArmTemplate, name string) (string, bool) {
+	if _, has := template.Parameters[name]; has {
+		return name, true
+	}
+
+	for key := range template.Parameters {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// parameterOverrideValue converts the value of a parameter set on the command line to the type of the parameter. Object
+// and array values are JSON.
+func parameterOverrideValue(paramType ParameterType, value string) (any, error) {
+	switch paramType {
+	case ParameterTypeBoolean:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a boolean", value)
+		}
+
+		return boolValue, nil
+	case ParameterTypeNumber:
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not an integer", value)
+		}
+
+		return intValue, nil
+	case ParameterTypeObject, ParameterTypeArray:
+		var jsonValue any
+		if err := json.Unmarshal([]byte(value), &jsonValue); err != nil {
+			return nil, fmt.Errorf("'%s' is not valid JSON: %w", value, err)
+		}
+
+		if !isValueAssignableToParameterType(paramType, jsonValue) {
+			return nil, fmt.Errorf("'%s' is not of type %s", value, paramType)
+		}
+
+		return jsonValue, nil
+	default:
+		return value, nil
+	}
+}
+
+// previewParameters returns the effective value of each parameter of the template, sorted by name. Parameters which are
+// not set use their default value.
+func previewParameters(template azure.ArmTemplate, parameters azure.ArmParameters) []*DeploymentPreviewParameter {
+	keys := maps.Keys(template.Parameters)
+	slices.Sort(keys)
+
+	previewParameters := make([]*DeploymentPreviewParameter, 0, len(keys))
+	for _, key := range keys {
+		param := template.Parameters[key]
+
+		value := param.DefaultValue
+		if configured, has := parameters[key]; has {
+			value = configured.Value
+		}
+
+		previewParameters = append(previewParameters, &DeploymentPreviewParameter{
+			Name:   key,
+			Value:  value,
+			Secure: param.Secure(),
+		})
+	}
+
+	return previewParameters
+}
@@ -0,0 +1,250 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadParametersWithEnvironmentOverlay(t *testing.T) {
+	projectPath := t.TempDir()
+	infraPath := filepath.Join(projectPath, "infra")
+	require.NoError(t, os.MkdirAll(infraPath, 0755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(infraPath, "main.parameters.json"), []byte(`{
+		"parameters": {
+			"environmentName": {"value": "${AZURE_ENV_NAME}"},
+			"sku": {"value": "B1"},
+			"principalId": {"value": "${AZURE_PRINCIPAL_ID}"}
+		}
+	}`), 0600))
+
+	provider := &BicepProvider{
+		env:          environment.NewWithValues("prod", map[string]string{"AZURE_ENV_NAME": "prod"}),
+		projectPath:  projectPath,
+		options:      Options{Path: "infra", Module: "main"},
+		curPrincipal: &mockCurrentPrincipal{},
+	}
+
+	parameters, err := provider.loadParameters(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "B1", parameters["sku"].Value)
+
+	require.NoError(t, os.WriteFile(filepath.Join(infraPath, "main.prod.parameters.json"), []byte(`{
+		"parameters": {
+			"sku": {"value": "P1v3"},
+			"replicas": {"value": 3}
+		}
+	}`), 0600))
+
+	parameters, err = provider.loadParameters(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]azure.ArmParameterValue{
+		"environmentName": {Value: "prod"},
+		"sku":             {Value: "P1v3"},
+		"replicas":        {Value: float64(3)},
+		"principalId":     {Value: "11111111-1111-1111-1111-111111111111"},
+	}, parameters)
+
+	// The base file is optional when the environment has its own parameters file.
+	require.NoError(t, os.Remove(filepath.Join(infraPath, "main.parameters.json")))
+	parameters, err = provider.loadParameters(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]azure.ArmParameterValue{
+		"sku":      {Value: "P1v3"},
+		"replicas": {Value: float64(3)},
+	}, parameters)
+
+	require.NoError(t, os.Remove(filepath.Join(infraPath, "main.prod.parameters.json")))
+	_, err = provider.loadParameters(context.Background())
+	require.Error(t, err)
+}
+
+func TestModulePathPrefersEnvironmentBicepparam(t *testing.T) {
+	projectPath := t.TempDir()
+	infraPath := filepath.Join(projectPath, "infra")
+	require.NoError(t, os.MkdirAll(infraPath, 0755))
+
+	provider := &BicepProvider{
+		env:         environment.New("prod"),
+		projectPath: projectPath,
+		options:     Options{Path: "infra", Module: "main"},
+	}
+
+	require.Equal(t, filepath.Join(infraPath, "main.bicep"), provider.modulePath())
+
+	require.NoError(t, os.WriteFile(filepath.Join(infraPath, "main.bicepparam"), nil, 0600))
+	require.Equal(t, filepath.Join(infraPath, "main.bicepparam"), provider.modulePath())
+
+	require.NoError(t, os.WriteFile(filepath.Join(infraPath, "main.prod.bicepparam"), nil, 0600))
+	require.Equal(t, filepath.Join(infraPath, "main.prod.bicepparam"), provider.modulePath())
+}
+
+func TestParameterOverrides(t *testing.T) {
+	template := azure.ArmTemplate{
+		Parameters: azure.ArmTemplateParameterDefinitions{
+			"sku":      {Type: "string"},
+			"replicas": {Type: "int"},
+			"enabled":  {Type: "bool"},
+			"tags":     {Type: "object"},
+		},
+	}
+
+	parametersFile := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(parametersFile, []byte(`{
+		"parameters": {
+			"sku": {"value": "S1"},
+			"replicas": {"value": 2}
+		}
+	}`), 0600))
+
+	provider := &BicepProvider{
+		options: Options{
+			ParametersFile: parametersFile,
+			Parameters: map[string]string{
+				"SKU":     "P1v3",
+				"enabled": "true",
+				"tags":    `{"team": "web"}`,
+			},
+		},
+	}
+
+	overrides, err := provider.parameterOverrides(template)
+	require.NoError(t, err)
+	require.Equal(t, azure.ArmParameters{
+		"sku":      {Value: "P1v3"},
+		"replicas": {Value: float64(2)},
+		"enabled":  {Value: true},
+		"tags":     {Value: map[string]any{"team": "web"}},
+	}, overrides)
+
+	provider.options = Options{Parameters: map[string]string{"replicas": "many"}}
+	_, err = provider.parameterOverrides(template)
+	require.ErrorContains(t, err, "invalid value for parameter 'replicas'")
+
+	provider.options = Options{Parameters: map[string]string{"tags": `["web"]`}}
+	_, err = provider.parameterOverrides(template)
+	require.Error(t, err)
+
+	provider.options = Options{Parameters: map[string]string{"other": "value"}}
+	_, err = provider.parameterOverrides(template)
+	require.ErrorContains(t, err, "parameter 'other' is not declared by the template")
+
+	require.NoError(t, os.WriteFile(parametersFile, []byte(`{"parameters": {"undeclared": {"value": "a"}}}`), 0600))
+	provider.options = Options{ParametersFile: parametersFile}
+	_, err = provider.parameterOverrides(template)
+	require.ErrorContains(t, err, "parameter 'undeclared' of the parameters file")
+}
+
+func TestPreviewParameters(t *testing.T) {
+	template := azure.ArmTemplate{
+		Parameters: azure.ArmTemplateParameterDefinitions{
+			"location":      {Type: "string"},
+			"sku":           {Type: "string", DefaultValue: "B1"},
+			"adminPassword": {Type: "securestring"},
+		},
+	}
+
+	parameters := previewParameters(template, azure.ArmParameters{
+		"location":      {Value: "westus2"},
+		"adminPassword": {Value: "secret"},
+	})
+
+	require.Equal(t, []*DeploymentPreviewParameter{
+		{Name: "adminPassword", Value: "secret", Secure: true},
+		{Name: "location", Value: "westus2"},
+		{Name: "sku", Value: "B1"},
+	}, parameters)
+}
+
+// fakeBicepParamCli compiles .bicepparam files of `param <name> = <json value>` lines. Like Bicep, it rejects a file which
+// does not assign every parameter of the template.
+type fakeBicepParamCli struct{}
+
+func (c *fakeBicepParamCli) Build(ctx context.Context, file string) (bicep.BuildResult, error) {
+	return bicep.BuildResult{}, errors.New("not supported")
+}
+
+func (c *fakeBicepParamCli) BuildBicepParam(ctx context.Context, file string, env []string) (bicep.BuildResult, error) {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return bicep.BuildResult{}, err
+	}
+
+	parameters := azure.ArmParameters{}
+	for _, line := range strings.Split(string(contents), "\n") {
+		name, value, found := strings.Cut(strings.TrimPrefix(line, "param "), " = ")
+		if !found {
+			continue
+		}
+
+		var parameter azure.ArmParameterValue
+		if value != "any(null)" {
+			if err := json.Unmarshal([]byte(value), &parameter.Value); err != nil {
+				return bicep.BuildResult{}, err
+			}
+		}
+		parameters[name] = parameter
+	}
+
+	for _, name := range []string{"sku", "replicas"} {
+		if _, has := parameters[name]; !has {
+			return bicep.BuildResult{}, fmt.Errorf("%s: the parameter %s is not assigned", filepath.Base(file), name)
+		}
+	}
+
+	parametersJson, err := json.Marshal(azure.ArmParameterFile{Parameters: parameters})
+	if err != nil {
+		return bicep.BuildResult{}, err
+	}
+
+	compiled, err := json.Marshal(map[string]string{
+		"templateJson":   `{"parameters": {"sku": {"type": "string"}, "replicas": {"type": "int"}}}`,
+		"parametersJson": string(parametersJson),
+	})
+
+	return bicep.BuildResult{Compiled: string(compiled)}, err
+}
+
+func TestCompileBicepMergesEnvironmentBicepparam(t *testing.T) {
+	infraPath := filepath.Join(t.TempDir(), "infra")
+	require.NoError(t, os.MkdirAll(infraPath, 0755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(infraPath, "main.bicepparam"), []byte("param sku = \"B1\"\nparam replicas = 1\n"), 0600))
+	// The environment file only sets a single parameter.
+	require.NoError(t, os.WriteFile(filepath.Join(infraPath, "main.prod.bicepparam"), []byte("param sku = \"P1v3\"\n"), 0600))
+
+	provider := &BicepProvider{
+		env: environment.NewWithValues("prod", map[string]string{
+			environment.PrincipalIdEnvVarName: "PRINCIPAL_ID",
+		}),
+		options:  Options{Path: infraPath, Module: "main"},
+		bicepCli: &fakeBicepParamCli{},
+	}
+
+	result, err := provider.compileBicep(context.Background(), provider.modulePath())
+	require.NoError(t, err)
+	require.Equal(t, azure.ArmParameters{
+		"sku":      {Value: "P1v3"},
+		"replicas": {Value: float64(1)},
+	}, result.Parameters)
+
+	// The file compiled in place of the environment file is removed.
+	entries, err := os.ReadDir(infraPath)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
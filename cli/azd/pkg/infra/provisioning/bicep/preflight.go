@@ -0,0 +1,1039 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+)
+
+// Resource types checked before a deployment, lowercased.
+const (
+	cognitiveAccountType       = "microsoft.cognitiveservices/accounts"
+	cognitiveDeploymentType    = "microsoft.cognitiveservices/accounts/deployments"
+	appServicePlanType         = "microsoft.web/serverfarms"
+	virtualMachineType         = "microsoft.compute/virtualmachines"
+	virtualMachineScaleSetType = "microsoft.compute/virtualmachinescalesets"
+	managedClusterType         = "microsoft.containerservice/managedclusters"
+	managedClusterPoolType     = "microsoft.containerservice/managedclusters/agentpools"
+	containerAppsEnvType       = "microsoft.app/managedenvironments"
+	nestedDeploymentType       = "microsoft.resources/deployments"
+)
+
+// containerAppsEnvProvider is the resource type of Container Apps environments, as registered by its provider.
+const containerAppsEnvProvider = "Microsoft.App/managedEnvironments"
+
+// skipPreflightEnvVarName is the environment variable which disables the resource availability checks, for templates
+// where the checks report resources which can be deployed anyway.
+const skipPreflightEnvVarName = "AZD_SKIP_PREFLIGHT_CHECKS"
+
+// checkResourceAvailability checks that the SKUs, quotas and model capacity needed by the resources of a template are
+// available in the given location, so a deployment which would fail with SkuNotAvailable or QuotaExceeded fails before
+// it starts. When scope is set, quotas are only checked if the environment was never deployed to it, since the
+// resources of a previous deployment already count towards the usage of the quotas.
+func (p *BicepProvider) checkResourceAvailability(
+	ctx context.Context,
+	compiled *compileBicepResult,
+	location string,
+	scope infra.Scope,
+) error {
+	if skip, err := strconv.ParseBool(os.Getenv(skipPreflightEnvVarName)); err == nil && skip {
+		log.Printf("Skipping resource availability checks since %s was set", skipPreflightEnvVarName)
+		return nil
+	}
+
+	requirements, err := templateRequirements(compiled.RawArmTemplate, compiled.Parameters, location)
+	if err != nil {
+		log.Printf("skipping resource availability checks: %v", err)
+		return nil
+	}
+
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	p.console.ShowSpinner(ctx, "Checking resource availability", input.Step)
+
+	checker := &availabilityChecker{
+		availability:   p.resourceAvailability,
+		subscriptionId: p.scopeEnv().GetSubscriptionId(),
+		checkQuota:     true,
+	}
+	if scope != nil {
+		deployments, err := p.findCompletedDeployments(ctx, p.layerEnvName(), scope, "")
+		checker.checkQuota = err != nil || len(deployments) == 0
+	}
+
+	err = checker.check(ctx, location, requirements)
+	p.console.StopSpinner(ctx, "", input.GetStepResultFormat(err))
+	if err != nil {
+		return &azcli.ErrorWithSuggestion{
+			Err: err,
+			Suggestion: fmt.Sprintf(
+				"Select a different location with `azd env set %s <location>`, or set %s=true to skip these checks.",
+				environment.LocationEnvVarName, skipPreflightEnvVarName),
+		}
+	}
+
+	return nil
+}
+
+// ensureLocationAvailability checks the resources of a template in the location just selected for the environment, and
+// offers to select another location when some of them are not available.
+func (p *BicepProvider) ensureLocationAvailability(
+	ctx context.Context,
+	compiled *compileBicepResult,
+	locationFilter prompt.LocationFilterPredicate,
+) error {
+	for {
+		location := p.env.GetLocation()
+		err := p.checkResourceAvailability(ctx, compiled, location, nil)
+
+		var availabilityErr *azapi.ResourceAvailabilityError
+		if !errors.As(err, &availabilityErr) {
+			return nil
+		}
+
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{Description: availabilityErr.Error()})
+		selectOther, err := p.console.Confirm(ctx, input.ConsoleOptions{
+			Message:      "Select a different location?",
+			DefaultValue: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !selectOther {
+			return nil
+		}
+
+		location, err = p.prompters.PromptLocation(
+			ctx, p.env.GetSubscriptionId(), "Select an Azure location to use:", locationFilter)
+		if err != nil {
+			return err
+		}
+
+		p.env.SetLocation(location)
+		if err := p.envManager.Save(ctx, p.env); err != nil {
+			return fmt.Errorf("saving location: %w", err)
+		}
+	}
+}
+
+// resourceRequirement is a resource of a template which needs a SKU, a quota or a model capacity in its location.
+type resourceRequirement struct {
+	resourceType string
+	name         string
+	location     string
+	// sku is the virtual machine size, the SKU of a Cognitive Services account or of a model deployment, or the pricing
+	// tier of an App Service plan.
+	sku string
+	// kind is the kind of a Cognitive Services account, like OpenAI.
+	kind  string
+	model azapi.ModelReference
+	// count is the number of virtual machines of the size, or the capacity of a model deployment.
+	count int64
+}
+
+// templateScope is what the expressions of a template can be resolved with: the values of its parameters and the
+// location of the deployment.
+type templateScope struct {
+	parameters map[string]any
+	location   string
+}
+
+var parameterExpression = regexp.MustCompile(`^\[parameters\('([^']+)'\)\]$`)
+
+// resolve returns the value of a template value when it is a literal, a parameter or the location of the deployment.
+// Any other expression can't be resolved before the deployment.
+func (s *templateScope) resolve(value any) (any, bool) {
+	text, isString := value.(string)
+	if !isString {
+		return value, value != nil
+	}
+
+	if strings.HasPrefix(text, "[[") {
+		return text[1:], true
+	}
+
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return text, true
+	}
+
+	switch strings.ToLower(text) {
+	case "[resourcegroup().location]", "[deployment().location]":
+		return s.location, s.location != ""
+	}
+
+	if match := parameterExpression.FindStringSubmatch(text); match != nil {
+		for name, paramValue := range s.parameters {
+			if strings.EqualFold(name, match[1]) {
+				return paramValue, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// resolveString resolves a string value of a template.
+func (s *templateScope) resolveString(value any) string {
+	resolved, ok := s.resolve(value)
+	if !ok {
+		return ""
+	}
+
+	text, _ := resolved.(string)
+	return text
+}
+
+// resolveInt resolves an integer value of a template, or returns the given default value.
+func (s *templateScope) resolveInt(value any, defaultValue int64) int64 {
+	resolved, ok := s.resolve(value)
+	if !ok {
+		return defaultValue
+	}
+
+	switch v := resolved.(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case string:
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return parsed
+		}
+	}
+
+	return defaultValue
+}
+
+// templateRequirements returns the resources of a compiled template, and of the templates of its modules, which need a
+// SKU, a quota or a model capacity in the location they are deployed to. Resources whose values depend on expressions
+// which can only be evaluated by the deployment are skipped.
+func templateRequirements(
+	rawTemplate azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+	location string,
+) ([]resourceRequirement, error) {
+	var template map[string]any
+	if err := json.Unmarshal(rawTemplate, &template); err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+
+	values := map[string]any{}
+	for name, param := range parameters {
+		values[name] = param.Value
+	}
+
+	scope := templateScope{
+		parameters: templateParameters(template, values, location),
+		location:   location,
+	}
+
+	return resourceRequirements(template, &scope), nil
+}
+
+// templateParameters returns the values of the parameters of a template, using the default value of the parameters
+// which don't have a value.
+func templateParameters(template map[string]any, values map[string]any, location string) map[string]any {
+	result := map[string]any{}
+	for name, value := range values {
+		result[name] = value
+	}
+
+	definitions, _ := template["parameters"].(map[string]any)
+
+	// Default values can reference other parameters, so they are resolved until no more values can be resolved.
+	for resolvedAny := true; resolvedAny; {
+		resolvedAny = false
+		defaultsScope := templateScope{parameters: result, location: location}
+		for name, definition := range definitions {
+			if _, has := result[name]; has {
+				continue
+			}
+
+			definitionMap, _ := definition.(map[string]any)
+			if value, ok := defaultsScope.resolve(definitionMap["defaultValue"]); ok {
+				result[name] = value
+				resolvedAny = true
+			}
+		}
+	}
+
+	return result
+}
+
+// templateResource is a resource of a template.
+type templateResource struct {
+	// symbolicName is the name of the resource in templates with symbolic names, or empty.
+	symbolicName string
+	values       map[string]any
+}
+
+// templateResources returns the resources of a template. Templates with symbolic names (languageVersion 2.0) declare
+// their resources as an object instead of an array.
+func templateResources(template map[string]any) []templateResource {
+	var resources []templateResource
+	switch declared := template["resources"].(type) {
+	case []any:
+		for _, resource := range declared {
+			if resourceMap, ok := resource.(map[string]any); ok {
+				resources = append(resources, templateResource{values: resourceMap})
+			}
+		}
+	case map[string]any:
+		for _, symbolicName := range sortedKeys(declared) {
+			if resourceMap, ok := declared[symbolicName].(map[string]any); ok {
+				resources = append(resources, templateResource{symbolicName: symbolicName, values: resourceMap})
+			}
+		}
+	}
+
+	return resources
+}
+
+func resourceRequirements(template map[string]any, scope *templateScope) []resourceRequirement {
+	resources := templateResources(template)
+	accounts := cognitiveAccountLocations(resources, scope)
+
+	var requirements []resourceRequirement
+	for _, resource := range resources {
+		resourceType, _ := resource.values["type"].(string)
+		requirements = append(requirements, resourceRequirementsOf(
+			strings.ToLower(resourceType), resource.values, scope, accounts.locationOf(resource.values, scope))...)
+	}
+
+	return requirements
+}
+
+// accountLocations are the locations of the Cognitive Services accounts of a template, by the references a model
+// deployment can use for its account: the name of the account, as a literal or as the expression which computes it,
+// and its symbolic name.
+type accountLocations struct {
+	byReference map[string]string
+	// single is the location of the only account of the template, or empty when it has none or several.
+	single string
+}
+
+func cognitiveAccountLocations(resources []templateResource, scope *templateScope) accountLocations {
+	result := accountLocations{byReference: map[string]string{}}
+	var count int
+	for _, resource := range resources {
+		resourceType, _ := resource.values["type"].(string)
+		if !strings.EqualFold(resourceType, cognitiveAccountType) {
+			continue
+		}
+
+		location := scope.resolveString(resource.values["location"])
+		if location == "" {
+			continue
+		}
+
+		count++
+		result.single = location
+		if resource.symbolicName != "" {
+			result.byReference[resource.symbolicName] = location
+		}
+
+		name, _ := resource.values["name"].(string)
+		for _, reference := range scope.references(templateExpression(name)) {
+			result.byReference[reference] = location
+		}
+	}
+
+	if count != 1 {
+		result.single = ""
+	}
+
+	return result
+}
+
+// locationOf returns the location of the account of a model deployment, found from the name of the deployment
+// ("account/deployment") or from the account it depends on. When the account is not found, the location of the only
+// account of the template is used, or the location of the deployment.
+func (a accountLocations) locationOf(deployment map[string]any, scope *templateScope) string {
+	resourceType, _ := deployment["type"].(string)
+	if !strings.EqualFold(resourceType, cognitiveDeploymentType) {
+		return ""
+	}
+
+	var parents []string
+	name, _ := deployment["name"].(string)
+	if args, ok := functionArguments(templateExpression(name), "format"); ok && len(args) > 1 {
+		if format, isLiteral := stringLiteral(args[0]); isLiteral && strings.HasPrefix(format, "{0}/") {
+			parents = append(parents, args[1])
+		}
+	} else if parent, _, found := strings.Cut(name, "/"); found && !strings.HasPrefix(name, "[") {
+		parents = append(parents, "'"+parent+"'")
+	}
+
+	dependsOn, _ := deployment["dependsOn"].([]any)
+	for _, dependency := range dependsOn {
+		dependencyText, _ := dependency.(string)
+		if !strings.HasPrefix(dependencyText, "[") {
+			if location, has := a.byReference[dependencyText]; has {
+				return location
+			}
+			continue
+		}
+
+		args, ok := functionArguments(templateExpression(dependencyText), "resourceId")
+		if ok && len(args) > 1 {
+			if accountType, isLiteral := stringLiteral(args[len(args)-2]); isLiteral &&
+				strings.EqualFold(accountType, cognitiveAccountType) {
+				parents = append(parents, args[len(args)-1])
+			}
+		}
+	}
+
+	for _, parent := range parents {
+		for _, reference := range scope.references(parent) {
+			if location, has := a.byReference[reference]; has {
+				return location
+			}
+		}
+	}
+
+	if a.single != "" {
+		return a.single
+	}
+
+	return scope.location
+}
+
+// templateExpression returns the expression of a template value, the value without its brackets, or the value as a
+// string literal when it is not an expression.
+func templateExpression(value string) string {
+	if strings.HasPrefix(value, "[") && !strings.HasPrefix(value, "[[") && strings.HasSuffix(value, "]") {
+		return strings.TrimSpace(value[1 : len(value)-1])
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// references returns the ways an expression of a template can be matched with another: its text and, when it can be
+// resolved, its value.
+func (s *templateScope) references(expression string) []string {
+	references := []string{expression}
+	if literal, isLiteral := stringLiteral(expression); isLiteral {
+		return append(references, literal)
+	}
+
+	if value := s.resolveString("[" + expression + "]"); value != "" {
+		references = append(references, value)
+	}
+
+	return references
+}
+
+// stringLiteral returns the value of an expression which is a string literal, like 'name'.
+func stringLiteral(expression string) (string, bool) {
+	if len(expression) < 2 || !strings.HasPrefix(expression, "'") || !strings.HasSuffix(expression, "'") {
+		return "", false
+	}
+
+	return strings.ReplaceAll(expression[1:len(expression)-1], "''", "'"), true
+}
+
+// functionArguments returns the arguments of an expression which is a call to the given function, like the arguments
+// of format('{0}/{1}', parameters('name'), 'chat').
+func functionArguments(expression string, function string) ([]string, bool) {
+	if len(expression) <= len(function)+1 ||
+		!strings.EqualFold(expression[:len(function)+1], function+"(") || !strings.HasSuffix(expression, ")") {
+		return nil, false
+	}
+
+	inner := expression[len(function)+1 : len(expression)-1]
+	var args []string
+	depth, inString, start := 0, false, 0
+	for i, char := range inner {
+		switch {
+		case char == '\'':
+			inString = !inString
+		case inString:
+		case char == '(' || char == '[':
+			depth++
+		case char == ')' || char == ']':
+			depth--
+		case char == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(inner[start:i]))
+			start = i + 1
+		}
+	}
+
+	if depth != 0 || inString {
+		return nil, false
+	}
+
+	return append(args, strings.TrimSpace(inner[start:])), true
+}
+
+func resourceRequirementsOf(
+	resourceType string,
+	resource map[string]any,
+	scope *templateScope,
+	// accountLocation is the location of the account of a model deployment.
+	accountLocation string,
+) []resourceRequirement {
+	name := scope.resolveString(resource["name"])
+	location := scope.resolveString(resource["location"])
+	if location == "" {
+		location = scope.location
+	}
+
+	properties, _ := resource["properties"].(map[string]any)
+	sku, _ := resource["sku"].(map[string]any)
+	requirement := resourceRequirement{
+		resourceType: resourceType,
+		name:         name,
+		location:     location,
+	}
+
+	switch resourceType {
+	case nestedDeploymentType:
+		return nestedDeploymentRequirements(properties, scope)
+	case cognitiveAccountType:
+		requirement.kind = scope.resolveString(resource["kind"])
+		requirement.sku = scope.resolveString(sku["name"])
+		if requirement.kind == "" || requirement.sku == "" {
+			return nil
+		}
+	case cognitiveDeploymentType:
+		model, _ := properties["model"].(map[string]any)
+		requirement.location = accountLocation
+		requirement.model = azapi.ModelReference{
+			Format:  scope.resolveString(model["format"]),
+			Name:    scope.resolveString(model["name"]),
+			Version: scope.resolveString(model["version"]),
+		}
+		requirement.sku = scope.resolveString(sku["name"])
+		if requirement.sku == "" {
+			requirement.sku = "Standard"
+		}
+		requirement.count = scope.resolveInt(sku["capacity"], 1)
+		if requirement.model.Format == "" || requirement.model.Name == "" {
+			return nil
+		}
+	case appServicePlanType:
+		requirement.sku = appServiceTier(scope.resolveString(sku["tier"]), scope.resolveString(sku["name"]))
+		if requirement.sku == "" {
+			return nil
+		}
+	case virtualMachineType:
+		hardwareProfile, _ := properties["hardwareProfile"].(map[string]any)
+		requirement.sku = scope.resolveString(hardwareProfile["vmSize"])
+		requirement.count = 1
+		if requirement.sku == "" {
+			return nil
+		}
+	case virtualMachineScaleSetType:
+		requirement.sku = scope.resolveString(sku["name"])
+		requirement.count = scope.resolveInt(sku["capacity"], 1)
+		if requirement.sku == "" {
+			return nil
+		}
+	case managedClusterType:
+		pools, _ := properties["agentPoolProfiles"].([]any)
+		var requirements []resourceRequirement
+		for _, pool := range pools {
+			poolMap, _ := pool.(map[string]any)
+			poolRequirement := requirement
+			poolRequirement.sku = scope.resolveString(poolMap["vmSize"])
+			poolRequirement.count = scope.resolveInt(poolMap["count"], 1)
+			if poolRequirement.sku != "" {
+				requirements = append(requirements, poolRequirement)
+			}
+		}
+
+		return requirements
+	case managedClusterPoolType:
+		requirement.sku = scope.resolveString(properties["vmSize"])
+		requirement.count = scope.resolveInt(properties["count"], 1)
+		if requirement.sku == "" {
+			return nil
+		}
+	case containerAppsEnvType:
+	default:
+		return nil
+	}
+
+	return []resourceRequirement{requirement}
+}
+
+// nestedDeploymentRequirements returns the requirements of a module, a nested deployment with an inline template.
+func nestedDeploymentRequirements(properties map[string]any, scope *templateScope) []resourceRequirement {
+	template, _ := properties["template"].(map[string]any)
+	if template == nil {
+		return nil
+	}
+
+	values := map[string]any{}
+	parameters, _ := properties["parameters"].(map[string]any)
+	for name, param := range parameters {
+		paramMap, _ := param.(map[string]any)
+		if value, ok := scope.resolve(paramMap["value"]); ok {
+			values[name] = value
+		}
+	}
+
+	nestedScope := templateScope{
+		parameters: templateParameters(template, values, scope.location),
+		location:   scope.location,
+	}
+
+	return resourceRequirements(template, &nestedScope)
+}
+
+// appServiceTiers maps the prefix of the SKU name of an App Service plan to its pricing tier. Longer prefixes go first.
+var appServiceTiers = []struct {
+	pattern *regexp.Regexp
+	tier    string
+}{
+	{regexp.MustCompile(`(?i)^P\d+mv3$`), "PremiumMV3"},
+	{regexp.MustCompile(`(?i)^P\d+v3$`), "PremiumV3"},
+	{regexp.MustCompile(`(?i)^P\d+v2$`), "PremiumV2"},
+	{regexp.MustCompile(`(?i)^P\d+$`), "Premium"},
+	{regexp.MustCompile(`(?i)^I\d+v2$`), "IsolatedV2"},
+	{regexp.MustCompile(`(?i)^I\d+$`), "Isolated"},
+	{regexp.MustCompile(`(?i)^EP\d+$`), "ElasticPremium"},
+	{regexp.MustCompile(`(?i)^FC\d+$`), "FlexConsumption"},
+	{regexp.MustCompile(`(?i)^Y\d+$`), "Dynamic"},
+	{regexp.MustCompile(`(?i)^S\d+$`), "Standard"},
+	{regexp.MustCompile(`(?i)^B\d+$`), "Basic"},
+	{regexp.MustCompile(`(?i)^D\d+$`), "Shared"},
+	{regexp.MustCompile(`(?i)^F\d+$`), "Free"},
+}
+
+// appServiceTier returns the pricing tier of an App Service plan from its SKU tier or, when the tier is not set, from
+// its SKU name.
+func appServiceTier(tier string, name string) string {
+	if tier != "" {
+		return tier
+	}
+
+	for _, appServiceTier := range appServiceTiers {
+		if appServiceTier.pattern.MatchString(name) {
+			return appServiceTier.tier
+		}
+	}
+
+	return ""
+}
+
+// availabilityChecker checks the requirements of a template against the SKUs, quotas and model capacity available to
+// the subscription. The checks are best effort: when the availability can't be read, the requirement is not checked.
+type availabilityChecker struct {
+	availability   azapi.ResourceAvailability
+	subscriptionId string
+	// checkQuota is false when the resources of the template may already exist, since they already count towards the
+	// usage of the quotas.
+	checkQuota bool
+
+	allComputeSkus []azapi.ResourceSku
+}
+
+// check returns a *azapi.ResourceAvailabilityError listing the requirements which can't be met in the given location.
+func (c *availabilityChecker) check(
+	ctx context.Context,
+	location string,
+	requirements []resourceRequirement,
+) error {
+	var violations []azapi.ResourceAvailabilityViolation
+	violations = append(violations, c.checkVirtualMachines(ctx, requirements)...)
+	violations = append(violations, c.checkCognitiveAccounts(ctx, requirements)...)
+	violations = append(violations, c.checkModelDeployments(ctx, requirements)...)
+	violations = append(violations, c.checkAppServicePlans(ctx, requirements)...)
+	violations = append(violations, c.checkContainerAppsEnvironments(ctx, requirements)...)
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &azapi.ResourceAvailabilityError{
+		Location:   location,
+		Violations: violations,
+	}
+}
+
+func (c *availabilityChecker) checkVirtualMachines(
+	ctx context.Context,
+	requirements []resourceRequirement,
+) []azapi.ResourceAvailabilityViolation {
+	byLocation := map[string][]resourceRequirement{}
+	for _, requirement := range requirements {
+		switch requirement.resourceType {
+		case virtualMachineType, virtualMachineScaleSetType, managedClusterType, managedClusterPoolType:
+			location := azapi.NormalizeLocation(requirement.location)
+			byLocation[location] = append(byLocation[location], requirement)
+		}
+	}
+
+	var violations []azapi.ResourceAvailabilityViolation
+	for _, location := range sortedKeys(byLocation) {
+		skus, err := c.availability.ComputeSkus(ctx, c.subscriptionId, location)
+		if err != nil {
+			log.Printf("skipping virtual machine size checks in %s: %v", location, err)
+			continue
+		}
+
+		// vCPUs needed by each virtual machine family
+		vCpus := map[string]int64{}
+		for _, requirement := range byLocation[location] {
+			idx := slices.IndexFunc(skus, func(sku azapi.ResourceSku) bool {
+				return strings.EqualFold(sku.ResourceType, "virtualMachines") && strings.EqualFold(sku.Name, requirement.sku)
+			})
+			if idx == -1 || !skus[idx].AvailableIn(location) {
+				violations = append(violations, azapi.ResourceAvailabilityViolation{
+					Code:         "SkuNotAvailable",
+					ResourceType: requirement.resourceType,
+					ResourceName: requirement.name,
+					Message: fmt.Sprintf(
+						"the virtual machine size %s is not available in %s", requirement.sku, location),
+					AlternativeLocations: c.virtualMachineSizeLocations(ctx, requirement.sku),
+				})
+				continue
+			}
+
+			if value, has := skus[idx].Capability("vCPUs"); has {
+				if cores, err := strconv.ParseInt(value, 10, 64); err == nil {
+					vCpus[skus[idx].Family] += cores * requirement.count
+				}
+			}
+		}
+
+		if c.checkQuota && len(vCpus) > 0 {
+			violations = append(violations, c.checkComputeQuota(ctx, location, vCpus)...)
+		}
+	}
+
+	return violations
+}
+
+// checkComputeQuota checks the vCPUs needed by each virtual machine family, and in total, against the compute quotas
+// of a location.
+func (c *availabilityChecker) checkComputeQuota(
+	ctx context.Context,
+	location string,
+	vCpus map[string]int64,
+) []azapi.ResourceAvailabilityViolation {
+	usages, err := c.availability.ComputeUsages(ctx, c.subscriptionId, location)
+	if err != nil {
+		log.Printf("skipping compute quota checks in %s: %v", location, err)
+		return nil
+	}
+
+	var total int64
+	for _, cores := range vCpus {
+		total += cores
+	}
+
+	needed := map[string]int64{"cores": total}
+	for family, cores := range vCpus {
+		needed[family] = cores
+	}
+
+	var violations []azapi.ResourceAvailabilityViolation
+	for _, quota := range sortedKeys(needed) {
+		idx := slices.IndexFunc(usages, func(usage azapi.ResourceUsage) bool {
+			return strings.EqualFold(usage.Name.Value, quota)
+		})
+		if idx == -1 {
+			continue
+		}
+
+		usage := usages[idx]
+		if usage.CurrentValue+needed[quota] > usage.Limit {
+			violations = append(violations, azapi.ResourceAvailabilityViolation{
+				Code:         "QuotaExceeded",
+				ResourceType: "Microsoft.Compute/usages",
+				ResourceName: usage.Name.LocalizedValue,
+				Message: fmt.Sprintf(
+					"%d vCPUs are needed but only %d of %d are available in %s",
+					needed[quota], max(usage.Limit-usage.CurrentValue, 0), usage.Limit, location),
+			})
+		}
+	}
+
+	return violations
+}
+
+// virtualMachineSizeLocations returns the locations where a virtual machine size is available.
+func (c *availabilityChecker) virtualMachineSizeLocations(ctx context.Context, size string) []string {
+	if c.allComputeSkus == nil {
+		skus, err := c.availability.ComputeSkus(ctx, c.subscriptionId, "")
+		if err != nil {
+			log.Printf("listing the locations of virtual machine sizes: %v", err)
+			return nil
+		}
+
+		c.allComputeSkus = skus
+	}
+
+	var locations []string
+	for _, sku := range c.allComputeSkus {
+		if strings.EqualFold(sku.ResourceType, "virtualMachines") && strings.EqualFold(sku.Name, size) {
+			for _, location := range sku.Locations {
+				if sku.AvailableIn(location) {
+					locations = append(locations, azapi.NormalizeLocation(location))
+				}
+			}
+		}
+	}
+
+	return sortedUnique(locations)
+}
+
+func (c *availabilityChecker) checkCognitiveAccounts(
+	ctx context.Context,
+	requirements []resourceRequirement,
+) []azapi.ResourceAvailabilityViolation {
+	var skus []azapi.ResourceSku
+	var violations []azapi.ResourceAvailabilityViolation
+	for _, requirement := range requirements {
+		if requirement.resourceType != cognitiveAccountType {
+			continue
+		}
+
+		if skus == nil {
+			var err error
+			if skus, err = c.availability.CognitiveServicesSkus(ctx, c.subscriptionId); err != nil {
+				log.Printf("skipping cognitive services checks: %v", err)
+				return nil
+			}
+		}
+
+		var locations []string
+		for _, sku := range skus {
+			if !strings.EqualFold(sku.ResourceType, "accounts") ||
+				!strings.EqualFold(sku.Kind, requirement.kind) ||
+				!strings.EqualFold(sku.Name, requirement.sku) {
+				continue
+			}
+
+			for _, location := range sku.Locations {
+				if sku.AvailableIn(location) {
+					locations = append(locations, azapi.NormalizeLocation(location))
+				}
+			}
+		}
+
+		locations = sortedUnique(locations)
+		if !slices.Contains(locations, azapi.NormalizeLocation(requirement.location)) {
+			violations = append(violations, azapi.ResourceAvailabilityViolation{
+				Code:         "SkuNotAvailable",
+				ResourceType: requirement.resourceType,
+				ResourceName: requirement.name,
+				Message: fmt.Sprintf("the %s account SKU %s is not available in %s",
+					requirement.kind, requirement.sku, requirement.location),
+				AlternativeLocations: locations,
+			})
+		}
+	}
+
+	return violations
+}
+
+func (c *availabilityChecker) checkModelDeployments(
+	ctx context.Context,
+	requirements []resourceRequirement,
+) []azapi.ResourceAvailabilityViolation {
+	// The capacity needed by the deployments of a model with the same SKU in the same location is added up.
+	type modelKey struct {
+		model    azapi.ModelReference
+		sku      string
+		location string
+	}
+
+	needed := map[modelKey]int64{}
+	names := map[modelKey][]string{}
+	var keys []modelKey
+	for _, requirement := range requirements {
+		if requirement.resourceType != cognitiveDeploymentType {
+			continue
+		}
+
+		key := modelKey{requirement.model, requirement.sku, azapi.NormalizeLocation(requirement.location)}
+		if _, has := needed[key]; !has {
+			keys = append(keys, key)
+		}
+
+		needed[key] += requirement.count
+		if requirement.name != "" {
+			names[key] = append(names[key], requirement.name)
+		}
+	}
+
+	capacities := map[azapi.ModelReference][]azapi.ModelCapacity{}
+	var violations []azapi.ResourceAvailabilityViolation
+	for _, key := range keys {
+		modelCapacities, has := capacities[key.model]
+		if !has {
+			var err error
+			modelCapacities, err = c.availability.ModelCapacities(ctx, c.subscriptionId, key.model)
+			if err != nil {
+				log.Printf("skipping capacity checks of model %s: %v", key.model.Name, err)
+			}
+
+			capacities[key.model] = modelCapacities
+			if err != nil {
+				continue
+			}
+		}
+
+		var alternatives []string
+		available, offered := int64(0), false
+		for _, capacity := range modelCapacities {
+			if !strings.EqualFold(capacity.Properties.SkuName, key.sku) {
+				continue
+			}
+
+			location := azapi.NormalizeLocation(capacity.Location)
+			if location == key.location {
+				available, offered = int64(capacity.Properties.AvailableCapacity), true
+			} else if int64(capacity.Properties.AvailableCapacity) >= needed[key] {
+				alternatives = append(alternatives, location)
+			}
+		}
+
+		model := key.model.Name
+		if key.model.Version != "" {
+			model = fmt.Sprintf("%s (%s)", key.model.Name, key.model.Version)
+		}
+
+		violation := azapi.ResourceAvailabilityViolation{
+			ResourceType:         cognitiveDeploymentType,
+			ResourceName:         strings.Join(names[key], ", "),
+			AlternativeLocations: sortedUnique(alternatives),
+		}
+
+		switch {
+		case !offered:
+			violation.Code = "SkuNotAvailable"
+			violation.Message = fmt.Sprintf(
+				"the model %s is not available with the %s SKU in %s", model, key.sku, key.location)
+		case c.checkQuota && needed[key] > available:
+			violation.Code = "QuotaExceeded"
+			violation.Message = fmt.Sprintf(
+				"a capacity of %d is needed for the model %s with the %s SKU but only %d is available in %s",
+				needed[key], model, key.sku, available, key.location)
+		default:
+			continue
+		}
+
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
+
+func (c *availabilityChecker) checkAppServicePlans(
+	ctx context.Context,
+	requirements []resourceRequirement,
+) []azapi.ResourceAvailabilityViolation {
+	tierLocations := map[string][]string{}
+	var violations []azapi.ResourceAvailabilityViolation
+	for _, requirement := range requirements {
+		if requirement.resourceType != appServicePlanType {
+			continue
+		}
+
+		locations, has := tierLocations[requirement.sku]
+		if !has {
+			var err error
+			locations, err = c.availability.AppServiceLocations(ctx, c.subscriptionId, requirement.sku)
+			if err != nil {
+				log.Printf("skipping app service checks of tier %s: %v", requirement.sku, err)
+			}
+
+			tierLocations[requirement.sku] = locations
+			if err != nil {
+				continue
+			}
+		}
+
+		if !slices.Contains(locations, azapi.NormalizeLocation(requirement.location)) {
+			violations = append(violations, azapi.ResourceAvailabilityViolation{
+				Code:         "SkuNotAvailable",
+				ResourceType: requirement.resourceType,
+				ResourceName: requirement.name,
+				Message: fmt.Sprintf(
+					"the App Service tier %s is not available in %s", requirement.sku, requirement.location),
+				AlternativeLocations: sortedUnique(locations),
+			})
+		}
+	}
+
+	return violations
+}
+
+func (c *availabilityChecker) checkContainerAppsEnvironments(
+	ctx context.Context,
+	requirements []resourceRequirement,
+) []azapi.ResourceAvailabilityViolation {
+	var locations []string
+	var violations []azapi.ResourceAvailabilityViolation
+	for _, requirement := range requirements {
+		if requirement.resourceType != containerAppsEnvType {
+			continue
+		}
+
+		if locations == nil {
+			var err error
+			locations, err = c.availability.ResourceTypeLocations(ctx, c.subscriptionId, containerAppsEnvProvider)
+			if err != nil {
+				log.Printf("skipping container apps environment checks: %v", err)
+				return nil
+			}
+		}
+
+		if !slices.Contains(locations, azapi.NormalizeLocation(requirement.location)) {
+			violations = append(violations, azapi.ResourceAvailabilityViolation{
+				Code:         "LocationNotAvailableForResourceType",
+				ResourceType: requirement.resourceType,
+				ResourceName: requirement.name,
+				Message: fmt.Sprintf(
+					"Container Apps environments are not available in %s", requirement.location),
+				AlternativeLocations: sortedUnique(locations),
+			})
+		}
+	}
+
+	return violations
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+func sortedUnique(values []string) []string {
+	result := slices.Clone(values)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
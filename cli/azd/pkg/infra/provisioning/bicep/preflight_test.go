@@ -0,0 +1,317 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
+	"github.com/stretchr/testify/require"
+)
+
+const preflightTemplate = `{
+	"parameters": {
+		"location": { "type": "string" },
+		"openAiLocation": { "type": "string", "defaultValue": "[parameters('location')]" },
+		"planSku": { "type": "string", "defaultValue": "P1v3" }
+	},
+	"resources": [
+		{
+			"type": "Microsoft.Web/serverfarms",
+			"name": "plan",
+			"location": "[parameters('location')]",
+			"sku": { "name": "[parameters('planSku')]" }
+		},
+		{
+			"type": "Microsoft.Resources/deployments",
+			"name": "openai",
+			"properties": {
+				"parameters": {
+					"location": { "value": "[parameters('openAiLocation')]" },
+					"capacity": { "value": 30 }
+				},
+				"template": {
+					"languageVersion": "2.0",
+					"parameters": {
+						"location": { "type": "string" },
+						"capacity": { "type": "int" }
+					},
+					"resources": {
+						"account": {
+							"type": "Microsoft.CognitiveServices/accounts",
+							"name": "[format('{0}-openai', uniqueString(resourceGroup().id))]",
+							"location": "[parameters('location')]",
+							"kind": "OpenAI",
+							"sku": { "name": "S0" }
+						},
+						"chat": {
+							"type": "Microsoft.CognitiveServices/accounts/deployments",
+							"name": "[format('{0}/chat', format('{0}-openai', uniqueString(resourceGroup().id)))]",
+							"sku": { "name": "Standard", "capacity": "[parameters('capacity')]" },
+							"properties": {
+								"model": { "format": "OpenAI", "name": "gpt-4o", "version": "2024-05-13" }
+							}
+						}
+					}
+				}
+			}
+		},
+		{
+			"type": "Microsoft.Compute/virtualMachines",
+			"name": "vm",
+			"location": "[resourceGroup().location]",
+			"properties": { "hardwareProfile": { "vmSize": "Standard_D4s_v3" } }
+		},
+		{
+			"type": "Microsoft.App/managedEnvironments",
+			"name": "[parameters('unknown')]",
+			"location": "[parameters('location')]"
+		}
+	]
+}`
+
+func TestTemplateRequirements(t *testing.T) {
+	requirements, err := templateRequirements(
+		azure.RawArmTemplate(preflightTemplate),
+		azure.ArmParameters{
+			"location": {Value: "westus"},
+		},
+		"westus",
+	)
+	require.NoError(t, err)
+	require.Equal(t, []resourceRequirement{
+		{
+			resourceType: appServicePlanType,
+			name:         "plan",
+			location:     "westus",
+			sku:          "PremiumV3",
+		},
+		{
+			resourceType: cognitiveAccountType,
+			location:     "westus",
+			sku:          "S0",
+			kind:         "OpenAI",
+		},
+		{
+			resourceType: cognitiveDeploymentType,
+			location:     "westus",
+			sku:          "Standard",
+			model:        azapi.ModelReference{Format: "OpenAI", Name: "gpt-4o", Version: "2024-05-13"},
+			count:        30,
+		},
+		{
+			resourceType: virtualMachineType,
+			name:         "vm",
+			location:     "westus",
+			sku:          "Standard_D4s_v3",
+			count:        1,
+		},
+		{
+			resourceType: containerAppsEnvType,
+			location:     "westus",
+		},
+	}, requirements)
+}
+
+func TestTemplateRequirementsModelDeploymentAccount(t *testing.T) {
+	template := `{
+		"parameters": {
+			"eastAccount": { "type": "string", "defaultValue": "east-openai" }
+		},
+		"resources": [
+			{
+				"type": "Microsoft.CognitiveServices/accounts",
+				"name": "[parameters('eastAccount')]",
+				"location": "eastus",
+				"kind": "OpenAI",
+				"sku": { "name": "S0" }
+			},
+			{
+				"type": "Microsoft.CognitiveServices/accounts",
+				"name": "[format('{0}-openai', uniqueString(resourceGroup().id))]",
+				"location": "swedencentral",
+				"kind": "OpenAI",
+				"sku": { "name": "S0" }
+			},
+			{
+				"type": "Microsoft.CognitiveServices/accounts/deployments",
+				"name": "[format('{0}/{1}', format('{0}-openai', uniqueString(resourceGroup().id)), 'chat')]",
+				"properties": { "model": { "format": "OpenAI", "name": "gpt-4o" } }
+			},
+			{
+				"type": "Microsoft.CognitiveServices/accounts/deployments",
+				"name": "east-openai/embeddings",
+				"properties": { "model": { "format": "OpenAI", "name": "text-embedding-3-large" } }
+			},
+			{
+				"type": "Microsoft.CognitiveServices/accounts/deployments",
+				"name": "[concat(parameters('eastAccount'), '/mini')]",
+				"dependsOn": [
+					"[resourceId('Microsoft.CognitiveServices/accounts', parameters('eastAccount'))]"
+				],
+				"properties": { "model": { "format": "OpenAI", "name": "gpt-4o-mini" } }
+			}
+		]
+	}`
+
+	requirements, err := templateRequirements(azure.RawArmTemplate(template), azure.ArmParameters{}, "westus")
+	require.NoError(t, err)
+
+	locations := map[string]string{}
+	for _, requirement := range requirements {
+		if requirement.resourceType == cognitiveDeploymentType {
+			locations[requirement.model.Name] = requirement.location
+		}
+	}
+
+	require.Equal(t, map[string]string{
+		"gpt-4o":                 "swedencentral",
+		"text-embedding-3-large": "eastus",
+		"gpt-4o-mini":            "eastus",
+	}, locations)
+}
+
+func TestAppServiceTier(t *testing.T) {
+	tests := []struct {
+		tier string
+		name string
+		want string
+	}{
+		{"", "P1v3", "PremiumV3"},
+		{"", "P2mv3", "PremiumMV3"},
+		{"", "B1", "Basic"},
+		{"", "Y1", "Dynamic"},
+		{"", "FC1", "FlexConsumption"},
+		{"Standard", "S1", "Standard"},
+		{"", "unknown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, appServiceTier(tt.tier, tt.name))
+		})
+	}
+}
+
+func TestAvailabilityChecker(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockResourceAvailability(mockContext)
+
+	requirements, err := templateRequirements(
+		azure.RawArmTemplate(preflightTemplate),
+		azure.ArmParameters{
+			"location": {Value: "westus"},
+		},
+		"westus",
+	)
+	require.NoError(t, err)
+
+	t.Run("WithQuota", func(t *testing.T) {
+		checker := &availabilityChecker{
+			availability:   mockazcli.NewResourceAvailabilityFromMockContext(mockContext),
+			subscriptionId: "SUBSCRIPTION_ID",
+			checkQuota:     true,
+		}
+
+		err := checker.check(*mockContext.Context, "westus", requirements)
+		var availabilityErr *azapi.ResourceAvailabilityError
+		require.True(t, errors.As(err, &availabilityErr))
+		require.Equal(t, "westus", availabilityErr.Location)
+
+		codes := []string{}
+		for _, violation := range availabilityErr.Violations {
+			codes = append(codes, violation.Code+" "+violation.ResourceType)
+		}
+
+		require.Equal(t, []string{
+			"QuotaExceeded Microsoft.Compute/usages",
+			"QuotaExceeded " + cognitiveDeploymentType,
+			"SkuNotAvailable " + appServicePlanType,
+		}, codes)
+
+		require.Equal(t, []string{"eastus"}, availabilityErr.Violations[1].AlternativeLocations)
+		require.Equal(t, []string{"eastus", "westus2"}, availabilityErr.Violations[2].AlternativeLocations)
+		// The locations with quota for the virtual machines are not known.
+		require.Empty(t, availabilityErr.AlternativeLocations())
+	})
+
+	t.Run("WithoutQuota", func(t *testing.T) {
+		checker := &availabilityChecker{
+			availability:   mockazcli.NewResourceAvailabilityFromMockContext(mockContext),
+			subscriptionId: "SUBSCRIPTION_ID",
+		}
+
+		err := checker.check(*mockContext.Context, "westus", requirements)
+		var availabilityErr *azapi.ResourceAvailabilityError
+		require.True(t, errors.As(err, &availabilityErr))
+		require.Len(t, availabilityErr.Violations, 1)
+		require.Equal(t, "SkuNotAvailable", availabilityErr.Violations[0].Code)
+		require.Equal(t, []string{"eastus", "westus2"}, availabilityErr.AlternativeLocations())
+	})
+}
+
+func mockResourceAvailability(mockContext *mocks.MockContext) {
+	respond := func(path string, body any) {
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, path)
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, body)
+		})
+	}
+
+	respond("/providers/Microsoft.Compute/skus", map[string]any{
+		"value": []azapi.ResourceSku{
+			{
+				ResourceType: "virtualMachines",
+				Name:         "Standard_D4s_v3",
+				Family:       "standardDSv3Family",
+				Locations:    []string{"westus"},
+				Capabilities: []azapi.ResourceSkuCapability{{Name: "vCPUs", Value: "4"}},
+			},
+		},
+	})
+	respond("/providers/Microsoft.Compute/locations/westus/usages", map[string]any{
+		"value": []azapi.ResourceUsage{
+			{
+				Name:         azapi.ResourceUsageName{Value: "cores", LocalizedValue: "Total Regional vCPUs"},
+				CurrentValue: 2,
+				Limit:        10,
+			},
+			{
+				Name:         azapi.ResourceUsageName{Value: "standardDSv3Family", LocalizedValue: "Standard DSv3 Family vCPUs"},
+				CurrentValue: 2,
+				Limit:        4,
+			},
+		},
+	})
+	respond("/providers/Microsoft.CognitiveServices/skus", map[string]any{
+		"value": []azapi.ResourceSku{
+			{ResourceType: "accounts", Name: "S0", Kind: "OpenAI", Locations: []string{"WESTUS", "EASTUS"}},
+		},
+	})
+	respond("/providers/Microsoft.CognitiveServices/modelCapacities", map[string]any{
+		"value": []azapi.ModelCapacity{
+			{Location: "westus", Properties: azapi.ModelCapacityProperties{SkuName: "Standard", AvailableCapacity: 10}},
+			{Location: "eastus", Properties: azapi.ModelCapacityProperties{SkuName: "Standard", AvailableCapacity: 50}},
+			{Location: "northeurope", Properties: azapi.ModelCapacityProperties{SkuName: "Standard", AvailableCapacity: 20}},
+		},
+	})
+	respond("/providers/Microsoft.Web/geoRegions", map[string]any{
+		"value": []map[string]any{
+			{"name": "East US"},
+			{"name": "West US 2"},
+		},
+	})
+	respond("/providers/Microsoft.App", map[string]any{
+		"resourceTypes": []map[string]any{
+			{"resourceType": "managedEnvironments", "locations": []string{"West US", "East US"}},
+		},
+	})
+}
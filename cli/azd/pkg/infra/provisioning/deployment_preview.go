@@ -7,6 +7,16 @@ package provisioning
 type DeploymentPreview struct {
 	Status     string
 	Properties *DeploymentPreviewProperties
+	// Parameters are the effective values of the parameters the deployment would use.
+	Parameters []*DeploymentPreviewParameter
+}
+
+// DeploymentPreviewParameter is the value a parameter of the deployment would have.
+type DeploymentPreviewParameter struct {
+	Name  string
+	Value any
+	// Secure is true for parameters whose value must not be displayed.
+	Secure bool
 }
 
 // DeploymentPreviewProperties holds the changes for the deployment preview.
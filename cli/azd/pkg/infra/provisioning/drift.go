@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"errors"
+	"time"
+)
+
+// DriftConfigPath is the path in the config of an environment where the result of its last drift check is kept.
+const DriftConfigPath = "provision.drift"
+
+// ErrDriftDetected is returned when the deployed resources no longer match the infrastructure as code.
+var ErrDriftDetected = errors.New("infrastructure drift detected")
+
+// DriftResult lists the resources which were changed outside of azd since they were provisioned.
+type DriftResult struct {
+	// CheckedAt is when the drift was checked.
+	CheckedAt time.Time          `json:"checkedAt"`
+	Resources []*DriftedResource `json:"resources"`
+}
+
+// HasDrift returns true when at least one resource drifted.
+func (r *DriftResult) HasDrift() bool {
+	return r != nil && len(r.Resources) > 0
+}
+
+// DriftedResource is a resource which no longer matches the infrastructure as code.
+type DriftedResource struct {
+	// Layer is the infrastructure layer the resource belongs to, empty for the infrastructure of the project.
+	Layer string `json:"layer,omitempty"`
+	Id    string `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	// ChangeType is the change provisioning would make to bring the resource back in line with the infrastructure as
+	// code: ChangeTypeModify for a resource whose properties were changed and ChangeTypeCreate for a resource which was
+	// deleted.
+	ChangeType ChangeType        `json:"changeType"`
+	Properties []DriftedProperty `json:"properties,omitempty"`
+}
+
+// DriftedProperty is a property of a resource whose value differs from the value in the infrastructure as code.
+type DriftedProperty struct {
+	// Path is the dot separated path of the property in the resource, e.g. "properties.siteConfig.alwaysOn".
+	Path     string `json:"path"`
+	Expected any    `json:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty"`
+}
@@ -92,6 +92,7 @@ func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 		Preview: &DeploymentPreview{
 			Status:     deployResult.Preview.Status,
 			Properties: &DeploymentPreviewProperties{},
+			Parameters: deployResult.Preview.Parameters,
 		},
 	}
 
@@ -112,6 +113,26 @@ func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 	return &filteredResult, nil
 }
 
+// CheckDrift reports the resources which were changed outside of azd since they were provisioned.
+func (m *Manager) CheckDrift(ctx context.Context) (*DriftResult, error) {
+	driftResult, err := m.provider.CheckDrift(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error checking infrastructure drift: %w", err)
+	}
+
+	for _, res := range driftResult.Resources {
+		res.Layer = m.options.Name
+		if displayName := infra.GetResourceTypeDisplayName(infra.AzureResourceType(res.Type)); displayName != "" {
+			res.Type = displayName
+		}
+	}
+
+	// make sure any spinner is stopped
+	m.console.StopSpinner(ctx, "", input.StepDone)
+
+	return driftResult, nil
+}
+
 // Destroys the Azure infrastructure for the specified project
 func (m *Manager) Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error) {
 	destroyResult, err := m.provider.Destroy(ctx, options)
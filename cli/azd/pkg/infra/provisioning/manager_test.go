@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
@@ -217,6 +218,7 @@ func registerContainerDependencies(mockContext *mocks.MockContext, env *environm
 		return envManager
 	})
 
+	mockContext.Container.RegisterSingleton(cloud.AzurePublic)
 	mockContext.Container.RegisterSingleton(prompt.NewDefaultPrompter)
 	_ = mockContext.Container.RegisterNamedTransient(string(provisioning.Test), test.NewTestProvider)
 	mockContext.Container.RegisterSingleton(func() account.Manager {
@@ -22,8 +22,70 @@ type Options struct {
 	Provider ProviderKind `yaml:"provider,omitempty"`
 	Path     string       `yaml:"path,omitempty"`
 	Module   string       `yaml:"module,omitempty"`
+	// Name is the name of the infrastructure layer these options provision: the name of one of the named Layers of the
+	// infrastructure of the project, or the name of the service for infrastructure owned by a service. It is empty for
+	// the infrastructure of the project.
+	Name string `yaml:"name,omitempty"`
+	// Scope overrides where the resources of the layer are deployed.
+	Scope ScopeOptions `yaml:"scope,omitempty"`
+	// Environment is the name of the azd environment a shared layer belongs to. A shared layer is deployed once for that
+	// environment and reused by every environment of the project, for example a VNet or a container registry. When empty,
+	// the layer belongs to the current environment.
+	Environment string `yaml:"environment,omitempty"`
+	// Layers is the ordered list of named layers the infrastructure of the project is made of. When empty, the
+	// infrastructure is a single module, described by the other options.
+	Layers []Options `yaml:"layers,omitempty"`
+	// DeploymentStacks opts into deploying the infrastructure as an Azure Deployment Stack, so that the resources it
+	// manages are deleted together with the stack on down. Only supported by the bicep provider.
+	DeploymentStacks *DeploymentStacksOptions `yaml:"deploymentStacks,omitempty"`
 	// Not expected to be defined at azure.yaml
 	IgnoreDeploymentState bool `yaml:"-"`
+	// Inputs are the outputs of the layers provisioned before this one. Parameters of the layer which are not otherwise
+	// set are bound to the input with the same name. Not expected to be defined at azure.yaml
+	Inputs map[string]OutputParameter `yaml:"-"`
+	// Parameters are one-off values for the parameters of the layer, which take precedence over the values of its
+	// parameter files. Values are converted to the type of the parameter. Not expected to be defined at azure.yaml
+	Parameters map[string]string `yaml:"-"`
+	// ParametersFile is the path of a parameters file whose values take precedence over the values of the parameter files
+	// of the layer, for one-off overrides. Not expected to be defined at azure.yaml
+	ParametersFile string `yaml:"-"`
+}
+
+// ScopeOptions overrides the target of the deployment of an infrastructure layer.
+type ScopeOptions struct {
+	// ResourceGroup is the resource group a resource group scoped layer is deployed to, instead of AZURE_RESOURCE_GROUP.
+	// Environment variables references like ${AZURE_ENV_NAME} are expanded.
+	ResourceGroup string `yaml:"resourceGroup,omitempty"`
+}
+
+// DeploymentStacksOptions configures the deployment stack the infrastructure is deployed as.
+type DeploymentStacksOptions struct {
+	// DenySettings protects the managed resources from changes made outside of the stack.
+	DenySettings DenySettingsOptions `yaml:"denySettings,omitempty"`
+	// ActionOnUnmanage sets what happens to resources the stack stops managing. Resources are deleted when not set.
+	ActionOnUnmanage ActionOnUnmanageOptions `yaml:"actionOnUnmanage,omitempty"`
+}
+
+// DenySettingsOptions are the deny settings of a deployment stack.
+type DenySettingsOptions struct {
+	// Mode is one of "none" (the default), "denyDelete" or "denyWriteAndDelete".
+	Mode               string   `yaml:"mode,omitempty"`
+	ApplyToChildScopes bool     `yaml:"applyToChildScopes,omitempty"`
+	ExcludedPrincipals []string `yaml:"excludedPrincipals,omitempty"`
+	ExcludedActions    []string `yaml:"excludedActions,omitempty"`
+}
+
+// ActionOnUnmanageOptions sets, per kind of resource, whether resources that are no longer managed by the stack are
+// deleted ("delete") or left in place ("detach").
+type ActionOnUnmanageOptions struct {
+	Resources        string `yaml:"resources,omitempty"`
+	ResourceGroups   string `yaml:"resourceGroups,omitempty"`
+	ManagementGroups string `yaml:"managementGroups,omitempty"`
+}
+
+// IsShared returns true when the layer is shared by every environment of the project.
+func (o Options) IsShared() bool {
+	return o.Environment != ""
 }
 
 type SkippedReasonType string
@@ -44,6 +106,9 @@ type DeployPreviewResult struct {
 type DestroyResult struct {
 	// InvalidatedEnvKeys is a list of keys that should be removed from the environment after the destroy is complete.
 	InvalidatedEnvKeys []string
+	// SharedResourceGroups lists the resource groups a layer deployed into which were not deleted, because other layers
+	// also deploy into them. Only the resources the layer deployed were deleted from them.
+	SharedResourceGroups []string
 }
 
 type StateResult struct {
@@ -56,6 +121,8 @@ type Provider interface {
 	State(ctx context.Context, options *StateOptions) (*StateResult, error)
 	Deploy(ctx context.Context) (*DeployResult, error)
 	Preview(ctx context.Context) (*DeployPreviewResult, error)
+	// CheckDrift compares the deployed resources with the infrastructure as code, without changing them.
+	CheckDrift(ctx context.Context) (*DriftResult, error)
 	Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error)
 	EnsureEnv(ctx context.Context) error
 }
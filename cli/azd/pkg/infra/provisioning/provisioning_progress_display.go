@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -28,9 +29,11 @@ type ProvisioningProgressDisplay struct {
 	deploymentStarted bool
 	// Keeps track of created resources
 	displayedResources map[string]bool
-	resourceManager    infra.ResourceManager
-	console            input.Console
-	target             infra.Deployment
+	// Keeps track of the resources reported as running, by resource id
+	runningResources map[string]bool
+	resourceManager  infra.ResourceManager
+	console          input.Console
+	target           infra.Deployment
 }
 
 func NewProvisioningProgressDisplay(
@@ -40,6 +43,7 @@ func NewProvisioningProgressDisplay(
 ) ProvisioningProgressDisplay {
 	return ProvisioningProgressDisplay{
 		displayedResources: map[string]bool{},
+		runningResources:   map[string]bool{},
 		target:             target,
 		resourceManager:    rm,
 		console:            console,
@@ -134,6 +138,8 @@ func (display *ProvisioningProgressDisplay) logNewlyCreatedResources(
 			resourceTypeDisplayName = infra.GetResourceTypeDisplayName(infra.AzureResourceType(resourceTypeName))
 		}
 
+		display.reportOperation(ctx, resource, resourceTypeDisplayName)
+
 		// Don't log resource types for Azure resources that we do not have a translation of the resource type for.
 		// This will be improved on in a future iteration.
 		if resourceTypeDisplayName != "" {
@@ -178,6 +184,12 @@ func (display *ProvisioningProgressDisplay) logNewlyCreatedResources(
 		if resourceTypeDisplayName != "" {
 			inProgress = append(inProgress, resourceTypeDisplayName)
 		}
+
+		resourceId := *inProgResource.Properties.TargetResource.ID
+		if !display.runningResources[resourceId] {
+			display.reportOperation(ctx, inProgResource, resourceTypeDisplayName)
+			display.runningResources[resourceId] = true
+		}
 	}
 
 	if !display.console.IsSpinnerInteractive() {
@@ -193,3 +205,18 @@ func (display *ProvisioningProgressDisplay) logNewlyCreatedResources(
 		display.console.ShowSpinner(ctx, "Creating/Updating resources", input.Step)
 	}
 }
+
+// reportOperation writes the provisioning progress event of a resource operation.
+func (display *ProvisioningProgressDisplay) reportOperation(
+	ctx context.Context,
+	operation *armresources.DeploymentOperation,
+	resourceTypeDisplayName string,
+) {
+	display.console.Event(ctx, contracts.ProvisioningProgressEventDataType, contracts.ProvisioningProgressEvent{
+		ResourceId:   *operation.Properties.TargetResource.ID,
+		ResourceName: *operation.Properties.TargetResource.ResourceName,
+		ResourceType: *operation.Properties.TargetResource.ResourceType,
+		DisplayName:  resourceTypeDisplayName,
+		State:        *operation.Properties.ProvisioningState,
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"golang.org/x/exp/maps"
+)
+
+// CheckDrift creates a refresh-only plan, which compares the terraform state with the deployed resources without
+// planning any change to them. The resources the plan reports as drifted were changed or deleted outside of terraform.
+func (t *TerraformProvider) CheckDrift(ctx context.Context) (*DriftResult, error) {
+	isRemoteBackendConfig, err := t.isRemoteBackendConfig()
+	if err != nil {
+		return nil, fmt.Errorf("reading backend config: %w", err)
+	}
+
+	if err := t.prepareModule(ctx, isRemoteBackendConfig); err != nil {
+		return nil, err
+	}
+
+	t.console.ShowSpinner(ctx, "Checking infrastructure drift", input.Step)
+
+	modulePath := t.modulePath()
+	planArgs := append(t.createPlanArgs(isRemoteBackendConfig), "-refresh-only")
+	runResult, err := t.cli.Plan(ctx, modulePath, t.driftPlanFilePath(), planArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("terraform plan failed:%s err %w", runResult, err)
+	}
+
+	showResult, err := t.cli.Show(ctx, modulePath, t.driftPlanFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("showing refresh-only plan failed: %s, err:%w", showResult, err)
+	}
+
+	var plan terraformPlan
+	if err := json.Unmarshal([]byte(showResult), &plan); err != nil {
+		return nil, fmt.Errorf("reading refresh-only plan: %w", err)
+	}
+
+	result := &DriftResult{}
+	for _, drift := range plan.ResourceDrift {
+		if drift.Mode != terraformModeManaged {
+			continue
+		}
+
+		drifted := &DriftedResource{
+			Type: drift.Type,
+			Name: drift.Address,
+		}
+
+		if slices.Contains(drift.Change.Actions, "delete") {
+			drifted.ChangeType = ChangeTypeCreate
+		} else {
+			drifted.ChangeType = ChangeTypeModify
+			drifted.Properties = driftedAttributes(drift.Change.Before, drift.Change.After)
+			if len(drifted.Properties) == 0 {
+				continue
+			}
+		}
+
+		for _, values := range []map[string]any{drift.Change.Before, drift.Change.After} {
+			if id, has := values["id"].(string); has && drifted.Id == "" {
+				drifted.Id = id
+			}
+		}
+
+		result.Resources = append(result.Resources, drifted)
+	}
+
+	return result, nil
+}
+
+// driftedAttributes returns the top level attributes of a resource whose value in the terraform state, before the
+// refresh, differs from their deployed value, after the refresh.
+func driftedAttributes(before map[string]any, after map[string]any) []DriftedProperty {
+	keys := maps.Keys(before)
+	for key := range after {
+		if _, has := before[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var properties []DriftedProperty
+	for _, key := range keys {
+		if reflect.DeepEqual(before[key], after[key]) {
+			continue
+		}
+
+		properties = append(properties, DriftedProperty{
+			Path:     key,
+			Expected: before[key],
+			Actual:   after[key],
+		})
+	}
+
+	return properties
+}
+
+// Gets the path to the refresh-only plan file used to check drift, kept apart from the plan file applied on deploy.
+func (t *TerraformProvider) driftPlanFilePath() string {
+	planFilename := fmt.Sprintf("%s.drift.tfplan", t.options.Module)
+	return filepath.Join(t.projectPath, ".azure", t.layerEnvName(), t.options.Path, planFilename)
+}
+
+// terraformPlan is a model type for the JSON representation of a plan file, limited to the drift it reports.
+// see https://developer.hashicorp.com/terraform/internals/json-format#plan-representation for more information.
+type terraformPlan struct {
+	ResourceDrift []terraformResourceChange `json:"resource_drift"`
+}
+
+// terraformResourceChange is a model type for a change to a resource in a plan file.
+type terraformResourceChange struct {
+	Address string                `json:"address"`
+	Mode    string                `json:"mode"`
+	Type    string                `json:"type"`
+	Name    string                `json:"name"`
+	Change  terraformChangeValues `json:"change"`
+}
+
+// terraformChangeValues is a model type for the values of a resource before and after a change.
+type terraformChangeValues struct {
+	Actions []string       `json:"actions"`
+	Before  map[string]any `json:"before"`
+	After   map[string]any `json:"after"`
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:lll
+const terraformDriftPlanMockOutput = `{
+	"format_version": "1.2",
+	"resource_drift": [
+		{
+			"address": "azurerm_resource_group.rg",
+			"mode": "managed",
+			"type": "azurerm_resource_group",
+			"name": "rg",
+			"change": {
+				"actions": ["update"],
+				"before": {"id": "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env", "location": "westus2", "tags": {"azd-env-name": "test-env"}},
+				"after": {"id": "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env", "location": "westus2", "tags": {"azd-env-name": "test-env", "owner": "someone"}}
+			}
+		},
+		{
+			"address": "azurerm_key_vault.kv",
+			"mode": "managed",
+			"type": "azurerm_key_vault",
+			"name": "kv",
+			"change": {
+				"actions": ["delete"],
+				"before": {"id": "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env/providers/Microsoft.KeyVault/vaults/kv"},
+				"after": null
+			}
+		},
+		{
+			"address": "data.azurerm_client_config.current",
+			"mode": "data",
+			"type": "azurerm_client_config",
+			"name": "current",
+			"change": {"actions": ["update"], "before": {"id": "1"}, "after": {"id": "2"}}
+		}
+	]
+}`
+
+func TestTerraformCheckDrift(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+
+	var planArgs []string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "plan")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		planArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "show") && strings.Contains(command, "drift.tfplan")
+	}).Respond(exec.RunResult{
+		Stdout: terraformDriftPlanMockOutput,
+	})
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	driftResult, err := infraProvider.CheckDrift(*mockContext.Context)
+	require.NoError(t, err)
+
+	require.Contains(t, planArgs, "-refresh-only")
+	require.True(t, driftResult.HasDrift())
+	require.Len(t, driftResult.Resources, 2)
+
+	require.Equal(t, "azurerm_resource_group.rg", driftResult.Resources[0].Name)
+	require.Equal(t, "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test-env", driftResult.Resources[0].Id)
+	require.Equal(t, ChangeTypeModify, driftResult.Resources[0].ChangeType)
+	require.Equal(t, []DriftedProperty{
+		{
+			Path:     "tags",
+			Expected: map[string]any{"azd-env-name": "test-env"},
+			Actual:   map[string]any{"azd-env-name": "test-env", "owner": "someone"},
+		},
+	}, driftResult.Resources[0].Properties)
+
+	require.Equal(t, "azurerm_key_vault.kv", driftResult.Resources[1].Name)
+	require.Equal(t, ChangeTypeCreate, driftResult.Resources[1].ChangeType)
+	require.Empty(t, driftResult.Resources[1].Properties)
+}
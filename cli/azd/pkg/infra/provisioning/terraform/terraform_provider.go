@@ -3,10 +3,12 @@ package terraform
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"dario.cat/mergo"
@@ -39,6 +41,9 @@ type TerraformProvider struct {
 	curPrincipal CurrentPrincipalIdProvider
 	projectPath  string
 	options      Options
+	// ownerEnv is the environment that owns a shared layer provisioned from another environment. It is nil for layers
+	// owned by the current environment.
+	ownerEnv *environment.Environment
 }
 
 type terraformDeploymentDetails struct {
@@ -90,6 +95,20 @@ func (t *TerraformProvider) Initialize(ctx context.Context, projectPath string,
 		return err
 	}
 
+	t.ownerEnv = nil
+	if options.IsShared() && options.Environment != t.env.Name() {
+		ownerEnv, err := t.envManager.Get(ctx, options.Environment)
+		if errors.Is(err, environment.ErrNotFound) {
+			return fmt.Errorf(
+				"layer '%s' is shared from environment '%s', which does not exist. Create it with 'azd env new %s'",
+				options.Name, options.Environment, options.Environment)
+		} else if err != nil {
+			return fmt.Errorf("loading environment '%s' of shared layer '%s': %w", options.Environment, options.Name, err)
+		}
+
+		t.ownerEnv = ownerEnv
+	}
+
 	if err := t.EnsureEnv(ctx); err != nil {
 		return err
 	}
@@ -99,13 +118,23 @@ func (t *TerraformProvider) Initialize(ctx context.Context, projectPath string,
 		fmt.Sprintf("TF_DATA_DIR=%s", t.dataDirPath()),
 		// Required when using service principal login
 		fmt.Sprintf("ARM_TENANT_ID=%s", os.Getenv("ARM_TENANT_ID")),
-		fmt.Sprintf("ARM_SUBSCRIPTION_ID=%s", t.env.GetSubscriptionId()),
+		fmt.Sprintf("ARM_SUBSCRIPTION_ID=%s", t.scopeEnv().GetSubscriptionId()),
 		fmt.Sprintf("ARM_CLIENT_ID=%s", os.Getenv("ARM_CLIENT_ID")),
 		fmt.Sprintf("ARM_CLIENT_SECRET=%s", os.Getenv("ARM_CLIENT_SECRET")),
 		// Include azd in user agent
 		fmt.Sprintf("TF_APPEND_USER_AGENT=%s", internal.UserAgent()),
 	}
 
+	// Variables are bound to the outputs of the layers provisioned before this one, by name.
+	for name, input := range options.Inputs {
+		value, err := terraformVariableValue(input)
+		if err != nil {
+			return fmt.Errorf("binding input '%s': %w", name, err)
+		}
+
+		envVars = append(envVars, fmt.Sprintf("TF_VAR_%s=%s", name, value))
+	}
+
 	spanCtx := trace.SpanContextFromContext(ctx)
 	if spanCtx.HasTraceID() {
 		envVars = append(envVars, fmt.Sprintf("ARM_CORRELATION_REQUEST_ID=%s", spanCtx.TraceID().String()))
@@ -119,8 +148,19 @@ func (t *TerraformProvider) Initialize(ctx context.Context, projectPath string,
 // values are unset.
 //
 // An environment is considered to be in a provision-ready state if it contains both an AZURE_SUBSCRIPTION_ID and
-// AZURE_LOCATION value.
+// AZURE_LOCATION value. Shared layers use the values of the environment owning them, which is never prompted for.
 func (t *TerraformProvider) EnsureEnv(ctx context.Context) error {
+	if t.ownerEnv != nil {
+		if t.ownerEnv.GetSubscriptionId() == "" || t.ownerEnv.GetLocation() == "" {
+			return fmt.Errorf(
+				"layer '%s' is shared from environment '%s', which has no %s or %s set. Provision it from that "+
+					"environment first", t.options.Name, t.ownerEnv.Name(),
+				environment.SubscriptionIdEnvVarName, environment.LocationEnvVarName)
+		}
+
+		return nil
+	}
+
 	return EnsureSubscriptionAndLocation(
 		ctx,
 		t.envManager,
@@ -139,25 +179,10 @@ func (t *TerraformProvider) plan(ctx context.Context) (*Deployment, *terraformDe
 
 	modulePath := t.modulePath()
 
-	initRes, err := t.init(ctx, isRemoteBackendConfig)
-	if err != nil {
-		return nil, nil, fmt.Errorf("terraform init failed: %s , err: %w", initRes, err)
-	}
-
-	if err != nil {
+	if err := t.prepareModule(ctx, isRemoteBackendConfig); err != nil {
 		return nil, nil, err
 	}
 
-	err = t.createInputParametersFile(ctx, t.parametersTemplateFilePath(), t.parametersFilePath())
-	if err != nil {
-		return nil, nil, fmt.Errorf("creating parameters file: %w", err)
-	}
-
-	validated, err := t.cli.Validate(ctx, modulePath)
-	if err != nil {
-		return nil, nil, fmt.Errorf("terraform validate failed: %s, err %w", validated, err)
-	}
-
 	planArgs := t.createPlanArgs(isRemoteBackendConfig)
 	runResult, err := t.cli.Plan(ctx, modulePath, t.planFilePath(), planArgs...)
 	if err != nil {
@@ -181,6 +206,26 @@ func (t *TerraformProvider) plan(ctx context.Context) (*Deployment, *terraformDe
 	return deployment, &deploymentDetails, nil
 }
 
+// prepareModule initializes the module, writes its parameters file and validates it, so it's ready to be planned.
+func (t *TerraformProvider) prepareModule(ctx context.Context, isRemoteBackendConfig bool) error {
+	initRes, err := t.init(ctx, isRemoteBackendConfig)
+	if err != nil {
+		return fmt.Errorf("terraform init failed: %s , err: %w", initRes, err)
+	}
+
+	err = t.createInputParametersFile(ctx, t.parametersTemplateFilePath(), t.parametersFilePath())
+	if err != nil {
+		return fmt.Errorf("creating parameters file: %w", err)
+	}
+
+	validated, err := t.cli.Validate(ctx, t.modulePath())
+	if err != nil {
+		return fmt.Errorf("terraform validate failed: %s, err %w", validated, err)
+	}
+
+	return nil
+}
+
 // Deploy the infrastructure within the specified template through terraform apply
 func (t *TerraformProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 	t.console.Message(ctx, "Locating plan file...")
@@ -302,6 +347,17 @@ func (t *TerraformProvider) createPlanArgs(isRemoteBackendConfig bool) []string
 		args = append(args, fmt.Sprintf("-state=%s", t.localStateFilePath()))
 	}
 
+	// One-off overrides come last, since terraform gives precedence to the variables it reads last.
+	if t.options.ParametersFile != "" {
+		args = append(args, fmt.Sprintf("-var-file=%s", t.options.ParametersFile))
+	}
+
+	names := maps.Keys(t.options.Parameters)
+	slices.Sort(names)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("-var=%s=%s", name, t.options.Parameters[name]))
+	}
+
 	return args
 }
 
@@ -613,29 +669,64 @@ func (t *TerraformProvider) modulePath() string {
 // Gets the path to the staging .azure terraform plan file path
 func (t *TerraformProvider) planFilePath() string {
 	planFilename := fmt.Sprintf("%s.tfplan", t.options.Module)
-	return filepath.Join(t.projectPath, ".azure", t.env.Name(), t.options.Path, planFilename)
+	return filepath.Join(t.projectPath, ".azure", t.layerEnvName(), t.options.Path, planFilename)
 }
 
 // Gets the path to the staging .azure terraform local state file path
 func (t *TerraformProvider) localStateFilePath() string {
-	return filepath.Join(t.projectPath, ".azure", t.env.Name(), t.options.Path, "terraform.tfstate")
+	return filepath.Join(t.projectPath, ".azure", t.layerEnvName(), t.options.Path, "terraform.tfstate")
 }
 
 // Gets the path to the staging .azure parameters file path
 func (t *TerraformProvider) backendConfigFilePath() string {
-	backendConfigFilename := fmt.Sprintf("%s.conf.json", t.env.Name())
-	return filepath.Join(t.projectPath, ".azure", t.env.Name(), t.options.Path, backendConfigFilename)
+	backendConfigFilename := fmt.Sprintf("%s.conf.json", t.layerEnvName())
+	return filepath.Join(t.projectPath, ".azure", t.layerEnvName(), t.options.Path, backendConfigFilename)
 }
 
 // Gets the path to the staging .azure backend config file path
 func (t *TerraformProvider) parametersFilePath() string {
 	parametersFilename := fmt.Sprintf("%s.tfvars.json", t.options.Module)
-	return filepath.Join(t.projectPath, ".azure", t.env.Name(), t.options.Path, parametersFilename)
+	return filepath.Join(t.projectPath, ".azure", t.layerEnvName(), t.options.Path, parametersFilename)
+}
+
+// Gets the value of a TF_VAR_ environment variable for an input. Strings are passed as is, other values are encoded as
+// JSON, which terraform parses for complex variable types.
+func terraformVariableValue(input OutputParameter) (string, error) {
+	if value, ok := input.Value.(string); ok {
+		return value, nil
+	}
+
+	bytes, err := json.Marshal(input.Value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// Gets the name of the environment the layer being provisioned belongs to. Shared layers keep their state in the shared
+// environment, so every environment of the project reuses it.
+func (t *TerraformProvider) layerEnvName() string {
+	if t.options.IsShared() {
+		return t.options.Environment
+	}
+
+	return t.env.Name()
+}
+
+// Gets the environment the subscription and the parameters of the layer are read from. This is the environment owning the
+// layer, which differs from the current environment for shared layers.
+func (t *TerraformProvider) scopeEnv() *environment.Environment {
+	if t.ownerEnv != nil {
+		return t.ownerEnv
+	}
+
+	return t.env
 }
 
 // Gets the path to the current env.
 func (t *TerraformProvider) dataDirPath() string {
-	return filepath.Join(t.projectPath, ".azure", t.env.Name(), t.options.Path, ".terraform")
+	return filepath.Join(t.projectPath, ".azure", t.layerEnvName(), t.options.Path, ".terraform")
 }
 
 // Check terraform file for remote backend provider
@@ -688,7 +779,7 @@ func (t *TerraformProvider) createInputParametersFile(
 			return principalId
 		}
 
-		return t.env.Getenv(name)
+		return t.scopeEnv().Getenv(name)
 	})
 
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
@@ -133,7 +134,7 @@ func createTerraformProvider(t *testing.T, mockContext *mocks.MockContext) *Terr
 		env,
 		mockContext.Console,
 		&mockCurrentPrincipal{},
-		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, azCli),
+		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, azCli, cloud.AzurePublic()),
 	)
 
 	err := provider.Initialize(*mockContext.Context, projectDir, options)
@@ -217,3 +218,24 @@ type mockCurrentPrincipal struct{}
 func (m *mockCurrentPrincipal) CurrentPrincipalId(_ context.Context) (string, error) {
 	return "11111111-1111-1111-1111-111111111111", nil
 }
+
+func TestCreatePlanArgsWithParameterOverrides(t *testing.T) {
+	provider := &TerraformProvider{
+		env:         environment.New("test-env"),
+		projectPath: "/project",
+		options: Options{
+			Path:           "infra",
+			Module:         "main",
+			ParametersFile: "/overrides.tfvars.json",
+			Parameters:     map[string]string{"sku": "P1v3", "location": "westus2"},
+		},
+	}
+
+	args := provider.createPlanArgs(true)
+	require.Equal(t, []string{
+		fmt.Sprintf("-var-file=%s", provider.parametersFilePath()),
+		"-var-file=/overrides.tfvars.json",
+		"-var=location=westus2",
+		"-var=sku=P1v3",
+	}, args)
+}
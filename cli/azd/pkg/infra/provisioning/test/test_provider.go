@@ -108,6 +108,10 @@ func (p *TestProvider) Preview(ctx context.Context) (*DeployPreviewResult, error
 	}, nil
 }
 
+func (p *TestProvider) CheckDrift(ctx context.Context) (*DriftResult, error) {
+	return &DriftResult{}, nil
+}
+
 func (p *TestProvider) Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error) {
 	// TODO: progress, "Starting destroy"
 
@@ -45,6 +45,8 @@ type Deployment interface {
 	Deployment(ctx context.Context) (*armresources.DeploymentExtended, error)
 	// Operations returns all the operations for this deployment.
 	Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error)
+	// ExportTemplate gets the template this deployment deployed.
+	ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error)
 }
 
 type ResourceGroupDeployment struct {
@@ -86,6 +88,11 @@ func (s *ResourceGroupDeployment) Deployment(ctx context.Context) (*armresources
 	return s.deployments.GetResourceGroupDeployment(ctx, s.subscriptionId, s.resourceGroupName, s.name)
 }
 
+// ExportTemplate gets the template this deployment deployed.
+func (s *ResourceGroupDeployment) ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error) {
+	return s.deployments.ExportResourceGroupDeploymentTemplate(ctx, s.subscriptionId, s.resourceGroupName, s.name)
+}
+
 // Gets the resource deployment operations for the current scope
 func (s *ResourceGroupDeployment) Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error) {
 	return s.deploymentOperations.ListResourceGroupDeploymentOperations(
@@ -95,14 +102,14 @@ func (s *ResourceGroupDeployment) Operations(ctx context.Context) ([]*armresourc
 // Gets the url to check deployment progress
 func (s *ResourceGroupDeployment) PortalUrl() string {
 	return fmt.Sprintf("%s/%s",
-		cPortalUrlPrefix,
+		s.deployments.PortalUrlBase()+cPortalUrlPath,
 		url.PathEscape(azure.ResourceGroupDeploymentRID(s.subscriptionId, s.resourceGroupName, s.name)))
 }
 
 // Gets the url to view deployment outputs
 func (s *ResourceGroupDeployment) OutputsUrl() string {
 	return fmt.Sprintf("%s/%s",
-		cOutputsUrlPrefix,
+		s.deployments.PortalUrlBase()+cOutputsUrlPath,
 		url.PathEscape(azure.ResourceGroupDeploymentRID(s.subscriptionId, s.resourceGroupName, s.name)))
 }
 
@@ -152,10 +159,10 @@ func (s *ResourceGroupScope) ListDeployments(ctx context.Context) ([]*armresourc
 	return s.deployments.ListResourceGroupDeployments(ctx, s.subscriptionId, s.resourceGroupName)
 }
 
-// cPortalUrlPrefix is the prefix which can be combined with the RID of a deployment to produce a URL into the Azure Portal
-// that shows information about the deployment.
-const cPortalUrlPrefix = "https://portal.azure.com/#view/HubsExtension/DeploymentDetailsBlade/~/overview/id"
-const cOutputsUrlPrefix = "https://portal.azure.com/#view/HubsExtension/DeploymentDetailsBlade/~/outputs/id"
+// cPortalUrlPath is the path which, after the portal of the cloud, can be combined with the RID of a deployment to produce a
+// URL into the Azure Portal that shows information about the deployment.
+const cPortalUrlPath = "/#view/HubsExtension/DeploymentDetailsBlade/~/overview/id"
+const cOutputsUrlPath = "/#view/HubsExtension/DeploymentDetailsBlade/~/outputs/id"
 
 type SubscriptionDeployment struct {
 	*SubscriptionScope
@@ -175,14 +182,14 @@ func (s *SubscriptionDeployment) SubscriptionId() string {
 // Gets the url to check deployment progress
 func (s *SubscriptionDeployment) PortalUrl() string {
 	return fmt.Sprintf("%s/%s",
-		cPortalUrlPrefix,
+		s.deploymentsService.PortalUrlBase()+cPortalUrlPath,
 		url.PathEscape(azure.SubscriptionDeploymentRID(s.subscriptionId, s.name)))
 }
 
 // Gets the url to view deployment outputs
 func (s *SubscriptionDeployment) OutputsUrl() string {
 	return fmt.Sprintf("%s/%s",
-		cOutputsUrlPrefix,
+		s.deploymentsService.PortalUrlBase()+cOutputsUrlPath,
 		url.PathEscape(azure.SubscriptionDeploymentRID(s.subscriptionId, s.name)))
 }
 
@@ -212,6 +219,11 @@ func (s *SubscriptionDeployment) Deployment(ctx context.Context) (*armresources.
 	return s.deploymentsService.GetSubscriptionDeployment(ctx, s.subscriptionId, s.name)
 }
 
+// ExportTemplate gets the template this deployment deployed.
+func (s *SubscriptionDeployment) ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error) {
+	return s.deploymentsService.ExportSubscriptionDeploymentTemplate(ctx, s.subscriptionId, s.name)
+}
+
 // Gets the resource deployment operations for the current scope
 func (s *SubscriptionDeployment) Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error) {
 	return s.deploymentOperations.ListSubscriptionDeploymentOperations(ctx, s.subscriptionId, s.name)
@@ -258,3 +270,211 @@ func NewSubscriptionScope(
 		subscriptionId:       subscriptionId,
 	}
 }
+
+type ManagementGroupDeployment struct {
+	*ManagementGroupScope
+	name     string
+	location string
+}
+
+func (s *ManagementGroupDeployment) Name() string {
+	return s.name
+}
+
+// Gets the url to check deployment progress
+func (s *ManagementGroupDeployment) PortalUrl() string {
+	return fmt.Sprintf("%s/%s",
+		s.deploymentsService.PortalUrlBase()+cPortalUrlPath,
+		url.PathEscape(azure.ManagementGroupDeploymentRID(s.managementGroupId, s.name)))
+}
+
+// Gets the url to view deployment outputs
+func (s *ManagementGroupDeployment) OutputsUrl() string {
+	return fmt.Sprintf("%s/%s",
+		s.deploymentsService.PortalUrlBase()+cOutputsUrlPath,
+		url.PathEscape(azure.ManagementGroupDeploymentRID(s.managementGroupId, s.name)))
+}
+
+// Deploy a given template with a set of parameters.
+func (s *ManagementGroupDeployment) Deploy(
+	ctx context.Context, template azure.RawArmTemplate, parameters azure.ArmParameters, tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	return s.deploymentsService.DeployToManagementGroup(
+		ctx, s.subscriptionId, s.managementGroupId, s.location, s.name, template, parameters, tags)
+}
+
+// Deploy a given template with a set of parameters.
+func (s *ManagementGroupDeployment) DeployPreview(
+	ctx context.Context,
+	template azure.RawArmTemplate,
+	parameters azure.ArmParameters) (*armresources.WhatIfOperationResult, error) {
+	return s.deploymentsService.WhatIfDeployToManagementGroup(
+		ctx, s.subscriptionId, s.managementGroupId, s.location, s.name, template, parameters)
+}
+
+// GetDeployment fetches the result of the most recent deployment.
+func (s *ManagementGroupDeployment) Deployment(ctx context.Context) (*armresources.DeploymentExtended, error) {
+	return s.deploymentsService.GetManagementGroupDeployment(ctx, s.subscriptionId, s.managementGroupId, s.name)
+}
+
+// ExportTemplate gets the template this deployment deployed.
+func (s *ManagementGroupDeployment) ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error) {
+	return s.deploymentsService.ExportManagementGroupDeploymentTemplate(
+		ctx, s.subscriptionId, s.managementGroupId, s.name)
+}
+
+// Gets the resource deployment operations for the current scope
+func (s *ManagementGroupDeployment) Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error) {
+	return s.deploymentOperations.ListManagementGroupDeploymentOperations(
+		ctx, s.subscriptionId, s.managementGroupId, s.name)
+}
+
+func NewManagementGroupDeployment(
+	deploymentsService azapi.Deployments,
+	deploymentOperations azapi.DeploymentOperations,
+	location string, subscriptionId string, managementGroupId string, deploymentName string,
+) *ManagementGroupDeployment {
+	return &ManagementGroupDeployment{
+		ManagementGroupScope: NewManagementGroupScope(
+			deploymentsService,
+			deploymentOperations,
+			subscriptionId,
+			managementGroupId),
+		name:     deploymentName,
+		location: location,
+	}
+}
+
+// ManagementGroupScope is the scope of the deployments of a management group. The subscription is only used to get the
+// credential of the tenant of the management group.
+type ManagementGroupScope struct {
+	deploymentsService   azapi.Deployments
+	deploymentOperations azapi.DeploymentOperations
+	subscriptionId       string
+	managementGroupId    string
+}
+
+// Gets the Azure subscription id
+func (s *ManagementGroupScope) SubscriptionId() string {
+	return s.subscriptionId
+}
+
+// Gets the management group id
+func (s *ManagementGroupScope) ManagementGroupId() string {
+	return s.managementGroupId
+}
+
+// ListDeployments returns all the deployments at management group scope.
+func (s *ManagementGroupScope) ListDeployments(ctx context.Context) ([]*armresources.DeploymentExtended, error) {
+	return s.deploymentsService.ListManagementGroupDeployments(ctx, s.subscriptionId, s.managementGroupId)
+}
+
+func NewManagementGroupScope(
+	deploymentsService azapi.Deployments,
+	deploymentOperations azapi.DeploymentOperations,
+	subscriptionId string, managementGroupId string) *ManagementGroupScope {
+	return &ManagementGroupScope{
+		deploymentsService:   deploymentsService,
+		deploymentOperations: deploymentOperations,
+		subscriptionId:       subscriptionId,
+		managementGroupId:    managementGroupId,
+	}
+}
+
+type TenantDeployment struct {
+	*TenantScope
+	name     string
+	location string
+}
+
+func (s *TenantDeployment) Name() string {
+	return s.name
+}
+
+// Gets the url to check deployment progress
+func (s *TenantDeployment) PortalUrl() string {
+	return fmt.Sprintf("%s/%s",
+		s.deploymentsService.PortalUrlBase()+cPortalUrlPath,
+		url.PathEscape(azure.TenantDeploymentRID(s.name)))
+}
+
+// Gets the url to view deployment outputs
+func (s *TenantDeployment) OutputsUrl() string {
+	return fmt.Sprintf("%s/%s",
+		s.deploymentsService.PortalUrlBase()+cOutputsUrlPath,
+		url.PathEscape(azure.TenantDeploymentRID(s.name)))
+}
+
+// Deploy a given template with a set of parameters.
+func (s *TenantDeployment) Deploy(
+	ctx context.Context, template azure.RawArmTemplate, parameters azure.ArmParameters, tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	return s.deploymentsService.DeployToTenant(ctx, s.subscriptionId, s.location, s.name, template, parameters, tags)
+}
+
+// Deploy a given template with a set of parameters.
+func (s *TenantDeployment) DeployPreview(
+	ctx context.Context,
+	template azure.RawArmTemplate,
+	parameters azure.ArmParameters) (*armresources.WhatIfOperationResult, error) {
+	return s.deploymentsService.WhatIfDeployToTenant(ctx, s.subscriptionId, s.location, s.name, template, parameters)
+}
+
+// GetDeployment fetches the result of the most recent deployment.
+func (s *TenantDeployment) Deployment(ctx context.Context) (*armresources.DeploymentExtended, error) {
+	return s.deploymentsService.GetTenantDeployment(ctx, s.subscriptionId, s.name)
+}
+
+// ExportTemplate gets the template this deployment deployed.
+func (s *TenantDeployment) ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error) {
+	return s.deploymentsService.ExportTenantDeploymentTemplate(ctx, s.subscriptionId, s.name)
+}
+
+// Gets the resource deployment operations for the current scope
+func (s *TenantDeployment) Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error) {
+	return s.deploymentOperations.ListTenantDeploymentOperations(ctx, s.subscriptionId, s.name)
+}
+
+func NewTenantDeployment(
+	deploymentsService azapi.Deployments,
+	deploymentOperations azapi.DeploymentOperations,
+	location string, subscriptionId string, deploymentName string,
+) *TenantDeployment {
+	return &TenantDeployment{
+		TenantScope: NewTenantScope(
+			deploymentsService,
+			deploymentOperations,
+			subscriptionId),
+		name:     deploymentName,
+		location: location,
+	}
+}
+
+// TenantScope is the scope of the deployments of a tenant. The subscription is only used to get the credential of the
+// tenant.
+type TenantScope struct {
+	deploymentsService   azapi.Deployments
+	deploymentOperations azapi.DeploymentOperations
+	subscriptionId       string
+}
+
+// Gets the Azure subscription id
+func (s *TenantScope) SubscriptionId() string {
+	return s.subscriptionId
+}
+
+// ListDeployments returns all the deployments at tenant scope.
+func (s *TenantScope) ListDeployments(ctx context.Context) ([]*armresources.DeploymentExtended, error) {
+	return s.deploymentsService.ListTenantDeployments(ctx, s.subscriptionId)
+}
+
+func NewTenantScope(
+	deploymentsService azapi.Deployments,
+	deploymentOperations azapi.DeploymentOperations,
+	subscriptionId string) *TenantScope {
+	return &TenantScope{
+		deploymentsService:   deploymentsService,
+		deploymentOperations: deploymentOperations,
+		subscriptionId:       subscriptionId,
+	}
+}
@@ -169,6 +169,60 @@ func TestScopeDeploy(t *testing.T) {
 		_, err := target.Deploy(*mockContext.Context, armTemplate, testArmParameters, nil)
 		require.NoError(t, err)
 	})
+
+	t.Run("ManagementGroupScopeSuccess", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		depOpService := mockazcli.NewDeploymentOperationsServiceFromMockContext(mockContext)
+		depService := mockazcli.NewDeploymentsServiceFromMockContext(mockContext)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPut && strings.Contains(
+				request.URL.Path,
+				"/providers/Microsoft.Management/managementGroups/MANAGEMENT_GROUP/providers/"+
+					"Microsoft.Resources/deployments/DEPLOYMENT_NAME",
+			)
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer([]byte(testArmResponse))),
+				Request: &http.Request{
+					Method: http.MethodGet,
+				},
+			}, nil
+		})
+
+		target := NewManagementGroupDeployment(
+			depService, depOpService, "eastus2", "SUBSCRIPTION_ID", "MANAGEMENT_GROUP", "DEPLOYMENT_NAME")
+
+		armTemplate := azure.RawArmTemplate(testArmTemplate)
+		_, err := target.Deploy(*mockContext.Context, armTemplate, testArmParameters, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("TenantScopeSuccess", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		depOpService := mockazcli.NewDeploymentOperationsServiceFromMockContext(mockContext)
+		depService := mockazcli.NewDeploymentsServiceFromMockContext(mockContext)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPut &&
+				request.URL.Path == "/providers/Microsoft.Resources/deployments/DEPLOYMENT_NAME"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer([]byte(testArmResponse))),
+				Request: &http.Request{
+					Method: http.MethodGet,
+				},
+			}, nil
+		})
+
+		target := NewTenantDeployment(depService, depOpService, "eastus2", "SUBSCRIPTION_ID", "DEPLOYMENT_NAME")
+
+		armTemplate := azure.RawArmTemplate(testArmTemplate)
+		_, err := target.Deploy(*mockContext.Context, armTemplate, testArmParameters, nil)
+		require.NoError(t, err)
+	})
 }
 
 func TestScopeGetResourceOperations(t *testing.T) {
@@ -22,8 +22,10 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/resource"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/google/uuid"
 	"github.com/nathan-fiscaletti/consolesize-go"
 	"github.com/theckman/yacspin"
 	"go.uber.org/atomic"
@@ -61,6 +63,9 @@ type Console interface {
 	Message(ctx context.Context, message string)
 	// Prints out a message following a contract ux item
 	MessageUxItem(ctx context.Context, item ux.UxItem)
+	// Writes an event with the given type and data when the console streams events, with the JSON Lines output format.
+	// Does nothing for other formats.
+	Event(ctx context.Context, eventType contracts.EventDataType, data any)
 	WarnForFeature(ctx context.Context, id alpha.FeatureId)
 	// Prints progress spinner with the given title.
 	// If a previous spinner is running, the title is updated.
@@ -122,6 +127,11 @@ type AskerConsole struct {
 
 	previewer *progressLog
 
+	// With the JSON Lines format, the spinner and the previewer are replaced by events: the step started by the spinner
+	// and the writer of the previewer, which writes its lines as console messages.
+	eventStepId    string
+	eventPreviewer *eventLineWriter
+
 	currentIndent *atomic.String
 	consoleWidth  *atomic.Int32
 	// holds the last 2 bytes written by message or messageUX. This is used to detect when there is already an empty
@@ -168,7 +178,9 @@ func (c *AskerConsole) IsUnformatted() bool {
 // Prints out a message to the underlying console write
 func (c *AskerConsole) Message(ctx context.Context, message string) {
 	// Disable output when formatting is enabled
-	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
+	if c.eventFormatter() != nil {
+		c.writeEvent(output.EventForMessage(message))
+	} else if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
 		// we call json.Marshal directly, because the formatter marshalls using indentation, and we would prefer
 		// these objects be written on a single line.
 		jsonMessage, err := json.Marshal(output.EventForMessage(message))
@@ -221,6 +233,11 @@ func shouldWarn(key alpha.FeatureId) bool {
 }
 
 func (c *AskerConsole) MessageUxItem(ctx context.Context, item ux.UxItem) {
+	if c.eventFormatter() != nil {
+		c.writeEvent(uxItemEvent(item))
+		return
+	}
+
 	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
 		// no need to check the spinner for json format, as the spinner won't start when using json format
 		// instead, there would be a message about starting spinner
@@ -235,6 +252,43 @@ func (c *AskerConsole) MessageUxItem(ctx context.Context, item ux.UxItem) {
 	c.updateLastBytes(msg + "\n")
 }
 
+func (c *AskerConsole) Event(ctx context.Context, eventType contracts.EventDataType, data any) {
+	if c.eventFormatter() == nil {
+		return
+	}
+
+	c.writeEvent(contracts.EventEnvelope{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+}
+
+// eventFormatter returns the formatter of the events when the console streams events, nil otherwise.
+func (c *AskerConsole) eventFormatter() *output.JsonLinesFormatter {
+	formatter, _ := c.formatter.(*output.JsonLinesFormatter)
+	return formatter
+}
+
+func (c *AskerConsole) writeEvent(event contracts.EventEnvelope) {
+	if err := c.eventFormatter().Format(event, c.writer, nil); err != nil {
+		log.Printf("failed writing %s event: %v", event.Type, err)
+	}
+}
+
+// uxItemEvent returns the event of a ux item: the event it marshals to, or a console message with its text for the items
+// which don't marshal to an event.
+func uxItemEvent(item ux.UxItem) contracts.EventEnvelope {
+	var event contracts.EventEnvelope
+	if itemJson, err := json.Marshal(item); err == nil {
+		if err := json.Unmarshal(itemJson, &event); err == nil && event.Type != "" {
+			return event
+		}
+	}
+
+	return output.EventForMessage(item.ToString(""))
+}
+
 func (c *AskerConsole) println(ctx context.Context, msg string) {
 	if c.spinner.Status() == yacspin.SpinnerRunning {
 		c.StopSpinner(ctx, "", Step)
@@ -260,6 +314,11 @@ func (c *AskerConsole) ShowPreviewer(ctx context.Context, options *ShowPreviewer
 	currentMsg := c.spinnerCurrentTitle
 	_ = c.spinner.Pause()
 
+	if c.eventFormatter() != nil {
+		c.eventPreviewer = &eventLineWriter{ctx: ctx, console: c}
+		return c.eventPreviewer
+	}
+
 	if options == nil {
 		options = defaultShowPreviewerOptions()
 	}
@@ -273,6 +332,12 @@ func (c *AskerConsole) ShowPreviewer(ctx context.Context, options *ShowPreviewer
 }
 
 func (c *AskerConsole) StopPreviewer(ctx context.Context, keepLogs bool) {
+	if c.eventPreviewer != nil {
+		c.eventPreviewer.flush()
+		c.eventPreviewer = nil
+		return
+	}
+
 	c.previewer.Stop(keepLogs)
 	c.previewer = nil
 	c.writer = c.defaultWriter
@@ -326,8 +391,26 @@ func (c *AskerConsole) ShowSpinner(ctx context.Context, title string, format Spi
 	c.showProgressMu.Lock()
 	defer c.showProgressMu.Unlock()
 
-	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
-		// Spinner is disabled when using json format.
+	if c.eventFormatter() != nil {
+		// The first title starts a step, which ends when the spinner is stopped.
+		if c.eventStepId == "" {
+			c.eventStepId = uuid.NewString()
+			c.writeEvent(contracts.EventEnvelope{
+				Type:      contracts.StepStartEventDataType,
+				Timestamp: time.Now(),
+				Data: contracts.StepStartEvent{
+					StepId: c.eventStepId,
+					Title:  title,
+				},
+			})
+		}
+
+		c.spinnerCurrentTitle = title
+		return
+	}
+
+	if c.formatter != nil && c.formatter.Kind().IsStructured() {
+		// Spinner is disabled when using a structured format, like json, yaml or tsv.
 		return
 	}
 
@@ -412,8 +495,13 @@ func (c *AskerConsole) getIndent(format SpinnerUxType) string {
 }
 
 func (c *AskerConsole) StopSpinner(ctx context.Context, lastMessage string, format SpinnerUxType) {
-	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
-		// Spinner is disabled when using json format.
+	if c.eventFormatter() != nil {
+		c.stopEventStep(lastMessage, format)
+		return
+	}
+
+	if c.formatter != nil && c.formatter.Kind().IsStructured() {
+		// Spinner is disabled when using a structured format, like json, yaml or tsv.
 		return
 	}
 
@@ -434,7 +522,54 @@ func (c *AskerConsole) StopSpinner(ctx context.Context, lastMessage string, form
 	c.spinnerLineMu.Unlock()
 }
 
+// stopEventStep ends the step started by the spinner, when there is one.
+func (c *AskerConsole) stopEventStep(lastMessage string, format SpinnerUxType) {
+	c.showProgressMu.Lock()
+	defer c.showProgressMu.Unlock()
+
+	if c.eventStepId == "" {
+		return
+	}
+
+	title := lastMessage
+	if title == "" {
+		title = c.spinnerCurrentTitle
+	}
+
+	status := contracts.StepStatusStopped
+	switch format {
+	case StepDone:
+		status = contracts.StepStatusDone
+	case StepFailed:
+		status = contracts.StepStatusFailed
+	case StepWarning:
+		status = contracts.StepStatusWarning
+	case StepSkipped:
+		status = contracts.StepStatusSkipped
+	}
+
+	c.writeEvent(contracts.EventEnvelope{
+		Type:      contracts.StepEndEventDataType,
+		Timestamp: time.Now(),
+		Data: contracts.StepEndEvent{
+			StepId: c.eventStepId,
+			Title:  title,
+			Status: status,
+		},
+	})
+
+	c.eventStepId = ""
+	c.spinnerCurrentTitle = ""
+}
+
 func (c *AskerConsole) IsSpinnerRunning(ctx context.Context) bool {
+	if c.eventFormatter() != nil {
+		c.showProgressMu.Lock()
+		defer c.showProgressMu.Unlock()
+
+		return c.eventStepId != ""
+	}
+
 	return c.spinner.Status() != yacspin.SpinnerStopped
 }
 
@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package input
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskerConsoleJsonLines(t *testing.T) {
+	ctx := context.Background()
+	buffer := &bytes.Buffer{}
+	formatter := output.NewJsonLinesFormatter()
+	console := NewConsole(true, false, buffer, ConsoleHandles{
+		Stdin:  strings.NewReader(""),
+		Stdout: buffer,
+		Stderr: buffer,
+	}, formatter)
+
+	console.ShowSpinner(ctx, "Deploying service api", Step)
+	require.True(t, console.IsSpinnerRunning(ctx))
+	console.ShowSpinner(ctx, "Deploying service api (Pushing container image)", Step)
+	console.Event(ctx, contracts.ServiceProgressEventDataType, contracts.ServiceProgressEvent{
+		Service:   "api",
+		Operation: "deploy",
+		Message:   "Pushing container image",
+	})
+	console.StopSpinner(ctx, "Deploying service api", StepDone)
+	require.False(t, console.IsSpinnerRunning(ctx))
+
+	previewer := console.ShowPreviewer(ctx, nil)
+	_, err := fmt.Fprint(previewer, "line 1\nline")
+	require.NoError(t, err)
+	_, err = fmt.Fprint(previewer, " 2")
+	require.NoError(t, err)
+	console.StopPreviewer(ctx, false)
+
+	console.Message(ctx, output.WithSuccessFormat("done"))
+
+	var events []contracts.EventEnvelope
+	for _, line := range strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n") {
+		var event contracts.EventEnvelope
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		require.Equal(t, formatter.CorrelationId(), event.CorrelationId)
+
+		events = append(events, event)
+	}
+
+	types := []contracts.EventDataType{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+
+	require.Equal(t, []contracts.EventDataType{
+		contracts.StepStartEventDataType,
+		contracts.ServiceProgressEventDataType,
+		contracts.StepEndEventDataType,
+		contracts.ConsoleMessageEventDataType,
+		contracts.ConsoleMessageEventDataType,
+		contracts.ConsoleMessageEventDataType,
+	}, types)
+
+	stepStart := events[0].Data.(map[string]any)
+	stepEnd := events[2].Data.(map[string]any)
+	require.Equal(t, "Deploying service api", stepStart["title"])
+	require.Equal(t, stepStart["stepId"], stepEnd["stepId"])
+	require.Equal(t, string(contracts.StepStatusDone), stepEnd["status"])
+
+	// The lines of the previewer and the messages are console messages, without colors.
+	require.Equal(t, "line 1\n", events[3].Data.(map[string]any)["message"])
+	require.Equal(t, "line 2\n", events[4].Data.(map[string]any)["message"])
+	require.Equal(t, "done\n", events[5].Data.(map[string]any)["message"])
+}
+
+func TestAskerConsoleStructuredFormatsHideSpinner(t *testing.T) {
+	for _, formatter := range []output.Formatter{&output.YamlFormatter{}, &output.TsvFormatter{}} {
+		ctx := context.Background()
+		buffer := &bytes.Buffer{}
+		console := NewConsole(true, false, buffer, ConsoleHandles{
+			Stdin:  strings.NewReader(""),
+			Stdout: buffer,
+			Stderr: buffer,
+		}, formatter)
+
+		console.ShowSpinner(ctx, "Deploying service api", Step)
+		require.False(t, console.IsSpinnerRunning(ctx))
+		console.StopSpinner(ctx, "Deploying service api", StepDone)
+		require.Empty(t, buffer.String())
+	}
+}
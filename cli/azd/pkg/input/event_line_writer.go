@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package input
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// eventLineWriter replaces the console previewer when the console streams events. Each line written is a console message.
+type eventLineWriter struct {
+	ctx     context.Context
+	console *AskerConsole
+
+	mu sync.Mutex
+	// holds the last line written, until it is complete
+	buf []byte
+}
+
+func (w *eventLineWriter) Write(logBytes []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, logBytes...)
+	for {
+		line, rest, found := bytes.Cut(w.buf, []byte("\n"))
+		if !found {
+			break
+		}
+
+		w.console.Message(w.ctx, string(bytes.TrimSuffix(line, []byte("\r"))))
+		w.buf = rest
+	}
+
+	return len(logBytes), nil
+}
+
+// flush writes the last line when it was not complete.
+func (w *eventLineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.console.Message(w.ctx, string(w.buf))
+		w.buf = nil
+	}
+}
@@ -1,9 +1,13 @@
 package output
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-colorable"
 )
 
 // withLinkFormat creates string with hyperlink-looking color
@@ -51,3 +55,16 @@ func WithBackticks(text string) string {
 func WithHyperlink(url string, text string) string {
 	return WithLinkFormat(fmt.Sprintf("\033]8;;%s\007%s\033]8;;\007", url, text))
 }
+
+// WithoutFormat removes the ANSI control sequences, like the colors, from text.
+func WithoutFormat(text string) string {
+	var buf bytes.Buffer
+
+	// We do not expect the io.Copy to fail since none of these sub-calls will ever return an error (other than
+	// EOF when we hit the end of the string)
+	if _, err := io.Copy(colorable.NewNonColorable(&buf), strings.NewReader(text)); err != nil {
+		panic(fmt.Sprintf("WithoutFormat: did not expect error from io.Copy but got: %v", err))
+	}
+
+	return buf.String()
+}
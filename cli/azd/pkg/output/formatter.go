@@ -15,8 +15,18 @@ const (
 	JsonFormat    Format = "json"
 	TableFormat   Format = "table"
 	NoneFormat    Format = "none"
+	YamlFormat    Format = "yaml"
+	TsvFormat     Format = "tsv"
+	// JsonLinesFormat streams the progress of a command as events, one JSON object per line.
+	JsonLinesFormat Format = "jsonl"
 )
 
+// IsStructured returns true for the formats which write the result of a command as data for other tools, like json,
+// instead of messages for users.
+func (f Format) IsStructured() bool {
+	return f == JsonFormat || f == YamlFormat || f == TsvFormat || f == JsonLinesFormat
+}
+
 type Formatter interface {
 	Kind() Format
 	Format(obj interface{}, writer io.Writer, opts interface{}) error
@@ -32,6 +42,12 @@ func NewFormatter(format string) (Formatter, error) {
 		return &TableFormatter{}, nil
 	case string(NoneFormat):
 		return &NoneFormatter{}, nil
+	case string(YamlFormat):
+		return &YamlFormatter{}, nil
+	case string(TsvFormat):
+		return &TsvFormatter{}, nil
+	case string(JsonLinesFormat):
+		return NewJsonLinesFormatter(), nil
 	default:
 		return nil, fmt.Errorf("unsupported format %v", format)
 	}
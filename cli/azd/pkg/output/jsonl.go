@@ -0,0 +1,65 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/google/uuid"
+)
+
+// JsonLinesFormatter writes a stream of events as JSON Lines, one JSON object per line. Events are written with the
+// correlation id of the formatter, and any other object, like the result of a command, is written as the data of a result
+// event.
+type JsonLinesFormatter struct {
+	correlationId string
+	// mu keeps the lines of events written concurrently from being interleaved.
+	mu sync.Mutex
+}
+
+// NewJsonLinesFormatter creates a formatter with a new correlation id.
+func NewJsonLinesFormatter() *JsonLinesFormatter {
+	return &JsonLinesFormatter{
+		correlationId: uuid.NewString(),
+	}
+}
+
+func (f *JsonLinesFormatter) Kind() Format {
+	return JsonLinesFormat
+}
+
+// CorrelationId returns the id written with each event, which identifies the invocation of azd.
+func (f *JsonLinesFormatter) CorrelationId() string {
+	return f.correlationId
+}
+
+func (f *JsonLinesFormatter) Format(obj interface{}, writer io.Writer, _ interface{}) error {
+	event, ok := obj.(contracts.EventEnvelope)
+	if !ok {
+		event = contracts.EventEnvelope{
+			Type:      contracts.ResultEventDataType,
+			Timestamp: time.Now(),
+			Data:      obj,
+		}
+	}
+
+	event.CorrelationId = f.correlationId
+
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err = writer.Write(append(b, '\n'))
+	return err
+}
+
+var _ Formatter = (*JsonLinesFormatter)(nil)
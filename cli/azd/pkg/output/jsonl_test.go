@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJsonLinesFormatter(t *testing.T) {
+	formatter := NewJsonLinesFormatter()
+	require.NotEmpty(t, formatter.CorrelationId())
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(contracts.EventEnvelope{
+		Type:      contracts.StepStartEventDataType,
+		Timestamp: time.Now(),
+		Data:      contracts.StepStartEvent{StepId: "1", Title: "Deploying service api"},
+	}, buffer, nil)
+	require.NoError(t, err)
+
+	err = formatter.Format(map[string]string{"name": "api"}, buffer, nil)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var events []map[string]any
+	for _, line := range lines {
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		require.Equal(t, formatter.CorrelationId(), event["correlationId"])
+
+		events = append(events, event)
+	}
+
+	// Events are written as is, other objects are the data of a result event.
+	require.Equal(t, "stepStart", events[0]["type"])
+	require.Equal(t, map[string]any{"stepId": "1", "title": "Deploying service api"}, events[0]["data"])
+	require.Equal(t, "result", events[1]["type"])
+	require.Equal(t, map[string]any{"name": "api"}, events[1]["data"])
+}
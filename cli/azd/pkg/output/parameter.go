@@ -17,9 +17,14 @@ const (
 )
 
 func AddOutputFlag(f *pflag.FlagSet, s *string, supportedFormats []Format, defaultFormat Format) {
-	formatNames := make([]string, len(supportedFormats))
-	for i, f := range supportedFormats {
-		formatNames[i] = string(f)
+	formatNames := make([]string, 0, len(supportedFormats)+2)
+	for _, f := range supportedFormats {
+		formatNames = append(formatNames, string(f))
+
+		// The results written as json can be written as yaml and tsv as well.
+		if f == JsonFormat {
+			formatNames = append(formatNames, string(YamlFormat), string(TsvFormat))
+		}
 	}
 
 	description := fmt.Sprintf("The output format (the supported formats are %s).", strings.Join(formatNames, ", "))
@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TsvFormatter writes objects as tab-separated values, without headings, so they can be processed by shell scripts:
+//
+//   - a slice is written as a line per item.
+//   - a map is written as a line per entry, starting with the key, in the order of the keys.
+//   - a struct with a map field, like the result of `azd show`, is written as the lines of its first map field, like a
+//     line per service. Its other fields are not written.
+//   - any other object is written as a single line.
+//
+// The values of a line are the values of the fields of the object, in the order of the JSON output, which follows the
+// `json` tags of the types in the contracts package. The fields of nested objects and the items of nested slices are
+// written in place. Tabs and line breaks in values are escaped as \t, \r and \n.
+type TsvFormatter struct {
+}
+
+func (f *TsvFormatter) Kind() Format {
+	return TsvFormat
+}
+
+func (f *TsvFormatter) Format(obj interface{}, writer io.Writer, _ interface{}) error {
+	node, err := jsonNode(obj)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	kind := v.Kind()
+	if node.Kind == yaml.MappingNode && kind == reflect.Struct {
+		if field, has := tsvMapField(node, v.Type()); has {
+			node, kind = field, reflect.Map
+		}
+	}
+
+	var rows [][]string
+	switch {
+	case node.Kind == yaml.SequenceNode:
+		for _, item := range node.Content {
+			rows = append(rows, tsvValues(item, nil))
+		}
+	case node.Kind == yaml.MappingNode && kind == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			rows = append(rows, tsvValues(node.Content[i+1], []string{tsvEscape(node.Content[i].Value)}))
+		}
+	case node.Kind == yaml.ScalarNode && node.Tag == "!!null" && kind == reflect.Map:
+		// An empty map field has no lines.
+	default:
+		rows = append(rows, tsvValues(node, nil))
+	}
+
+	for _, row := range rows {
+		if _, err := io.WriteString(writer, strings.Join(row, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var _ Formatter = (*TsvFormatter)(nil)
+
+// tsvMapField returns the node of the first map field of the struct type t, given node, the mapping node of a value of t.
+// has is false when t has no map field.
+func tsvMapField(node *yaml.Node, t reflect.Type) (field *yaml.Node, has bool) {
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() || structField.Type.Kind() != reflect.Map {
+			continue
+		}
+
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		} else if name == "" {
+			name = structField.Name
+		}
+
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == name {
+				return node.Content[j+1], true
+			}
+		}
+
+		// The field is omitted when empty.
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}, true
+	}
+
+	return nil, false
+}
+
+// tsvValues appends the scalar values of node to values, depth first.
+func tsvValues(node *yaml.Node, values []string) []string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return append(values, "")
+		}
+
+		return append(values, tsvEscape(node.Value))
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			values = tsvValues(node.Content[i], values)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			values = tsvValues(item, values)
+		}
+	}
+
+	return values
+}
+
+var tsvEscaper = strings.NewReplacer("\t", `\t`, "\r", `\r`, "\n", `\n`)
+
+func tsvEscape(value string) string {
+	return tsvEscaper.Replace(value)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tsvInput struct {
+	Name    string      `json:"name"`
+	IsCool  bool        `json:"isCool"`
+	Nested  *tsvNested  `json:"nested,omitempty"`
+	Missing *string     `json:"missing"`
+	Items   []tsvNested `json:"items,omitempty"`
+}
+
+type tsvNested struct {
+	Endpoint string `json:"endpoint"`
+}
+
+func TestTsvFormatterScalar(t *testing.T) {
+	obj := &tsvInput{
+		Name:   "with\ttab",
+		IsCool: true,
+		Nested: &tsvNested{Endpoint: "https://a"},
+		Items:  []tsvNested{{Endpoint: "https://b"}, {Endpoint: "https://c"}},
+	}
+
+	formatter := &TsvFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+	require.Equal(t, "with\\ttab\ttrue\thttps://a\t\thttps://b\thttps://c\n", buffer.String())
+}
+
+func TestTsvFormatterSlice(t *testing.T) {
+	obj := []tsvInput{
+		{Name: "one", IsCool: true},
+		{Name: "two", IsCool: false},
+	}
+
+	formatter := &TsvFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+	require.Equal(t, "one\ttrue\t\ntwo\tfalse\t\n", buffer.String())
+}
+
+func TestTsvFormatterMap(t *testing.T) {
+	obj := map[string]string{
+		"B_KEY": "line1\nline2",
+		"A_KEY": "value",
+	}
+
+	formatter := &TsvFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+	require.Equal(t, "A_KEY\tvalue\nB_KEY\tline1\\nline2\n", buffer.String())
+}
+
+type tsvResult struct {
+	Name     string               `json:"name"`
+	Services map[string]tsvNested `json:"services,omitempty"`
+	Other    map[string]string    `json:"other"`
+}
+
+func TestTsvFormatterStructWithMap(t *testing.T) {
+	obj := &tsvResult{
+		Name: "project",
+		Services: map[string]tsvNested{
+			"web": {Endpoint: "https://web"},
+			"api": {Endpoint: "https://api"},
+		},
+		Other: map[string]string{"KEY": "value"},
+	}
+
+	formatter := &TsvFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+	require.Equal(t, "api\thttps://api\nweb\thttps://web\n", buffer.String())
+
+	buffer.Reset()
+	err = formatter.Format(&tsvResult{Name: "empty"}, buffer, nil)
+	require.NoError(t, err)
+	require.Empty(t, buffer.String())
+}
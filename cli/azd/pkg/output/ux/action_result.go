@@ -6,7 +6,9 @@ package ux
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
@@ -14,6 +16,8 @@ type ActionResult struct {
 	SuccessMessage string
 	FollowUp       string
 	Err            error
+	// The error contract of Err, written instead of the error message as JSON.
+	ErrResult *contracts.ErrorResult
 }
 
 func (ar *ActionResult) ToString(currentIndentation string) (result string) {
@@ -30,6 +34,13 @@ func (ar *ActionResult) ToString(currentIndentation string) (result string) {
 }
 
 func (ar *ActionResult) MarshalJSON() ([]byte, error) {
+	if ar.ErrResult != nil {
+		return json.Marshal(contracts.EventEnvelope{
+			Type:      contracts.ErrorEventDataType,
+			Timestamp: time.Now(),
+			Data:      ar.ErrResult,
+		})
+	}
 	if ar.Err != nil {
 		return json.Marshal(output.EventForMessage(ar.Err.Error()))
 	}
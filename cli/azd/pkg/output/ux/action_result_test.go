@@ -4,9 +4,11 @@
 package ux
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/stretchr/testify/require"
 )
@@ -46,3 +48,26 @@ func TestActionResult_ToString(t *testing.T) {
 		})
 	}
 }
+
+func TestActionResult_MarshalJSON(t *testing.T) {
+	ar := &ActionResult{
+		Err: errors.New("not logged in"),
+		ErrResult: &contracts.ErrorResult{
+			Code:     contracts.ErrorCodeNotLoggedIn,
+			Category: contracts.ErrorCategoryAuth,
+			Message:  "not logged in",
+		},
+	}
+
+	b, err := json.Marshal(ar)
+	require.NoError(t, err)
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(b, &event))
+	require.Equal(t, "error", event["type"])
+	require.Equal(t, map[string]any{
+		"code":     "NotLoggedIn",
+		"category": "auth",
+		"message":  "not logged in",
+	}, event["data"])
+}
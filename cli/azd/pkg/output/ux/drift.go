@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/fatih/color"
+)
+
+// DriftReport defines a ux item for displaying the resources which drifted from the infrastructure as code.
+type DriftReport struct {
+	Resources []*DriftedResource
+}
+
+// DriftedResource is a resource which was changed or deleted outside of azd.
+type DriftedResource struct {
+	Layer   string
+	Type    string
+	Name    string
+	Deleted bool
+	// Properties are the changed properties, formatted for display.
+	Properties []string
+}
+
+func (r *DriftedResource) status() string {
+	if r.Deleted {
+		return "Deleted"
+	}
+
+	return "Modified"
+}
+
+func (dr *DriftReport) ToString(currentIndentation string) string {
+	if len(dr.Resources) == 0 {
+		return fmt.Sprintf("%s%s", currentIndentation, output.WithSuccessFormat("No drift detected."))
+	}
+
+	var maxStatusLen int
+	var maxTypeLen int
+	for _, res := range dr.Resources {
+		if statusLen := len(res.status()); statusLen > maxStatusLen {
+			maxStatusLen = statusLen
+		}
+		if typeLen := len(res.Type); typeLen > maxTypeLen {
+			maxTypeLen = typeLen
+		}
+	}
+
+	lines := []string{currentIndentation + "Drifted resources:", ""}
+	for _, res := range dr.Resources {
+		status := res.status()
+		statusColor := color.YellowString
+		if res.Deleted {
+			statusColor = color.RedString
+		}
+
+		name := res.Name
+		if res.Layer != "" {
+			name = fmt.Sprintf("%s %s", name, output.WithGrayFormat("(layer %s)", res.Layer))
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s %s %s",
+			currentIndentation,
+			statusColor(status+strings.Repeat(" ", maxStatusLen-len(status))+" :"),
+			res.Type+strings.Repeat(" ", maxTypeLen-len(res.Type))+" :",
+			name,
+		))
+
+		for _, property := range res.Properties {
+			lines = append(lines, fmt.Sprintf("%s    %s", currentIndentation, output.WithGrayFormat("%s", property)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (dr *DriftReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(contracts.EventEnvelope{
+		Type:      contracts.ConsoleMessageEventDataType,
+		Timestamp: time.Now(),
+		Data:      dr.Resources,
+	})
+}
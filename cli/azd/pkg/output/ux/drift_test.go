@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/test/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftReport(t *testing.T) {
+	dr := &DriftReport{
+		Resources: []*DriftedResource{
+			{
+				Type: "Web App",
+				Name: "app-123",
+				Properties: []string{
+					"properties.siteConfig.alwaysOn: expected true, found false",
+					"tags.owner: expected <none>, found \"someone\"",
+				},
+			},
+			{
+				Layer:   "network",
+				Type:    "Virtual Network",
+				Name:    "vnet-123",
+				Deleted: true,
+			},
+		},
+	}
+
+	output := dr.ToString("  ")
+	snapshot.SnapshotT(t, output)
+}
+
+func TestDriftReportNoDrift(t *testing.T) {
+	dr := &DriftReport{}
+
+	require.Contains(t, dr.ToString("  "), "No drift detected.")
+}
@@ -17,6 +17,14 @@ import (
 // PreviewProvision defines a ux item for displaying a provision preview.
 type PreviewProvision struct {
 	Operations []*Resource
+	// Parameters are the effective parameters of the provisioning.
+	Parameters []*PreviewParameter
+}
+
+// PreviewParameter is a parameter of the provisioning with its value, formatted for display.
+type PreviewParameter struct {
+	Name  string
+	Value string
 }
 
 // OperationType defines the valid options for a resource change.
@@ -69,11 +77,20 @@ func colorType(opType OperationType) func(string, ...interface{}) string {
 }
 
 func (pp *PreviewProvision) ToString(currentIndentation string) string {
-	if len(pp.Operations) == 0 {
-		// no output when there are no operations
-		return ""
+	var sections []string
+	if len(pp.Operations) > 0 {
+		sections = append(sections, pp.operationsToString(currentIndentation))
+	}
+
+	if len(pp.Parameters) > 0 {
+		sections = append(sections, pp.parametersToString(currentIndentation))
 	}
 
+	// no output when there are no operations nor parameters
+	return strings.Join(sections, "\n\n")
+}
+
+func (pp *PreviewProvision) operationsToString(currentIndentation string) string {
 	title := currentIndentation + "Resources:"
 
 	changes := make([]string, len(pp.Operations))
@@ -113,6 +130,27 @@ func (pp *PreviewProvision) ToString(currentIndentation string) string {
 	return fmt.Sprintf("%s\n\n%s", title, strings.Join(changes, "\n"))
 }
 
+func (pp *PreviewProvision) parametersToString(currentIndentation string) string {
+	var maxNameLen int
+	for _, param := range pp.Parameters {
+		if nameLen := len(param.Name); nameLen > maxNameLen {
+			maxNameLen = nameLen
+		}
+	}
+
+	lines := make([]string, len(pp.Parameters))
+	for index, param := range pp.Parameters {
+		lines[index] = fmt.Sprintf("%s%s%s : %s",
+			currentIndentation,
+			param.Name,
+			strings.Repeat(" ", maxNameLen-len(param.Name)),
+			param.Value,
+		)
+	}
+
+	return fmt.Sprintf("%sParameters:\n\n%s", currentIndentation, strings.Join(lines, "\n"))
+}
+
 func (pp *PreviewProvision) MarshalJSON() ([]byte, error) {
 	return json.Marshal(contracts.EventEnvelope{
 		Type:      contracts.ConsoleMessageEventDataType,
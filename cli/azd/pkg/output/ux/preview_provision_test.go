@@ -48,3 +48,28 @@ func TestPreviewProvisionNoChanges(t *testing.T) {
 	output := pp.ToString("   ")
 	require.Equal(t, "", output)
 }
+
+func TestPreviewProvisionParameters(t *testing.T) {
+	pp := &PreviewProvision{
+		Operations: []*Resource{
+			{
+				Type:      "Web App",
+				Name:      "app-123",
+				Operation: OperationTypeModify,
+			},
+		},
+		Parameters: []*PreviewParameter{
+			{
+				Name:  "adminPassword",
+				Value: "********",
+			},
+			{
+				Name:  "sku",
+				Value: `"P1v3"`,
+			},
+		},
+	}
+
+	output := pp.ToString("   ")
+	snapshot.SnapshotT(t, output)
+}
@@ -6,6 +6,7 @@ package ux
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/fatih/color"
@@ -27,6 +28,9 @@ type Show struct {
 	Services        []*ShowService
 	Environments    []*ShowEnvironment
 	AzurePortalLink string
+	// DriftCheckedAt is when the infrastructure was last checked for drift, zero when it was never checked.
+	DriftCheckedAt   time.Time
+	DriftedResources []*DriftedResource
 }
 
 const (
@@ -39,6 +43,7 @@ const (
 	cCurrentEnv        = " [Current]"
 	cRemoteEnv         = " (Remote)"
 	cViewInPortal      = "\n  View in Azure Portal:\n"
+	cDrift             = "\n  Infrastructure drift:\n"
 )
 
 func (s *Show) ToString(currentIndentation string) string {
@@ -47,7 +52,7 @@ func (s *Show) ToString(currentIndentation string) string {
 		pickHeader = cHeaderNotDeployed
 	}
 	return fmt.Sprintf(
-		"%s%s%s%s%s%s%s%s%s    %s\n",
+		"%s%s%s%s%s%s%s%s%s%s    %s\n",
 		pickHeader,
 		cHeaderNote,
 		color.HiBlueString("%s\n\n", cShowDifferentEnv),
@@ -56,6 +61,7 @@ func (s *Show) ToString(currentIndentation string) string {
 		services(s.Services),
 		cEnvironments,
 		environments(s.Environments),
+		drift(s.DriftCheckedAt, s.DriftedResources),
 		cViewInPortal,
 		azurePortalLink(s.AzurePortalLink),
 	)
@@ -120,6 +126,41 @@ func environments(environments []*ShowEnvironment) string {
 	return strings.Join(lines, "\n")
 }
 
+// drift returns the drift section, which is only shown once the infrastructure was checked for drift.
+func drift(checkedAt time.Time, resources []*DriftedResource) string {
+	if checkedAt.IsZero() {
+		return ""
+	}
+
+	checked := output.WithGrayFormat(" (checked %s)", checkedAt.Format(time.RFC1123))
+	if len(resources) == 0 {
+		return fmt.Sprintf("%s    No drift detected%s", cDrift, checked)
+	}
+
+	lines := make([]string, len(resources))
+	for index, resource := range resources {
+		statusColor := color.YellowString
+		if resource.Deleted {
+			statusColor = color.RedString
+		}
+
+		lines[index] = fmt.Sprintf(
+			"    %s  %s %s",
+			statusColor("%-8s", resource.status()),
+			resource.Type,
+			color.HiBlueString(resource.Name),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s%s\n    Run %s to bring them back in line%s",
+		cDrift,
+		strings.Join(lines, "\n"),
+		color.HiBlueString("azd provision"),
+		checked,
+	)
+}
+
 func (s *Show) MarshalJSON() ([]byte, error) {
 	return nil, fmt.Errorf("not implemented")
 }
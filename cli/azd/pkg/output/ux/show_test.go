@@ -5,6 +5,7 @@ package ux
 
 import (
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/test/snapshot"
 )
@@ -104,3 +105,27 @@ func TestShowRemoteAndCurrent(t *testing.T) {
 	output := pp.ToString("")
 	snapshot.SnapshotT(t, output)
 }
+
+func TestShowDrift(t *testing.T) {
+	pp := &Show{
+		AppName:         "Foo",
+		Services:        []*ShowService{},
+		Environments:    []*ShowEnvironment{},
+		AzurePortalLink: "foo.com",
+		DriftCheckedAt:  time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		DriftedResources: []*DriftedResource{
+			{
+				Type: "Web App",
+				Name: "app-123",
+			},
+			{
+				Type:    "Key Vault",
+				Name:    "kv-123",
+				Deleted: true,
+			},
+		},
+	}
+
+	output := pp.ToString("")
+	snapshot.SnapshotT(t, output)
+}
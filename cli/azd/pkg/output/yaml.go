@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YamlFormatter writes objects as YAML. Objects are converted to JSON first, so the field names and their order match the
+// JSON output, which follows the `json` tags of the types in the contracts package.
+type YamlFormatter struct {
+}
+
+func (f *YamlFormatter) Kind() Format {
+	return YamlFormat
+}
+
+func (f *YamlFormatter) Format(obj interface{}, writer io.Writer, _ interface{}) error {
+	node, err := jsonNode(obj)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+var _ Formatter = (*YamlFormatter)(nil)
+
+// jsonNode returns the YAML node of the JSON representation of obj, which keeps the order of the fields. The nodes use the
+// block style of YAML instead of the flow style of JSON.
+func jsonNode(obj interface{}) (*yaml.Node, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is a subset of YAML, so the YAML decoder reads it as is.
+	var document yaml.Node
+	if err := yaml.Unmarshal(b, &document); err != nil {
+		return nil, err
+	}
+
+	clearStyle(&document)
+
+	if len(document.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	return document.Content[0], nil
+}
+
+// clearStyle resets the style of node and its children to the default style. Strings whose value would be read as another
+// type are still quoted by the encoder.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type yamlInput struct {
+	Size     string            `json:"size"`
+	IsCool   bool              `json:"isCool"`
+	Version  string            `json:"version"`
+	Tags     []string          `json:"tags,omitempty"`
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+func TestYamlFormatterScalar(t *testing.T) {
+	obj := yamlInput{
+		Size:     "mega",
+		IsCool:   true,
+		Version:  "1.0",
+		Tags:     []string{"a", "b"},
+		Settings: map[string]string{"zone": "1", "mode": "fast"},
+	}
+
+	formatter := &YamlFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+
+	// Fields keep the order of the JSON output, and strings which read as other types are quoted.
+	expected := `size: mega
+isCool: true
+version: "1.0"
+tags:
+  - a
+  - b
+settings:
+  mode: fast
+  zone: "1"
+`
+	require.Equal(t, expected, buffer.String())
+}
+
+func TestYamlFormatterSlice(t *testing.T) {
+	obj := []yamlInput{
+		{Size: "mega", IsCool: true, Version: "1"},
+		{Size: "small", IsCool: false, Version: "2"},
+	}
+
+	formatter := &YamlFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+
+	expected := `- size: mega
+  isCool: true
+  version: "1"
+- size: small
+  isCool: false
+  version: "2"
+`
+	require.Equal(t, expected, buffer.String())
+}
@@ -8,10 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azdo"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -26,6 +29,7 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
 	azdoGit "github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"golang.org/x/exp/maps"
 )
 
 // AzdoScmProvider implements ScmProvider using Azure DevOps as the provider
@@ -638,6 +642,10 @@ type AzdoCiProvider struct {
 	credentials   *azcli.AzureCredentials
 	console       input.Console
 	commandRunner exec.CommandRunner
+	cloud         *cloud.Cloud
+	// The auth type and service connection configured by configureConnection
+	authType            PipelineAuthType
+	serviceConnectionId string
 }
 
 func NewAzdoCiProvider(
@@ -646,6 +654,7 @@ func NewAzdoCiProvider(
 	azdContext *azdcontext.AzdContext,
 	console input.Console,
 	commandRunner exec.CommandRunner,
+	cloud *cloud.Cloud,
 ) CiProvider {
 	return &AzdoCiProvider{
 		envManager:    envManager,
@@ -653,6 +662,7 @@ func NewAzdoCiProvider(
 		AzdContext:    azdContext,
 		console:       console,
 		commandRunner: commandRunner,
+		cloud:         cloud,
 	}
 }
 
@@ -672,10 +682,10 @@ func (p *AzdoCiProvider) preConfigureCheck(
 ) (bool, error) {
 	authType := PipelineAuthType(pipelineManagerArgs.PipelineAuthTypeName)
 
-	if authType == AuthTypeFederated {
+	if authType == AuthTypeFederated && infraOptions.Provider == provisioning.Terraform {
 		return false, fmt.Errorf(
 			//nolint:lll
-			"Azure DevOps does not support federated authentication. To explicitly use client credentials set the %s flag. %w",
+			"Azure DevOps does not support federated authentication with Terraform. To explicitly use client credentials set the %s flag. %w",
 			output.WithBackticks("--auth-type client-credentials"),
 			ErrAuthNotSupported,
 		)
@@ -702,17 +712,70 @@ func (p *AzdoCiProvider) credentialOptions(
 	repoDetails *gitRepositoryDetails,
 	infraOptions provisioning.Options,
 	authType PipelineAuthType,
-) *CredentialOptions {
+) (*CredentialOptions, error) {
 	if authType == "" || authType == AuthTypeClientCredentials {
 		return &CredentialOptions{
 			EnableClientCredentials: true,
+		}, nil
+	}
+
+	if authType == AuthTypeFederated {
+		details := repoDetails.details.(*AzdoRepositoryDetails)
+
+		connection, err := p.getAzdoConnection(ctx)
+		if err != nil {
+			return nil, err
 		}
+
+		organizationId, err := azdo.GetOrganizationId(ctx, connection)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CredentialOptions{
+			EnableFederatedCredentials: true,
+			FederatedCredentialOptions: []*graphsdk.FederatedIdentityCredential{
+				azdoFederatedCredential(details.orgName, organizationId, details.projectName),
+			},
+		}, nil
 	}
 
 	return &CredentialOptions{
 		EnableClientCredentials:    false,
 		EnableFederatedCredentials: false,
+	}, nil
+}
+
+// azdoFederatedCredential returns the federated identity credential trusting the tokens issued to the service connection
+// of the Azure DevOps project.
+func azdoFederatedCredential(
+	orgName string,
+	organizationId string,
+	projectName string,
+) *graphsdk.FederatedIdentityCredential {
+	credentialSafeName := fmt.Sprintf("%s-%s-%s", orgName, projectName, azdo.ServiceConnectionName)
+
+	return &graphsdk.FederatedIdentityCredential{
+		Name:        url.PathEscape(strings.ReplaceAll(credentialSafeName, " ", "-")),
+		Issuer:      azdo.ServiceConnectionIssuer(organizationId),
+		Subject:     azdo.ServiceConnectionSubject(orgName, projectName),
+		Description: convert.RefOf("Created by Azure Developer CLI"),
+		Audiences:   []string{federatedIdentityAudience},
+	}
+}
+
+// getAzdoConnection returns an azuredevops.Connection for the organization of the environment
+func (p *AzdoCiProvider) getAzdoConnection(ctx context.Context) (*azuredevops.Connection, error) {
+	org, _, err := azdo.EnsureOrgNameExists(ctx, p.envManager, p.Env, p.console)
+	if err != nil {
+		return nil, err
 	}
+	pat, _, err := azdo.EnsurePatExists(ctx, p.Env, p.console)
+	if err != nil {
+		return nil, err
+	}
+
+	return azdo.GetConnection(ctx, org, pat)
 }
 
 // configureConnection set up Azure DevOps with the Azure credential
@@ -725,22 +788,27 @@ func (p *AzdoCiProvider) configureConnection(
 	credentials *azcli.AzureCredentials,
 ) error {
 	p.credentials = credentials
+	p.authType = authType
 	details := repoDetails.details.(*AzdoRepositoryDetails)
-	org, _, err := azdo.EnsureOrgNameExists(ctx, p.envManager, p.Env, p.console)
+	connection, err := p.getAzdoConnection(ctx)
 	if err != nil {
 		return err
 	}
-	pat, _, err := azdo.EnsurePatExists(ctx, p.Env, p.console)
+	serviceConnection, err := azdo.CreateServiceConnection(
+		ctx,
+		connection,
+		details.projectId,
+		*p.Env,
+		p.credentials,
+		authType == AuthTypeFederated,
+		p.cloud,
+		p.console,
+	)
 	if err != nil {
 		return err
 	}
-	connection, err := azdo.GetConnection(ctx, org, pat)
-	if err != nil {
-		return err
-	}
-	err = azdo.CreateServiceConnection(ctx, connection, details.projectId, *p.Env, p.credentials, p.console)
-	if err != nil {
-		return err
+	if serviceConnection != nil && serviceConnection.Id != nil {
+		p.serviceConnectionId = serviceConnection.Id.String()
 	}
 
 	p.console.MessageUxItem(ctx, &ux.MultilineMessage{
@@ -762,18 +830,23 @@ func (p *AzdoCiProvider) configurePipeline(
 ) (CiPipeline, error) {
 	details := repoDetails.details.(*AzdoRepositoryDetails)
 
-	org, _, err := azdo.EnsureOrgNameExists(ctx, p.envManager, p.Env, p.console)
-	if err != nil {
-		return nil, err
-	}
-	pat, _, err := azdo.EnsurePatExists(ctx, p.Env, p.console)
+	connection, err := p.getAzdoConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
-	connection, err := azdo.GetConnection(ctx, org, pat)
-	if err != nil {
-		return nil, err
+
+	if p.authType == AuthTypeFederated {
+		// Lets pipeline steps log in with `azd auth login --federated-credential-provider azure-pipelines`, outside of
+		// the tasks using the service connection.
+		additionalVariables = maps.Clone(additionalVariables)
+		if additionalVariables == nil {
+			additionalVariables = map[string]string{}
+		}
+		additionalVariables["AZURE_CLIENT_ID"] = p.credentials.ClientId
+		additionalVariables["AZURE_TENANT_ID"] = p.credentials.TenantId
+		additionalVariables["AZURE_SERVICE_CONNECTION_ID"] = p.serviceConnectionId
 	}
+
 	buildDefinition, err := azdo.CreatePipeline(
 		ctx,
 		details.projectId,
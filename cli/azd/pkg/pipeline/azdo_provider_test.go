@@ -139,22 +139,48 @@ func Test_azdo_ci_provider_preConfigureCheck(t *testing.T) {
 		require.True(t, updatedConfig)
 	})
 
-	t.Run("fails if auth type is set to federated", func(t *testing.T) {
+	t.Run("success with federated auth type", func(t *testing.T) {
 		ctx := context.Background()
 
 		testConsole := mockinput.NewMockConsole()
+		testConsole.WhenPrompt(func(options input.ConsoleOptions) bool {
+			return options.Message == "Personal Access Token (PAT):"
+		}).Respond("testPAT12345")
 		pipelineManagerArgs := PipelineManagerArgs{
 			PipelineAuthTypeName: string(AuthTypeFederated),
 		}
 		provider := getAzdoCiProviderTestHarness(testConsole)
 
-		updatedConfig, err := provider.preConfigureCheck(ctx, pipelineManagerArgs, provisioning.Options{}, "")
+		_, err := provider.preConfigureCheck(ctx, pipelineManagerArgs, provisioning.Options{}, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("fails if auth type is set to federated with terraform", func(t *testing.T) {
+		ctx := context.Background()
+
+		testConsole := mockinput.NewMockConsole()
+		pipelineManagerArgs := PipelineManagerArgs{
+			PipelineAuthTypeName: string(AuthTypeFederated),
+		}
+		provider := getAzdoCiProviderTestHarness(testConsole)
+
+		updatedConfig, err := provider.preConfigureCheck(
+			ctx, pipelineManagerArgs, provisioning.Options{Provider: provisioning.Terraform}, "")
 		require.Error(t, err)
 		require.False(t, updatedConfig)
 		require.True(t, errors.Is(err, ErrAuthNotSupported))
 	})
 }
 
+func Test_azdoFederatedCredential(t *testing.T) {
+	credential := azdoFederatedCredential("fake_org", "00000000-0000-0000-0000-000000000001", "my project")
+
+	require.Equal(t, "fake_org-my-project-azconnection", credential.Name)
+	require.Equal(t, "https://vstoken.dev.azure.com/00000000-0000-0000-0000-000000000001", credential.Issuer)
+	require.Equal(t, "sc://fake_org/my project/azconnection", credential.Subject)
+	require.Equal(t, []string{"api://AzureADTokenExchange"}, credential.Audiences)
+}
+
 func Test_saveEnvironmentConfig(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	env := environment.New("test")
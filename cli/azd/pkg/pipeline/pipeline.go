@@ -115,7 +115,7 @@ type CiProvider interface {
 		repoDetails *gitRepositoryDetails,
 		infraOptions provisioning.Options,
 		authType PipelineAuthType,
-	) *CredentialOptions
+	) (*CredentialOptions, error)
 }
 
 func folderExists(folderPath string) bool {
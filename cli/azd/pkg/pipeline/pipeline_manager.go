@@ -266,12 +266,15 @@ func (pm *PipelineManager) Configure(ctx context.Context) (result *PipelineConfi
 	pm.console.ShowSpinner(ctx, displayMsg, input.Step)
 
 	// Get the requested credential options from the CI provider
-	credentialOptions := pm.ciProvider.credentialOptions(
+	credentialOptions, err := pm.ciProvider.credentialOptions(
 		ctx,
 		gitRepoInfo,
 		infra.Options,
 		PipelineAuthType(pm.args.PipelineAuthTypeName),
 	)
+	if err != nil {
+		return result, fmt.Errorf("failed to resolve credential options: %w", err)
+	}
 
 	subscriptionId := pm.env.GetSubscriptionId()
 	credentials := &azcli.AzureCredentials{
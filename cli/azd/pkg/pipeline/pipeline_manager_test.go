@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
@@ -405,7 +406,7 @@ func createPipelineManager(
 	envManager := &mockenv.MockEnvManager{}
 	envManager.On("Save", mock.Anything, env).Return(nil)
 
-	adService := azcli.NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
+	adService := azcli.NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, cloud.AzurePublic())
 
 	// Singletons
 	ioc.RegisterInstance(mockContext.Container, *mockContext.Context)
@@ -413,6 +414,7 @@ func createPipelineManager(
 	ioc.RegisterInstance[environment.Manager](mockContext.Container, envManager)
 	ioc.RegisterInstance(mockContext.Container, env)
 	ioc.RegisterInstance(mockContext.Container, adService)
+	ioc.RegisterInstance(mockContext.Container, cloud.AzurePublic())
 	ioc.RegisterInstance[account.SubscriptionCredentialProvider](
 		mockContext.Container,
 		mockContext.SubscriptionCredentialProvider,
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
 	"github.com/azure/azure-dev/cli/azd/pkg/apphost"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
@@ -162,26 +163,14 @@ func (ai *DotNetImporter) Services(
 			return nil, err
 		}
 
-		// TODO(ellismg): Some of this code is duplicated from project.Parse, we should centralize this logic long term.
 		svc := &ServiceConfig{
 			RelativePath: relPath,
 			Language:     ServiceLanguageDotNet,
 			Host:         DotNetContainerAppTarget,
 		}
 
-		svc.Name = name
-		svc.Project = p
-		svc.EventDispatcher = ext.NewEventDispatcher[ServiceLifecycleEventArgs]()
-
-		svc.Infra.Provider, err = provisioning.ParseProvider(svc.Infra.Provider)
-		if err != nil {
-			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
-		}
-
-		svc.DotNetContainerApp = &DotNetContainerAppOptions{
-			Manifest:    manifest,
-			ProjectName: name,
-			ProjectPath: svcConfig.Path(),
+		if err := initAppHostService(p, svcConfig, manifest, name, svc); err != nil {
+			return nil, err
 		}
 
 		services[svc.Name] = svc
@@ -194,7 +183,6 @@ func (ai *DotNetImporter) Services(
 			return nil, err
 		}
 
-		// TODO(ellismg): Some of this code is duplicated from project.Parse, we should centralize this logic long term.
 		svc := &ServiceConfig{
 			RelativePath: relPath,
 			Language:     ServiceLanguageDocker,
@@ -205,26 +193,119 @@ func (ai *DotNetImporter) Services(
 			},
 		}
 
-		svc.Name = name
-		svc.Project = p
-		svc.EventDispatcher = ext.NewEventDispatcher[ServiceLifecycleEventArgs]()
+		if err := initAppHostService(p, svcConfig, manifest, name, svc); err != nil {
+			return nil, err
+		}
+
+		services[svc.Name] = svc
+	}
+
+	executables := apphost.Executables(manifest)
+	for name, exe := range executables {
+		if exe.WorkingDirectory == "" {
+			return nil, fmt.Errorf("executable resource '%s' did not include a working directory", name)
+		}
+
+		relPath, err := filepath.Rel(p.Path, exe.WorkingDirectory)
+		if err != nil {
+			return nil, err
+		}
 
-		svc.Infra.Provider, err = provisioning.ParseProvider(svc.Infra.Provider)
+		language, docker, err := detectExecutableLanguage(ctx, exe.WorkingDirectory, exe.Command)
 		if err != nil {
-			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+			return nil, fmt.Errorf("detecting language of %s: %w", name, err)
+		}
+
+		svc := &ServiceConfig{
+			RelativePath: relPath,
+			Language:     language,
+			Host:         DotNetContainerAppTarget,
+			Docker:       docker,
 		}
 
-		svc.DotNetContainerApp = &DotNetContainerAppOptions{
-			Manifest:    manifest,
-			ProjectName: name,
-			ProjectPath: svcConfig.Path(),
+		if err := initAppHostService(p, svcConfig, manifest, name, svc); err != nil {
+			return nil, err
 		}
 
 		services[svc.Name] = svc
 	}
+
 	return services, nil
 }
 
+// initAppHostService fills in the parts of the configuration of a service of the app host which are not specific to the
+// kind of resource it is created for.
+func initAppHostService(
+	p *ProjectConfig, appHost *ServiceConfig, manifest *apphost.Manifest, name string, svc *ServiceConfig,
+) error {
+	// TODO(ellismg): Some of this code is duplicated from project.Parse, we should centralize this logic long term.
+	svc.Name = name
+	svc.Project = p
+	svc.EventDispatcher = ext.NewEventDispatcher[ServiceLifecycleEventArgs]()
+
+	var err error
+	svc.Infra.Provider, err = provisioning.ParseProvider(svc.Infra.Provider)
+	if err != nil {
+		return fmt.Errorf("parsing service %s: %w", svc.Name, err)
+	}
+
+	svc.DotNetContainerApp = &DotNetContainerAppOptions{
+		Manifest:    manifest,
+		ProjectName: name,
+		ProjectPath: appHost.Path(),
+	}
+
+	return nil
+}
+
+// executableLanguages maps the languages appdetect finds in the working directory of an executable.v0 resource to the
+// language of the service azd creates for it.
+var executableLanguages = map[appdetect.Language]ServiceLanguageKind{
+	appdetect.JavaScript: ServiceLanguageJavaScript,
+	appdetect.TypeScript: ServiceLanguageTypeScript,
+	appdetect.Python:     ServiceLanguagePython,
+	appdetect.Go:         ServiceLanguageGo,
+}
+
+// detectExecutableLanguage determines how the source code of an executable.v0 resource is built into a container. When the
+// working directory contains a Dockerfile, it is used. Otherwise the container is built from source with `pack`, based on
+// the language detected in the working directory or, failing that, inferred from the command that starts the executable.
+func detectExecutableLanguage(
+	ctx context.Context, workingDirectory string, command string,
+) (ServiceLanguageKind, DockerProjectOptions, error) {
+	project, err := appdetect.DetectDirectory(
+		ctx, workingDirectory, appdetect.WithJavaScript(), appdetect.WithPython(), appdetect.WithGo())
+	if err != nil {
+		return ServiceLanguageKind(""), DockerProjectOptions{}, err
+	}
+
+	if project != nil && project.Docker != nil {
+		return ServiceLanguageDocker, DockerProjectOptions{
+			Path:    project.Docker.Path,
+			Context: workingDirectory,
+		}, nil
+	}
+
+	if project != nil {
+		if language, has := executableLanguages[project.Language]; has {
+			return language, DockerProjectOptions{}, nil
+		}
+	}
+
+	switch strings.TrimSuffix(filepath.Base(command), filepath.Ext(command)) {
+	case "node", "npm", "npx", "yarn", "pnpm":
+		return ServiceLanguageJavaScript, DockerProjectOptions{}, nil
+	case "python", "python3", "py", "uvicorn", "gunicorn", "flask":
+		return ServiceLanguagePython, DockerProjectOptions{}, nil
+	case "go":
+		return ServiceLanguageGo, DockerProjectOptions{}, nil
+	}
+
+	return ServiceLanguageKind(""), DockerProjectOptions{}, fmt.Errorf(
+		"could not determine the language of the application in %s, add a Dockerfile to the directory to containerize it",
+		workingDirectory)
+}
+
 func (ai *DotNetImporter) SynthAllInfrastructure(
 	ctx context.Context, p *ProjectConfig, svcConfig *ServiceConfig,
 ) (fs.FS, error) {
@@ -269,18 +350,18 @@ func (ai *DotNetImporter) SynthAllInfrastructure(
 	// writeManifestForResource writes the containerApp.tmpl.yaml for the given resource to the generated filesystem. The
 	// manifest is written to a file name "containerApp.tmpl.yaml" in the same directory as the project that produces the
 	// container we will deploy.
-	writeManifestForResource := func(name string, path string) error {
+	writeManifestForResource := func(name string, dir string) error {
 		containerAppManifest, err := apphost.ContainerAppManifestTemplateForProject(manifest, name)
 		if err != nil {
 			return fmt.Errorf("generating containerApp.tmpl.yaml for resource %s: %w", name, err)
 		}
 
-		projectRelPath, err := filepath.Rel(p.Path, path)
+		projectRelDir, err := filepath.Rel(p.Path, dir)
 		if err != nil {
 			return err
 		}
 
-		manifestPath := filepath.Join(filepath.Dir(projectRelPath), "manifests", "containerApp.tmpl.yaml")
+		manifestPath := filepath.Join(projectRelDir, "manifests", "containerApp.tmpl.yaml")
 
 		if err := generatedFS.MkdirAll(filepath.Dir(manifestPath), osutil.PermissionDirectoryOwnerOnly); err != nil {
 			return err
@@ -290,13 +371,19 @@ func (ai *DotNetImporter) SynthAllInfrastructure(
 	}
 
 	for name, path := range apphost.ProjectPaths(manifest) {
-		if writeManifestForResource(name, path) != nil {
+		if err := writeManifestForResource(name, filepath.Dir(path)); err != nil {
 			return nil, err
 		}
 	}
 
 	for name, docker := range apphost.Dockerfiles(manifest) {
-		if writeManifestForResource(name, docker.Path) != nil {
+		if err := writeManifestForResource(name, filepath.Dir(docker.Path)); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, exe := range apphost.Executables(manifest) {
+		if err := writeManifestForResource(name, exe.WorkingDirectory); err != nil {
 			return nil, err
 		}
 	}
@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_detectExecutableLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		command  string
+		language ServiceLanguageKind
+		wantErr  bool
+	}{
+		{"Dockerfile", []string{"package.json", "Dockerfile"}, "npm", ServiceLanguageDocker, false},
+		{"Node", []string{"package.json"}, "npm", ServiceLanguageJavaScript, false},
+		{"TypeScript", []string{"package.json", "index.ts"}, "npm", ServiceLanguageTypeScript, false},
+		{"Python", []string{"requirements.txt", "main.py"}, "python", ServiceLanguagePython, false},
+		{"PythonFromCommand", []string{"main.py"}, "/usr/bin/python3", ServiceLanguagePython, false},
+		{"NodeFromCommand", []string{"index.js"}, "node", ServiceLanguageJavaScript, false},
+		{"Go", []string{"go.mod", "main.go"}, "go", ServiceLanguageGo, false},
+		{"GoFromCommand", []string{"main.go"}, "go", ServiceLanguageGo, false},
+		{"Unknown", []string{"main.swift"}, "swift", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("{}"), osutil.PermissionFile))
+			}
+
+			language, docker, err := detectExecutableLanguage(context.Background(), dir, tt.command)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.language, language)
+
+			if language == ServiceLanguageDocker {
+				require.Equal(t, filepath.Join(dir, "Dockerfile"), docker.Path)
+				require.Equal(t, dir, docker.Context)
+			} else {
+				require.Empty(t, docker.Path)
+			}
+		})
+	}
+}
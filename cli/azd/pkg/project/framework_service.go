@@ -22,7 +22,15 @@ const (
 	ServiceLanguageTypeScript ServiceLanguageKind = "ts"
 	ServiceLanguagePython     ServiceLanguageKind = "python"
 	ServiceLanguageJava       ServiceLanguageKind = "java"
+	ServiceLanguageGo         ServiceLanguageKind = "go"
+	ServiceLanguageRuby       ServiceLanguageKind = "ruby"
+	ServiceLanguagePhp        ServiceLanguageKind = "php"
+	ServiceLanguageRust       ServiceLanguageKind = "rust"
 	ServiceLanguageDocker     ServiceLanguageKind = "docker"
+
+	// ServiceLanguageGradle is the framework of Java services built with Gradle. Like docker, it's derived from the
+	// service, here from its build files, rather than set in azure.yaml.
+	ServiceLanguageGradle ServiceLanguageKind = "gradle"
 )
 
 func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error) {
@@ -42,7 +50,11 @@ func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error)
 		ServiceLanguageJavaScript,
 		ServiceLanguageTypeScript,
 		ServiceLanguagePython,
-		ServiceLanguageJava:
+		ServiceLanguageJava,
+		ServiceLanguageGo,
+		ServiceLanguageRuby,
+		ServiceLanguagePhp,
+		ServiceLanguageRust:
 		// Excluding ServiceLanguageDocker since it is implicitly derived currently, and not an actual language
 		return kind, nil
 	}
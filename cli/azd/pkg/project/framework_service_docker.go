@@ -28,6 +28,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/docker"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/pack"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -84,6 +85,7 @@ type dockerProject struct {
 	console             input.Console
 	alphaFeatureManager *alpha.FeatureManager
 	commandRunner       exec.CommandRunner
+	toolCache           *toolcache.Cache
 }
 
 // NewDockerProject creates a new instance of a Azd project that
@@ -95,6 +97,7 @@ func NewDockerProject(
 	console input.Console,
 	alphaFeatureManager *alpha.FeatureManager,
 	commandRunner exec.CommandRunner,
+	toolCache *toolcache.Cache,
 ) CompositeFrameworkService {
 	return &dockerProject{
 		env:                 env,
@@ -103,6 +106,7 @@ func NewDockerProject(
 		console:             console,
 		alphaFeatureManager: alphaFeatureManager,
 		commandRunner:       commandRunner,
+		toolCache:           toolCache,
 		framework:           NewNoOpProject(env),
 	}
 }
@@ -117,8 +121,9 @@ func NewDockerProjectAsFrameworkService(
 	console input.Console,
 	alphaFeatureManager *alpha.FeatureManager,
 	commandRunner exec.CommandRunner,
+	toolCache *toolcache.Cache,
 ) FrameworkService {
-	return NewDockerProject(env, docker, containerHelper, console, alphaFeatureManager, commandRunner)
+	return NewDockerProject(env, docker, containerHelper, console, alphaFeatureManager, commandRunner, toolCache)
 }
 
 func (p *dockerProject) Requirements() FrameworkRequirements {
@@ -291,25 +296,67 @@ func (p *dockerProject) Package(
 // Default builder image to produce container images from source
 const DefaultBuilderImage = "mcr.microsoft.com/oryx/builder:debian-bullseye-20231107.2"
 
+// Builder images to produce container images from the source of languages the default builder doesn't support
+const (
+	PaketoBuilderImage     = "paketobuildpacks/builder-jammy-base:latest"
+	PaketoFullBuilderImage = "paketobuildpacks/builder-jammy-full:latest"
+)
+
+// Buildpack to produce container images from the source of Rust services, which no builder image includes. The version is
+// pinned, so builds don't change with new releases of the buildpack. It can be overridden with AZD_RUST_BUILDPACK.
+const RustBuildpack = "docker.io/paketocommunity/rust:0.39.0"
+
+// paketoBuilders are the builder images for the languages built with Paketo buildpacks
+var paketoBuilders = map[ServiceLanguageKind]string{
+	ServiceLanguageGo:     PaketoBuilderImage,
+	ServiceLanguageRuby:   PaketoBuilderImage,
+	ServiceLanguagePhp:    PaketoFullBuilderImage,
+	ServiceLanguageRust:   PaketoBuilderImage,
+	ServiceLanguageGradle: PaketoBuilderImage,
+}
+
 func (p *dockerProject) packBuild(
 	ctx context.Context,
 	svc *ServiceConfig,
 	dockerOptions DockerProjectOptions,
 	imageName string) (*ServiceBuildResult, error) {
-	packCli, err := pack.NewPackCli(ctx, p.console, p.commandRunner)
+	packCli, err := pack.NewPackCli(ctx, p.console, p.commandRunner, p.toolCache)
 	if err != nil {
 		return nil, err
 	}
 	builder := DefaultBuilderImage
+	language := svc.Language
+	if language == ServiceLanguageJava && isGradleProject(svc.Path()) {
+		// The default builder builds Java with Maven only
+		language = ServiceLanguageGradle
+	}
+
+	paketoBuilder, paketo := paketoBuilders[language]
+	if paketo {
+		builder = paketoBuilder
+	}
 
 	environ := []string{}
+	buildpacks := []string{}
 	userDefinedImage := false
 	if os.Getenv("AZD_BUILDER_IMAGE") != "" {
 		builder = os.Getenv("AZD_BUILDER_IMAGE")
 		userDefinedImage = true
 	}
 
-	if !userDefinedImage {
+	if !userDefinedImage && svc.Language == ServiceLanguageRust {
+		rustBuildpack := RustBuildpack
+		if override := os.Getenv("AZD_RUST_BUILDPACK"); override != "" {
+			rustBuildpack = override
+		}
+
+		buildpacks = append(buildpacks, rustBuildpack)
+	}
+
+	if !userDefinedImage && paketo {
+		// Always default to port 80 for consistency across languages
+		environ = append(environ, "BPE_DEFAULT_PORT=80")
+	} else if !userDefinedImage {
 		// Always default to port 80 for consistency across languages
 		environ = append(environ, "ORYX_RUNTIME_PORT=80")
 
@@ -371,6 +418,7 @@ func (p *dockerProject) packBuild(
 		ctx,
 		svc.Path(),
 		builder,
+		buildpacks,
 		imageName,
 		environ,
 		previewer)
@@ -109,7 +109,8 @@ services:
 		NewContainerHelper(env, envManager, clock.NewMock(), nil, docker),
 		mockinput.NewMockConsole(),
 		mockContext.AlphaFeaturesManager,
-		mockContext.CommandRunner)
+		mockContext.CommandRunner,
+		nil)
 	framework.SetSource(internalFramework)
 
 	buildTask := framework.Build(*mockContext.Context, service, nil)
@@ -217,7 +218,8 @@ services:
 		NewContainerHelper(env, envManager, clock.NewMock(), nil, docker),
 		mockinput.NewMockConsole(),
 		mockContext.AlphaFeaturesManager,
-		mockContext.CommandRunner)
+		mockContext.CommandRunner,
+		nil)
 	framework.SetSource(internalFramework)
 
 	buildTask := framework.Build(*mockContext.Context, service, nil)
@@ -273,7 +275,8 @@ func Test_DockerProject_Build(t *testing.T) {
 		NewContainerHelper(env, envManager, clock.NewMock(), nil, dockerCli),
 		mockinput.NewMockConsole(),
 		mockContext.AlphaFeaturesManager,
-		mockContext.CommandRunner)
+		mockContext.CommandRunner,
+		nil)
 	buildTask := dockerProject.Build(*mockContext.Context, serviceConfig, nil)
 	logProgress(buildTask)
 
@@ -326,7 +329,8 @@ func Test_DockerProject_Package(t *testing.T) {
 		NewContainerHelper(env, envManager, clock.NewMock(), nil, dockerCli),
 		mockinput.NewMockConsole(),
 		mockContext.AlphaFeaturesManager,
-		mockContext.CommandRunner)
+		mockContext.CommandRunner,
+		nil)
 	packageTask := dockerProject.Package(
 		*mockContext.Context,
 		serviceConfig,
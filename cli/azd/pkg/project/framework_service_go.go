@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/golang"
+)
+
+// GoExecutableName is the name of the executable built for a Go service, which App Service startup commands and the
+// defaultExecutablePath of Azure Functions custom handlers refer to.
+const GoExecutableName = "app"
+
+const (
+	defaultGoOS   = "linux"
+	defaultGoArch = "amd64"
+)
+
+type goProject struct {
+	env   *environment.Environment
+	goCli golang.GoCli
+}
+
+// NewGoProject creates a new instance of the Go project
+func NewGoProject(goCli golang.GoCli, env *environment.Environment) FrameworkService {
+	return &goProject{
+		env:   env,
+		goCli: goCli,
+	}
+}
+
+func (gp *goProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		Package: FrameworkPackageRequirements{
+			RequireRestore: true,
+			RequireBuild:   true,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (gp *goProject) RequiredExternalTools(context.Context) []tools.ExternalTool {
+	return []tools.ExternalTool{gp.goCli}
+}
+
+// Initializes the Go project
+func (gp *goProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	return nil
+}
+
+// Restores the modules of the project with `go mod download`
+func (gp *goProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			task.SetProgress(NewServiceProgress("Downloading Go modules"))
+			if err := gp.goCli.ModDownload(ctx, serviceConfig.Path()); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServiceRestoreResult{})
+		},
+	)
+}
+
+// Builds the executable of the project with `go build`. The executable targets linux/amd64, which Azure hosts run, unless
+// GOOS or GOARCH are set in the environment.
+func (gp *goProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+) *async.TaskWithProgress[*ServiceBuildResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceBuildResult, ServiceProgress]) {
+			buildOutput, err := os.MkdirTemp("", "azd")
+			if err != nil {
+				task.SetError(fmt.Errorf("creating build directory for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			goos, goarch, env := gp.buildEnv()
+			executable := GoExecutableName
+			if goos == "windows" {
+				executable += ".exe"
+			}
+
+			task.SetProgress(NewServiceProgress(fmt.Sprintf("Building Go executable for %s/%s", goos, goarch)))
+			if err := gp.goCli.Build(ctx, serviceConfig.Path(), filepath.Join(buildOutput, executable), env); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServiceBuildResult{
+				Restore:         restoreOutput,
+				BuildOutputPath: buildOutput,
+			})
+		},
+	)
+}
+
+// Packages the executable together with the files of the project it reads at runtime, like templates, static files or
+// the host.json of a Functions custom handler. Go sources and modules are left out.
+func (gp *goProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+) *async.TaskWithProgress[*ServicePackageResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServicePackageResult, ServiceProgress]) {
+			task.SetProgress(NewServiceProgress("Copying deployment package"))
+			packageDest, err := packageExecutable(
+				serviceConfig,
+				buildOutput.BuildOutputPath,
+				buildForZipOptions{
+					excludeConditions: []excludeDirEntryCondition{
+						excludeGoSources,
+						excludeGoVendor,
+					},
+				})
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServicePackageResult{
+				Build:       buildOutput,
+				PackagePath: packageDest,
+			})
+		},
+	)
+}
+
+// buildEnv returns the target OS and architecture of the build, and the environment which sets them. CGO is disabled
+// unless enabled explicitly, so the executable doesn't depend on the C libraries of the build machine.
+func (gp *goProject) buildEnv() (string, string, []string) {
+	goos := gp.env.Getenv("GOOS")
+	if goos == "" {
+		goos = defaultGoOS
+	}
+
+	goarch := gp.env.Getenv("GOARCH")
+	if goarch == "" {
+		goarch = defaultGoArch
+	}
+
+	cgoEnabled := gp.env.Getenv("CGO_ENABLED")
+	if cgoEnabled == "" {
+		cgoEnabled = "0"
+	}
+
+	return goos, goarch, []string{
+		"GOOS=" + goos,
+		"GOARCH=" + goarch,
+		"CGO_ENABLED=" + cgoEnabled,
+	}
+}
+
+func excludeGoSources(path string, file os.FileInfo) bool {
+	if file.IsDir() {
+		return false
+	}
+
+	name := file.Name()
+	return strings.HasSuffix(name, ".go") || name == "go.mod" || name == "go.sum" || name == "go.work" ||
+		name == "go.work.sum"
+}
+
+func excludeGoVendor(path string, file os.FileInfo) bool {
+	return file.IsDir() && file.Name() == "vendor"
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/golang"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GoProject_Restore(t *testing.T) {
+	var runArgs exec.RunArgs
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "go mod download")
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	env := environment.New("test")
+	goCli := golang.NewGoCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageGo)
+
+	goProject := NewGoProject(goCli, env)
+	restoreTask := goProject.Restore(*mockContext.Context, serviceConfig)
+	logProgress(restoreTask)
+
+	result, err := restoreTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, "go", runArgs.Cmd)
+	require.Equal(t, serviceConfig.Path(), runArgs.Cwd)
+	require.Equal(t, []string{"mod", "download"}, runArgs.Args)
+}
+
+func Test_GoProject_Build(t *testing.T) {
+	t.Setenv("GOOS", "")
+	t.Setenv("GOARCH", "")
+	t.Setenv("CGO_ENABLED", "")
+
+	tests := []struct {
+		name       string
+		dotenv     map[string]string
+		executable string
+		env        []string
+	}{
+		{
+			"Default",
+			nil,
+			"app",
+			[]string{"GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0"},
+		},
+		{
+			"Target",
+			map[string]string{"GOARCH": "arm64", "CGO_ENABLED": "1"},
+			"app",
+			[]string{"GOOS=linux", "GOARCH=arm64", "CGO_ENABLED=1"},
+		},
+		{
+			"Windows",
+			map[string]string{"GOOS": "windows"},
+			"app.exe",
+			[]string{"GOOS=windows", "GOARCH=amd64", "CGO_ENABLED=0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runArgs exec.RunArgs
+
+			mockContext := mocks.NewMockContext(context.Background())
+			mockContext.CommandRunner.
+				When(func(args exec.RunArgs, command string) bool {
+					return strings.Contains(command, "go build")
+				}).
+				RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+					runArgs = args
+					return exec.NewRunResult(0, "", ""), nil
+				})
+
+			env := environment.NewWithValues("test", tt.dotenv)
+			goCli := golang.NewGoCli(mockContext.CommandRunner)
+			serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageGo)
+
+			goProject := NewGoProject(goCli, env)
+			buildTask := goProject.Build(*mockContext.Context, serviceConfig, nil)
+			logProgress(buildTask)
+
+			result, err := buildTask.Await()
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.Equal(t, "go", runArgs.Cmd)
+			require.Equal(t, serviceConfig.Path(), runArgs.Cwd)
+			require.Equal(t,
+				[]string{"build", "-o", filepath.Join(result.BuildOutputPath, tt.executable), "."},
+				runArgs.Args,
+			)
+			require.Equal(t, tt.env, runArgs.Env)
+		})
+	}
+}
+
+func Test_GoProject_Package(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.New("test")
+	goCli := golang.NewGoCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", AzureFunctionTarget, ServiceLanguageGo)
+
+	files := []string{"main.go", "go.mod", "go.sum", "host.json", filepath.Join("vendor", "modules.txt")}
+	for _, file := range files {
+		path := filepath.Join(serviceConfig.Path(), file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, nil, osutil.PermissionFile))
+	}
+
+	buildOutput := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(buildOutput, GoExecutableName), nil, osutil.PermissionExecutableFile))
+
+	goProject := NewGoProject(goCli, env)
+	packageTask := goProject.Package(
+		*mockContext.Context,
+		serviceConfig,
+		&ServiceBuildResult{
+			BuildOutputPath: buildOutput,
+		},
+	)
+	logProgress(packageTask)
+
+	result, err := packageTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	entries, err := os.ReadDir(result.PackagePath)
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{GoExecutableName, "host.json"}, names)
+}
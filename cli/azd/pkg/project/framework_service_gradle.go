@@ -0,0 +1,201 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/gradle"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
+	"github.com/otiai10/copy"
+)
+
+type gradleProject struct {
+	env       *environment.Environment
+	gradleCli gradle.GradleCli
+	javacCli  javac.JavacCli
+}
+
+// NewGradleProject creates a new instance of a gradle project
+func NewGradleProject(env *environment.Environment, gradleCli gradle.GradleCli, javaCli javac.JavacCli) FrameworkService {
+	return &gradleProject{
+		env:       env,
+		gradleCli: gradleCli,
+		javacCli:  javaCli,
+	}
+}
+
+// isGradleProject returns true for the Java projects built with Gradle rather than Maven.
+func isGradleProject(path string) bool {
+	if _, err := os.Stat(filepath.Join(path, "pom.xml")); err == nil {
+		return false
+	}
+
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *gradleProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		// Gradle runs the tasks the packaging task depends on, like resolving dependencies & compiling
+		Package: FrameworkPackageRequirements{
+			RequireRestore: false,
+			RequireBuild:   false,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (g *gradleProject) RequiredExternalTools(context.Context) []tools.ExternalTool {
+	return []tools.ExternalTool{
+		g.gradleCli,
+		g.javacCli,
+	}
+}
+
+// Initializes the gradle project
+func (g *gradleProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	g.gradleCli.SetPath(serviceConfig.Path(), serviceConfig.Project.Path)
+	return nil
+}
+
+// Restores dependencies using the Gradle CLI
+func (g *gradleProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			projectPath, tasks, err := g.projectTasks(serviceConfig, "dependencies")
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetProgress(NewServiceProgress("Resolving gradle dependencies"))
+			if err := g.gradleCli.RunTasks(ctx, projectPath, tasks...); err != nil {
+				task.SetError(fmt.Errorf("resolving gradle dependencies: %w", err))
+				return
+			}
+
+			task.SetResult(&ServiceRestoreResult{})
+		},
+	)
+}
+
+// Builds the gradle project
+func (g *gradleProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+) *async.TaskWithProgress[*ServiceBuildResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceBuildResult, ServiceProgress]) {
+			projectPath, tasks, err := g.projectTasks(serviceConfig, "classes")
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetProgress(NewServiceProgress("Compiling gradle project"))
+			if err := g.gradleCli.RunTasks(ctx, projectPath, tasks...); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServiceBuildResult{
+				Restore:         restoreOutput,
+				BuildOutputPath: serviceConfig.Path(),
+			})
+		},
+	)
+}
+
+// Packages the archive built by the `assemble` task, like the executable jar of Spring Boot (bootJar) or the war of the
+// war plugin. The plain archives Spring Boot builds next to them are left out.
+func (g *gradleProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+) *async.TaskWithProgress[*ServicePackageResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServicePackageResult, ServiceProgress]) {
+			packageDest, err := os.MkdirTemp("", "azd")
+			if err != nil {
+				task.SetError(fmt.Errorf("creating staging directory: %w", err))
+				return
+			}
+
+			projectPath, tasks, err := g.projectTasks(serviceConfig, "assemble")
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetProgress(NewServiceProgress("Packaging gradle project"))
+			if err := g.gradleCli.RunTasks(ctx, projectPath, tasks...); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			archive, err := javaArchive(
+				serviceConfig, buildOutput.BuildOutputPath, filepath.Join("build", "libs"), isPlainJavaArchive)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetProgress(NewServiceProgress("Copying deployment package"))
+			ext := strings.ToLower(filepath.Ext(archive))
+			err = copy.Copy(archive, filepath.Join(packageDest, AppServiceJavaPackageName+ext))
+			if err != nil {
+				task.SetError(fmt.Errorf("copying to staging directory failed: %w", err))
+				return
+			}
+
+			task.SetResult(&ServicePackageResult{
+				Build:       buildOutput,
+				PackagePath: packageDest,
+			})
+		},
+	)
+}
+
+// projectTasks returns the path Gradle runs in for the service, and the tasks to run. The tasks of a project of a
+// multi-project build run from the root of the build, qualified with the path of the project.
+func (g *gradleProject) projectTasks(serviceConfig *ServiceConfig, tasks ...string) (string, []string, error) {
+	workspace, err := appdetect.FindWorkspace(serviceConfig.Path(), serviceConfig.Project.Path)
+	if err != nil {
+		return "", nil, fmt.Errorf("finding multi-project build of %s: %w", serviceConfig.Name, err)
+	}
+
+	if workspace == nil || workspace.Tool != appdetect.WorkspaceGradle {
+		return serviceConfig.Path(), tasks, nil
+	}
+
+	qualified := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		qualified = append(qualified, workspace.Member+":"+task)
+	}
+
+	return workspace.Path, qualified, nil
+}
+
+// isPlainJavaArchive returns true for the archives without dependencies Spring Boot builds next to the executable ones.
+func isPlainJavaArchive(name string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(name, filepath.Ext(name)), "-plain")
+}
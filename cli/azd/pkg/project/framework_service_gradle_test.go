@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/gradle"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GradleProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		cwd     string
+		tasks   []string
+		archive string
+	}{
+		{
+			"Project",
+			map[string]string{
+				"src/api/settings.gradle":              "rootProject.name = 'api'",
+				"src/api/build.gradle":                 "",
+				"src/api/" + getGradlewCmd():           "",
+				"src/api/build/libs/api-1.0.jar":       "boot",
+				"src/api/build/libs/api-1.0-plain.jar": "plain",
+			},
+			"src/api",
+			[]string{"dependencies", "classes", "assemble"},
+			"boot",
+		},
+		{
+			"MultiProject",
+			map[string]string{
+				"src/settings.gradle.kts":              "include(\":api\", \":lib\")",
+				getGradlewCmd():                        "",
+				"src/api/build.gradle.kts":             "",
+				"src/lib/build.gradle.kts":             "",
+				"src/api/build/libs/api-1.0.war":       "war",
+				"src/api/build/libs/api-1.0-plain.war": "plain",
+			},
+			"src",
+			[]string{":api:dependencies", ":api:classes", ":api:assemble"},
+			"war",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ostest.Chdir(t, t.TempDir())
+			root, err := os.Getwd()
+			require.NoError(t, err)
+
+			for name, contents := range tt.files {
+				require.NoError(t, os.MkdirAll(filepath.Dir(name), osutil.PermissionDirectory))
+				require.NoError(t, os.WriteFile(name, []byte(contents), osutil.PermissionExecutableFile))
+			}
+
+			var runArgs []exec.RunArgs
+
+			mockContext := mocks.NewMockContext(context.Background())
+			mockContext.CommandRunner.
+				When(func(args exec.RunArgs, command string) bool {
+					return strings.Contains(command, getGradlewCmd())
+				}).
+				RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+					runArgs = append(runArgs, args)
+					return exec.NewRunResult(0, "", ""), nil
+				})
+
+			env := environment.New("test")
+			serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJava)
+			require.True(t, isGradleProject(serviceConfig.Path()))
+
+			gradleCli := gradle.NewGradleCli(mockContext.CommandRunner)
+			javaCli := javac.NewCli(mockContext.CommandRunner)
+
+			gradleProject := NewGradleProject(env, gradleCli, javaCli)
+			require.NoError(t, gradleProject.Initialize(*mockContext.Context, serviceConfig))
+
+			restoreTask := gradleProject.Restore(*mockContext.Context, serviceConfig)
+			logProgress(restoreTask)
+			restoreResult, err := restoreTask.Await()
+			require.NoError(t, err)
+
+			buildTask := gradleProject.Build(*mockContext.Context, serviceConfig, restoreResult)
+			logProgress(buildTask)
+			buildResult, err := buildTask.Await()
+			require.NoError(t, err)
+
+			packageTask := gradleProject.Package(*mockContext.Context, serviceConfig, buildResult)
+			logProgress(packageTask)
+			packageResult, err := packageTask.Await()
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(packageResult.PackagePath)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+
+			contents, err := os.ReadFile(filepath.Join(packageResult.PackagePath, entries[0].Name()))
+			require.NoError(t, err)
+			require.Equal(t, tt.archive, string(contents))
+			require.Equal(t, AppServiceJavaPackageName+filepath.Ext(entries[0].Name()), entries[0].Name())
+
+			require.Len(t, runArgs, len(tt.tasks))
+			for i, task := range tt.tasks {
+				cwd, err := filepath.Abs(runArgs[i].Cwd)
+				require.NoError(t, err)
+				require.Equal(t, filepath.Join(root, filepath.FromSlash(tt.cwd)), cwd)
+				require.Equal(t, []string{"--console=plain", task}, runArgs[i].Args)
+			}
+		})
+	}
+}
+
+func getGradlewCmd() string {
+	if runtime.GOOS == "windows" {
+		return "gradlew.bat"
+	}
+
+	return "gradlew"
+}
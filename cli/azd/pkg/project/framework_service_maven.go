@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -64,8 +65,14 @@ func (m *mavenProject) Restore(
 ) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
 	return async.RunTaskWithProgress(
 		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			projectPath, modules, err := m.projectModules(serviceConfig)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
 			task.SetProgress(NewServiceProgress("Resolving maven dependencies"))
-			if err := m.mavenCli.ResolveDependencies(ctx, serviceConfig.Path()); err != nil {
+			if err := m.mavenCli.ResolveDependencies(ctx, projectPath, modules...); err != nil {
 				task.SetError(fmt.Errorf("resolving maven dependencies: %w", err))
 				return
 			}
@@ -83,8 +90,14 @@ func (m *mavenProject) Build(
 ) *async.TaskWithProgress[*ServiceBuildResult, ServiceProgress] {
 	return async.RunTaskWithProgress(
 		func(task *async.TaskContextWithProgress[*ServiceBuildResult, ServiceProgress]) {
+			projectPath, modules, err := m.projectModules(serviceConfig)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
 			task.SetProgress(NewServiceProgress("Compiling maven project"))
-			if err := m.mavenCli.Compile(ctx, serviceConfig.Path()); err != nil {
+			if err := m.mavenCli.Compile(ctx, projectPath, modules...); err != nil {
 				task.SetError(err)
 				return
 			}
@@ -110,52 +123,24 @@ func (m *mavenProject) Package(
 				return
 			}
 
-			task.SetProgress(NewServiceProgress("Packaging maven project"))
-			if err := m.mavenCli.Package(ctx, serviceConfig.Path()); err != nil {
+			projectPath, modules, err := m.projectModules(serviceConfig)
+			if err != nil {
 				task.SetError(err)
 				return
 			}
 
-			packageSrcPath := buildOutput.BuildOutputPath
-			if packageSrcPath == "" {
-				packageSrcPath = serviceConfig.Path()
-			}
-
-			if serviceConfig.OutputPath != "" {
-				packageSrcPath = filepath.Join(packageSrcPath, serviceConfig.OutputPath)
-			} else {
-				packageSrcPath = filepath.Join(packageSrcPath, "target")
+			task.SetProgress(NewServiceProgress("Packaging maven project"))
+			if err := m.mavenCli.Package(ctx, projectPath, modules...); err != nil {
+				task.SetError(err)
+				return
 			}
 
-			packageSrcFileInfo, err := os.Stat(packageSrcPath)
+			archive, err := javaArchive(serviceConfig, buildOutput.BuildOutputPath, "target", nil)
 			if err != nil {
-				if serviceConfig.OutputPath == "" {
-					task.SetError(fmt.Errorf("reading default maven target path %s: %w", packageSrcPath, err))
-				} else {
-					task.SetError(fmt.Errorf("reading dist path %s: %w", packageSrcPath, err))
-				}
+				task.SetError(err)
 				return
 			}
 
-			archive := ""
-			if packageSrcFileInfo.IsDir() {
-				archive, err = m.discoverArchive(packageSrcPath)
-				if err != nil {
-					task.SetError(err)
-					return
-				}
-			} else {
-				archive = packageSrcPath
-				if !isSupportedJavaArchive(archive) {
-					ext := filepath.Ext(archive)
-					task.SetError(
-						fmt.Errorf(
-							//nolint:lll
-							"file %s with extension %s is not a supported java archive file (.ear, .war, .jar)", ext, archive))
-					return
-				}
-			}
-
 			task.SetProgress(NewServiceProgress("Copying deployment package"))
 			ext := strings.ToLower(filepath.Ext(archive))
 			err = copy.Copy(archive, filepath.Join(packageDest, AppServiceJavaPackageName+ext))
@@ -172,12 +157,70 @@ func (m *mavenProject) Package(
 	)
 }
 
+// projectModules returns the path Maven runs in for the service, and the modules to build. Modules of a multi-module project
+// are built from the root of the project, together with the modules they depend on.
+func (m *mavenProject) projectModules(serviceConfig *ServiceConfig) (string, []string, error) {
+	workspace, err := appdetect.FindWorkspace(serviceConfig.Path(), serviceConfig.Project.Path)
+	if err != nil {
+		return "", nil, fmt.Errorf("finding multi-module project of %s: %w", serviceConfig.Name, err)
+	}
+
+	if workspace == nil || workspace.Tool != appdetect.WorkspaceMaven {
+		return serviceConfig.Path(), nil, nil
+	}
+
+	return workspace.Path, []string{workspace.Member}, nil
+}
+
 func isSupportedJavaArchive(archiveFile string) bool {
 	ext := strings.ToLower(filepath.Ext(archiveFile))
 	return ext == ".jar" || ext == ".war" || ext == ".ear"
 }
 
-func (m *mavenProject) discoverArchive(dir string) (string, error) {
+// javaArchive returns the java archive built for the service: the archive at the 'dist' path of the service, or the only
+// archive in the directory at the 'dist' path, which defaults to the output directory of the build tool. Archives for which
+// ignore returns true are left out.
+func javaArchive(
+	serviceConfig *ServiceConfig,
+	buildOutputPath string,
+	defaultOutputPath string,
+	ignore func(name string) bool,
+) (string, error) {
+	packageSrcPath := buildOutputPath
+	if packageSrcPath == "" {
+		packageSrcPath = serviceConfig.Path()
+	}
+
+	if serviceConfig.OutputPath != "" {
+		packageSrcPath = filepath.Join(packageSrcPath, serviceConfig.OutputPath)
+	} else {
+		packageSrcPath = filepath.Join(packageSrcPath, defaultOutputPath)
+	}
+
+	packageSrcFileInfo, err := os.Stat(packageSrcPath)
+	if err != nil {
+		if serviceConfig.OutputPath == "" {
+			return "", fmt.Errorf("reading default build output path %s: %w", packageSrcPath, err)
+		}
+
+		return "", fmt.Errorf("reading dist path %s: %w", packageSrcPath, err)
+	}
+
+	if packageSrcFileInfo.IsDir() {
+		return discoverJavaArchive(packageSrcPath, ignore)
+	}
+
+	if !isSupportedJavaArchive(packageSrcPath) {
+		ext := filepath.Ext(packageSrcPath)
+		return "", fmt.Errorf(
+			//nolint:lll
+			"file %s with extension %s is not a supported java archive file (.ear, .war, .jar)", ext, packageSrcPath)
+	}
+
+	return packageSrcPath, nil
+}
+
+func discoverJavaArchive(dir string, ignore func(name string) bool) (string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return "", fmt.Errorf("discovering java archive files in %s: %w", dir, err)
@@ -190,7 +233,7 @@ func (m *mavenProject) discoverArchive(dir string) (string, error) {
 		}
 
 		name := entry.Name()
-		if isSupportedJavaArchive(name) {
+		if isSupportedJavaArchive(name) && (ignore == nil || !ignore(name)) {
 			archiveFiles = append(archiveFiles, name)
 		}
 	}
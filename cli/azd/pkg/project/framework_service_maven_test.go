@@ -319,3 +319,64 @@ func getMvnwCmd() string {
 		return "mvnw"
 	}
 }
+
+func Test_MavenProject_MultiModule(t *testing.T) {
+	ostest.Chdir(t, t.TempDir())
+	root, err := os.Getwd()
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"pom.xml":                "<project><modules><module>common</module><module>src/api</module></modules></project>",
+		"common/pom.xml":         "<project></project>",
+		"src/api/pom.xml":        "<project></project>",
+		getMvnwCmd():             "",
+		"src/api/target/app.jar": "",
+	}
+	for name, contents := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(name, []byte(contents), osutil.PermissionExecutableFile))
+	}
+
+	var runArgs []exec.RunArgs
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, getMvnwCmd())
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = append(runArgs, args)
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	env := environment.New("test")
+	serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJava)
+	mavenCli := maven.NewMavenCli(mockContext.CommandRunner)
+	javaCli := javac.NewCli(mockContext.CommandRunner)
+
+	mavenProject := NewMavenProject(env, mavenCli, javaCli)
+	require.NoError(t, mavenProject.Initialize(*mockContext.Context, serviceConfig))
+
+	restoreTask := mavenProject.Restore(*mockContext.Context, serviceConfig)
+	logProgress(restoreTask)
+	restoreResult, err := restoreTask.Await()
+	require.NoError(t, err)
+
+	buildTask := mavenProject.Build(*mockContext.Context, serviceConfig, restoreResult)
+	logProgress(buildTask)
+	buildResult, err := buildTask.Await()
+	require.NoError(t, err)
+
+	packageTask := mavenProject.Package(*mockContext.Context, serviceConfig, buildResult)
+	logProgress(packageTask)
+	packageResult, err := packageTask.Await()
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(packageResult.PackagePath, AppServiceJavaPackageName+".jar"))
+
+	require.Len(t, runArgs, 3)
+	for i, goal := range []string{"dependency:resolve", "compile", "package"} {
+		require.Equal(t, root, runArgs[i].Cwd)
+		require.Equal(t, goal, runArgs[i].Args[0])
+		require.Equal(t, []string{"-pl", "src/api", "-am"}, runArgs[i].Args[len(runArgs[i].Args)-3:])
+	}
+}
@@ -5,10 +5,16 @@ package project
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/azure/azure-dev/cli/azd/internal/appdetect"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -18,13 +24,18 @@ import (
 type npmProject struct {
 	env *environment.Environment
 	cli npm.NpmCli
+
+	// workspaces caches the workspace of each service, by the path of the service.
+	workspaces   map[string]*appdetect.Workspace
+	workspacesMu sync.Mutex
 }
 
 // NewNpmProject creates a new instance of a NPM project
 func NewNpmProject(cli npm.NpmCli, env *environment.Environment) FrameworkService {
 	return &npmProject{
-		env: env,
-		cli: cli,
+		env:        env,
+		cli:        cli,
+		workspaces: map[string]*appdetect.Workspace{},
 	}
 }
 
@@ -48,13 +59,31 @@ func (np *npmProject) Initialize(ctx context.Context, serviceConfig *ServiceConf
 	return nil
 }
 
-// Restores dependencies for the NPM project using npm install command
+// Restores dependencies for the NPM project using npm install command. The members of npm, pnpm and yarn workspaces are
+// restored by installing the workspace from its root.
 func (np *npmProject) Restore(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
 ) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
 	return async.RunTaskWithProgress(
 		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			workspace, err := np.workspace(serviceConfig)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			if workspace != nil {
+				task.SetProgress(NewServiceProgress(fmt.Sprintf("Installing %s workspace dependencies", workspace.Tool)))
+				if err := np.cli.InstallWorkspace(ctx, string(workspace.Tool), workspace.Path); err != nil {
+					task.SetError(err)
+					return
+				}
+
+				task.SetResult(&ServiceRestoreResult{})
+				return
+			}
+
 			task.SetProgress(NewServiceProgress("Installing NPM dependencies"))
 			if err := np.cli.Install(ctx, serviceConfig.Path()); err != nil {
 				task.SetError(err)
@@ -77,7 +106,7 @@ func (np *npmProject) Build(
 			// Exec custom `build` script if available
 			// If `build`` script is not defined in the package.json the NPM script will NOT fail
 			task.SetProgress(NewServiceProgress("Running NPM build script"))
-			if err := np.cli.RunScript(ctx, serviceConfig.Path(), "build"); err != nil {
+			if err := np.runBuildScript(ctx, serviceConfig); err != nil {
 				task.SetError(err)
 				return
 			}
@@ -109,12 +138,18 @@ func (np *npmProject) Package(
 				return
 			}
 
+			workspace, err := np.workspace(serviceConfig)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
 			task.SetProgress(NewServiceProgress("Running NPM package script"))
 
 			// Long term this script we call should better align with our inner-loop scenarios
 			// Keeping this defaulted to `build` will create confusion for users when we start to support
 			// both local dev / debug builds and production bundled builds
-			if err := np.cli.RunScript(ctx, serviceConfig.Path(), "build"); err != nil {
+			if err := np.runBuildScript(ctx, serviceConfig); err != nil {
 				task.SetError(err)
 				return
 			}
@@ -137,6 +172,29 @@ func (np *npmProject) Package(
 				return
 			}
 
+			if workspace != nil && serviceConfig.Host != StaticWebAppTarget {
+				dependencies, err := workspaceDependencies(workspace, filepath.Join(packageSource, "package.json"))
+				if err != nil {
+					task.SetError(fmt.Errorf("reading dependencies of %s: %w", serviceConfig.Name, err))
+					return
+				}
+
+				if len(dependencies) > 0 {
+					task.SetError(
+						fmt.Errorf(
+							//nolint:lll
+							"service %s depends on %s of the %s workspace at '%s' and can't be deployed to %s with its package.json: node_modules isn't deployed and dependencies on other members of the workspace can't be installed on Azure. Host the service in a container, or bundle its dependencies into a build output without a package.json, set with the 'dist' property of the service",
+							serviceConfig.Name,
+							strings.Join(dependencies, ", "),
+							workspace.Tool,
+							workspace.Path,
+							serviceConfig.Host,
+						),
+					)
+					return
+				}
+			}
+
 			task.SetProgress(NewServiceProgress("Copying deployment package"))
 
 			if err := buildForZip(
@@ -164,6 +222,97 @@ func (np *npmProject) Package(
 	)
 }
 
+// workspace returns the npm, pnpm or yarn workspace the service is a member of, or nil when it isn't a member of one.
+func (np *npmProject) workspace(serviceConfig *ServiceConfig) (*appdetect.Workspace, error) {
+	np.workspacesMu.Lock()
+	defer np.workspacesMu.Unlock()
+
+	if workspace, has := np.workspaces[serviceConfig.Path()]; has {
+		return workspace, nil
+	}
+
+	workspace, err := appdetect.FindWorkspace(serviceConfig.Path(), serviceConfig.Project.Path)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace of %s: %w", serviceConfig.Name, err)
+	}
+
+	if workspace != nil && !workspace.Tool.IsNode() {
+		workspace = nil
+	}
+
+	np.workspaces[serviceConfig.Path()] = workspace
+	return workspace, nil
+}
+
+// workspaceDependencies returns the dependencies of a package.json on other members of the workspace: the dependencies
+// with the workspace: or file: protocol, or named after a member of the workspace. No dependencies are returned when the
+// package.json doesn't exist.
+func workspaceDependencies(workspace *appdetect.Workspace, packageJsonPath string) ([]string, error) {
+	contents, err := os.ReadFile(packageJsonPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var packageJson appdetect.PackagesJson
+	if err := json.Unmarshal(contents, &packageJson); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", packageJsonPath, err)
+	}
+
+	members, err := workspace.MemberNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []string
+	for _, specs := range []map[string]string{
+		packageJson.Dependencies,
+		packageJson.DevDependencies,
+		packageJson.OptionalDependencies,
+	} {
+		for name, spec := range specs {
+			if strings.HasPrefix(spec, "workspace:") ||
+				strings.HasPrefix(spec, "file:") ||
+				(name != workspace.Member && slices.Contains(members, name)) {
+				dependencies = append(dependencies, name)
+			}
+		}
+	}
+
+	slices.Sort(dependencies)
+	return slices.Compact(dependencies), nil
+}
+
+// runBuildScript runs the `build` script of the service, from the root of its workspace for the members of a workspace.
+func (np *npmProject) runBuildScript(ctx context.Context, serviceConfig *ServiceConfig) error {
+	workspace, err := np.workspace(serviceConfig)
+	if err != nil {
+		return err
+	}
+
+	if workspace == nil {
+		return np.cli.RunScript(ctx, serviceConfig.Path(), "build")
+	}
+
+	// Not every package manager can skip missing scripts, like `npm run --if-present` does.
+	contents, err := os.ReadFile(filepath.Join(serviceConfig.Path(), "package.json"))
+	if err != nil {
+		return err
+	}
+
+	var packageJson appdetect.PackagesJson
+	if err := json.Unmarshal(contents, &packageJson); err != nil {
+		return fmt.Errorf("parsing package.json of %s: %w", serviceConfig.Name, err)
+	}
+
+	if _, has := packageJson.Scripts["build"]; !has {
+		return nil
+	}
+
+	return np.cli.RunWorkspaceScript(ctx, string(workspace.Tool), workspace.Path, workspace.Member, "build")
+}
+
 const cNodeModulesName = "node_modules"
 
 func excludeNodeModules(path string, file os.FileInfo) bool {
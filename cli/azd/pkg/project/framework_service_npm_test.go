@@ -123,3 +123,159 @@ func Test_NpmProject_Package(t *testing.T) {
 		runArgs.Args,
 	)
 }
+
+func Test_NpmProject_Workspace(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		cmd         string
+		installArgs []string
+		buildArgs   []string
+	}{
+		{
+			"Npm",
+			map[string]string{"package.json": `{"workspaces": ["src/*"]}`},
+			"npm",
+			[]string{"install"},
+			[]string{"run", "build", "--workspace", "@acme/api"},
+		},
+		{
+			"Pnpm",
+			map[string]string{"package.json": `{}`, "pnpm-workspace.yaml": "packages:\n  - 'src/*'\n"},
+			"pnpm",
+			[]string{"install"},
+			[]string{"--filter", "@acme/api...", "run", "build"},
+		},
+		{
+			"Yarn",
+			map[string]string{"package.json": `{"workspaces": ["src/*"]}`, "yarn.lock": ""},
+			"yarn",
+			[]string{"install"},
+			[]string{"workspace", "@acme/api", "run", "build"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ostest.Chdir(t, t.TempDir())
+			root, err := os.Getwd()
+			require.NoError(t, err)
+
+			tt.files["src/api/package.json"] = `{"name": "@acme/api", "scripts": {"build": "tsc"}}`
+			for name, contents := range tt.files {
+				require.NoError(t, os.MkdirAll(filepath.Dir(name), osutil.PermissionDirectory))
+				require.NoError(t, os.WriteFile(name, []byte(contents), osutil.PermissionFile))
+			}
+
+			var runArgs []exec.RunArgs
+
+			mockContext := mocks.NewMockContext(context.Background())
+			mockContext.CommandRunner.
+				When(func(args exec.RunArgs, command string) bool {
+					return args.Cmd == tt.cmd
+				}).
+				RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+					runArgs = append(runArgs, args)
+					return exec.NewRunResult(0, "", ""), nil
+				})
+
+			env := environment.New("test")
+			npmCli := npm.NewNpmCli(mockContext.CommandRunner)
+			serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageTypeScript)
+
+			npmProject := NewNpmProject(npmCli, env)
+			restoreTask := npmProject.Restore(*mockContext.Context, serviceConfig)
+			logProgress(restoreTask)
+			restoreResult, err := restoreTask.Await()
+			require.NoError(t, err)
+
+			buildTask := npmProject.Build(*mockContext.Context, serviceConfig, restoreResult)
+			logProgress(buildTask)
+			_, err = buildTask.Await()
+			require.NoError(t, err)
+
+			require.Len(t, runArgs, 2)
+			require.Equal(t, root, runArgs[0].Cwd)
+			require.Equal(t, tt.installArgs, runArgs[0].Args)
+			require.Equal(t, root, runArgs[1].Cwd)
+			require.Equal(t, tt.buildArgs, runArgs[1].Args)
+		})
+	}
+}
+
+func Test_NpmProject_Package_WorkspaceMember(t *testing.T) {
+	ostest.Chdir(t, t.TempDir())
+
+	files := map[string]string{
+		"package.json":          `{"workspaces": ["src/*"]}`,
+		"src/lib/package.json":  `{"name": "@acme/lib"}`,
+		"src/api/index.js":      "",
+		"src/api/dist/index.js": "",
+	}
+	for name, contents := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(name, []byte(contents), osutil.PermissionFile))
+	}
+
+	writePackageJson := func(t *testing.T, contents string) {
+		require.NoError(t, os.WriteFile("src/api/package.json", []byte(contents), osutil.PermissionFile))
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return args.Cmd == "npm"
+		}).
+		Respond(exec.NewRunResult(0, "", ""))
+
+	npmProject := NewNpmProject(npm.NewNpmCli(mockContext.CommandRunner), environment.New("test"))
+
+	t.Run("DependsOnMember", func(t *testing.T) {
+		for _, dependencies := range []string{
+			`{"@acme/lib": "*"}`,
+			`{"@acme/lib": "workspace:*"}`,
+			`{"shared": "file:../shared"}`,
+		} {
+			writePackageJson(t, `{"name": "@acme/api", "dependencies": `+dependencies+`}`)
+			serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJavaScript)
+
+			packageTask := npmProject.Package(
+				*mockContext.Context, serviceConfig, &ServiceBuildResult{BuildOutputPath: serviceConfig.Path()})
+			logProgress(packageTask)
+
+			_, err := packageTask.Await()
+			require.ErrorContains(t, err, "service api depends on", dependencies)
+			require.ErrorContains(t, err, "of the npm workspace", dependencies)
+		}
+	})
+
+	t.Run("WithoutMemberDependencies", func(t *testing.T) {
+		writePackageJson(t, `{"name": "@acme/api", "dependencies": {"express": "^4.18.0"}}`)
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJavaScript)
+
+		packageTask := npmProject.Package(
+			*mockContext.Context, serviceConfig, &ServiceBuildResult{BuildOutputPath: serviceConfig.Path()})
+		logProgress(packageTask)
+
+		result, err := packageTask.Await()
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(result.PackagePath, "package.json"))
+	})
+
+	t.Run("Bundled", func(t *testing.T) {
+		writePackageJson(t, `{"name": "@acme/api", "dependencies": {"@acme/lib": "*"}}`)
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageJavaScript)
+		serviceConfig.OutputPath = "dist"
+
+		packageTask := npmProject.Package(
+			*mockContext.Context,
+			serviceConfig,
+			&ServiceBuildResult{BuildOutputPath: filepath.Join(serviceConfig.Path(), "dist")},
+		)
+		logProgress(packageTask)
+
+		result, err := packageTask.Await()
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(result.PackagePath, "index.js"))
+	})
+}
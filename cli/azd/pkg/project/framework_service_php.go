@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/composer"
+)
+
+type phpProject struct {
+	env         *environment.Environment
+	composerCli composer.ComposerCli
+}
+
+// NewPhpProject creates a new instance of the PHP project
+func NewPhpProject(composerCli composer.ComposerCli, env *environment.Environment) FrameworkService {
+	return &phpProject{
+		env:         env,
+		composerCli: composerCli,
+	}
+}
+
+func (pp *phpProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		// The vendor directory is part of the package, so the packages of the project are installed first
+		Package: FrameworkPackageRequirements{
+			RequireRestore: true,
+			RequireBuild:   false,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (pp *phpProject) RequiredExternalTools(context.Context) []tools.ExternalTool {
+	return []tools.ExternalTool{pp.composerCli}
+}
+
+// Initializes the PHP project
+func (pp *phpProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	return nil
+}
+
+// Restores the packages of the project with `composer install`
+func (pp *phpProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			task.SetProgress(NewServiceProgress("Installing Composer packages"))
+			if err := pp.composerCli.Install(ctx, serviceConfig.Path()); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServiceRestoreResult{})
+		},
+	)
+}
+
+// Build for PHP apps performs a no-op and returns the service path with an optional output path when specified.
+func (pp *phpProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+) *async.TaskWithProgress[*ServiceBuildResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceBuildResult, ServiceProgress]) {
+			buildSource := serviceConfig.Path()
+
+			if serviceConfig.OutputPath != "" {
+				buildSource = filepath.Join(buildSource, serviceConfig.OutputPath)
+			}
+
+			task.SetResult(&ServiceBuildResult{
+				Restore:         restoreOutput,
+				BuildOutputPath: buildSource,
+			})
+		},
+	)
+}
+
+// Packages the source of the project together with its vendor directory.
+func (pp *phpProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+) *async.TaskWithProgress[*ServicePackageResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServicePackageResult, ServiceProgress]) {
+			packageDest, err := os.MkdirTemp("", "azd")
+			if err != nil {
+				task.SetError(fmt.Errorf("creating package directory for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			packageSource := buildOutput.BuildOutputPath
+			if packageSource == "" {
+				packageSource = filepath.Join(serviceConfig.Path(), serviceConfig.OutputPath)
+			}
+
+			if entries, err := os.ReadDir(packageSource); err != nil || len(entries) == 0 {
+				task.SetError(fmt.Errorf("package source '%s' is empty or does not exist", packageSource))
+				return
+			}
+
+			task.SetProgress(NewServiceProgress("Copying deployment package"))
+			if err := buildForZip(packageSource, packageDest, buildForZipOptions{}); err != nil {
+				task.SetError(fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			if err := validatePackageOutput(packageDest); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServicePackageResult{
+				Build:       buildOutput,
+				PackagePath: packageDest,
+			})
+		},
+	)
+}
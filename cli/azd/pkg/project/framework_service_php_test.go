@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/composer"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PhpProject_Restore(t *testing.T) {
+	var runArgs exec.RunArgs
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "composer install")
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	env := environment.New("test")
+	composerCli := composer.NewComposerCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", ContainerAppTarget, ServiceLanguagePhp)
+
+	phpProject := NewPhpProject(composerCli, env)
+	restoreTask := phpProject.Restore(*mockContext.Context, serviceConfig)
+	logProgress(restoreTask)
+
+	result, err := restoreTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, "composer", runArgs.Cmd)
+	require.Equal(t, serviceConfig.Path(), runArgs.Cwd)
+	require.Equal(t, []string{"install", "--no-interaction"}, runArgs.Args)
+}
+
+func Test_PhpProject_Package(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.New("test")
+	composerCli := composer.NewComposerCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguagePhp)
+
+	files := []string{"composer.json", "index.php", filepath.Join("vendor", "autoload.php")}
+	for _, file := range files {
+		path := filepath.Join(serviceConfig.Path(), file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, nil, osutil.PermissionFile))
+	}
+
+	phpProject := NewPhpProject(composerCli, env)
+	packageTask := phpProject.Package(
+		*mockContext.Context,
+		serviceConfig,
+		&ServiceBuildResult{
+			BuildOutputPath: serviceConfig.Path(),
+		},
+	)
+	logProgress(packageTask)
+
+	result, err := packageTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	for _, file := range files {
+		require.FileExists(t, filepath.Join(result.PackagePath, file))
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bundler"
+)
+
+type rubyProject struct {
+	env        *environment.Environment
+	bundlerCli bundler.BundlerCli
+}
+
+// NewRubyProject creates a new instance of the Ruby project
+func NewRubyProject(bundlerCli bundler.BundlerCli, env *environment.Environment) FrameworkService {
+	return &rubyProject{
+		env:        env,
+		bundlerCli: bundlerCli,
+	}
+}
+
+func (rp *rubyProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		// Ruby does not require compilation and will just package the raw source files
+		Package: FrameworkPackageRequirements{
+			RequireRestore: false,
+			RequireBuild:   false,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (rp *rubyProject) RequiredExternalTools(context.Context) []tools.ExternalTool {
+	return []tools.ExternalTool{rp.bundlerCli}
+}
+
+// Initializes the Ruby project
+func (rp *rubyProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	return nil
+}
+
+// Restores the gems of the project with `bundle install`
+func (rp *rubyProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			task.SetProgress(NewServiceProgress("Installing Ruby gems"))
+			if err := rp.bundlerCli.Install(ctx, serviceConfig.Path()); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServiceRestoreResult{})
+		},
+	)
+}
+
+// Build for Ruby apps performs a no-op and returns the service path with an optional output path when specified.
+func (rp *rubyProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+) *async.TaskWithProgress[*ServiceBuildResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceBuildResult, ServiceProgress]) {
+			buildSource := serviceConfig.Path()
+
+			if serviceConfig.OutputPath != "" {
+				buildSource = filepath.Join(buildSource, serviceConfig.OutputPath)
+			}
+
+			task.SetResult(&ServiceBuildResult{
+				Restore:         restoreOutput,
+				BuildOutputPath: buildSource,
+			})
+		},
+	)
+}
+
+// Packages the source of the project. Gems installed in the project by bundler are left out, since they can contain
+// native extensions built for the local machine.
+func (rp *rubyProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+) *async.TaskWithProgress[*ServicePackageResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServicePackageResult, ServiceProgress]) {
+			packageDest, err := os.MkdirTemp("", "azd")
+			if err != nil {
+				task.SetError(fmt.Errorf("creating package directory for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			packageSource := buildOutput.BuildOutputPath
+			if packageSource == "" {
+				packageSource = filepath.Join(serviceConfig.Path(), serviceConfig.OutputPath)
+			}
+
+			if entries, err := os.ReadDir(packageSource); err != nil || len(entries) == 0 {
+				task.SetError(fmt.Errorf("package source '%s' is empty or does not exist", packageSource))
+				return
+			}
+
+			task.SetProgress(NewServiceProgress("Copying deployment package"))
+			if err := buildForZip(
+				packageSource,
+				packageDest,
+				buildForZipOptions{
+					excludeConditions: []excludeDirEntryCondition{
+						excludeBundlerConfig,
+						excludeBundlerGems,
+					},
+				}); err != nil {
+				task.SetError(fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			if err := validatePackageOutput(packageDest); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServicePackageResult{
+				Build:       buildOutput,
+				PackagePath: packageDest,
+			})
+		},
+	)
+}
+
+func excludeBundlerConfig(path string, file os.FileInfo) bool {
+	return file.IsDir() && file.Name() == ".bundle"
+}
+
+func excludeBundlerGems(path string, file os.FileInfo) bool {
+	return file.IsDir() && file.Name() == "bundle" && filepath.Base(filepath.Dir(path)) == "vendor"
+}
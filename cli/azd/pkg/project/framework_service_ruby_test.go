@@ -0,0 +1,92 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bundler"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RubyProject_Restore(t *testing.T) {
+	var runArgs exec.RunArgs
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "bundle install")
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	env := environment.New("test")
+	bundlerCli := bundler.NewBundlerCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", ContainerAppTarget, ServiceLanguageRuby)
+
+	rubyProject := NewRubyProject(bundlerCli, env)
+	restoreTask := rubyProject.Restore(*mockContext.Context, serviceConfig)
+	logProgress(restoreTask)
+
+	result, err := restoreTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, "bundle", runArgs.Cmd)
+	require.Equal(t, serviceConfig.Path(), runArgs.Cwd)
+	require.Equal(t, []string{"install"}, runArgs.Args)
+}
+
+func Test_RubyProject_Package(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.New("test")
+	bundlerCli := bundler.NewBundlerCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageRuby)
+
+	files := []string{
+		"Gemfile",
+		"config.ru",
+		filepath.Join(".bundle", "config"),
+		filepath.Join("vendor", "bundle", "ruby", "gem.rb"),
+		filepath.Join("vendor", "javascript", "app.js"),
+	}
+	for _, file := range files {
+		path := filepath.Join(serviceConfig.Path(), file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, nil, osutil.PermissionFile))
+	}
+
+	rubyProject := NewRubyProject(bundlerCli, env)
+	packageTask := rubyProject.Package(
+		*mockContext.Context,
+		serviceConfig,
+		&ServiceBuildResult{
+			BuildOutputPath: serviceConfig.Path(),
+		},
+	)
+	logProgress(packageTask)
+
+	result, err := packageTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	require.FileExists(t, filepath.Join(result.PackagePath, "Gemfile"))
+	require.FileExists(t, filepath.Join(result.PackagePath, "config.ru"))
+	require.FileExists(t, filepath.Join(result.PackagePath, "vendor", "javascript", "app.js"))
+	require.NoDirExists(t, filepath.Join(result.PackagePath, ".bundle"))
+	require.NoDirExists(t, filepath.Join(result.PackagePath, "vendor", "bundle"))
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/cargo"
+	"github.com/otiai10/copy"
+)
+
+// RustExecutableName is the name the executable built for a Rust service is packaged as, which App Service startup
+// commands and the defaultExecutablePath of Azure Functions custom handlers refer to.
+const RustExecutableName = "app"
+
+type rustProject struct {
+	env      *environment.Environment
+	cargoCli cargo.CargoCli
+}
+
+// NewRustProject creates a new instance of the Rust project
+func NewRustProject(cargoCli cargo.CargoCli, env *environment.Environment) FrameworkService {
+	return &rustProject{
+		env:      env,
+		cargoCli: cargoCli,
+	}
+}
+
+func (rp *rustProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		Package: FrameworkPackageRequirements{
+			RequireRestore: true,
+			RequireBuild:   true,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (rp *rustProject) RequiredExternalTools(context.Context) []tools.ExternalTool {
+	return []tools.ExternalTool{rp.cargoCli}
+}
+
+// Initializes the Rust project
+func (rp *rustProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	return nil
+}
+
+// Restores the crates of the project with `cargo fetch`
+func (rp *rustProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+) *async.TaskWithProgress[*ServiceRestoreResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
+			task.SetProgress(NewServiceProgress("Fetching Rust crates"))
+			if err := rp.cargoCli.Fetch(ctx, serviceConfig.Path()); err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServiceRestoreResult{})
+		},
+	)
+}
+
+// RustDefaultTarget is the target Rust executables are built for when CARGO_BUILD_TARGET is not set.
+const RustDefaultTarget = "x86_64-unknown-linux-gnu"
+
+// Builds the executable of the project with `cargo build --release`. The executable targets 64-bit Linux, which Azure
+// hosts run, unless CARGO_BUILD_TARGET is set in the environment.
+func (rp *rustProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+) *async.TaskWithProgress[*ServiceBuildResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServiceBuildResult, ServiceProgress]) {
+			target := rp.env.Getenv("CARGO_BUILD_TARGET")
+			if target == "" {
+				target = RustDefaultTarget
+			}
+			env := []string{"CARGO_BUILD_TARGET=" + target}
+
+			task.SetProgress(NewServiceProgress("Building Rust executable"))
+			executables, err := rp.cargoCli.Build(ctx, serviceConfig.Path(), env)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			if len(executables) != 1 {
+				task.SetError(fmt.Errorf(
+					"project '%s' must build exactly one executable, but built %d: %s",
+					serviceConfig.Path(),
+					len(executables),
+					strings.Join(executables, ", ")))
+				return
+			}
+
+			buildOutput, err := os.MkdirTemp("", "azd")
+			if err != nil {
+				task.SetError(fmt.Errorf("creating build directory for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			executable := RustExecutableName + filepath.Ext(executables[0])
+			if err := copy.Copy(executables[0], filepath.Join(buildOutput, executable)); err != nil {
+				task.SetError(fmt.Errorf("copying Rust executable for %s: %w", serviceConfig.Name, err))
+				return
+			}
+
+			task.SetResult(&ServiceBuildResult{
+				Restore:         restoreOutput,
+				BuildOutputPath: buildOutput,
+			})
+		},
+	)
+}
+
+// Packages the executable together with the files of the project it reads at runtime, like templates or static files.
+// Rust sources and the build output of cargo are left out.
+func (rp *rustProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+) *async.TaskWithProgress[*ServicePackageResult, ServiceProgress] {
+	return async.RunTaskWithProgress(
+		func(task *async.TaskContextWithProgress[*ServicePackageResult, ServiceProgress]) {
+			task.SetProgress(NewServiceProgress("Copying deployment package"))
+			packageDest, err := packageExecutable(
+				serviceConfig,
+				buildOutput.BuildOutputPath,
+				buildForZipOptions{
+					excludeConditions: []excludeDirEntryCondition{
+						excludeRustSources,
+						excludeCargoTarget,
+					},
+				})
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			task.SetResult(&ServicePackageResult{
+				Build:       buildOutput,
+				PackagePath: packageDest,
+			})
+		},
+	)
+}
+
+func excludeRustSources(path string, file os.FileInfo) bool {
+	if file.IsDir() {
+		return false
+	}
+
+	name := file.Name()
+	return strings.HasSuffix(name, ".rs") || name == "Cargo.toml" || name == "Cargo.lock"
+}
+
+func excludeCargoTarget(path string, file os.FileInfo) bool {
+	return file.IsDir() && file.Name() == "target" && isCargoProject(filepath.Dir(path))
+}
+
+func isCargoProject(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "Cargo.toml"))
+	return err == nil
+}
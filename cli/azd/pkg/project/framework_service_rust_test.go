@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/cargo"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RustProject_Restore(t *testing.T) {
+	var runArgs exec.RunArgs
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "cargo fetch")
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	env := environment.New("test")
+	cargoCli := cargo.NewCargoCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", ContainerAppTarget, ServiceLanguageRust)
+
+	rustProject := NewRustProject(cargoCli, env)
+	restoreTask := rustProject.Restore(*mockContext.Context, serviceConfig)
+	logProgress(restoreTask)
+
+	result, err := restoreTask.Await()
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, "cargo", runArgs.Cmd)
+	require.Equal(t, serviceConfig.Path(), runArgs.Cwd)
+	require.Equal(t, []string{"fetch"}, runArgs.Args)
+}
+
+func Test_RustProject_Build(t *testing.T) {
+	t.Run("Executable", func(t *testing.T) {
+		executable := filepath.Join(t.TempDir(), "rusttestapp")
+		require.NoError(t, os.WriteFile(executable, []byte("binary"), osutil.PermissionExecutableFile))
+
+		var runArgs exec.RunArgs
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, "cargo build")
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				runArgs = args
+				stdout := strings.Join([]string{
+					`{"reason":"compiler-artifact","target":{"kind":["lib"]},"executable":null}`,
+					`{"reason":"compiler-artifact","target":{"kind":["custom-build"]},"executable":null}`,
+					fmt.Sprintf(`{"reason":"compiler-artifact","target":{"kind":["bin"]},"executable":%q}`, executable),
+					`{"reason":"build-finished","success":true}`,
+				}, "\n")
+				return exec.NewRunResult(0, stdout, ""), nil
+			})
+
+		env := environment.NewWithValues("test", map[string]string{
+			"CARGO_BUILD_TARGET": "x86_64-unknown-linux-musl",
+		})
+		cargoCli := cargo.NewCargoCli(mockContext.CommandRunner)
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageRust)
+
+		rustProject := NewRustProject(cargoCli, env)
+		buildTask := rustProject.Build(*mockContext.Context, serviceConfig, nil)
+		logProgress(buildTask)
+
+		result, err := buildTask.Await()
+		require.NoError(t, err)
+		require.Equal(t, "cargo", runArgs.Cmd)
+		require.Equal(t, []string{"build", "--release", "--message-format=json"}, runArgs.Args)
+		require.Equal(t, []string{"CARGO_BUILD_TARGET=x86_64-unknown-linux-musl"}, runArgs.Env)
+
+		contents, err := os.ReadFile(filepath.Join(result.BuildOutputPath, RustExecutableName))
+		require.NoError(t, err)
+		require.Equal(t, "binary", string(contents))
+	})
+
+	t.Run("NoExecutable", func(t *testing.T) {
+		var runArgs exec.RunArgs
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, "cargo build")
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				runArgs = args
+				return exec.NewRunResult(0, `{"reason":"build-finished","success":true}`, ""), nil
+			})
+
+		env := environment.New("test")
+		cargoCli := cargo.NewCargoCli(mockContext.CommandRunner)
+		serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageRust)
+
+		rustProject := NewRustProject(cargoCli, env)
+		buildTask := rustProject.Build(*mockContext.Context, serviceConfig, nil)
+		logProgress(buildTask)
+
+		_, err := buildTask.Await()
+		require.ErrorContains(t, err, "must build exactly one executable")
+		require.Equal(t, []string{"CARGO_BUILD_TARGET=" + RustDefaultTarget}, runArgs.Env)
+	})
+}
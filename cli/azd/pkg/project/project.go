@@ -3,6 +3,7 @@ package project
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/blang/semver/v4"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 )
@@ -83,28 +85,73 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 	}
 
 	for key, svc := range projectConfig.Services {
-		svc.Name = key
-		svc.Project = &projectConfig
-		svc.EventDispatcher = ext.NewEventDispatcher[ServiceLifecycleEventArgs]()
+		if err := initService(&projectConfig, key, svc); err != nil {
+			return nil, err
+		}
+	}
 
-		var err error
-		svc.Language, err = parseServiceLanguage(svc.Language)
-		if err != nil {
-			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+	if err := validateLayerNames(&projectConfig); err != nil {
+		return nil, fmt.Errorf("parsing project %s: %w", projectConfig.Name, err)
+	}
+
+	return &projectConfig, nil
+}
+
+// validateLayerNames checks that the infrastructure layers of the project have unique names. The named layers of the
+// infrastructure of the project and the layers of the services which own infrastructure, named after the service, are
+// targeted by name alike.
+func validateLayerNames(projectConfig *ProjectConfig) error {
+	if projectConfig.Infra.Name != "" {
+		return errors.New("infra can't have a name, only the layers of infra are named")
+	}
+
+	names := map[string]bool{}
+	for _, layer := range projectConfig.Infra.Layers {
+		if layer.Name == "" {
+			return errors.New("infrastructure layers must have a name")
 		}
 
-		svc.Host, err = parseServiceHost(svc.Host)
-		if err != nil {
-			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+		if names[layer.Name] {
+			return fmt.Errorf("infrastructure layer '%s' is defined more than once", layer.Name)
 		}
+		names[layer.Name] = true
+	}
 
-		svc.Infra.Provider, err = provisioning.ParseProvider(svc.Infra.Provider)
-		if err != nil {
-			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+	services := maps.Keys(projectConfig.Services)
+	slices.Sort(services)
+	for _, name := range services {
+		if projectConfig.Services[name].HasInfra() && names[name] {
+			return fmt.Errorf(
+				"infrastructure layer '%s' has the name of service '%s', which has its own infrastructure", name, name)
 		}
 	}
 
-	return &projectConfig, nil
+	return nil
+}
+
+// initService initializes a service parsed from a project file, as a service with the given name of the project.
+func initService(projectConfig *ProjectConfig, name string, svc *ServiceConfig) error {
+	svc.Name = name
+	svc.Project = projectConfig
+	svc.EventDispatcher = ext.NewEventDispatcher[ServiceLifecycleEventArgs]()
+
+	var err error
+	svc.Language, err = parseServiceLanguage(svc.Language)
+	if err != nil {
+		return fmt.Errorf("parsing service %s: %w", svc.Name, err)
+	}
+
+	svc.Host, err = parseServiceHost(svc.Host)
+	if err != nil {
+		return fmt.Errorf("parsing service %s: %w", svc.Name, err)
+	}
+
+	svc.Infra.Provider, err = provisioning.ParseProvider(svc.Infra.Provider)
+	if err != nil {
+		return fmt.Errorf("parsing service %s: %w", svc.Name, err)
+	}
+
+	return nil
 }
 
 // Load hydrates the azure.yaml configuring into an viewable structure
@@ -123,6 +170,10 @@ func Load(ctx context.Context, projectFilePath string) (*ProjectConfig, error) {
 		return nil, fmt.Errorf("parsing project file: %w", err)
 	}
 
+	if err := loadIncludes(projectConfig, projectFilePath); err != nil {
+		return nil, err
+	}
+
 	if projectConfig.Metadata != nil && projectConfig.Metadata.Template != "" {
 		template := strings.Split(projectConfig.Metadata.Template, "@")
 		if len(template) == 1 { // no version specifier, just the template ID
@@ -196,9 +247,14 @@ func SaveConfig(ctx context.Context, config config.Config, projectFilePath strin
 	return Save(ctx, projectConfig, projectFilePath)
 }
 
-// Saves the current instance back to the azure.yaml file
+// Saves the current instance back to the azure.yaml file. Services, hooks and pipeline settings loaded from included
+// files are written back to the file they were loaded from.
 func Save(ctx context.Context, projectConfig *ProjectConfig, projectFilePath string) error {
-	projectBytes, err := yaml.Marshal(projectConfig)
+	if err := saveIncludes(projectConfig); err != nil {
+		return err
+	}
+
+	projectBytes, err := yaml.Marshal(projectConfigWithoutIncludes(projectConfig))
 	if err != nil {
 		return fmt.Errorf("marshalling project yaml: %w", err)
 	}
@@ -0,0 +1,310 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/bmatcuk/doublestar/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// projectIncludes tracks the file each service, hook and pipeline setting included by a project was loaded from, so they
+// are saved back to the same file.
+type projectIncludes struct {
+	// projectDir is the directory of the project, which included file paths are relative to.
+	projectDir string
+	// files are the paths of the included files, in the order they were loaded.
+	files    []string
+	services map[string]string
+	hooks    map[string]string
+	pipeline string
+	// loaded is the marshalled content of each included file when the project was loaded.
+	loaded map[string][]byte
+}
+
+// projectFragment is the content of a file included by a project.
+type projectFragment struct {
+	Services map[string]*ServiceConfig  `yaml:"services,omitempty"`
+	Hooks    map[string]*ext.HookConfig `yaml:"hooks,omitempty"`
+	Pipeline *PipelineOptions           `yaml:"pipeline,omitempty"`
+}
+
+// loadIncludes merges the services, hooks and pipeline settings of the files included by a project into the project.
+// A service or hook defined by more than one file is an error, as is pipeline settings defined by more than one file.
+func loadIncludes(projectConfig *ProjectConfig, projectFilePath string) error {
+	if len(projectConfig.Include) == 0 {
+		return nil
+	}
+
+	projectDir := filepath.Dir(projectFilePath)
+	files, err := includedFiles(projectDir, projectFilePath, projectConfig.Include)
+	if err != nil {
+		return err
+	}
+
+	includes := &projectIncludes{
+		projectDir: projectDir,
+		files:      files,
+		services:   map[string]string{},
+		hooks:      map[string]string{},
+	}
+	projectFileName := filepath.Base(projectFilePath)
+
+	// The files, relative to the project, each service and hook is defined in, starting with the project file.
+	serviceSources := map[string]string{}
+	for name := range projectConfig.Services {
+		serviceSources[name] = projectFileName
+	}
+
+	hookSources := map[string]string{}
+	for name := range projectConfig.Hooks {
+		hookSources[name] = projectFileName
+	}
+
+	pipelineSource := ""
+	if projectConfig.Pipeline != (PipelineOptions{}) {
+		pipelineSource = projectFileName
+	}
+
+	for _, file := range files {
+		relativePath := includes.relativePath(file)
+
+		fragment, err := readProjectFragment(file)
+		if err != nil {
+			return fmt.Errorf("parsing included project file '%s': %w", relativePath, err)
+		}
+
+		if fragment.Pipeline != nil {
+			if pipelineSource != "" {
+				return fmt.Errorf("pipeline settings in '%s' are already defined in '%s'", relativePath, pipelineSource)
+			}
+
+			projectConfig.Pipeline = *fragment.Pipeline
+			includes.pipeline = file
+			pipelineSource = relativePath
+		}
+
+		for name, hook := range fragment.Hooks {
+			if source, has := hookSources[name]; has {
+				return fmt.Errorf("hook '%s' in '%s' is already defined in '%s'", name, relativePath, source)
+			}
+
+			if projectConfig.Hooks == nil {
+				projectConfig.Hooks = map[string]*ext.HookConfig{}
+			}
+			projectConfig.Hooks[name] = hook
+			includes.hooks[name] = file
+			hookSources[name] = relativePath
+		}
+
+		for name, svc := range fragment.Services {
+			if source, has := serviceSources[name]; has {
+				return fmt.Errorf("service '%s' in '%s' is already defined in '%s'", name, relativePath, source)
+			}
+
+			if err := initService(projectConfig, name, svc); err != nil {
+				return fmt.Errorf("parsing included project file '%s': %w", relativePath, err)
+			}
+
+			if projectConfig.Services == nil {
+				projectConfig.Services = map[string]*ServiceConfig{}
+			}
+			projectConfig.Services[name] = svc
+			includes.services[name] = file
+			serviceSources[name] = relativePath
+		}
+	}
+
+	// The content of each included file as loaded, to only write back the files whose content changed.
+	includes.loaded = map[string][]byte{}
+	for _, file := range files {
+		content, err := includes.marshalFragment(projectConfig, file)
+		if err != nil {
+			return err
+		}
+
+		includes.loaded[file] = content
+	}
+
+	projectConfig.includes = includes
+	return nil
+}
+
+// includedFiles returns the paths of the files matching the include patterns of a project, in the order of the patterns
+// and sorted by path for each pattern. Patterns are relative to the project directory and support '**'.
+func includedFiles(projectDir string, projectFilePath string, patterns []string) ([]string, error) {
+	projectFilePath, err := filepath.Abs(projectFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	seen := map[string]struct{}{
+		projectFilePath: {},
+	}
+
+	for _, pattern := range patterns {
+		matches, err := doublestar.Glob(os.DirFS(projectDir), filepath.ToSlash(pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern '%s': %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			log.Printf("include pattern '%s' doesn't match any file", pattern)
+		}
+
+		slices.Sort(matches)
+		for _, match := range matches {
+			file, err := filepath.Abs(filepath.Join(projectDir, filepath.FromSlash(match)))
+			if err != nil {
+				return nil, err
+			}
+
+			if _, has := seen[file]; has {
+				continue
+			}
+
+			seen[file] = struct{}{}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// readProjectFragment reads a file included by a project. Fields other than services, hooks and pipeline are an error,
+// since they would otherwise be silently ignored.
+func readProjectFragment(file string) (*projectFragment, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var fragment projectFragment
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fragment); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return &fragment, nil
+}
+
+// saveIncludes writes the services, hooks and pipeline settings of a project back to the included files they were loaded
+// from. Files whose services, hooks and pipeline settings didn't change since they were loaded are left untouched.
+func saveIncludes(projectConfig *ProjectConfig) error {
+	includes := projectConfig.includes
+	if includes == nil {
+		return nil
+	}
+
+	for _, file := range includes.files {
+		fragmentBytes, err := includes.marshalFragment(projectConfig, file)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(fragmentBytes, includes.loaded[file]) {
+			continue
+		}
+
+		if err := os.WriteFile(file, fragmentBytes, osutil.PermissionFile); err != nil {
+			return fmt.Errorf("saving included project file '%s': %w", includes.relativePath(file), err)
+		}
+
+		includes.loaded[file] = fragmentBytes
+	}
+
+	return nil
+}
+
+// marshalFragment returns the content of an included file, made of the services, hooks and pipeline settings of the
+// project loaded from it.
+func (i *projectIncludes) marshalFragment(projectConfig *ProjectConfig, file string) ([]byte, error) {
+	fragment := projectFragment{}
+
+	for name, svc := range projectConfig.Services {
+		if i.services[name] == file {
+			if fragment.Services == nil {
+				fragment.Services = map[string]*ServiceConfig{}
+			}
+			fragment.Services[name] = svc
+		}
+	}
+
+	for name, hook := range projectConfig.Hooks {
+		if i.hooks[name] == file {
+			if fragment.Hooks == nil {
+				fragment.Hooks = map[string]*ext.HookConfig{}
+			}
+			fragment.Hooks[name] = hook
+		}
+	}
+
+	if i.pipeline == file {
+		pipeline := projectConfig.Pipeline
+		fragment.Pipeline = &pipeline
+	}
+
+	fragmentBytes, err := yaml.Marshal(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling included project file '%s': %w", i.relativePath(file), err)
+	}
+
+	return fragmentBytes, nil
+}
+
+// projectConfigWithoutIncludes returns a copy of the project without the services, hooks and pipeline settings loaded
+// from included files, which is what is saved to the project file.
+func projectConfigWithoutIncludes(projectConfig *ProjectConfig) *ProjectConfig {
+	includes := projectConfig.includes
+	if includes == nil {
+		return projectConfig
+	}
+
+	result := *projectConfig
+
+	if projectConfig.Services != nil {
+		result.Services = map[string]*ServiceConfig{}
+		for name, svc := range projectConfig.Services {
+			if _, included := includes.services[name]; !included {
+				result.Services[name] = svc
+			}
+		}
+	}
+
+	if projectConfig.Hooks != nil {
+		result.Hooks = map[string]*ext.HookConfig{}
+		for name, hook := range projectConfig.Hooks {
+			if _, included := includes.hooks[name]; !included {
+				result.Hooks[name] = hook
+			}
+		}
+	}
+
+	if includes.pipeline != "" {
+		result.Pipeline = PipelineOptions{}
+	}
+
+	return &result
+}
+
+// relativePath returns the path of an included file relative to the project, for display.
+func (i *projectIncludes) relativePath(file string) string {
+	if relativePath, err := filepath.Rel(i.projectDir, file); err == nil {
+		return relativePath
+	}
+
+	return file
+}
@@ -0,0 +1,284 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, []byte(content), osutil.PermissionFile))
+	}
+
+	return dir
+}
+
+func TestLoadIncludes(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"azure.yaml": `
+name: test-proj
+include:
+  - teams/**/*.yaml
+services:
+  api:
+    project: src/api
+    language: js
+    host: containerapp
+`,
+		"teams/web/services.yaml": `
+services:
+  web:
+    project: src/web
+    language: ts
+    host: staticwebapp
+hooks:
+  preprovision:
+    run: ./scripts/preprovision.sh
+`,
+		"teams/worker/services.yaml": `
+services:
+  worker:
+    project: src/worker
+    language: python
+    host: containerapp
+pipeline:
+  provider: azdo
+`,
+	})
+
+	projectConfig, err := Load(context.Background(), filepath.Join(dir, "azure.yaml"))
+	require.NoError(t, err)
+
+	require.Len(t, projectConfig.Services, 3)
+	require.Equal(t, "web", projectConfig.Services["web"].Name)
+	require.Equal(t, ServiceLanguageTypeScript, projectConfig.Services["web"].Language)
+	require.Same(t, projectConfig, projectConfig.Services["worker"].Project)
+	require.Equal(t, filepath.Join(dir, "src", "worker"), projectConfig.Services["worker"].Path())
+	require.Contains(t, projectConfig.Hooks, "preprovision")
+	require.Equal(t, "azdo", projectConfig.Pipeline.Provider)
+}
+
+func TestLoadIncludesDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		included string
+		expected string
+	}{
+		{
+			name: "Service",
+			included: `
+services:
+  api:
+    project: src/api
+    language: js
+    host: appservice
+`,
+			expected: "service 'api' in 'infra.yaml' is already defined in 'azure.yaml'",
+		},
+		{
+			name: "Hook",
+			included: `
+hooks:
+  postprovision:
+    run: ./scripts/postprovision.sh
+`,
+			expected: "hook 'postprovision' in 'infra.yaml' is already defined in 'azure.yaml'",
+		},
+		{
+			name: "Pipeline",
+			included: `
+pipeline:
+  provider: github
+`,
+			expected: "pipeline settings in 'infra.yaml' are already defined in 'azure.yaml'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, map[string]string{
+				"azure.yaml": `
+name: test-proj
+include:
+  - infra.yaml
+services:
+  api:
+    project: src/api
+    language: js
+    host: containerapp
+hooks:
+  postprovision:
+    run: ./scripts/postprovision.sh
+pipeline:
+  provider: azdo
+`,
+				"infra.yaml": test.included,
+			})
+
+			_, err := Load(context.Background(), filepath.Join(dir, "azure.yaml"))
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	t.Run("BetweenIncludedFiles", func(t *testing.T) {
+		dir := writeProjectFiles(t, map[string]string{
+			"azure.yaml": `
+name: test-proj
+include:
+  - teams/*.yaml
+`,
+			"teams/a.yaml": `
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+`,
+			"teams/b.yaml": `
+services:
+  web:
+    project: src/other
+    language: js
+    host: appservice
+`,
+		})
+
+		_, err := Load(context.Background(), filepath.Join(dir, "azure.yaml"))
+		require.EqualError(
+			t, err, "service 'web' in '"+filepath.Join("teams", "b.yaml")+"' is already defined in '"+
+				filepath.Join("teams", "a.yaml")+"'")
+	})
+
+	t.Run("HookBetweenIncludedFiles", func(t *testing.T) {
+		dir := writeProjectFiles(t, map[string]string{
+			"azure.yaml": `
+name: test-proj
+include:
+  - teams/*.yaml
+`,
+			"teams/a.yaml": `
+hooks:
+  predeploy:
+    run: ./scripts/a.sh
+`,
+			"teams/b.yaml": `
+hooks:
+  predeploy:
+    run: ./scripts/b.sh
+`,
+		})
+
+		_, err := Load(context.Background(), filepath.Join(dir, "azure.yaml"))
+		require.EqualError(
+			t, err, "hook 'predeploy' in '"+filepath.Join("teams", "b.yaml")+"' is already defined in '"+
+				filepath.Join("teams", "a.yaml")+"'")
+	})
+}
+
+func TestLoadIncludesUnknownField(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"azure.yaml": `
+name: test-proj
+include:
+  - extra.yaml
+`,
+		"extra.yaml": `
+name: other-proj
+`,
+	})
+
+	_, err := Load(context.Background(), filepath.Join(dir, "azure.yaml"))
+	require.ErrorContains(t, err, "parsing included project file 'extra.yaml'")
+}
+
+func TestSaveIncludes(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"azure.yaml": `
+name: test-proj
+include:
+  - web.yaml
+  - hooks.yaml
+services:
+  api:
+    project: src/api
+    language: js
+    host: containerapp
+`,
+		"web.yaml": `
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+pipeline:
+  provider: azdo
+`,
+		"hooks.yaml": `
+# Owned by the platform team
+hooks:
+  preprovision:
+    run: ./scripts/preprovision.sh
+`,
+	})
+
+	projectFilePath := filepath.Join(dir, "azure.yaml")
+	projectConfig, err := Load(context.Background(), projectFilePath)
+	require.NoError(t, err)
+
+	projectConfig.Services["web"].Host = StaticWebAppTarget
+	projectConfig.Services["worker"] = &ServiceConfig{
+		RelativePath: "src/worker",
+		Language:     ServiceLanguagePython,
+		Host:         ContainerAppTarget,
+	}
+
+	require.NoError(t, Save(context.Background(), projectConfig, projectFilePath))
+
+	var project map[string]any
+	projectBytes, err := os.ReadFile(projectFilePath)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(projectBytes, &project))
+	require.ElementsMatch(t, []string{"api", "worker"}, keys(project["services"]))
+	require.NotContains(t, project, "pipeline")
+	require.Equal(t, []any{"web.yaml", "hooks.yaml"}, project["include"])
+
+	var fragment map[string]any
+	fragmentBytes, err := os.ReadFile(filepath.Join(dir, "web.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(fragmentBytes, &fragment))
+	require.ElementsMatch(t, []string{"web"}, keys(fragment["services"]))
+	require.Equal(t, "staticwebapp", fragment["services"].(map[string]any)["web"].(map[string]any)["host"])
+	require.Equal(t, map[string]any{"provider": "azdo"}, fragment["pipeline"])
+
+	// Included files without changes are not written.
+	hooksBytes, err := os.ReadFile(filepath.Join(dir, "hooks.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(hooksBytes), "# Owned by the platform team")
+
+	// The saved files load back to the same project.
+	reloaded, err := Load(context.Background(), projectFilePath)
+	require.NoError(t, err)
+	require.Len(t, reloaded.Services, 3)
+	require.Equal(t, StaticWebAppTarget, reloaded.Services["web"].Host)
+}
+
+func keys(value any) []string {
+	var result []string
+	for key := range value.(map[string]any) {
+		result = append(result, key)
+	}
+
+	return result
+}
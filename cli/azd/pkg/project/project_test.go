@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -259,6 +260,74 @@ func Test_Invalid_Project_File(t *testing.T) {
 	}
 }
 
+func TestParseLayerNames(t *testing.T) {
+	parse := func(infra string) error {
+		_, err := Parse(context.Background(), heredoc.Doc(`
+			name: test-proj
+			services:
+			  api:
+			    project: src/api
+			    language: js
+			    host: appservice
+			    infra:
+			      path: infra
+			  web:
+			    project: src/web
+			    language: js
+			    host: appservice
+		`)+infra)
+		return err
+	}
+
+	require.NoError(t, parse(heredoc.Doc(`
+		infra:
+		  layers:
+		    - name: network
+		      path: infra/network
+		    - name: web
+		      path: infra/web
+	`)))
+
+	tests := map[string]struct {
+		infra string
+		err   string
+	}{
+		"DuplicateLayer": {
+			heredoc.Doc(`
+				infra:
+				  layers:
+				    - name: network
+				      path: infra/network
+				    - name: network
+				      path: infra/app
+			`),
+			"infrastructure layer 'network' is defined more than once",
+		},
+		"ServiceLayer": {
+			heredoc.Doc(`
+				infra:
+				  layers:
+				    - name: api
+				      path: infra/api
+			`),
+			"infrastructure layer 'api' has the name of service 'api'",
+		},
+		"ProjectInfraName": {
+			heredoc.Doc(`
+				infra:
+				  name: network
+			`),
+			"infra can't have a name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorContains(t, parse(tt.infra), tt.err)
+		})
+	}
+}
+
 func TestMinimalYaml(t *testing.T) {
 	prj := &ProjectConfig{
 		Name:     "minimal",
@@ -68,6 +68,34 @@ func buildForZip(src, dst string, options buildForZipOptions) error {
 	})
 }
 
+// packageExecutable is used by projects which build to an executable, like Go and Rust. The files of the project, without
+// the ones options excludes, are copied to a new package directory together with the content of executableDir.
+func packageExecutable(serviceConfig *ServiceConfig, executableDir string, options buildForZipOptions) (string, error) {
+	packageDest, err := os.MkdirTemp("", "azd")
+	if err != nil {
+		return "", fmt.Errorf("creating package directory for %s: %w", serviceConfig.Name, err)
+	}
+
+	packageSource := serviceConfig.Path()
+	if serviceConfig.OutputPath != "" {
+		packageSource = filepath.Join(packageSource, serviceConfig.OutputPath)
+	}
+
+	if err := buildForZip(packageSource, packageDest, options); err != nil {
+		return "", fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err)
+	}
+
+	if err := copy.Copy(executableDir, packageDest); err != nil {
+		return "", fmt.Errorf("copying executable for %s: %w", serviceConfig.Name, err)
+	}
+
+	if err := validatePackageOutput(packageDest); err != nil {
+		return "", err
+	}
+
+	return packageDest, nil
+}
+
 func globalExcludeAzdFolder(path string, file os.FileInfo) bool {
 	return file.IsDir() && file.Name() == ".azure"
 }
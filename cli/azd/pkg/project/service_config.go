@@ -33,6 +33,8 @@ type ServiceConfig struct {
 	Infra provisioning.Options `yaml:"infra,omitempty"`
 	// Hook configuration for service
 	Hooks map[string]*ext.HookConfig `yaml:"hooks,omitempty"`
+	// The environment variables, or app settings, applied to the target resource of the service on deploy
+	Env map[string]ServiceEnvVar `yaml:"env,omitempty"`
 	// Options specific to the DotNetContainerApp target. These are set by the importer and
 	// can not be controlled via the project file today.
 	DotNetContainerApp *DotNetContainerAppOptions `yaml:"-,omitempty"`
@@ -48,6 +50,25 @@ type DotNetContainerAppOptions struct {
 	ProjectPath string
 }
 
+// HasInfra returns true when the service owns an infrastructure module (`infra.path` is set for the service in azure.yaml).
+// It is provisioned independently of the infrastructure of the project, as its own layer.
+func (sc *ServiceConfig) HasInfra() bool {
+	return sc.Infra.Path != ""
+}
+
+// InfraOptions returns the provisioning options of the infrastructure module owned by the service. The path of the module
+// is made relative to the project, and the layer is named after the service.
+func (sc *ServiceConfig) InfraOptions() provisioning.Options {
+	options := sc.Infra
+	options.Name = sc.Name
+
+	if !filepath.IsAbs(options.Path) {
+		options.Path = filepath.Join(sc.RelativePath, options.Path)
+	}
+
+	return options
+}
+
 // Path returns the fully qualified path to the project
 func (sc *ServiceConfig) Path() string {
 	if filepath.IsAbs(sc.RelativePath) {
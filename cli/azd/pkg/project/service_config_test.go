@@ -224,3 +224,21 @@ func createTestServiceConfig(path string, host ServiceTargetKind, language Servi
 		EventDispatcher: ext.NewEventDispatcher[ServiceLifecycleEventArgs](),
 	}
 }
+
+func TestServiceConfigInfraOptions(t *testing.T) {
+	service := createTestServiceConfig("src/api", ContainerAppTarget, ServiceLanguageTypeScript)
+	require.False(t, service.HasInfra())
+
+	service.Infra.Path = "infra"
+	service.Infra.Module = "api"
+	require.True(t, service.HasInfra())
+
+	options := service.InfraOptions()
+	require.Equal(t, "api", options.Name)
+	require.Equal(t, filepath.Join("src", "api", "infra"), options.Path)
+	require.Equal(t, "api", options.Module)
+
+	// The options of the service itself are not modified.
+	require.Equal(t, "infra", service.Infra.Path)
+	require.Equal(t, "", service.Infra.Name)
+}
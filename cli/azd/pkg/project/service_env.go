@@ -0,0 +1,170 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"golang.org/x/exp/maps"
+)
+
+// ServiceEnvVar is an environment variable declared for a service in the env section of azure.yaml, applied to the
+// target resource of the service on deploy. It is declared either as a plain value or as a mapping with a value and a
+// secret flag:
+//
+//	env:
+//	  LOG_LEVEL: info
+//	  DB_PASSWORD:
+//	    value: ${DB_PASSWORD}
+//	    secret: true
+type ServiceEnvVar struct {
+	// The value of the variable, which can reference variables of the azd environment, like ${VAR}.
+	Value ExpandableString `yaml:"value"`
+	// Secret values are stored as secrets by hosts which support them, and referenced by the variable.
+	Secret bool `yaml:"secret,omitempty"`
+}
+
+// serviceEnvVar has the fields of ServiceEnvVar without its yaml marshalling.
+type serviceEnvVar ServiceEnvVar
+
+func (v ServiceEnvVar) MarshalYAML() (interface{}, error) {
+	if !v.Secret {
+		return v.Value, nil
+	}
+
+	return serviceEnvVar(v), nil
+}
+
+func (v *ServiceEnvVar) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value ExpandableString
+	if err := unmarshal(&value); err == nil {
+		*v = ServiceEnvVar{Value: value}
+		return nil
+	}
+
+	var envVar serviceEnvVar
+	if err := unmarshal(&envVar); err != nil {
+		return err
+	}
+
+	*v = ServiceEnvVar(envVar)
+	return nil
+}
+
+// ServiceSetting is an environment variable of a service, with its value evaluated for the current environment.
+type ServiceSetting struct {
+	Name   string
+	Value  string
+	Secret bool
+}
+
+// ServiceSettingsTarget is implemented by service targets which apply the env section of a service to its target
+// resource: app settings for App Service and Function apps, environment variables for container apps and a ConfigMap
+// and Secret for AKS.
+type ServiceSettingsTarget interface {
+	// ApplySettings sets the settings of the service on the target resource, and removes the settings named in removed.
+	// Targets which create a new version of the resource on deploy, like container apps, apply the settings with the
+	// deploy which follows instead.
+	ApplySettings(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		settings []ServiceSetting,
+		removed []string,
+	) error
+}
+
+// Settings evaluates the env section of the service for the given environment. Settings are sorted by name.
+func (sc *ServiceConfig) Settings(env *environment.Environment) ([]ServiceSetting, error) {
+	names := maps.Keys(sc.Env)
+	slices.Sort(names)
+
+	settings := make([]ServiceSetting, 0, len(names))
+	for _, name := range names {
+		envVar := sc.Env[name]
+		value, err := envVar.Value.Envsubst(env.Getenv)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating env '%s' of service '%s': %w", name, sc.Name, err)
+		}
+
+		settings = append(settings, ServiceSetting{
+			Name:   name,
+			Value:  value,
+			Secret: envVar.Secret,
+		})
+	}
+
+	return settings, nil
+}
+
+// appSettings returns the values of the settings by name, as app settings of App Service and Function apps. App settings
+// are visible to anyone who can read the app, so the value of a secret setting must be a Key Vault reference, which the
+// app resolves with its managed identity.
+func appSettings(settings []ServiceSetting) (map[string]string, error) {
+	values := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		if setting.Secret && !isKeyVaultReference(setting.Value) {
+			return nil, fmt.Errorf(
+				"secret setting '%s' must be a Key Vault reference, like "+
+					"@Microsoft.KeyVault(SecretUri=https://<vault>/secrets/<name>), since app settings are not secret",
+				setting.Name)
+		}
+
+		values[setting.Name] = setting.Value
+	}
+
+	return values, nil
+}
+
+// isKeyVaultReference returns whether the value of an app setting is a reference to a Key Vault secret.
+func isKeyVaultReference(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "@Microsoft.KeyVault(")
+}
+
+// appliedSettingsConfigPath returns the path in the config of an environment where the names of the settings applied
+// to a service on its last deploy are kept, so settings removed from azure.yaml can be removed from the target resource.
+func appliedSettingsConfigPath(serviceName string) string {
+	return fmt.Sprintf("services.%s.settings", serviceName)
+}
+
+// appliedSettings returns the names of the settings applied to the service on its last deploy.
+func appliedSettings(env *environment.Environment, serviceName string) []string {
+	value, has := env.Config.Get(appliedSettingsConfigPath(serviceName))
+	if !has {
+		return nil
+	}
+
+	switch values := value.(type) {
+	case []string:
+		return values
+	case []any:
+		var names []string
+		for _, value := range values {
+			if name, ok := value.(string); ok {
+				names = append(names, name)
+			}
+		}
+
+		return names
+	default:
+		return nil
+	}
+}
+
+// removedSettings returns the names of the settings applied to the service on its last deploy which are no longer
+// declared.
+func removedSettings(previous []string, settings []ServiceSetting) []string {
+	var removed []string
+	for _, name := range previous {
+		if !slices.ContainsFunc(settings, func(setting ServiceSetting) bool { return setting.Name == name }) {
+			removed = append(removed, name)
+		}
+	}
+
+	return removed
+}
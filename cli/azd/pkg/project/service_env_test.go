@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestServiceEnvVarYaml(t *testing.T) {
+	const projectYaml = `
+name: test-proj
+services:
+  api:
+    project: src/api
+    language: js
+    host: containerapp
+    env:
+      LOG_LEVEL: info
+      DB_HOST: ${DB_HOST}
+      DB_PASSWORD:
+        value: ${DB_PASSWORD}
+        secret: true
+`
+
+	projectConfig, err := Parse(context.Background(), projectYaml)
+	require.NoError(t, err)
+
+	service := projectConfig.Services["api"]
+	require.Equal(t, map[string]ServiceEnvVar{
+		"LOG_LEVEL":   {Value: NewExpandableString("info")},
+		"DB_HOST":     {Value: NewExpandableString("${DB_HOST}")},
+		"DB_PASSWORD": {Value: NewExpandableString("${DB_PASSWORD}"), Secret: true},
+	}, service.Env)
+
+	env := environment.NewWithValues("test", map[string]string{
+		"DB_HOST":     "db.example.com",
+		"DB_PASSWORD": "password",
+	})
+	settings, err := service.Settings(env)
+	require.NoError(t, err)
+	require.Equal(t, []ServiceSetting{
+		{Name: "DB_HOST", Value: "db.example.com"},
+		{Name: "DB_PASSWORD", Value: "password", Secret: true},
+		{Name: "LOG_LEVEL", Value: "info"},
+	}, settings)
+
+	serviceYaml, err := yaml.Marshal(service.Env)
+	require.NoError(t, err)
+
+	var roundTripped map[string]ServiceEnvVar
+	require.NoError(t, yaml.Unmarshal(serviceYaml, &roundTripped))
+	require.Equal(t, service.Env, roundTripped)
+	require.Contains(t, string(serviceYaml), "LOG_LEVEL: info\n")
+}
+
+func TestAppSettings(t *testing.T) {
+	values, err := appSettings([]ServiceSetting{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{
+			Name:   "DB_PASSWORD",
+			Value:  "@Microsoft.KeyVault(SecretUri=https://vault.vault.azure.net/secrets/db-password)",
+			Secret: true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"LOG_LEVEL":   "info",
+		"DB_PASSWORD": "@Microsoft.KeyVault(SecretUri=https://vault.vault.azure.net/secrets/db-password)",
+	}, values)
+
+	_, err = appSettings([]ServiceSetting{{Name: "DB_PASSWORD", Value: "password", Secret: true}})
+	require.ErrorContains(t, err, "secret setting 'DB_PASSWORD' must be a Key Vault reference")
+}
@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
@@ -86,6 +87,7 @@ type ServiceManager interface {
 		ctx context.Context,
 		serviceConfig *ServiceConfig,
 		packageOutput *ServicePackageResult,
+		options *DeployOptions,
 	) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress]
 
 	// Gets the framework service for the specified service config
@@ -100,6 +102,7 @@ type ServiceManager interface {
 
 type serviceManager struct {
 	env                 *environment.Environment
+	envManager          environment.Manager
 	resourceManager     ResourceManager
 	serviceLocator      ioc.ServiceLocator
 	operationCache      map[string]any
@@ -109,12 +112,14 @@ type serviceManager struct {
 // NewServiceManager creates a new instance of the ServiceManager component
 func NewServiceManager(
 	env *environment.Environment,
+	envManager environment.Manager,
 	resourceManager ResourceManager,
 	serviceLocator ioc.ServiceLocator,
 	alphaFeatureManager *alpha.FeatureManager,
 ) ServiceManager {
 	return &serviceManager{
 		env:                 env,
+		envManager:          envManager,
 		resourceManager:     resourceManager,
 		serviceLocator:      serviceLocator,
 		operationCache:      map[string]any{},
@@ -420,12 +425,18 @@ func (sm *serviceManager) Package(
 // Deploys the generated artifacts to the Azure resource that will host the service application
 // Common examples would be uploading zip archive using ZipDeploy deployment or
 // pushing container images to a container registry.
+// The settings declared in the env section of the service are applied to the target resource before it is deployed.
 func (sm *serviceManager) Deploy(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
 	packageResult *ServicePackageResult,
+	options *DeployOptions,
 ) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
 	return async.RunTaskWithProgress(func(task *async.TaskContextWithProgress[*ServiceDeployResult, ServiceProgress]) {
+		if options == nil {
+			options = &DeployOptions{}
+		}
+
 		cachedResult, ok := sm.getOperationResult(ctx, serviceConfig, string(ServiceEventDeploy))
 		if ok && cachedResult != nil {
 			task.SetResult(cachedResult.(*ServiceDeployResult))
@@ -492,7 +503,36 @@ func (sm *serviceManager) Deploy(
 			ServiceEventDeploy,
 			serviceConfig,
 			func() *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
-				return serviceTarget.Deploy(ctx, serviceConfig, packageResult, targetResource)
+				return async.RunTaskWithProgress(
+					func(deployTask *async.TaskContextWithProgress[*ServiceDeployResult, ServiceProgress]) {
+						// Progress is reported on the task of the deploy, which outlives this task.
+						hasSettings, applied, err := sm.applySettings(
+							ctx, task, serviceTarget, serviceConfig, targetResource, options)
+						if err != nil {
+							deployTask.SetError(err)
+							return
+						}
+
+						targetTask := serviceTarget.Deploy(ctx, serviceConfig, packageResult, targetResource)
+						syncProgress(task, targetTask.Progress())
+
+						targetResult, err := targetTask.Await()
+						if err != nil {
+							deployTask.SetError(err)
+							return
+						}
+
+						// Settings are only recorded once deployed, since some targets apply them with the deploy.
+						if hasSettings {
+							if err := sm.recordAppliedSettings(ctx, serviceConfig.Name, applied); err != nil {
+								deployTask.SetError(err)
+								return
+							}
+						}
+
+						deployTask.SetResult(targetResult)
+					},
+				)
 			},
 		)
 
@@ -513,6 +553,81 @@ func (sm *serviceManager) Deploy(
 	})
 }
 
+// applySettings applies the settings declared in the env section of the service to its target resource, and returns the
+// names of the settings to record as applied once the service is deployed. The names of the applied settings are kept in
+// the config of the environment, so settings which are no longer declared can be removed on a later deploy with the
+// SyncSettings option. hasSettings is false when there are no settings to apply.
+func (sm *serviceManager) applySettings(
+	ctx context.Context,
+	task *async.TaskContextWithProgress[*ServiceDeployResult, ServiceProgress],
+	serviceTarget ServiceTarget,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *DeployOptions,
+) (hasSettings bool, applied []string, err error) {
+	previous := appliedSettings(sm.env, serviceConfig.Name)
+	if len(serviceConfig.Env) == 0 && (len(previous) == 0 || !options.SyncSettings) {
+		return false, nil, nil
+	}
+
+	settingsTarget, ok := serviceTarget.(ServiceSettingsTarget)
+	if !ok {
+		return false, nil, fmt.Errorf("service host '%s' doesn't support the env section of service '%s'",
+			serviceConfig.Host, serviceConfig.Name)
+	}
+
+	settings, err := serviceConfig.Settings(sm.env)
+	if err != nil {
+		return false, nil, err
+	}
+
+	applied = make([]string, 0, len(settings))
+	for _, setting := range settings {
+		applied = append(applied, setting.Name)
+	}
+
+	removed := removedSettings(previous, settings)
+	if !options.SyncSettings {
+		// Settings which are no longer declared are kept on the target resource, and remembered so a later deploy with
+		// SyncSettings removes them.
+		applied = append(applied, removed...)
+		slices.Sort(applied)
+		removed = nil
+	}
+
+	task.SetProgress(NewServiceProgress("Applying settings"))
+	if len(removed) > 0 {
+		log.Printf("removing settings %v of service '%s'", removed, serviceConfig.Name)
+	}
+
+	err = settingsTarget.ApplySettings(ctx, serviceConfig, targetResource, settings, removed)
+	if err != nil {
+		return false, nil, fmt.Errorf("applying settings: %w", err)
+	}
+
+	return true, applied, nil
+}
+
+// recordAppliedSettings keeps the names of the settings applied to the service in the config of the environment.
+func (sm *serviceManager) recordAppliedSettings(ctx context.Context, serviceName string, applied []string) error {
+	var err error
+	configPath := appliedSettingsConfigPath(serviceName)
+	if len(applied) == 0 {
+		err = sm.env.Config.Unset(configPath)
+	} else {
+		err = sm.env.Config.Set(configPath, applied)
+	}
+	if err != nil {
+		return fmt.Errorf("recording applied settings: %w", err)
+	}
+
+	if err := sm.envManager.Save(ctx, sm.env); err != nil {
+		return fmt.Errorf("saving environment: %w", err)
+	}
+
+	return nil
+}
+
 // GetServiceTarget constructs a ServiceTarget from the underlying service configuration
 func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *ServiceConfig) (ServiceTarget, error) {
 	var target ServiceTarget
@@ -545,7 +660,12 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig *ServiceConfig) (FrameworkService, error) {
 	var frameworkService FrameworkService
 
-	if err := sm.serviceLocator.ResolveNamed(string(serviceConfig.Language), &frameworkService); err != nil {
+	framework := serviceConfig.Language
+	if framework == ServiceLanguageJava && isGradleProject(serviceConfig.Path()) {
+		framework = ServiceLanguageGradle
+	}
+
+	if err := sm.serviceLocator.ResolveNamed(string(framework), &frameworkService); err != nil {
 		panic(fmt.Errorf(
 			"failed to resolve language '%s' for service '%s', %w",
 			serviceConfig.Language,
@@ -555,8 +675,12 @@ func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig
 	}
 
 	// For hosts which run in containers, if the source project is not already a container, we need to wrap it in a docker
-	// project that handles the containerization.
-	if serviceConfig.Host.RequiresContainer() && serviceConfig.Language != ServiceLanguageDocker {
+	// project that handles the containerization. Services of an Aspire app host are containerized by `dotnet publish`,
+	// except for executables written in other languages.
+	requiresContainer := serviceConfig.Host.RequiresContainer() ||
+		(serviceConfig.Host == DotNetContainerAppTarget && serviceConfig.Language != ServiceLanguageDotNet)
+
+	if requiresContainer && serviceConfig.Language != ServiceLanguageDocker {
 		var compositeFramework CompositeFrameworkService
 		if err := sm.serviceLocator.ResolveNamed(string(ServiceLanguageDocker), &compositeFramework); err != nil {
 			panic(fmt.Errorf(
@@ -21,6 +21,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockarmresources"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,6 +37,7 @@ const (
 	frameworkPackageCalled     contextKey = "frameworkPackageCalled"
 	serviceTargetPackageCalled contextKey = "serviceTargetPackageCalled"
 	serviceTargetDeployCalled  contextKey = "serviceTargetDeployCalled"
+	serviceTargetSettings      contextKey = "serviceTargetSettings"
 )
 
 func createServiceManager(mockContext *mocks.MockContext, env *environment.Environment) ServiceManager {
@@ -50,7 +53,10 @@ func createServiceManager(mockContext *mocks.MockContext, env *environment.Envir
 			},
 		}))
 
-	return NewServiceManager(env, resourceManager, serviceLocator, alphaManager)
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, env).Return(nil)
+
+	return NewServiceManager(env, envManager, resourceManager, serviceLocator, alphaManager)
 }
 
 func Test_ServiceManager_GetRequiredTools(t *testing.T) {
@@ -207,7 +213,7 @@ func Test_ServiceManager_Deploy(t *testing.T) {
 	deployCalled := convert.RefOf(false)
 	ctx := context.WithValue(*mockContext.Context, serviceTargetDeployCalled, deployCalled)
 
-	deployTask := sm.Deploy(ctx, serviceConfig, nil)
+	deployTask := sm.Deploy(ctx, serviceConfig, nil, nil)
 	logProgress(deployTask)
 
 	result, err := deployTask.Await()
@@ -218,6 +224,54 @@ func Test_ServiceManager_Deploy(t *testing.T) {
 	require.True(t, raisedPostDeployEvent)
 }
 
+func Test_ServiceManager_Deploy_Settings(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	setupMocksForServiceManager(mockContext)
+	env := environment.NewWithValues("test", map[string]string{
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		"DB_HOST":                            "db.example.com",
+	})
+	serviceConfig := createTestServiceConfig("./src/api", ServiceTargetFake, ServiceLanguageFake)
+	serviceConfig.Env = map[string]ServiceEnvVar{
+		"DB_HOST":     {Value: NewExpandableString("${DB_HOST}")},
+		"DB_PASSWORD": {Value: NewExpandableString("secret"), Secret: true},
+	}
+
+	deploy := func(options *DeployOptions) *fakeAppliedSettings {
+		applied := &fakeAppliedSettings{}
+		ctx := context.WithValue(*mockContext.Context, serviceTargetSettings, applied)
+
+		// A new service manager is created for each deploy, since it caches the deploy result.
+		deployTask := createServiceManager(mockContext, env).Deploy(ctx, serviceConfig, nil, options)
+		logProgress(deployTask)
+
+		_, err := deployTask.Await()
+		require.NoError(t, err)
+
+		return applied
+	}
+
+	applied := deploy(nil)
+	require.Equal(t, []ServiceSetting{
+		{Name: "DB_HOST", Value: "db.example.com"},
+		{Name: "DB_PASSWORD", Value: "secret", Secret: true},
+	}, applied.settings)
+	require.Empty(t, applied.removed)
+	require.Equal(t, []string{"DB_HOST", "DB_PASSWORD"}, appliedSettings(env, "api"))
+
+	// Settings which are no longer declared are kept, unless settings are synced.
+	delete(serviceConfig.Env, "DB_PASSWORD")
+
+	applied = deploy(nil)
+	require.Equal(t, []ServiceSetting{{Name: "DB_HOST", Value: "db.example.com"}}, applied.settings)
+	require.Empty(t, applied.removed)
+	require.Equal(t, []string{"DB_HOST", "DB_PASSWORD"}, appliedSettings(env, "api"))
+
+	applied = deploy(&DeployOptions{SyncSettings: true})
+	require.Equal(t, []string{"DB_PASSWORD"}, applied.removed)
+	require.Equal(t, []string{"DB_HOST"}, appliedSettings(env, "api"))
+}
+
 func Test_ServiceManager_GetFrameworkService(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	setupMocksForServiceManager(mockContext)
@@ -304,7 +358,7 @@ func Test_ServiceManager_Events_With_Errors(t *testing.T) {
 		{
 			name: "deploy",
 			run: func(ctx context.Context, serviceManager ServiceManager, serviceConfig *ServiceConfig) (any, error) {
-				deployTask := serviceManager.Deploy(ctx, serviceConfig, nil)
+				deployTask := serviceManager.Deploy(ctx, serviceConfig, nil, nil)
 				logProgress(deployTask)
 				return deployTask.Await()
 			},
@@ -572,6 +626,26 @@ func (st *fakeServiceTarget) Deploy(
 	})
 }
 
+type fakeAppliedSettings struct {
+	settings []ServiceSetting
+	removed  []string
+}
+
+func (st *fakeServiceTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings []ServiceSetting,
+	removed []string,
+) error {
+	if applied, ok := ctx.Value(serviceTargetSettings).(*fakeAppliedSettings); ok {
+		applied.settings = settings
+		applied.removed = removed
+	}
+
+	return nil
+}
+
 func (st *fakeServiceTarget) Endpoints(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
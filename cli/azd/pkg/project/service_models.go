@@ -64,6 +64,12 @@ type PackageOptions struct {
 	OutputPath string
 }
 
+type DeployOptions struct {
+	// SyncSettings removes the settings applied on a previous deploy which are no longer declared in the env section of
+	// the service.
+	SyncSettings bool
+}
+
 // ServicePackageResult is the result of a successful Package operation
 type ServicePackageResult struct {
 	Build       *ServiceBuildResult `json:"build"`
@@ -19,6 +19,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/kubectl"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -225,6 +226,86 @@ func (t *aksTarget) Deploy(
 		})
 }
 
+// Applies the settings of the service to the AKS cluster as a ConfigMap, and a Secret for the secret settings, both
+// named '<service>-env' in the namespace of the service. Deployments reference them with envFrom. Both are owned by azd
+// and always hold exactly the declared settings, so settings which are no longer declared are removed even without
+// syncing settings.
+func (t *aksTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings []ServiceSetting,
+	removed []string,
+) error {
+	if err := t.validateTargetResource(ctx, serviceConfig, targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	manifest, err := settingsManifest(serviceConfig, t.getK8sNamespace(serviceConfig), settings)
+	if err != nil {
+		return err
+	}
+
+	if _, err := t.kubectl.ApplyWithStdIn(ctx, manifest, nil); err != nil {
+		return fmt.Errorf("failed applying settings: %w", err)
+	}
+
+	return nil
+}
+
+// settingsManifest returns the k8s manifest of the ConfigMap and Secret holding the settings of the service.
+func settingsManifest(serviceConfig *ServiceConfig, namespace string, settings []ServiceSetting) (string, error) {
+	data := map[string]string{}
+	secretData := map[string]string{}
+	for _, setting := range settings {
+		if setting.Secret {
+			secretData[setting.Name] = setting.Value
+		} else {
+			data[setting.Name] = setting.Value
+		}
+	}
+
+	metadata := map[string]string{
+		"name":      settingsResourceName(serviceConfig),
+		"namespace": namespace,
+	}
+
+	documents := []map[string]any{
+		{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   metadata,
+			"data":       data,
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"type":       "Opaque",
+			"metadata":   metadata,
+			"stringData": secretData,
+		},
+	}
+
+	var manifest strings.Builder
+	for _, document := range documents {
+		documentBytes, err := yaml.Marshal(document)
+		if err != nil {
+			return "", fmt.Errorf("marshalling settings manifest: %w", err)
+		}
+
+		manifest.WriteString("---\n")
+		manifest.Write(documentBytes)
+	}
+
+	return manifest.String(), nil
+}
+
+// settingsResourceName returns the name of the ConfigMap and Secret holding the settings of a service deployed to AKS.
+func settingsResourceName(serviceConfig *ServiceConfig) string {
+	name := strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(serviceConfig.Name))
+	return fmt.Sprintf("%s-env", name)
+}
+
 // Gets the service endpoints for the AKS service target
 func (t *aksTarget) Endpoints(
 	ctx context.Context,
@@ -15,6 +15,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -159,6 +160,38 @@ func Test_Deploy_No_Credentials(t *testing.T) {
 	require.ErrorContains(t, err, "failed retrieving cluster admin credentials")
 }
 
+func Test_SettingsManifest(t *testing.T) {
+	serviceConfig := createTestServiceConfig("./src/api", AksTarget, ServiceLanguageTypeScript)
+	serviceConfig.Name = "my_api"
+
+	manifest, err := settingsManifest(serviceConfig, "test-ns", []ServiceSetting{
+		{Name: "DB_PASSWORD", Value: "password", Secret: true},
+		{Name: "LOG_LEVEL", Value: "info"},
+	})
+	require.NoError(t, err)
+
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	var documents []map[string]any
+	for {
+		var document map[string]any
+		if err := decoder.Decode(&document); err != nil {
+			break
+		}
+		documents = append(documents, document)
+	}
+
+	require.Len(t, documents, 2)
+	metadata := map[string]any{"name": "my-api-env", "namespace": "test-ns"}
+
+	require.Equal(t, "ConfigMap", documents[0]["kind"])
+	require.Equal(t, metadata, documents[0]["metadata"])
+	require.Equal(t, map[string]any{"LOG_LEVEL": "info"}, documents[0]["data"])
+
+	require.Equal(t, "Secret", documents[1]["kind"])
+	require.Equal(t, metadata, documents[1]["metadata"])
+	require.Equal(t, map[string]any{"DB_PASSWORD": "password"}, documents[1]["stringData"])
+}
+
 func setupK8sManifests(t *testing.T, serviceConfig *ServiceConfig) error {
 	manifestsDir := filepath.Join(serviceConfig.RelativePath, defaultDeploymentPath)
 	err := os.MkdirAll(manifestsDir, osutil.PermissionDirectory)
@@ -513,8 +546,17 @@ func createAksServiceTarget(
 		On("GetTargetResource", *mockContext.Context, "SUBSCRIPTION_ID", serviceConfig).
 		Return(targetResource, nil)
 
-	managedClustersService := azcli.NewManagedClustersService(credentialProvider, mockContext.HttpClient)
-	containerRegistryService := azcli.NewContainerRegistryService(credentialProvider, mockContext.HttpClient, dockerCli)
+	managedClustersService := azcli.NewManagedClustersService(
+		credentialProvider,
+		mockContext.HttpClient,
+		cloud.AzurePublic(),
+	)
+	containerRegistryService := azcli.NewContainerRegistryService(
+		credentialProvider,
+		mockContext.HttpClient,
+		dockerCli,
+		cloud.AzurePublic(),
+	)
 	containerHelper := NewContainerHelper(env, envManager, clock.NewMock(), containerRegistryService, dockerCli)
 
 	return NewAksTarget(
@@ -131,6 +131,33 @@ func (st *appServiceTarget) Deploy(
 	)
 }
 
+// Sets the settings of the service as app settings of the App Service
+func (st *appServiceTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings []ServiceSetting,
+	removed []string,
+) error {
+	if err := st.validateTargetResource(ctx, serviceConfig, targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	values, err := appSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	return st.cli.UpdateAppServiceAppSettings(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		values,
+		removed,
+	)
+}
+
 // Gets the exposed endpoints for the App Service
 func (st *appServiceTarget) Endpoints(
 	ctx context.Context,
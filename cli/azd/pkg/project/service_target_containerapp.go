@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
@@ -22,6 +23,10 @@ type containerAppTarget struct {
 	containerHelper     *ContainerHelper
 	containerAppService containerapps.ContainerAppService
 	resourceManager     ResourceManager
+
+	// pendingSettings are the settings of each service applied with its next deploy, by service name.
+	pendingSettings   map[string]*containerapps.AddRevisionOptions
+	pendingSettingsMu sync.Mutex
 }
 
 // NewContainerAppTarget creates the container app service target.
@@ -41,6 +46,7 @@ func NewContainerAppTarget(
 		containerHelper:     containerHelper,
 		containerAppService: containerAppService,
 		resourceManager:     resourceManager,
+		pendingSettings:     map[string]*containerapps.AddRevisionOptions{},
 	}
 }
 
@@ -103,6 +109,7 @@ func (at *containerAppTarget) Deploy(
 				targetResource.ResourceGroupName(),
 				targetResource.ResourceName(),
 				imageName,
+				at.takePendingSettings(serviceConfig.Name),
 			)
 			if err != nil {
 				task.SetError(fmt.Errorf("updating container app service: %w", err))
@@ -130,6 +137,49 @@ func (at *containerAppTarget) Deploy(
 	)
 }
 
+// Sets the settings of the service as environment variables of the container app. Secret settings are stored as secrets
+// of the container app. The settings are applied by the revision the deploy which follows adds, so applying them doesn't
+// add a revision of its own.
+func (at *containerAppTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings []ServiceSetting,
+	removed []string,
+) error {
+	if err := at.validateTargetResource(ctx, serviceConfig, targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	options := &containerapps.AddRevisionOptions{
+		EnvVars:        make([]containerapps.ContainerAppEnvVar, 0, len(settings)),
+		RemovedEnvVars: removed,
+	}
+	for _, setting := range settings {
+		options.EnvVars = append(options.EnvVars, containerapps.ContainerAppEnvVar{
+			Name:   setting.Name,
+			Value:  setting.Value,
+			Secret: setting.Secret,
+		})
+	}
+
+	at.pendingSettingsMu.Lock()
+	defer at.pendingSettingsMu.Unlock()
+	at.pendingSettings[serviceConfig.Name] = options
+
+	return nil
+}
+
+// takePendingSettings returns the settings to apply with the next deploy of the service, which are then no longer pending.
+func (at *containerAppTarget) takePendingSettings(serviceName string) *containerapps.AddRevisionOptions {
+	at.pendingSettingsMu.Lock()
+	defer at.pendingSettingsMu.Unlock()
+
+	options := at.pendingSettings[serviceName]
+	delete(at.pendingSettings, serviceName)
+	return options
+}
+
 // Gets endpoint for the container app service
 func (at *containerAppTarget) Endpoints(
 	ctx context.Context,
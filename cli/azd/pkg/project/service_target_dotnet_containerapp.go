@@ -108,7 +108,8 @@ func (at *dotnetContainerAppTarget) Deploy(
 
 			var remoteImageName string
 
-			if serviceConfig.Language == ServiceLanguageDocker {
+			if serviceConfig.Language != ServiceLanguageDotNet {
+				// Dockerfiles and executables written in other languages were built into an image during packaging.
 				containerDeployTask := at.containerHelper.Deploy(ctx, serviceConfig, packageOutput, targetResource, false)
 				syncProgress(task, containerDeployTask.Progress())
 
@@ -358,7 +359,7 @@ func (_ *containerAppTemplateManifestFuncs) UrlHost(s string) (string, error) {
 }
 
 // ConnectionString returns the connection string for the given resource name. Presently, we only support resources of
-// type `redis.v0` and `postgres.v0`.
+// type `redis.v0`, `postgres.v0` and `kafka.server.v0`.
 //
 // It is callable from a template under the name `connectionString`.
 func (fns *containerAppTemplateManifestFuncs) ConnectionString(name string) (string, error) {
@@ -394,6 +395,8 @@ func (fns *containerAppTemplateManifestFuncs) ConnectionString(name string) (str
 		}
 
 		return fmt.Sprintf("Host=%s;Database=postgres;Username=postgres;Password=%s", targetContainerName, password), nil
+	case "kafka.server.v0":
+		return fmt.Sprintf("%s:9092", scaffold.ContainerAppName(name)), nil
 	default:
 		return "", fmt.Errorf("connectionString: unsupported resource type '%s'", resource.Type)
 	}
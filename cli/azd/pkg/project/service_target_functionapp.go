@@ -132,6 +132,33 @@ func (f *functionAppTarget) Deploy(
 	)
 }
 
+// Sets the settings of the service as app settings of the Function App
+func (f *functionAppTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings []ServiceSetting,
+	removed []string,
+) error {
+	if err := f.validateTargetResource(ctx, serviceConfig, targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	values, err := appSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	return f.cli.UpdateAppServiceAppSettings(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		values,
+		removed,
+	)
+}
+
 // Gets the exposed endpoints for the Function App
 func (f *functionAppTarget) Endpoints(
 	ctx context.Context,
@@ -12,6 +12,7 @@ import (
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 )
 
@@ -77,6 +78,16 @@ type AzCli interface {
 		appName string,
 		deployZipFile io.Reader,
 	) (*string, error)
+	// UpdateAppServiceAppSettings sets the given app settings of an App Service or Function app, and removes the app
+	// settings named in removed. Other app settings are kept.
+	UpdateAppServiceAppSettings(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroup string,
+		appName string,
+		settings map[string]string,
+		removed []string,
+	) error
 	DeployFunctionAppUsingZipFile(
 		ctx context.Context,
 		subscriptionID string,
@@ -92,6 +103,9 @@ type AzCli interface {
 	) (*AzCliFunctionAppProperties, error)
 
 	DeleteResourceGroup(ctx context.Context, subscriptionId string, resourceGroupName string) error
+	// DeleteResource deletes the resource with the given id, which may be at any scope, including resources deployed to a
+	// management group or a tenant. The latest stable API version of the type of the resource is used.
+	DeleteResource(ctx context.Context, subscriptionId string, resourceId string) error
 	CreateOrUpdateResourceGroup(
 		ctx context.Context,
 		subscriptionId string,
@@ -184,6 +198,8 @@ type Filter struct {
 type NewAzCliArgs struct {
 	EnableDebug     bool
 	EnableTelemetry bool
+	// The cloud the clients connect to. Defaults to the public Azure cloud.
+	Cloud *cloud.Cloud
 }
 
 func NewAzCli(
@@ -191,8 +207,14 @@ func NewAzCli(
 	httpClient httputil.HttpClient,
 	args NewAzCliArgs,
 ) AzCli {
+	azureCloud := args.Cloud
+	if azureCloud == nil {
+		azureCloud = cloud.AzurePublic()
+	}
+
 	return &azCli{
 		credentialProvider: credentialProvider,
+		cloud:              azureCloud,
 		enableDebug:        args.EnableDebug,
 		enableTelemetry:    args.EnableTelemetry,
 		httpClient:         httpClient,
@@ -208,6 +230,8 @@ type azCli struct {
 	// Allows us to mock the Http Requests from the go modules
 	httpClient httputil.HttpClient
 
+	cloud *cloud.Cloud
+
 	credentialProvider account.SubscriptionCredentialProvider
 }
 
@@ -224,6 +248,7 @@ func (cli *azCli) UserAgent() string {
 func (cli *azCli) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptionsBuilder {
 	return azsdk.NewClientOptionsBuilder().
 		WithTransport(cli.httpClient).
+		WithCloud(cli.cloud.Configuration).
 		WithPerCallPolicy(azsdk.NewUserAgentPolicy(cli.UserAgent())).
 		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
 }
@@ -231,9 +256,11 @@ func (cli *azCli) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptions
 func clientOptionsBuilder(
 	ctx context.Context,
 	httpClient httputil.HttpClient,
-	userAgent string) *azsdk.ClientOptionsBuilder {
+	userAgent string,
+	cloud *cloud.Cloud) *azsdk.ClientOptionsBuilder {
 	return azsdk.NewClientOptionsBuilder().
 		WithTransport(httpClient).
+		WithCloud(cloud.Configuration).
 		WithPerCallPolicy(azsdk.NewUserAgentPolicy(userAgent)).
 		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/docker"
 )
@@ -43,6 +44,9 @@ type ContainerRegistryService interface {
 	Credentials(ctx context.Context, subscriptionId string, loginServer string) (*DockerCredentials, error)
 	// Gets a list of container registries for the specified subscription
 	GetContainerRegistries(ctx context.Context, subscriptionId string) ([]*armcontainerregistry.Registry, error)
+	// Exchanges a Resource Manager access token for a refresh token of the specified container registry, which can be used
+	// as the password of the '00000000-0000-0000-0000-000000000000' user.
+	ExchangeToken(ctx context.Context, loginServer string, accessToken string) (string, error)
 }
 
 type containerRegistryService struct {
@@ -50,6 +54,7 @@ type containerRegistryService struct {
 	docker             docker.Docker
 	httpClient         httputil.HttpClient
 	userAgent          string
+	cloud              *cloud.Cloud
 }
 
 // Creates a new instance of the ContainerRegistryService
@@ -57,9 +62,11 @@ func NewContainerRegistryService(
 	credentialProvider account.SubscriptionCredentialProvider,
 	httpClient httputil.HttpClient,
 	docker docker.Docker,
+	cloud *cloud.Cloud,
 ) ContainerRegistryService {
 	return &containerRegistryService{
 		credentialProvider: credentialProvider,
+		cloud:              cloud,
 		docker:             docker,
 		httpClient:         httpClient,
 		userAgent:          internal.UserAgent(),
@@ -232,7 +239,7 @@ func (crs *containerRegistryService) createRegistriesClient(
 		return nil, err
 	}
 
-	options := clientOptionsBuilder(ctx, crs.httpClient, crs.userAgent).BuildArmClientOptions()
+	options := clientOptionsBuilder(ctx, crs.httpClient, crs.userAgent, crs.cloud).BuildArmClientOptions()
 	client, err := armcontainerregistry.NewRegistriesClient(subscriptionId, credential, options)
 	if err != nil {
 		return nil, fmt.Errorf("creating registries client: %w", err)
@@ -252,43 +259,57 @@ func (crs *containerRegistryService) getAcrToken(
 		return nil, fmt.Errorf("getting credentials for subscription '%s': %w", subscriptionId, err)
 	}
 
-	token, err := creds.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{azure.ManagementScope}})
+	token, err := creds.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{crs.cloud.ResourceManagerScope()}})
 	if err != nil {
 		return nil, fmt.Errorf("getting token for subscription '%s': %w", subscriptionId, err)
 	}
 
+	refreshToken, err := crs.ExchangeToken(ctx, loginServer, token.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &acrToken{RefreshToken: refreshToken}, nil
+}
+
+// Exchanges a Resource Manager access token for an ACR refresh token
+func (crs *containerRegistryService) ExchangeToken(
+	ctx context.Context,
+	loginServer string,
+	accessToken string,
+) (string, error) {
 	// Implementation based on docs @ https://azure.github.io/acr/AAD-OAuth.html
-	options := clientOptionsBuilder(ctx, crs.httpClient, crs.userAgent).BuildCoreClientOptions()
+	options := clientOptionsBuilder(ctx, crs.httpClient, crs.userAgent, crs.cloud).BuildCoreClientOptions()
 	pipeline := azruntime.NewPipeline("azd-acr", internal.Version, azruntime.PipelineOptions{}, options)
 
 	formData := url.Values{}
 	formData.Set("grant_type", "access_token")
 	formData.Set("service", loginServer)
-	formData.Set("access_token", token.Token)
+	formData.Set("access_token", accessToken)
 
 	tokenUrl := fmt.Sprintf("https://%s/oauth2/exchange", loginServer)
 	req, err := azruntime.NewRequest(ctx, http.MethodPost, tokenUrl)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return "", fmt.Errorf("creating request: %w", err)
 	}
 
 	setHttpRequestBody(req, formData)
 
 	response, err := pipeline.Do(req)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	if !azruntime.HasStatusCode(response, http.StatusOK) {
-		return nil, azruntime.NewResponseError(response)
+		return "", azruntime.NewResponseError(response)
 	}
 
 	acrTokenBody, err := httputil.ReadRawResponse[acrToken](response)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return acrTokenBody, nil
+	return acrTokenBody.RefreshToken, nil
 }
 
 func setHttpRequestBody(req *policy.Request, formData url.Values) {
@@ -67,7 +67,11 @@ func (cli *azCli) GetKeyVaultSecret(
 ) (*AzCliKeyVaultSecret, error) {
 	vaultUrl := vaultName
 	if !strings.Contains(strings.ToLower(vaultName), "https://") {
-		vaultUrl = fmt.Sprintf("https://%s.vault.azure.net", vaultName)
+		if cli.cloud.KeyVaultEndpointSuffix == "" {
+			return nil, fmt.Errorf("the cloud %s doesn't define the DNS suffix of Key Vault", cli.cloud.Name)
+		}
+
+		vaultUrl = fmt.Sprintf("https://%s.%s", vaultName, cli.cloud.KeyVaultEndpointSuffix)
 	}
 
 	client, err := cli.createSecretsDataClient(ctx, subscriptionId, vaultUrl)
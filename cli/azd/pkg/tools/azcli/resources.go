@@ -3,7 +3,9 @@ package azcli
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 )
 
@@ -156,6 +158,84 @@ func (cli *azCli) DeleteResourceGroup(ctx context.Context, subscriptionId string
 	return nil
 }
 
+func (cli *azCli) DeleteResource(ctx context.Context, subscriptionId string, resourceId string) error {
+	resId, err := arm.ParseResourceID(resourceId)
+	if err != nil {
+		return fmt.Errorf("parsing resource id: %w", err)
+	}
+
+	apiVersion, err := cli.resourceApiVersion(ctx, subscriptionId, resId.ResourceType)
+	if err != nil {
+		return err
+	}
+
+	client, err := cli.createResourcesClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDeleteByID(ctx, resourceId, apiVersion, nil)
+	if err != nil {
+		return fmt.Errorf("beginning resource deletion: %w", err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("deleting resource: %w", err)
+	}
+
+	return nil
+}
+
+// resourceApiVersion returns the latest stable API version of a resource type, or its latest preview API version when it
+// has no stable one.
+func (cli *azCli) resourceApiVersion(
+	ctx context.Context, subscriptionId string, resourceType arm.ResourceType) (string, error) {
+	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return "", err
+	}
+
+	options := cli.clientOptionsBuilder(ctx).BuildArmClientOptions()
+	client, err := armresources.NewProvidersClient(subscriptionId, credential, options)
+	if err != nil {
+		return "", fmt.Errorf("creating Providers client: %w", err)
+	}
+
+	provider, err := client.GetAtTenantScope(ctx, resourceType.Namespace, nil)
+	if err != nil {
+		return "", fmt.Errorf("getting resource provider %s: %w", resourceType.Namespace, err)
+	}
+
+	typeName := strings.Join(resourceType.Types, "/")
+	for _, providerType := range provider.ResourceTypes {
+		if providerType.ResourceType == nil || !strings.EqualFold(*providerType.ResourceType, typeName) {
+			continue
+		}
+
+		// API versions are listed from the newest to the oldest.
+		latest := ""
+		for _, version := range providerType.APIVersions {
+			if version == nil {
+				continue
+			}
+
+			if !strings.Contains(*version, "-preview") {
+				return *version, nil
+			}
+
+			if latest == "" {
+				latest = *version
+			}
+		}
+
+		if latest != "" {
+			return latest, nil
+		}
+	}
+
+	return "", fmt.Errorf("no API version found for resource type %s", resourceType.String())
+}
+
 func (cli *azCli) createResourcesClient(ctx context.Context, subscriptionId string) (*armresources.Client, error) {
 	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
 	if err != nil {
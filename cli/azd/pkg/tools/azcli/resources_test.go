@@ -0,0 +1,50 @@
+package azcli
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeleteResource(t *testing.T) {
+	policyAssignment := "/providers/Microsoft.Management/managementGroups/mg/providers/" +
+		"Microsoft.Authorization/policyAssignments/audit"
+
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzCliFromMockContext(mockContext)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == "/providers/Microsoft.Authorization"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.Provider{
+			Namespace: to.Ptr("Microsoft.Authorization"),
+			ResourceTypes: []*armresources.ProviderResourceType{
+				{
+					ResourceType: to.Ptr("roleAssignments"),
+					APIVersions:  []*string{to.Ptr("2022-04-01")},
+				},
+				{
+					ResourceType: to.Ptr("policyAssignments"),
+					APIVersions:  []*string{to.Ptr("2024-05-01-preview"), to.Ptr("2024-04-01"), to.Ptr("2023-04-01")},
+				},
+			},
+		})
+	})
+
+	var deletedApiVersion string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodDelete && request.URL.Path == policyAssignment
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		deletedApiVersion = request.URL.Query().Get("api-version")
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	err := azCli.DeleteResource(*mockContext.Context, "SUBSCRIPTION_ID", policyAssignment)
+	require.NoError(t, err)
+	require.Equal(t, "2024-04-01", deletedApiVersion)
+}
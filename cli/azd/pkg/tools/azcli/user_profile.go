@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 )
@@ -18,12 +18,15 @@ type UserProfileService struct {
 	credentialProvider auth.MultiTenantCredentialProvider
 	userAgent          string
 	httpClient         httputil.HttpClient
+	cloud              *cloud.Cloud
 }
 
 func NewUserProfileService(
 	credentialProvider auth.MultiTenantCredentialProvider,
-	httpClient httputil.HttpClient) *UserProfileService {
+	httpClient httputil.HttpClient,
+	cloud *cloud.Cloud) *UserProfileService {
 	return &UserProfileService{
+		cloud:              cloud,
 		userAgent:          azdinternal.UserAgent(),
 		httpClient:         httpClient,
 		credentialProvider: credentialProvider,
@@ -31,7 +34,7 @@ func NewUserProfileService(
 }
 
 func (u *UserProfileService) createGraphClient(ctx context.Context, tenantId string) (*graphsdk.GraphClient, error) {
-	options := clientOptionsBuilder(ctx, u.httpClient, u.userAgent).
+	options := clientOptionsBuilder(ctx, u.httpClient, u.userAgent, u.cloud).
 		WithPerCallPolicy(azsdk.NewMsGraphCorrelationPolicy(ctx)).
 		BuildCoreClientOptions()
 	cred, err := u.credentialProvider.GetTokenCredential(ctx, tenantId)
@@ -68,9 +71,7 @@ func (u *UserProfileService) GetAccessToken(ctx context.Context, tenantId string
 	}
 
 	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{
-		Scopes: []string{
-			fmt.Sprintf("%s/.default", cloud.AzurePublic.Services[cloud.ResourceManager].Audience),
-		},
+		Scopes: []string{u.cloud.ResourceManagerScope()},
 	})
 
 	if err != nil {
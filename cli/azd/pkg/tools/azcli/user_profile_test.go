@@ -9,6 +9,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/stretchr/testify/require"
@@ -31,7 +32,7 @@ func Test_GetUserAccessToken(t *testing.T) {
 		TokenMap: map[string]mocks.MockCredentials{
 			"": mockCredential,
 		},
-	}, mockContext.HttpClient)
+	}, mockContext.HttpClient, cloud.AzurePublic())
 
 	actual, err := userProfile.GetAccessToken(*mockContext.Context, "")
 	require.NoError(t, err)
@@ -52,7 +53,11 @@ func Test_GetSignedInUserId(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
 		registerGetMeGraphMock(mockContext, http.StatusOK, &mockUserProfile)
 
-		userProfile := NewUserProfileService(&mocks.MockMultiTenantCredentialProvider{}, mockContext.HttpClient)
+		userProfile := NewUserProfileService(
+			&mocks.MockMultiTenantCredentialProvider{},
+			mockContext.HttpClient,
+			cloud.AzurePublic(),
+		)
 
 		userId, err := userProfile.GetSignedInUserId(*mockContext.Context, "")
 		require.NoError(t, err)
@@ -63,7 +68,11 @@ func Test_GetSignedInUserId(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
 		registerGetMeGraphMock(mockContext, http.StatusBadRequest, nil)
 
-		userProfile := NewUserProfileService(&mocks.MockMultiTenantCredentialProvider{}, mockContext.HttpClient)
+		userProfile := NewUserProfileService(
+			&mocks.MockMultiTenantCredentialProvider{},
+			mockContext.HttpClient,
+			cloud.AzurePublic(),
+		)
 
 		userId, err := userProfile.GetSignedInUserId(*mockContext.Context, "")
 		require.Error(t, err)
@@ -55,6 +55,59 @@ func (cli *azCli) DeployAppServiceZip(
 	return convert.RefOf(response.StatusText), nil
 }
 
+func (cli *azCli) UpdateAppServiceAppSettings(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	settings map[string]string,
+	removed []string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	appSettings, err := client.ListApplicationSettings(ctx, resourceGroup, appName, nil)
+	if err != nil {
+		return fmt.Errorf("listing app settings: %w", err)
+	}
+
+	properties := appSettings.Properties
+	if properties == nil {
+		properties = map[string]*string{}
+	}
+
+	changed := false
+	for name, value := range settings {
+		if current, has := properties[name]; !has || current == nil || *current != value {
+			properties[name] = convert.RefOf(value)
+			changed = true
+		}
+	}
+
+	for _, name := range removed {
+		if _, has := properties[name]; has {
+			delete(properties, name)
+			changed = true
+		}
+	}
+
+	// Updating the app settings restarts the app, which is avoided when they are unchanged.
+	if !changed {
+		return nil
+	}
+
+	_, err = client.UpdateApplicationSettings(ctx, resourceGroup, appName, armappservice.StringDictionary{
+		Properties: properties,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("updating app settings: %w", err)
+	}
+
+	return nil
+}
+
 func (cli *azCli) createWebAppsClient(ctx context.Context, subscriptionId string) (*armappservice.WebAppsClient, error) {
 	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
 	if err != nil {
@@ -77,7 +130,7 @@ func (cli *azCli) createZipDeployClient(ctx context.Context, subscriptionId stri
 	}
 
 	options := cli.clientOptionsBuilder(ctx).BuildArmClientOptions()
-	client, err := azsdk.NewZipDeployClient(subscriptionId, credential, options)
+	client, err := azsdk.NewZipDeployClient(subscriptionId, cli.cloud.AppServiceScmEndpointSuffix, credential, options)
 	if err != nil {
 		return nil, fmt.Errorf("creating WebApps client: %w", err)
 	}
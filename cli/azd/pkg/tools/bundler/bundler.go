@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bundler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+type BundlerCli interface {
+	tools.ExternalTool
+	// Install installs the gems of the Gemfile of the project.
+	Install(ctx context.Context, projectPath string) error
+}
+
+type bundlerCli struct {
+	commandRunner exec.CommandRunner
+}
+
+func NewBundlerCli(commandRunner exec.CommandRunner) BundlerCli {
+	return &bundlerCli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *bundlerCli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("bundle")
+	if err != nil {
+		return err
+	}
+
+	if ver, err := tools.ExecuteCommand(ctx, cli.commandRunner, "bundle", "--version"); err == nil {
+		log.Printf("bundler version: %s", ver)
+	}
+
+	return nil
+}
+
+func (cli *bundlerCli) InstallUrl() string {
+	return "https://bundler.io"
+}
+
+func (cli *bundlerCli) Name() string {
+	return "Bundler"
+}
+
+func (cli *bundlerCli) Install(ctx context.Context, projectPath string) error {
+	runArgs := exec.
+		NewRunArgs("bundle", "install").
+		WithCwd(projectPath)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to install gems for project %s: %w", projectPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cargo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+type CargoCli interface {
+	tools.ExternalTool
+	// Fetch downloads the crates the project depends on.
+	Fetch(ctx context.Context, projectPath string) error
+	// Build compiles the project in release mode and returns the paths of the executables built. env is added to the
+	// environment of the build, to set CARGO_BUILD_TARGET for example.
+	Build(ctx context.Context, projectPath string, env []string) ([]string, error)
+}
+
+type cargoCli struct {
+	commandRunner exec.CommandRunner
+}
+
+func NewCargoCli(commandRunner exec.CommandRunner) CargoCli {
+	return &cargoCli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *cargoCli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("cargo")
+	if err != nil {
+		return err
+	}
+
+	if ver, err := tools.ExecuteCommand(ctx, cli.commandRunner, "cargo", "--version"); err == nil {
+		log.Printf("cargo version: %s", ver)
+	}
+
+	return nil
+}
+
+func (cli *cargoCli) InstallUrl() string {
+	return "https://www.rust-lang.org/tools/install"
+}
+
+func (cli *cargoCli) Name() string {
+	return "Cargo"
+}
+
+func (cli *cargoCli) Fetch(ctx context.Context, projectPath string) error {
+	runArgs := exec.
+		NewRunArgs("cargo", "fetch").
+		WithCwd(projectPath)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch crates for project %s: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// cargoMessage is a message cargo writes to stdout for --message-format=json.
+type cargoMessage struct {
+	Reason string `json:"reason"`
+	Target struct {
+		Kind []string `json:"kind"`
+	} `json:"target"`
+	Executable *string `json:"executable"`
+}
+
+func (cli *cargoCli) Build(ctx context.Context, projectPath string, env []string) ([]string, error) {
+	runArgs := exec.
+		NewRunArgs("cargo", "build", "--release", "--message-format=json").
+		WithCwd(projectPath).
+		WithEnv(env)
+
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build project %s: %w", projectPath, err)
+	}
+
+	var executables []string
+	scanner := bufio.NewScanner(strings.NewReader(res.Stdout))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var message cargoMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+
+		if message.Reason == "compiler-artifact" && message.Executable != nil && slices.Contains(message.Target.Kind, "bin") {
+			executables = append(executables, *message.Executable)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading build output of project %s: %w", projectPath, err)
+	}
+
+	return executables, nil
+}
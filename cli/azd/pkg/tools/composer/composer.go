@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package composer
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+type ComposerCli interface {
+	tools.ExternalTool
+	// Install installs the packages of the composer.json of the project into its vendor directory.
+	Install(ctx context.Context, projectPath string) error
+}
+
+type composerCli struct {
+	commandRunner exec.CommandRunner
+}
+
+func NewComposerCli(commandRunner exec.CommandRunner) ComposerCli {
+	return &composerCli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *composerCli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("composer")
+	if err != nil {
+		return err
+	}
+
+	if ver, err := tools.ExecuteCommand(ctx, cli.commandRunner, "composer", "--version"); err == nil {
+		log.Printf("composer version: %s", ver)
+	}
+
+	return nil
+}
+
+func (cli *composerCli) InstallUrl() string {
+	return "https://getcomposer.org/download/"
+}
+
+func (cli *composerCli) Name() string {
+	return "Composer"
+}
+
+func (cli *composerCli) Install(ctx context.Context, projectPath string) error {
+	runArgs := exec.
+		NewRunArgs("composer", "install", "--no-interaction").
+		WithCwd(projectPath)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to install packages for project %s: %w", projectPath, err)
+	}
+
+	return nil
+}
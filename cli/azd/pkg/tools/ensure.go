@@ -9,17 +9,17 @@ import (
 	osexec "os/exec"
 )
 
-// missingToolErrors wraps a set of errors discovered when
+// MissingToolErrors wraps a set of errors discovered when
 // probing for tools and implements the Error interface to pretty
 // print the underlying errors. We use this instead of the existing
 // `multierr` package we use elsewhere, because we want to control
 // the error string (the default one produced by multierr is not
 // as nice as what we do here).
-type missingToolErrors struct {
+type MissingToolErrors struct {
 	errs []error
 }
 
-func (m *missingToolErrors) Error() string {
+func (m *MissingToolErrors) Error() string {
 	buf := bytes.Buffer{}
 
 	fmt.Fprintf(&buf, "required external tools are missing:")
@@ -30,6 +30,11 @@ func (m *missingToolErrors) Error() string {
 	return buf.String()
 }
 
+// Unwrap returns the errors of the missing tools.
+func (m *MissingToolErrors) Unwrap() []error {
+	return m.errs
+}
+
 // EnsureInstalled checks that all tools are installed, returning an
 // error if one or more tools are not.
 func EnsureInstalled(ctx context.Context, tools ...ExternalTool) error {
@@ -49,6 +54,10 @@ func EnsureInstalled(ctx context.Context, tools ...ExternalTool) error {
 		}
 
 		err := tool.CheckInstalled(ctx)
+		if err != nil {
+			err = acquireMissingTool(ctx, tool, err)
+		}
+
 		var errSem *ErrSemver
 		if errors.As(err, &errSem) {
 			errorMsg := err.Error()
@@ -73,7 +82,7 @@ func EnsureInstalled(ctx context.Context, tools ...ExternalTool) error {
 	}
 
 	if len(allErrors) > 0 {
-		return &missingToolErrors{errs: allErrors}
+		return &MissingToolErrors{errs: allErrors}
 	}
 
 	return nil
@@ -97,12 +106,54 @@ func Unique(tools []ExternalTool) []ExternalTool {
 	return uniqueTools
 }
 
+// acquireMissingTool attempts to install a managed copy of a tool which failed its installation check because it is
+// missing or out of date, using the ToolAcquirer from the context. The result of checking the tool again is returned when
+// the tool is acquired, otherwise the original error is returned.
+func acquireMissingTool(ctx context.Context, tool ExternalTool, checkErr error) error {
+	acquirer, ok := ctx.Value(toolAcquirerKey).(ToolAcquirer)
+	if !ok || acquirer == nil {
+		return checkErr
+	}
+
+	var errSem *ErrSemver
+	if !errors.As(checkErr, &errSem) && !errors.Is(checkErr, osexec.ErrNotFound) {
+		return checkErr
+	}
+
+	if err := acquirer.Acquire(ctx, tool); err != nil {
+		if !errors.Is(err, ErrToolNotManaged) {
+			log.Printf("failed acquiring '%s': %v", tool.Name(), err)
+		}
+
+		return checkErr
+	}
+
+	return tool.CheckInstalled(ctx)
+}
+
+// ErrToolNotManaged is returned by a ToolAcquirer when it does not know how to install a tool.
+var ErrToolNotManaged = errors.New("tool is not managed by azd")
+
+// ToolAcquirer installs managed copies of external tools on behalf of the user.
+type ToolAcquirer interface {
+	// Acquire installs a managed copy of the tool and adds it to the exec.SearchPath of the context.
+	// ErrToolNotManaged is returned when the tool cannot be installed by the acquirer.
+	Acquire(ctx context.Context, tool ExternalTool) error
+}
+
 type confirmCacheKey string
 
 const (
 	installedCheckCacheKey confirmCacheKey = "checkCache"
+	toolAcquirerKey        confirmCacheKey = "toolAcquirer"
 )
 
+// WithToolAcquirer returns a context which causes EnsureInstalled to use acquirer to install tools that are missing or
+// older than the minimum version azd supports.
+func WithToolAcquirer(ctx context.Context, acquirer ToolAcquirer) context.Context {
+	return context.WithValue(ctx, toolAcquirerKey, acquirer)
+}
+
 func WithInstalledCheckCache(ctx context.Context) context.Context {
 	return context.WithValue(ctx, installedCheckCacheKey, make(map[string]struct{}))
 }
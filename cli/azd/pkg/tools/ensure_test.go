@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	osexec "os/exec"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -33,3 +34,64 @@ func (t *TestTool) InstallUrl() string {
 func (t *TestTool) Name() string {
 	return "Test Tool"
 }
+
+func Test_EnsureInstalledAcquiresMissingTool(t *testing.T) {
+	tool := &missingTool{}
+	acquirer := &testAcquirer{tool: tool}
+	ctx := WithToolAcquirer(context.Background(), acquirer)
+
+	err := EnsureInstalled(ctx, tool)
+	require.NoError(t, err)
+	require.Equal(t, 1, acquirer.acquired)
+	require.Equal(t, 2, tool.installChecks)
+}
+
+func Test_EnsureInstalledReportsUnmanagedTool(t *testing.T) {
+	tool := &missingTool{}
+	acquirer := &testAcquirer{}
+	ctx := WithToolAcquirer(context.Background(), acquirer)
+
+	err := EnsureInstalled(ctx, tool)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Missing Tool is not installed")
+	require.Equal(t, 0, acquirer.acquired)
+}
+
+// missingTool reports it is not installed until it has been acquired.
+type missingTool struct {
+	installChecks int
+	acquired      bool
+}
+
+func (t *missingTool) CheckInstalled(ctx context.Context) error {
+	t.installChecks++
+	if !t.acquired {
+		return osexec.ErrNotFound
+	}
+
+	return nil
+}
+
+func (t *missingTool) InstallUrl() string {
+	return "http://www.microsoft.com"
+}
+
+func (t *missingTool) Name() string {
+	return "Missing Tool"
+}
+
+// testAcquirer acquires tool, and reports every other tool as not managed.
+type testAcquirer struct {
+	tool     *missingTool
+	acquired int
+}
+
+func (a *testAcquirer) Acquire(ctx context.Context, tool ExternalTool) error {
+	if a.tool == nil || tool != a.tool {
+		return ErrToolNotManaged
+	}
+
+	a.acquired++
+	a.tool.acquired = true
+	return nil
+}
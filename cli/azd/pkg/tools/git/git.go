@@ -22,6 +22,8 @@ type GitCli interface {
 	tools.ExternalTool
 	GetRemoteUrl(ctx context.Context, string, remoteName string) (string, error)
 	ShallowClone(ctx context.Context, repositoryPath string, branch string, target string) error
+	// ShallowCloneWithToken clones a private repository, authenticating with a personal access token.
+	ShallowCloneWithToken(ctx context.Context, repositoryPath string, branch string, target string, token string) error
 	InitRepo(ctx context.Context, repositoryPath string) error
 	AddRemote(ctx context.Context, repositoryPath string, remoteName string, remoteUrl string) error
 	UpdateRemote(ctx context.Context, repositoryPath string, remoteName string, remoteUrl string) error
@@ -90,16 +92,10 @@ func (cli *gitCli) Name() string {
 }
 
 func (cli *gitCli) ShallowClone(ctx context.Context, repositoryPath string, branch string, target string) error {
-	args := []string{"clone", "--depth", "1", repositoryPath}
-	if branch != "" {
-		args = append(args, "--branch", branch)
-	}
-	args = append(args, target)
-
 	// Do not call `newRunArgs()` here because we don't want to apply the codespaces special patch that removes
 	// default authentication. `git clone` should work for private repos within a codespace with default auth.
 	// See: https://github.com/Azure/azure-dev/issues/2582
-	runArgs := exec.NewRunArgs("git", args...)
+	runArgs := exec.NewRunArgs("git", shallowCloneArgs(repositoryPath, branch, target)...)
 	_, err := cli.commandRunner.Run(ctx, runArgs)
 	if err != nil {
 		return fmt.Errorf("failed to clone repository %s: %w", repositoryPath, err)
@@ -108,6 +104,46 @@ func (cli *gitCli) ShallowClone(ctx context.Context, repositoryPath string, bran
 	return nil
 }
 
+// cloneTokenEnvVarName is the environment variable the credential helper of ShallowCloneWithToken reads the token from.
+const cloneTokenEnvVarName = "AZD_GIT_CLONE_TOKEN"
+
+// cloneTokenCredentialHelper answers the credential requests of git with the token of cloneTokenEnvVarName, so the
+// token is never part of the arguments of the command, which are logged and visible in the process list.
+const cloneTokenCredentialHelper = `!f() { test "$1" = get && echo username=azd && echo "password=$` +
+	cloneTokenEnvVarName + `"; }; f`
+
+func (cli *gitCli) ShallowCloneWithToken(
+	ctx context.Context,
+	repositoryPath string,
+	branch string,
+	target string,
+	token string,
+) error {
+	// The credential helpers of the user are reset, so they neither answer for the token nor store it.
+	args := append([]string{
+		"-c", "credential.helper=",
+		"-c", "credential.helper=" + cloneTokenCredentialHelper,
+	}, shallowCloneArgs(repositoryPath, branch, target)...)
+
+	runArgs := exec.NewRunArgsWithSensitiveData("git", args, []string{token}).
+		WithEnv([]string{cloneTokenEnvVarName + "=" + token, "GIT_TERMINAL_PROMPT=0"})
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to clone repository %s: %w", repositoryPath, err)
+	}
+
+	return nil
+}
+
+func shallowCloneArgs(repositoryPath string, branch string, target string) []string {
+	args := []string{"clone", "--depth", "1", repositoryPath}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+
+	return append(args, target)
+}
+
 var noSuchRemoteRegex = regexp.MustCompile("(fatal|error): No such remote")
 var notGitRepositoryRegex = regexp.MustCompile("(fatal|error): not a git repository")
 var ErrNoSuchRemote = errors.New("no such remote")
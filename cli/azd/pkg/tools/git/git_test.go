@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShallowCloneWithToken(t *testing.T) {
+	if _, err := osexec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	source := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(source, "main.bicep"), []byte(""), osutil.PermissionFile))
+	for _, args := range [][]string{
+		{"init"},
+		{"checkout", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=azd", "-c", "user.email=azd@example.com", "commit", "-m", "initial"},
+	} {
+		cmd := osexec.Command("git", args...)
+		cmd.Dir = source
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	const token = "catalog-pat-1234"
+	cli := NewGitCli(exec.NewCommandRunner(&exec.RunnerOptions{DebugLogging: true}))
+	target := filepath.Join(t.TempDir(), "clone")
+	err := cli.ShallowCloneWithToken(context.Background(), "file://"+filepath.ToSlash(source), "main", target, token)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(target, "main.bicep"))
+
+	require.Contains(t, logs.String(), "Run exec: 'git")
+	require.Contains(t, logs.String(), cloneTokenEnvVarName)
+	require.NotContains(t, logs.String(), token)
+}
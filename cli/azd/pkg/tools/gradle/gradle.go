@@ -0,0 +1,196 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gradle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	osexec "os/exec"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+type GradleCli interface {
+	tools.ExternalTool
+	SetPath(projectPath string, rootProjectPath string)
+
+	// RunTasks runs the given tasks of the build in projectPath. Tasks of the projects of a multi-project build are
+	// qualified with the path of the project, like ':api:assemble'.
+	RunTasks(ctx context.Context, projectPath string, tasks ...string) error
+}
+
+type gradleCli struct {
+	commandRunner   exec.CommandRunner
+	projectPath     string
+	rootProjectPath string
+
+	// Lazily initialized. Access through gradleCmd.
+	gradleCmdStr  string
+	gradleCmdOnce sync.Once
+	gradleCmdErr  error
+}
+
+func NewGradleCli(commandRunner exec.CommandRunner) GradleCli {
+	return &gradleCli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *gradleCli) Name() string {
+	return "Gradle"
+}
+
+func (cli *gradleCli) InstallUrl() string {
+	return "https://gradle.org/install"
+}
+
+func (cli *gradleCli) CheckInstalled(ctx context.Context) error {
+	_, err := cli.gradleCmd()
+	if err != nil {
+		return err
+	}
+
+	if ver, err := cli.extractVersion(ctx); err == nil {
+		log.Printf("gradle version: %s", ver)
+	}
+
+	return nil
+}
+
+func (cli *gradleCli) SetPath(projectPath string, rootProjectPath string) {
+	cli.projectPath = projectPath
+	cli.rootProjectPath = rootProjectPath
+}
+
+func (cli *gradleCli) gradleCmd() (string, error) {
+	cli.gradleCmdOnce.Do(func() {
+		gradleCmd, err := getGradlePath(cli.projectPath, cli.rootProjectPath)
+		if err != nil {
+			cli.gradleCmdErr = err
+		} else {
+			cli.gradleCmdStr = gradleCmd
+		}
+	})
+
+	if cli.gradleCmdErr != nil {
+		return "", cli.gradleCmdErr
+	}
+
+	return cli.gradleCmdStr, nil
+}
+
+// getGradlePath returns the Gradle wrapper of the project, found in the project directory up to the root project
+// directory, and otherwise gradle from PATH.
+func getGradlePath(projectPath string, rootProjectPath string) (string, error) {
+	gradlew, err := getGradleWrapperPath(projectPath, rootProjectPath)
+	if gradlew != "" {
+		return gradlew, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed finding gradlew in repository path: %w", err)
+	}
+
+	gradle, err := osexec.LookPath("gradle")
+	if err == nil {
+		return gradle, nil
+	}
+
+	if !errors.Is(err, osexec.ErrNotFound) {
+		return "", fmt.Errorf("failed looking up gradle in PATH: %w", err)
+	}
+
+	return "", errors.New(
+		"gradle could not be found. Install either Gradle or the Gradle Wrapper by " +
+			"visiting https://gradle.org/install or https://docs.gradle.org/current/userguide/gradle_wrapper.html",
+	)
+}
+
+// getGradleWrapperPath finds the path to gradlew in the project directory, up to the root project directory.
+//
+// If gradlew is not found, an empty string is returned with no error.
+func getGradleWrapperPath(projectPath string, rootProjectPath string) (string, error) {
+	searchDir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := filepath.Abs(rootProjectPath)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		// gradlew.bat is found on Windows, through PATHEXT
+		gradlew, err := osexec.LookPath(filepath.Join(searchDir, "gradlew"))
+		if err == nil {
+			log.Printf("found gradlew as: %s\n", gradlew)
+			return gradlew, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		searchDir = filepath.Dir(searchDir)
+
+		// Past root, terminate search and return not found
+		if len(searchDir) < len(root) {
+			return "", nil
+		}
+	}
+}
+
+// cGradleVersionRegexp captures the version number of gradle from the output of "gradle --version", which looks like:
+//
+// ------------------------------------------------------------
+// Gradle 8.5
+// ------------------------------------------------------------
+var cGradleVersionRegexp = regexp.MustCompile(`(?m)^Gradle (\S+)`)
+
+func (cli *gradleCli) extractVersion(ctx context.Context) (string, error) {
+	gradleCmd, err := cli.gradleCmd()
+	if err != nil {
+		return "", err
+	}
+
+	runArgs := exec.NewRunArgs(gradleCmd, "--version")
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s --version: %w", gradleCmd, err)
+	}
+
+	parts := cGradleVersionRegexp.FindStringSubmatch(res.Stdout)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("could not parse %s --version output, did not match expected format", gradleCmd)
+	}
+
+	return parts[1], nil
+}
+
+func (cli *gradleCli) RunTasks(ctx context.Context, projectPath string, tasks ...string) error {
+	gradleCmd, err := cli.gradleCmd()
+	if err != nil {
+		return err
+	}
+
+	// The console of Gradle is meant for terminals, and its progress output clutters the logs.
+	args := append([]string{"--console=plain"}, tasks...)
+	runArgs := exec.NewRunArgs(gradleCmd, args...).WithCwd(projectPath)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("gradle %s on project '%s' failed: %w", strings.Join(tasks, " "), projectPath, err)
+	}
+
+	return nil
+}
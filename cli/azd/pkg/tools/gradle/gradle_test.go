@@ -0,0 +1,120 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gradle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getGradlePath(t *testing.T) {
+	rootPath := t.TempDir()
+	projectPath := filepath.Join(rootPath, "src", "api")
+	pathDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(projectPath, 0755))
+	ostest.Unsetenv(t, "PATH")
+
+	tests := []struct {
+		name       string
+		gradlewDir string
+		gradleDir  string
+		want       string
+		wantErr    bool
+	}{
+		{name: "GradlewProjectPath", gradlewDir: projectPath, want: filepath.Join(projectPath, gradlewWithExt())},
+		{name: "GradlewRootPath", gradlewDir: rootPath, want: filepath.Join(rootPath, gradlewWithExt())},
+		{
+			name:       "GradlewFirst",
+			gradlewDir: rootPath,
+			gradleDir:  pathDir,
+			want:       filepath.Join(rootPath, gradlewWithExt()),
+		},
+		{name: "Gradle", gradleDir: pathDir, want: filepath.Join(pathDir, gradleWithExt())},
+		{name: "NotFound", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{gradlewWithExt(), gradleWithExt()} {
+				require.NoError(t, os.RemoveAll(filepath.Join(projectPath, name)))
+				require.NoError(t, os.RemoveAll(filepath.Join(rootPath, name)))
+				require.NoError(t, os.RemoveAll(filepath.Join(pathDir, name)))
+			}
+
+			if tt.gradlewDir != "" {
+				placeExecutable(t, filepath.Join(tt.gradlewDir, gradlewWithExt()))
+			}
+
+			if tt.gradleDir != "" {
+				placeExecutable(t, filepath.Join(tt.gradleDir, gradleWithExt()))
+				t.Setenv("PATH", tt.gradleDir)
+			}
+
+			actual, err := getGradlePath(projectPath, rootPath)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func Test_extractVersion(t *testing.T) {
+	ostest.Chdir(t, t.TempDir())
+	placeExecutable(t, gradlewWithExt())
+
+	execMock := mockexec.NewMockCommandRunner().
+		When(func(a exec.RunArgs, command string) bool { return a.Args[0] == "--version" }).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			return exec.NewRunResult(0, heredoc.Doc(`
+
+			------------------------------------------------------------
+			Gradle 8.5
+			------------------------------------------------------------
+
+			Build time:   2023-11-29 14:08:57 UTC
+			Kotlin:       1.9.20
+			JVM:          17.0.9 (Microsoft 17.0.9+8-LTS)
+			`), ""), nil
+		})
+
+	gradle := NewGradleCli(execMock).(*gradleCli)
+	ver, err := gradle.extractVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "8.5", ver)
+}
+
+func placeExecutable(t *testing.T, path string) {
+	ostest.Create(t, path)
+	require.NoError(t, os.Chmod(path, 0755))
+}
+
+func gradleWithExt() string {
+	if runtime.GOOS == "windows" {
+		return "gradle.bat"
+	}
+
+	return "gradle"
+}
+
+func gradlewWithExt() string {
+	if runtime.GOOS == "windows" {
+		return "gradlew.bat"
+	}
+
+	return "gradlew"
+}
@@ -92,7 +92,7 @@ func NewKubectl(commandRunner exec.CommandRunner) KubectlCli {
 
 // Checks whether or not the K8s CLI is installed and available within the PATH
 func (cli *kubectlCli) CheckInstalled(ctx context.Context) error {
-	if err := tools.ToolInPath("kubectl"); err != nil {
+	if err := tools.ToolInSearchPath(ctx, "kubectl"); err != nil {
 		return err
 	}
 
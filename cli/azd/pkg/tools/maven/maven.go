@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
 
 	osexec "os/exec"
@@ -19,9 +20,11 @@ import (
 type MavenCli interface {
 	tools.ExternalTool
 	SetPath(projectPath string, rootProjectPath string)
-	ResolveDependencies(ctx context.Context, projectPath string) error
-	Compile(ctx context.Context, projectPath string) error
-	Package(ctx context.Context, projectPath string) error
+	// ResolveDependencies, Compile and Package run in projectPath. When modules are given, projectPath is the root of a
+	// multi-module project and only the modules, and the modules they depend on, are built.
+	ResolveDependencies(ctx context.Context, projectPath string, modules ...string) error
+	Compile(ctx context.Context, projectPath string, modules ...string) error
+	Package(ctx context.Context, projectPath string, modules ...string) error
 }
 
 type mavenCli struct {
@@ -173,13 +176,13 @@ func (cli *mavenCli) extractVersion(ctx context.Context) (string, error) {
 	return parts[1], nil
 }
 
-func (cli *mavenCli) Compile(ctx context.Context, projectPath string) error {
+func (cli *mavenCli) Compile(ctx context.Context, projectPath string, modules ...string) error {
 	mvnCmd, err := cli.mvnCmd()
 	if err != nil {
 		return err
 	}
 
-	runArgs := exec.NewRunArgs(mvnCmd, "compile").WithCwd(projectPath)
+	runArgs := exec.NewRunArgs(mvnCmd, withModules([]string{"compile"}, modules)...).WithCwd(projectPath)
 	_, err = cli.commandRunner.Run(ctx, runArgs)
 	if err != nil {
 		return fmt.Errorf("mvn compile on project '%s' failed: %w", projectPath, err)
@@ -188,14 +191,14 @@ func (cli *mavenCli) Compile(ctx context.Context, projectPath string) error {
 	return nil
 }
 
-func (cli *mavenCli) Package(ctx context.Context, projectPath string) error {
+func (cli *mavenCli) Package(ctx context.Context, projectPath string, modules ...string) error {
 	mvnCmd, err := cli.mvnCmd()
 	if err != nil {
 		return err
 	}
 
 	// Maven's package phase includes tests by default. Skip it explicitly.
-	runArgs := exec.NewRunArgs(mvnCmd, "package", "-DskipTests").WithCwd(projectPath)
+	runArgs := exec.NewRunArgs(mvnCmd, withModules([]string{"package", "-DskipTests"}, modules)...).WithCwd(projectPath)
 	_, err = cli.commandRunner.Run(ctx, runArgs)
 	if err != nil {
 		return fmt.Errorf("mvn package on project '%s' failed: %w", projectPath, err)
@@ -204,12 +207,12 @@ func (cli *mavenCli) Package(ctx context.Context, projectPath string) error {
 	return nil
 }
 
-func (cli *mavenCli) ResolveDependencies(ctx context.Context, projectPath string) error {
+func (cli *mavenCli) ResolveDependencies(ctx context.Context, projectPath string, modules ...string) error {
 	mvnCmd, err := cli.mvnCmd()
 	if err != nil {
 		return err
 	}
-	runArgs := exec.NewRunArgs(mvnCmd, "dependency:resolve").WithCwd(projectPath)
+	runArgs := exec.NewRunArgs(mvnCmd, withModules([]string{"dependency:resolve"}, modules)...).WithCwd(projectPath)
 	_, err = cli.commandRunner.Run(ctx, runArgs)
 	if err != nil {
 		return fmt.Errorf("mvn dependency:resolve on project '%s' failed: %w", projectPath, err)
@@ -218,6 +221,15 @@ func (cli *mavenCli) ResolveDependencies(ctx context.Context, projectPath string
 	return nil
 }
 
+// withModules adds the arguments which select the modules to build, and the modules they depend on (--also-make).
+func withModules(args []string, modules []string) []string {
+	if len(modules) == 0 {
+		return args
+	}
+
+	return append(args, "-pl", strings.Join(modules, ","), "-am")
+}
+
 func NewMavenCli(commandRunner exec.CommandRunner) MavenCli {
 	return &mavenCli{
 		commandRunner: commandRunner,
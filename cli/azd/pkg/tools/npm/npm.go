@@ -21,6 +21,20 @@ type NpmCli interface {
 	// Returns an error only if the script execution fails. If the script doesn't exist, no error is returned.
 	RunScript(ctx context.Context, projectPath string, scriptName string) error
 	Prune(ctx context.Context, projectPath string, production bool) error
+
+	// InstallWorkspace installs the packages of all the members of a workspace with its package manager: npm, pnpm or
+	// yarn.
+	InstallWorkspace(ctx context.Context, packageManager string, workspacePath string) error
+
+	// RunWorkspaceScript runs the given script of a member of a workspace from the root of the workspace, with its package
+	// manager: npm, pnpm or yarn. pnpm runs the script of the workspace packages the member depends on first.
+	RunWorkspaceScript(
+		ctx context.Context,
+		packageManager string,
+		workspacePath string,
+		packageName string,
+		scriptName string,
+	) error
 }
 
 type npmCli struct {
@@ -117,3 +131,48 @@ func (cli *npmCli) Prune(ctx context.Context, projectPath string, production boo
 
 	return nil
 }
+
+func (cli *npmCli) InstallWorkspace(ctx context.Context, packageManager string, workspacePath string) error {
+	runArgs := exec.
+		NewRunArgs(packageManager, "install").
+		WithCwd(workspacePath)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to install %s workspace %s: %w", packageManager, workspacePath, err)
+	}
+
+	return nil
+}
+
+func (cli *npmCli) RunWorkspaceScript(
+	ctx context.Context,
+	packageManager string,
+	workspacePath string,
+	packageName string,
+	scriptName string,
+) error {
+	var args []string
+	switch packageManager {
+	case "npm":
+		args = []string{"run", scriptName, "--workspace", packageName}
+	case "pnpm":
+		// The trailing '...' selects the workspace packages the member depends on too.
+		args = []string{"--filter", packageName + "...", "run", scriptName}
+	case "yarn":
+		args = []string{"workspace", packageName, "run", scriptName}
+	default:
+		return fmt.Errorf("unsupported package manager '%s'", packageManager)
+	}
+
+	runArgs := exec.
+		NewRunArgs(packageManager, args...).
+		WithCwd(workspacePath)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to run %s script %s of %s, %w", packageManager, scriptName, packageName, err)
+	}
+
+	return nil
+}
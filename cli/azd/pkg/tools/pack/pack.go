@@ -1,36 +1,24 @@
 package pack
 
 import (
-	"archive/tar"
-	"archive/zip"
-	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
-	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
-	"github.com/azure/azure-dev/cli/azd/internal/tracing"
-	"github.com/azure/azure-dev/cli/azd/internal/tracing/events"
-	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
-	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 	"github.com/blang/semver/v4"
 )
 
-// PackVersion is the minimum version of pack that we require (and the one we fetch when we fetch pack on behalf of a
-// user).
-var PackVersion semver.Version = semver.MustParse("0.30.0")
+// PackVersion is the version of pack that we fetch on behalf of a user, from the tool cache catalog.
+var PackVersion semver.Version = toolcache.MustLookup("pack").Version
 
 var statusCodeFailureRegexp = regexp.MustCompile(`failed with status code: (\d+)`)
 
@@ -59,58 +47,21 @@ type PackCli interface {
 		ctx context.Context,
 		cwd string,
 		builder string,
+		buildpacks []string,
 		imageName string,
 		environ []string,
 		progressWriter io.Writer,
 	) error
 }
 
-// NewPackCli creates a new PackCli. azd manages its own copy of the pack CLI, stored in `$AZD_CONFIG_DIR/bin`. If
-// pack is not present at this location, or if it is present but is older than the minimum supported version, it is
-// downloaded.
+// NewPackCli creates a new PackCli. azd manages its own copy of the pack CLI, stored in the azd tool cache. If the
+// version of pack azd uses is not present in the cache, it is downloaded.
 func NewPackCli(
 	ctx context.Context,
 	console input.Console,
 	commandRunner exec.CommandRunner,
+	toolCache *toolcache.Cache,
 ) (PackCli, error) {
-	return newPackCliImpl(
-		ctx,
-		console,
-		commandRunner,
-		http.DefaultClient,
-		extractCli)
-}
-
-func NewPackCliWithPath(
-	commandRunner exec.CommandRunner,
-	cliPath string,
-) PackCli {
-	return &packCli{
-		path:   cliPath,
-		runner: commandRunner,
-	}
-}
-
-// packCliPath returns the path where we store our local copy of pack ($AZD_CONFIG_DIR/bin).
-func packCliPath() (string, error) {
-	configDir, err := config.GetUserConfigDir()
-	if err != nil {
-		return "", err
-	}
-
-	if runtime.GOOS == "windows" {
-		return filepath.Join(configDir, "bin", "pack.exe"), nil
-	}
-
-	return filepath.Join(configDir, "bin", "pack"), nil
-}
-
-func newPackCliImpl(
-	ctx context.Context,
-	console input.Console,
-	commandRunner exec.CommandRunner,
-	transporter policy.Transporter,
-	extract func(string, string) (string, error)) (PackCli, error) {
 	if override := os.Getenv("AZD_PACK_TOOL_PATH"); override != "" {
 		log.Printf("using external pack tool: %s", override)
 
@@ -120,7 +71,8 @@ func newPackCliImpl(
 		}, nil
 	}
 
-	cliPath, err := packCliPath()
+	def := toolcache.MustLookup("pack")
+	cliPath, err := toolCache.BinaryPath(def)
 	if err != nil {
 		return nil, fmt.Errorf("finding pack: %w", err)
 	}
@@ -128,13 +80,9 @@ func newPackCliImpl(
 		return nil, fmt.Errorf("finding pack: %w", err)
 	}
 	if errors.Is(err, os.ErrNotExist) {
-		if err := os.MkdirAll(filepath.Dir(cliPath), osutil.PermissionDirectory); err != nil {
-			return nil, fmt.Errorf("downloading pack: %w", err)
-		}
-
 		msg := "Acquiring pack cli"
 		console.ShowSpinner(ctx, msg, input.Step)
-		err := downloadPack(ctx, transporter, PackVersion, extract, cliPath)
+		_, err := toolCache.Install(ctx, def)
 		console.StopSpinner(ctx, "", input.Step)
 		if err != nil {
 			return nil, fmt.Errorf("downloading pack: %w", err)
@@ -152,24 +100,21 @@ func newPackCliImpl(
 	}
 
 	log.Printf("pack version: %s", ver)
-
-	if ver.LT(PackVersion) {
-		log.Printf("installed pack version %s is older than %s; updating.", ver.String(), PackVersion.String())
-
-		msg := "Upgrading pack"
-		console.ShowSpinner(ctx, msg, input.Step)
-		err := downloadPack(ctx, transporter, PackVersion, extract, cliPath)
-		console.StopSpinner(ctx, "", input.Step)
-		if err != nil {
-			return nil, fmt.Errorf("upgrading pack: %w", err)
-		}
-	}
-
 	log.Printf("using local pack: %s", cliPath)
 
 	return cli, nil
 }
 
+func NewPackCliWithPath(
+	commandRunner exec.CommandRunner,
+	cliPath string,
+) PackCli {
+	return &packCli{
+		path:   cliPath,
+		runner: commandRunner,
+	}
+}
+
 type packCli struct {
 	path   string
 	runner exec.CommandRunner
@@ -204,6 +149,7 @@ func (cli *packCli) Build(
 	ctx context.Context,
 	cwd string,
 	builder string,
+	buildpacks []string,
 	imageName string,
 	environ []string,
 	progressWriter io.Writer,
@@ -219,6 +165,9 @@ func (cli *packCli) Build(
 	}
 
 	runArgs := exec.NewRunArgs(cli.path, "build", imageName, "--builder", builder, "--path", cwd)
+	for _, buildpack := range buildpacks {
+		runArgs.Args = append(runArgs.Args, "--buildpack", buildpack)
+	}
 	runArgs.Args = append(runArgs.Args, envArgs...)
 	if progressWriter != nil {
 		runArgs = runArgs.WithStdOut(progressWriter).WithStdErr(progressWriter)
@@ -250,200 +199,3 @@ func wrapStatusCodeErr(err error, res exec.RunResult) error {
 
 	return err
 }
-
-func packName() string {
-	if runtime.GOOS == "windows" {
-		return "pack.exe"
-	} else {
-		return "pack"
-	}
-}
-
-func extractFromZip(
-	zipped string,
-	out string) (string, error) {
-	zipReader, err := zip.OpenReader(zipped)
-	if err != nil {
-		return "", err
-	}
-
-	log.Printf("extract from %s", zipped)
-	defer zipReader.Close()
-
-	var extractedAt string
-	for _, file := range zipReader.File {
-		fileName := file.FileInfo().Name()
-		if !file.FileInfo().IsDir() && fileName == packName() {
-			log.Printf("found cli at: %s", file.Name)
-			fileReader, err := file.Open()
-			if err != nil {
-				return extractedAt, err
-			}
-			filePath := filepath.Join(out, fileName)
-			cliFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-			if err != nil {
-				return extractedAt, err
-			}
-			defer cliFile.Close()
-			/* #nosec G110 - decompression bomb false positive */
-			_, err = io.Copy(cliFile, fileReader)
-			if err != nil {
-				return extractedAt, err
-			}
-			extractedAt = filePath
-			break
-		}
-	}
-	if extractedAt != "" {
-		log.Printf("extracted to: %s", extractedAt)
-		return extractedAt, nil
-	}
-	return extractedAt, fmt.Errorf("pack cli binary was not found within the zip file")
-}
-func extractFromTar(
-	zipped string,
-	out string) (string, error) {
-	gzFile, err := os.Open(zipped)
-	if err != nil {
-		return "", err
-	}
-	defer gzFile.Close()
-
-	gzReader, err := gzip.NewReader(gzFile)
-	if err != nil {
-		return "", err
-	}
-	defer gzReader.Close()
-
-	var extractedAt string
-	// tarReader doesn't need to be closed as it is closed by the gz reader
-	tarReader := tar.NewReader(gzReader)
-	for {
-		fileHeader, err := tarReader.Next()
-		if errors.Is(err, io.EOF) {
-			return extractedAt, fmt.Errorf("did not find pack cli within tar file")
-		}
-		if fileHeader == nil {
-			continue
-		}
-		if err != nil {
-			return extractedAt, err
-		}
-		// Tha name contains the path, remove it
-		fileNameParts := strings.Split(fileHeader.Name, "/")
-		fileName := fileNameParts[len(fileNameParts)-1]
-		// cspell: disable-next-line `Typeflag` is comming fron *tar.Header
-		if fileHeader.Typeflag == tar.TypeReg && fileName == "pack" {
-			filePath := filepath.Join(out, fileName)
-			packCliFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(fileHeader.Mode))
-			if err != nil {
-				return extractedAt, err
-			}
-			defer packCliFile.Close()
-			/* #nosec G110 - decompression bomb false positive */
-			_, err = io.Copy(packCliFile, tarReader)
-			if err != nil {
-				return extractedAt, err
-			}
-			extractedAt = filePath
-			break
-		}
-	}
-	if extractedAt != "" {
-		return extractedAt, nil
-	}
-	return extractedAt, fmt.Errorf("unable to find pack cli within archive")
-}
-
-// extractCli gets the pack cli from either a zip or a tar.gz
-func extractCli(src, dst string) (string, error) {
-	if strings.HasSuffix(src, ".zip") {
-		return extractFromZip(src, dst)
-	} else if strings.HasSuffix(src, ".tgz") {
-		return extractFromTar(src, dst)
-	}
-	return "", fmt.Errorf("unknown format while trying to extract")
-}
-
-// downloadPack downloads a given version of pack cli from the release site.
-func downloadPack(
-	ctx context.Context,
-	transporter policy.Transporter,
-	version semver.Version,
-	extractFile func(src, dst string) (string, error),
-	path string) error {
-	systemArch := runtime.GOARCH
-	archString := "" // amd64 is the implicit default
-	if systemArch != "amd64" {
-		archString = fmt.Sprintf("-%s", systemArch)
-	}
-
-	var releaseName string
-	switch runtime.GOOS {
-	case "windows":
-		releaseName = fmt.Sprintf("pack-v%s-windows%s.zip", version, archString)
-	case "darwin":
-		releaseName = fmt.Sprintf("pack-v%s-macos%s.tgz", version, archString)
-	case "linux":
-		releaseName = fmt.Sprintf("pack-v%s-linux%s.tgz", version, archString)
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	// example: https://github.com/buildpacks/pack/releases/download/v0.29.0/pack-v0.29.0-windows.zip
-	ghReleaseUrl := fmt.Sprintf("https://github.com/buildpacks/pack/releases/download/v%s/%s", version, releaseName)
-	log.Printf("downloading pack cli release %s -> %s", ghReleaseUrl, releaseName)
-
-	spanCtx, span := tracing.Start(ctx, events.PackCliInstallEvent)
-	defer span.End()
-
-	req, err := http.NewRequestWithContext(spanCtx, "GET", ghReleaseUrl, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := transporter.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("http error %d", resp.StatusCode)
-	}
-
-	tmpPath := filepath.Dir(path)
-	compressedRelease, err := os.CreateTemp(tmpPath, releaseName)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = compressedRelease.Close()
-		_ = os.Remove(compressedRelease.Name())
-	}()
-
-	if _, err := io.Copy(compressedRelease, resp.Body); err != nil {
-		return err
-	}
-	if err := compressedRelease.Close(); err != nil {
-		return err
-	}
-
-	// change file name from temporal name to the final name, as the download has completed
-	compressedFileName := filepath.Join(tmpPath, releaseName)
-	if err := osutil.Rename(ctx, compressedRelease.Name(), compressedFileName); err != nil {
-		return err
-	}
-	defer func() {
-		log.Printf("delete %s", compressedFileName)
-		_ = os.Remove(compressedFileName)
-	}()
-
-	// unzip downloaded file
-	log.Printf("extracting file %s", compressedFileName)
-	_, err = extractFile(compressedFileName, tmpPath)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -5,19 +5,23 @@ package swa
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	osexec "os/exec"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 )
 
-// cSwaCliPackage is the npm package (including the version version) we execute with npx to run the SWA CLI.
-const cSwaCliPackage = "@azure/static-web-apps-cli@1.0.6"
-
-func NewSwaCli(commandRunner exec.CommandRunner) SwaCli {
+// NewSwaCli creates a new SwaCli. The SWA CLI is run from the copy azd installs into its tool cache, which has the
+// version in the tool cache catalog.
+func NewSwaCli(commandRunner exec.CommandRunner, toolCache *toolcache.Cache) SwaCli {
 	return &swaCli{
 		commandRunner: commandRunner,
+		toolCache:     toolCache,
 	}
 }
 
@@ -42,6 +46,7 @@ type SwaCli interface {
 type swaCli struct {
 	// commandRunner allows us to stub out the CommandRunner, for testing.
 	commandRunner exec.CommandRunner
+	toolCache     *toolcache.Cache
 }
 
 func (cli *swaCli) Build(ctx context.Context, cwd string, appFolderPath string, outputRelativeFolderPath string) error {
@@ -97,9 +102,21 @@ func (cli *swaCli) Deploy(
 	return res.Stdout + res.Stderr, nil
 }
 
+// CheckInstalled checks that the SWA CLI is present in the tool cache. exec.ErrNotFound is returned when it is not, so it
+// is acquired into the cache by tools.EnsureInstalled.
 func (cli *swaCli) CheckInstalled(_ context.Context) error {
+	swaPath, err := cli.path()
+	if err != nil {
+		return err
+	}
 
-	return tools.ToolInPath("npx")
+	if _, err := os.Stat(swaPath); errors.Is(err, os.ErrNotExist) {
+		return osexec.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (cli *swaCli) Name() string {
@@ -110,10 +127,19 @@ func (cli *swaCli) InstallUrl() string {
 	return "https://azure.github.io/static-web-apps-cli/docs/use/install"
 }
 
+// path returns the path of the SWA CLI in the tool cache.
+func (cli *swaCli) path() (string, error) {
+	return cli.toolCache.BinaryPath(toolcache.MustLookup("swa"))
+}
+
 func (cli *swaCli) executeCommand(ctx context.Context, cwd string, args ...string) (exec.RunResult, error) {
+	swaPath, err := cli.path()
+	if err != nil {
+		return exec.RunResult{}, err
+	}
+
 	runArgs := exec.
-		NewRunArgs("npx", "-y", cSwaCliPackage).
-		AppendParams(args...).
+		NewRunArgs(swaPath, args...).
 		WithCwd(cwd)
 
 	return cli.commandRunner.Run(ctx, runArgs)
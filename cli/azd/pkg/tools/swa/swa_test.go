@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/toolcache"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/stretchr/testify/require"
 )
@@ -19,16 +20,15 @@ func Test_SwaBuild(t *testing.T) {
 
 	t.Run("NoErrors", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
-		swacli := NewSwaCli(mockContext.CommandRunner)
+		swacli := newTestSwaCli(t, mockContext)
 
 		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
-			return strings.Contains(command, "npx")
+			return strings.Contains(command, "swa")
 		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
 			ran = true
 
 			require.Equal(t, "./projectPath", args.Cwd)
 			require.Equal(t, []string{
-				"-y", "@azure/static-web-apps-cli@1.0.6",
 				"build",
 				"--app-location", "service/path",
 				"--output-location", "build",
@@ -51,16 +51,15 @@ func Test_SwaBuild(t *testing.T) {
 
 	t.Run("Error", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
-		swacli := NewSwaCli(mockContext.CommandRunner)
+		swacli := newTestSwaCli(t, mockContext)
 
 		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
-			return strings.Contains(command, "npx")
+			return strings.Contains(command, "swa")
 		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
 			ran = true
 
 			require.Equal(t, "./projectPath", args.Cwd)
 			require.Equal(t, []string{
-				"-y", "@azure/static-web-apps-cli@1.0.6",
 				"build",
 				"--app-location", "service/path",
 				"--output-location", "build",
@@ -88,16 +87,15 @@ func Test_SwaDeploy(t *testing.T) {
 
 	t.Run("NoErrors", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
-		swacli := NewSwaCli(mockContext.CommandRunner)
+		swacli := newTestSwaCli(t, mockContext)
 
 		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
-			return strings.Contains(command, "npx")
+			return strings.Contains(command, "swa")
 		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
 			ran = true
 
 			require.Equal(t, "./projectPath", args.Cwd)
 			require.Equal(t, []string{
-				"-y", "@azure/static-web-apps-cli@1.0.6",
 				"deploy",
 				"--tenant-id", "tenantID",
 				"--subscription-id", "subscriptionID",
@@ -138,16 +136,15 @@ func Test_SwaDeploy(t *testing.T) {
 
 	t.Run("Error", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
-		swacli := NewSwaCli(mockContext.CommandRunner)
+		swacli := newTestSwaCli(t, mockContext)
 
 		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
-			return strings.Contains(command, "npx")
+			return strings.Contains(command, "swa")
 		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
 			ran = true
 
 			require.Equal(t, "./projectPath", args.Cwd)
 			require.Equal(t, []string{
-				"-y", "@azure/static-web-apps-cli@1.0.6",
 				"deploy",
 				"--tenant-id", "tenantID",
 				"--subscription-id", "subscriptionID",
@@ -187,3 +184,12 @@ func Test_SwaDeploy(t *testing.T) {
 		)
 	})
 }
+
+func newTestSwaCli(t *testing.T, mockContext *mocks.MockContext) SwaCli {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	return NewSwaCli(
+		mockContext.CommandRunner,
+		toolcache.NewCache(mockContext.Console, mockContext.CommandRunner),
+	)
+}
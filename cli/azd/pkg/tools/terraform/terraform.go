@@ -65,7 +65,7 @@ func (cli *terraformCli) versionInfo() tools.VersionInfo {
 }
 
 func (cli *terraformCli) CheckInstalled(ctx context.Context) error {
-	err := tools.ToolInPath("terraform")
+	err := tools.ToolInSearchPath(ctx, "terraform")
 	if err != nil {
 		return err
 	}
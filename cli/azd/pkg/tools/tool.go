@@ -41,7 +41,17 @@ func (err *ErrSemver) Error() string {
 // exec.ErrNotFound and other errors.
 func ToolInPath(name string) error {
 	_, err := osexec.LookPath(name)
+	return lookPathError(name, err)
+}
+
+// ToolInSearchPath is like ToolInPath, but also searches the exec.SearchPath of the context, which holds the directories
+// of the tools azd has acquired on behalf of the user.
+func ToolInSearchPath(ctx context.Context, name string) error {
+	_, err := exec.LookPath(ctx, name)
+	return lookPathError(name, err)
+}
 
+func lookPathError(name string, err error) error {
 	switch {
 	case err == nil:
 		return nil
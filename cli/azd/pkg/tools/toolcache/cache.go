@@ -0,0 +1,425 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package toolcache manages copies of external tools that azd downloads on behalf of the user, stored in
+// `$AZD_CONFIG_DIR/tools/<name>/<version>`.
+package toolcache
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/events"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// installEvents are the names of the install events of the tools azd installed before it had a tool cache, which are
+// kept for the telemetry of their installs.
+var installEvents = map[string]string{
+	"bicep": events.BicepInstallEvent,
+	"pack":  events.PackCliInstallEvent,
+}
+
+// InstalledTool is a version of a tool present in the tool cache.
+type InstalledTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+	// Current is true when this is the version azd installs for the tool.
+	Current bool `json:"current"`
+}
+
+// Cache manages the tools azd has installed into its tool cache.
+type Cache struct {
+	console       input.Console
+	commandRunner exec.CommandRunner
+	transporter   policy.Transporter
+	goos          string
+	goarch        string
+}
+
+// NewCache creates a new Cache rooted in the azd user configuration directory.
+func NewCache(
+	console input.Console,
+	commandRunner exec.CommandRunner,
+) *Cache {
+	return NewCacheWithTransporter(console, commandRunner, http.DefaultClient)
+}
+
+// NewCacheWithTransporter is like NewCache but allows providing a custom transport to use when downloading tools, for
+// testing purposes. Tools are downloaded for the OS and architecture azd is running on.
+func NewCacheWithTransporter(
+	console input.Console,
+	commandRunner exec.CommandRunner,
+	transporter policy.Transporter,
+) *Cache {
+	return &Cache{
+		console:       console,
+		commandRunner: commandRunner,
+		transporter:   transporter,
+		goos:          runtime.GOOS,
+		goarch:        runtime.GOARCH,
+	}
+}
+
+// cacheRoot returns the root directory of the tool cache ($AZD_CONFIG_DIR/tools).
+func cacheRoot() (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "tools"), nil
+}
+
+// BinaryPath returns the path the executable of the given tool is stored at once it has been installed.
+func (c *Cache) BinaryPath(def Definition) (string, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return "", err
+	}
+
+	toolDir := filepath.Join(root, def.Name, def.Version.String())
+	if def.Package != "" {
+		// npm creates a .cmd shim for the executables of a package on Windows.
+		binary := def.Name
+		if c.goos == "windows" {
+			binary += ".cmd"
+		}
+
+		return filepath.Join(toolDir, "node_modules", ".bin", binary), nil
+	}
+
+	asset, err := def.Asset(def.Version, c.goos, c.goarch)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(toolDir, asset.Binary), nil
+}
+
+// List returns all the versions of tools present in the tool cache.
+func (c *Cache) List() ([]InstalledTool, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	toolDirs, err := os.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return []InstalledTool{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading tool cache: %w", err)
+	}
+
+	installed := []InstalledTool{}
+	for _, toolDir := range toolDirs {
+		if !toolDir.IsDir() {
+			continue
+		}
+
+		versionDirs, err := os.ReadDir(filepath.Join(root, toolDir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading tool cache: %w", err)
+		}
+
+		def, known := Lookup(toolDir.Name())
+		for _, versionDir := range versionDirs {
+			if !versionDir.IsDir() {
+				continue
+			}
+
+			installed = append(installed, InstalledTool{
+				Name:    toolDir.Name(),
+				Version: versionDir.Name(),
+				Path:    filepath.Join(root, toolDir.Name(), versionDir.Name()),
+				Current: known && versionDir.Name() == def.Version.String(),
+			})
+		}
+	}
+
+	return installed, nil
+}
+
+// Prune removes every version of a tool from the cache which is not the version azd installs. The removed versions are
+// returned.
+func (c *Cache) Prune() ([]InstalledTool, error) {
+	installed, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	pruned := []InstalledTool{}
+	for _, tool := range installed {
+		if tool.Current {
+			continue
+		}
+
+		log.Printf("removing %s %s from the tool cache", tool.Name, tool.Version)
+		if err := os.RemoveAll(tool.Path); err != nil {
+			return pruned, fmt.Errorf("removing %s %s: %w", tool.Name, tool.Version, err)
+		}
+
+		pruned = append(pruned, tool)
+	}
+
+	return pruned, nil
+}
+
+// Install downloads a tool into the cache, verifying it against its checksum, and returns the path to the executable.
+// Nothing is downloaded when the tool is already present in the cache.
+func (c *Cache) Install(ctx context.Context, def Definition) (string, error) {
+	binaryPath, err := c.BinaryPath(def)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(binaryPath); err == nil {
+		log.Printf("using cached %s: %s", def.Name, binaryPath)
+		return binaryPath, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	var spanErr error
+	eventName, has := installEvents[def.Name]
+	if !has {
+		eventName = events.ToolCacheInstallEvent
+	}
+
+	spanCtx, span := tracing.Start(ctx, eventName)
+	defer func() { span.EndWithStatus(spanErr) }()
+	span.SetAttributes(fields.ToolName.String(def.Name), fields.ToolVersion.String(def.Version.String()))
+
+	toolDir, err := c.toolDir(def)
+	if err != nil {
+		spanErr = err
+		return "", err
+	}
+
+	if err := os.MkdirAll(toolDir, osutil.PermissionDirectory); err != nil {
+		spanErr = err
+		return "", err
+	}
+
+	if def.Package != "" {
+		err = c.installPackage(spanCtx, def, toolDir)
+	} else {
+		err = c.installAsset(spanCtx, def, toolDir, binaryPath)
+	}
+
+	if err != nil {
+		spanErr = err
+		_ = os.RemoveAll(toolDir)
+		return "", fmt.Errorf("installing %s %s: %w", def.Name, def.Version, err)
+	}
+
+	log.Printf("installed %s %s: %s", def.Name, def.Version, binaryPath)
+	return binaryPath, nil
+}
+
+// Acquire implements tools.ToolAcquirer, installing the cached copy of a tool from the catalog and adding its directory to
+// the exec.SearchPath of the context, so commands run with the context find it before any other copy installed on the
+// machine.
+func (c *Cache) Acquire(ctx context.Context, tool tools.ExternalTool) error {
+	idx := slices.IndexFunc(Catalog, func(def Definition) bool {
+		return def.ExternalToolName != "" && def.ExternalToolName == tool.Name()
+	})
+	if idx == -1 {
+		return tools.ErrToolNotManaged
+	}
+
+	searchPath := exec.SearchPathFromContext(ctx)
+	if searchPath == nil {
+		return tools.ErrToolNotManaged
+	}
+
+	def := Catalog[idx]
+	title := fmt.Sprintf("Acquiring %s %s", def.Name, def.Version)
+	c.console.ShowSpinner(ctx, title, input.Step)
+	binaryPath, err := c.Install(ctx, def)
+	if err != nil {
+		c.console.StopSpinner(ctx, title, input.StepFailed)
+		return err
+	}
+	c.console.StopSpinner(ctx, title, input.StepDone)
+
+	log.Printf("adding %s to the tool search path", filepath.Dir(binaryPath))
+	searchPath.Prepend(filepath.Dir(binaryPath))
+	return nil
+}
+
+// toolDir returns the directory the given version of a tool is installed into.
+func (c *Cache) toolDir(def Definition) (string, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(root, def.Name, def.Version.String()), nil
+}
+
+// installPackage installs a tool distributed as an npm package into toolDir.
+func (c *Cache) installPackage(ctx context.Context, def Definition, toolDir string) error {
+	packageSpec := fmt.Sprintf("%s@%s", def.Package, def.Version)
+	log.Printf("installing npm package %s", packageSpec)
+
+	runArgs := exec.NewRunArgs(
+		"npm", "install", "--prefix", toolDir, "--no-fund", "--no-audit", "--no-save", packageSpec,
+	)
+	if _, err := c.commandRunner.Run(ctx, runArgs); err != nil {
+		return fmt.Errorf("installing npm package %s: %w", packageSpec, err)
+	}
+
+	return nil
+}
+
+// installAsset downloads the release asset of a tool into toolDir.
+func (c *Cache) installAsset(ctx context.Context, def Definition, toolDir string, binaryPath string) error {
+	asset, err := def.Asset(def.Version, c.goos, c.goarch)
+	if err != nil {
+		return err
+	}
+
+	expected, err := c.expectedChecksum(ctx, def, asset)
+	if err != nil {
+		return fmt.Errorf("fetching checksum: %w", err)
+	}
+
+	return c.downloadAsset(ctx, asset, expected, toolDir, binaryPath)
+}
+
+// expectedChecksum returns the SHA256 hash a release asset is verified against, as published by its release site. An
+// empty hash is returned when the release site publishes no checksum and the asset cannot be verified.
+func (c *Cache) expectedChecksum(ctx context.Context, def Definition, asset Asset) (string, error) {
+	if asset.ChecksumUrl == "" {
+		log.Printf("%s %s has no published checksum for %s/%s, it will not be verified",
+			def.Name, def.Version, c.goos, c.goarch)
+		return "", nil
+	}
+
+	return c.fetchChecksum(ctx, asset.ChecksumUrl, path.Base(asset.Url))
+}
+
+// downloadAsset fetches a release asset, verifies it against the expected checksum, when there is one, and stores the
+// executable at binaryPath.
+func (c *Cache) downloadAsset(
+	ctx context.Context, asset Asset, expected string, toolDir string, binaryPath string,
+) error {
+	assetName := path.Base(asset.Url)
+
+	f, err := os.CreateTemp(toolDir, fmt.Sprintf("%s.tmp*", assetName))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	log.Printf("downloading %s", asset.Url)
+
+	body, err := c.get(ctx, asset.Url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hash), body); err != nil {
+		return err
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); expected != "" && !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, expected, actual)
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if asset.Archive == ArchiveNone {
+		if err := os.Chmod(f.Name(), osutil.PermissionExecutableFile); err != nil {
+			return err
+		}
+
+		return osutil.Rename(ctx, f.Name(), binaryPath)
+	}
+
+	extracted, err := ExtractFile(f.Name(), asset.Archive, asset.Binary, toolDir)
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(extracted, osutil.PermissionExecutableFile)
+}
+
+// fetchChecksum downloads a checksum file and returns the hash it lists for assetName.
+func (c *Cache) fetchChecksum(ctx context.Context, url string, assetName string) (string, error) {
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	return parseChecksum(body, assetName)
+}
+
+// parseChecksum reads a checksum file, which is either a single hash or lines of `<hash>  <file name>`.
+func parseChecksum(r io.Reader, assetName string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 1:
+			return fields[0], nil
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == assetName:
+			return fields[0], nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("no checksum found for %s", assetName)
+}
+
+func (c *Cache) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.transporter.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("http error %d fetching %s", resp.StatusCode, url)
+	}
+
+	return resp.Body, nil
+}
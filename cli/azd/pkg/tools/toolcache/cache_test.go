@@ -0,0 +1,235 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package toolcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockzip"
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const testToolContents = "this is helm"
+
+func testDefinition() Definition {
+	def, _ := Lookup("helm")
+	return def
+}
+
+func newTestCache(t *testing.T, mockContext *mocks.MockContext) *Cache {
+	cache := NewCacheWithTransporter(mockContext.Console, mockContext.CommandRunner, mockContext.HttpClient)
+	cache.goos = "linux"
+	cache.goarch = "amd64"
+
+	return cache
+}
+
+// mockRelease registers handlers for a helm release and its published checksum under urlPrefix, returning the actual
+// checksum of the release. The checksum of the release is published when checksum is empty.
+func mockRelease(t *testing.T, mockContext *mocks.MockContext, urlPrefix string, checksum string) string {
+	archive, err := mockzip.GzippedTar([]mockzip.File{
+		{Name: "linux-amd64/helm", Content: testToolContents},
+		{Name: "linux-amd64/README.md", Content: "readme"},
+	})
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(archive.Bytes())
+	actual := hex.EncodeToString(hash[:])
+	if checksum == "" {
+		checksum = actual
+	}
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return strings.HasPrefix(request.URL.String(), urlPrefix) &&
+			strings.HasSuffix(request.URL.Path, ".tar.gz")
+	}).Respond(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(archive.Bytes())),
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return strings.HasPrefix(request.URL.String(), urlPrefix) &&
+			strings.HasSuffix(request.URL.Path, ".sha256sum")
+	}).Respond(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(fmt.Sprintf("%s  helm-v3.13.0-linux-amd64.tar.gz\n", checksum))),
+	})
+
+	return actual
+}
+
+func TestInstall(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockRelease(t, mockContext, "https://get.helm.sh/", "")
+	cache := newTestCache(t, mockContext)
+
+	binaryPath, err := cache.Install(*mockContext.Context, testDefinition())
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(binaryPath)
+	require.NoError(t, err)
+	require.Equal(t, testToolContents, string(contents))
+
+	installed, err := cache.List()
+	require.NoError(t, err)
+	require.Len(t, installed, 1)
+	require.Equal(t, "helm", installed[0].Name)
+	require.Equal(t, "3.13.0", installed[0].Version)
+	require.True(t, installed[0].Current)
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockRelease(t, mockContext, "https://get.helm.sh/", strings.Repeat("0", 64))
+	cache := newTestCache(t, mockContext)
+
+	_, err := cache.Install(*mockContext.Context, testDefinition())
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	installed, err := cache.List()
+	require.NoError(t, err)
+	require.Empty(t, installed)
+}
+
+func TestInstallPackage(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	cache := newTestCache(t, mockContext)
+	toolDir := filepath.Join(configDir, "tools", "swa", "1.0.6")
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "npm"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		require.Equal(t, []string{
+			"install", "--prefix", toolDir, "--no-fund", "--no-audit", "--no-save", "@azure/static-web-apps-cli@1.0.6",
+		}, args.Args)
+
+		binDir := filepath.Join(toolDir, "node_modules", ".bin")
+		require.NoError(t, os.MkdirAll(binDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "swa"), []byte("swa"), 0600))
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	binaryPath, err := cache.Install(*mockContext.Context, MustLookup("swa"))
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(toolDir, "node_modules", ".bin", "swa"), binaryPath)
+}
+
+func TestAcquire(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	hash := sha256.Sum256([]byte("this is kubectl"))
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.URL.String() == "https://dl.k8s.io/release/v1.28.2/bin/linux/amd64/kubectl"
+	}).Respond(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString("this is kubectl")),
+	})
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.URL.String() == "https://dl.k8s.io/release/v1.28.2/bin/linux/amd64/kubectl.sha256"
+	}).Respond(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(hex.EncodeToString(hash[:]))),
+	})
+	cache := newTestCache(t, mockContext)
+
+	searchPath := exec.NewSearchPath()
+	ctx := exec.WithSearchPath(*mockContext.Context, searchPath)
+
+	err := cache.Acquire(ctx, &namedTool{name: "kubectl"})
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(configDir, "tools", "kubectl", "1.28.2")}, searchPath.Dirs())
+	require.NotContains(t, os.Getenv("PATH"), configDir)
+
+	err = cache.Acquire(ctx, &namedTool{name: "docker"})
+	require.ErrorIs(t, err, tools.ErrToolNotManaged)
+}
+
+type namedTool struct {
+	name string
+}
+
+func (t *namedTool) CheckInstalled(context.Context) error { return nil }
+func (t *namedTool) InstallUrl() string                   { return "" }
+func (t *namedTool) Name() string                         { return t.name }
+
+func TestPrune(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockRelease(t, mockContext, "https://get.helm.sh/", "")
+	cache := newTestCache(t, mockContext)
+
+	_, err := cache.Install(*mockContext.Context, testDefinition())
+	require.NoError(t, err)
+
+	oldDef := testDefinition()
+	oldDef.Version = semver.MustParse("3.12.0")
+	oldPath := filepath.Join(configDir, "tools", "helm", oldDef.Version.String())
+	require.NoError(t, os.MkdirAll(oldPath, 0755))
+
+	pruned, err := cache.Prune()
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	require.Equal(t, "3.12.0", pruned[0].Version)
+
+	_, err = os.Stat(oldPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	installed, err := cache.List()
+	require.NoError(t, err)
+	require.Len(t, installed, 1)
+	require.True(t, installed[0].Current)
+}
+
+func Test_parseChecksum(t *testing.T) {
+	const hash = "4d2a5b7c"
+
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+		wantErr  bool
+	}{
+		{"HashOnly", hash + "\n", hash, false},
+		{"SumsFile", "ffff  other.zip\n" + hash + "  tool.zip\n", hash, false},
+		{"BinaryMarker", hash + " *tool.zip\n", hash, false},
+		{"Missing", "ffff  other.zip\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChecksum(strings.NewReader(tt.contents), "tool.zip")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
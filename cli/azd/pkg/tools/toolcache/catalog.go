@@ -0,0 +1,279 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package toolcache
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/blang/semver/v4"
+)
+
+// ArchiveKind is the packaging format of a release asset.
+type ArchiveKind string
+
+const (
+	// ArchiveNone is used when the release asset is the executable itself.
+	ArchiveNone ArchiveKind = ""
+	// ArchiveZip is used when the executable is contained in a .zip file.
+	ArchiveZip ArchiveKind = "zip"
+	// ArchiveTarGz is used when the executable is contained in a gzipped tarball.
+	ArchiveTarGz ArchiveKind = "tar.gz"
+)
+
+// Asset is a downloadable release of a tool for a specific OS and architecture.
+type Asset struct {
+	// Url is the location the release is downloaded from.
+	Url string
+	// ChecksumUrl is the location of the SHA256 checksum published alongside the release, if any. The file may either
+	// contain just the hash, or lines in the `<hash>  <file name>` format produced by `sha256sum`.
+	ChecksumUrl string
+	// Archive is the packaging format of the release.
+	Archive ArchiveKind
+	// Binary is the file name of the executable, within the archive when the release is packaged.
+	Binary string
+}
+
+// Definition describes a tool that azd is able to acquire and store in its tool cache.
+type Definition struct {
+	// Name is the name of the tool, as used by the `azd tools` commands.
+	Name string
+	// ExternalToolName is the value returned by [tools.ExternalTool.Name] for the azd wrapper of this tool, if any. It is
+	// used to acquire the tool automatically when the wrapper reports it is missing or out of date.
+	ExternalToolName string
+	// Version is the version of the tool azd installs.
+	Version semver.Version
+	// Asset computes the release asset for a version of the tool on a given OS and architecture. It is nil for tools
+	// installed from an npm Package.
+	Asset func(version semver.Version, goos string, goarch string) (Asset, error)
+	// Package is the npm package the tool is installed from, for tools distributed through npm rather than as release
+	// assets. npm verifies the integrity of the package, and installs it from the registry npm is configured with.
+	Package string
+}
+
+// Catalog is the set of tools azd can install into its tool cache, with the versions azd installs.
+var Catalog = []Definition{
+	{
+		Name:             "kubectl",
+		ExternalToolName: "kubectl",
+		Version:          semver.MustParse("1.28.2"),
+		Asset:            kubectlAsset,
+	},
+	{
+		Name:             "terraform",
+		ExternalToolName: "Terraform CLI",
+		Version:          semver.MustParse("1.5.7"),
+		Asset:            terraformAsset,
+	},
+	{
+		Name:    "helm",
+		Version: semver.MustParse("3.13.0"),
+		Asset:   helmAsset,
+	},
+	{
+		Name:    "bicep",
+		Version: semver.MustParse("0.21.1"),
+		Asset:   bicepAsset,
+	},
+	{
+		Name:    "pack",
+		Version: semver.MustParse("0.30.0"),
+		Asset:   packAsset,
+	},
+	{
+		Name:             "swa",
+		ExternalToolName: "SWA CLI",
+		Version:          semver.MustParse("1.0.6"),
+		Package:          "@azure/static-web-apps-cli",
+	},
+}
+
+// Lookup finds a tool in the catalog by name.
+func Lookup(name string) (Definition, bool) {
+	for _, def := range Catalog {
+		if def.Name == name {
+			return def, true
+		}
+	}
+
+	return Definition{}, false
+}
+
+// MustLookup is like Lookup, but panics when the tool is not in the catalog.
+func MustLookup(name string) Definition {
+	def, ok := Lookup(name)
+	if !ok {
+		panic(fmt.Sprintf("tool %s is not in the catalog", name))
+	}
+
+	return def
+}
+
+func exeName(name string, goos string) string {
+	if goos == "windows" {
+		return name + ".exe"
+	}
+
+	return name
+}
+
+func checkPlatform(goos string, goarch string) error {
+	switch goos {
+	case "windows", "darwin", "linux":
+	default:
+		return fmt.Errorf("unsupported platform: %s", goos)
+	}
+
+	switch goarch {
+	case "amd64", "arm64":
+	default:
+		return fmt.Errorf("unsupported architecture: %s", goarch)
+	}
+
+	return nil
+}
+
+// example: https://dl.k8s.io/release/v1.28.2/bin/linux/amd64/kubectl
+func kubectlAsset(version semver.Version, goos string, goarch string) (Asset, error) {
+	if err := checkPlatform(goos, goarch); err != nil {
+		return Asset{}, err
+	}
+
+	binary := exeName("kubectl", goos)
+	url := fmt.Sprintf("https://dl.k8s.io/release/v%s/bin/%s/%s/%s", version, goos, goarch, binary)
+
+	return Asset{
+		Url:         url,
+		ChecksumUrl: url + ".sha256",
+		Archive:     ArchiveNone,
+		Binary:      binary,
+	}, nil
+}
+
+// example: https://releases.hashicorp.com/terraform/1.5.7/terraform_1.5.7_linux_amd64.zip
+func terraformAsset(version semver.Version, goos string, goarch string) (Asset, error) {
+	if err := checkPlatform(goos, goarch); err != nil {
+		return Asset{}, err
+	}
+
+	baseUrl := fmt.Sprintf("https://releases.hashicorp.com/terraform/%s", version)
+
+	return Asset{
+		Url:         fmt.Sprintf("%s/terraform_%s_%s_%s.zip", baseUrl, version, goos, goarch),
+		ChecksumUrl: fmt.Sprintf("%s/terraform_%s_SHA256SUMS", baseUrl, version),
+		Archive:     ArchiveZip,
+		Binary:      exeName("terraform", goos),
+	}, nil
+}
+
+// example: https://get.helm.sh/helm-v3.13.0-linux-amd64.tar.gz
+func helmAsset(version semver.Version, goos string, goarch string) (Asset, error) {
+	if err := checkPlatform(goos, goarch); err != nil {
+		return Asset{}, err
+	}
+
+	if goos == "windows" {
+		url := fmt.Sprintf("https://get.helm.sh/helm-v%s-windows-%s.zip", version, goarch)
+		return Asset{
+			Url:         url,
+			ChecksumUrl: url + ".sha256sum",
+			Archive:     ArchiveZip,
+			Binary:      "helm.exe",
+		}, nil
+	}
+
+	url := fmt.Sprintf("https://get.helm.sh/helm-v%s-%s-%s.tar.gz", version, goos, goarch)
+	return Asset{
+		Url:         url,
+		ChecksumUrl: url + ".sha256sum",
+		Archive:     ArchiveTarGz,
+		Binary:      "helm",
+	}, nil
+}
+
+// example: https://downloads.bicep.azure.com/v0.21.1/bicep-linux-x64
+func bicepAsset(version semver.Version, goos string, goarch string) (Asset, error) {
+	if err := checkPlatform(goos, goarch); err != nil {
+		return Asset{}, err
+	}
+
+	arch := "x64"
+	if goarch == "arm64" {
+		arch = "arm64"
+	}
+
+	var releaseName string
+	switch goos {
+	case "windows":
+		releaseName = fmt.Sprintf("bicep-win-%s.exe", arch)
+	case "darwin":
+		releaseName = fmt.Sprintf("bicep-osx-%s", arch)
+	case "linux":
+		// bicep is only published for musl on x64, arm64 systems use the glibc build.
+		if goarch == "amd64" && preferMuslBicep(os.Stat) {
+			releaseName = "bicep-linux-musl-x64"
+		} else {
+			releaseName = fmt.Sprintf("bicep-linux-%s", arch)
+		}
+	}
+
+	// The bicep release site does not publish checksums.
+	return Asset{
+		Url:     fmt.Sprintf("https://downloads.bicep.azure.com/v%s/%s", version, releaseName),
+		Archive: ArchiveNone,
+		Binary:  exeName("bicep", goos),
+	}, nil
+}
+
+type stater func(name string) (os.FileInfo, error)
+
+// preferMuslBicep determines if we should install the version of bicep that used musl instead of glibc. We prefer
+// musl bicep on linux systems that have musl installed and do not have glibc installed. If both musl and glibc are
+// installed, we prefer the glibc based version.  This behavior matches the `az` CLI (see: Azure/azure-cli#23040)
+func preferMuslBicep(stat stater) bool {
+	if _, err := stat("/lib/ld-musl-x86_64.so.1"); err == nil {
+		if _, err := stat("/lib/x86_64-linux-gnu/libc.so.6"); err == nil {
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// example: https://github.com/buildpacks/pack/releases/download/v0.30.0/pack-v0.30.0-linux.tgz
+func packAsset(version semver.Version, goos string, goarch string) (Asset, error) {
+	if err := checkPlatform(goos, goarch); err != nil {
+		return Asset{}, err
+	}
+
+	archSuffix := "" // amd64 is the implicit default
+	if goarch != "amd64" {
+		archSuffix = "-" + goarch
+	}
+
+	baseUrl := fmt.Sprintf("https://github.com/buildpacks/pack/releases/download/v%s", version)
+
+	var url string
+	var archive ArchiveKind
+	switch goos {
+	case "windows":
+		url = fmt.Sprintf("%s/pack-v%s-windows%s.zip", baseUrl, version, archSuffix)
+		archive = ArchiveZip
+	case "darwin":
+		url = fmt.Sprintf("%s/pack-v%s-macos%s.tgz", baseUrl, version, archSuffix)
+		archive = ArchiveTarGz
+	case "linux":
+		url = fmt.Sprintf("%s/pack-v%s-linux%s.tgz", baseUrl, version, archSuffix)
+		archive = ArchiveTarGz
+	}
+
+	return Asset{
+		Url:         url,
+		ChecksumUrl: url + ".sha256",
+		Archive:     archive,
+		Binary:      exeName("pack", goos),
+	}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package toolcache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_packAsset(t *testing.T) {
+	version := semver.MustParse("0.30.0")
+	baseUrl := "https://github.com/buildpacks/pack/releases/download/v0.30.0/"
+
+	tests := []struct {
+		goos    string
+		goarch  string
+		url     string
+		archive ArchiveKind
+		binary  string
+	}{
+		{"linux", "amd64", baseUrl + "pack-v0.30.0-linux.tgz", ArchiveTarGz, "pack"},
+		{"linux", "arm64", baseUrl + "pack-v0.30.0-linux-arm64.tgz", ArchiveTarGz, "pack"},
+		{"darwin", "arm64", baseUrl + "pack-v0.30.0-macos-arm64.tgz", ArchiveTarGz, "pack"},
+		{"windows", "amd64", baseUrl + "pack-v0.30.0-windows.zip", ArchiveZip, "pack.exe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.goarch, func(t *testing.T) {
+			asset, err := packAsset(version, tt.goos, tt.goarch)
+			require.NoError(t, err)
+			require.Equal(t, Asset{
+				Url:         tt.url,
+				ChecksumUrl: tt.url + ".sha256",
+				Archive:     tt.archive,
+				Binary:      tt.binary,
+			}, asset)
+		})
+	}
+}
+
+func Test_preferMuslBicep(t *testing.T) {
+	tests := []struct {
+		name     string
+		hasMusl  bool
+		hasGlibc bool
+		want     bool
+	}{
+		{
+			name:     "musl preferred",
+			hasMusl:  true,
+			hasGlibc: false,
+			want:     true,
+		},
+		{
+			name:     "glibc preferred",
+			hasMusl:  false,
+			hasGlibc: true,
+			want:     false,
+		},
+		{
+			name:     "both available",
+			hasMusl:  true,
+			hasGlibc: true,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStat := func(name string) (os.FileInfo, error) {
+				if tt.hasMusl && name == "/lib/ld-musl-x86_64.so.1" {
+					return &fakeFileInfo{}, nil
+				}
+				if tt.hasGlibc && name == "/lib/x86_64-linux-gnu/libc.so.6" {
+					return &fakeFileInfo{}, nil
+				}
+
+				return nil, os.ErrNotExist
+			}
+			got := preferMuslBicep(mockStat)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type fakeFileInfo struct {
+}
+
+func (f *fakeFileInfo) Name() string {
+	return ""
+}
+
+func (f *fakeFileInfo) Size() int64 {
+	return 0
+}
+
+func (f *fakeFileInfo) Mode() os.FileMode {
+	return 0
+}
+
+func (f *fakeFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (f *fakeFileInfo) IsDir() bool {
+	return false
+}
+
+func (f *fakeFileInfo) Sys() interface{} {
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package toolcache
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ExtractFile finds the regular file named binary in the archive at src, ignoring the directory it is stored in, and
+// writes it into the directory dst. The path to the extracted file is returned.
+func ExtractFile(src string, kind ArchiveKind, binary string, dst string) (string, error) {
+	switch kind {
+	case ArchiveZip:
+		return extractFromZip(src, binary, dst)
+	case ArchiveTarGz:
+		return extractFromTarGz(src, binary, dst)
+	default:
+		return "", fmt.Errorf("unknown archive format '%s'", kind)
+	}
+}
+
+func extractFromZip(src string, binary string, dst string) (string, error) {
+	zipReader, err := zip.OpenReader(src)
+	if err != nil {
+		return "", err
+	}
+	defer zipReader.Close()
+
+	log.Printf("extract %s from %s", binary, src)
+
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || file.FileInfo().Name() != binary {
+			continue
+		}
+
+		fileReader, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		defer fileReader.Close()
+
+		return writeExtracted(fileReader, filepath.Join(dst, binary), file.Mode())
+	}
+
+	return "", fmt.Errorf("%s was not found within the zip file", binary)
+}
+
+func extractFromTarGz(src string, binary string, dst string) (string, error) {
+	gzFile, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer gzFile.Close()
+
+	gzReader, err := gzip.NewReader(gzFile)
+	if err != nil {
+		return "", err
+	}
+	defer gzReader.Close()
+
+	log.Printf("extract %s from %s", binary, src)
+
+	// tarReader doesn't need to be closed as it is closed by the gz reader
+	tarReader := tar.NewReader(gzReader)
+	for {
+		fileHeader, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("%s was not found within the tar file", binary)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		// cspell: disable-next-line `Typeflag` is coming from *tar.Header
+		if fileHeader.Typeflag != tar.TypeReg || path.Base(fileHeader.Name) != binary {
+			continue
+		}
+
+		return writeExtracted(tarReader, filepath.Join(dst, binary), os.FileMode(fileHeader.Mode))
+	}
+}
+
+func writeExtracted(r io.Reader, filePath string, mode os.FileMode) (string, error) {
+	out, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	/* #nosec G110 - decompression bomb false positive */
+	if _, err := io.Copy(out, r); err != nil {
+		return "", err
+	}
+
+	log.Printf("extracted to: %s", filePath)
+	return filePath, nil
+}
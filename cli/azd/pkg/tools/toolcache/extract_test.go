@@ -0,0 +1,138 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package toolcache
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockzip"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_extractZip(t *testing.T) {
+	const contentZipped = "zipped pack"
+
+	tests := []struct {
+		name    string
+		files   []mockzip.File
+		wantErr bool
+	}{
+		{
+			"found",
+			[]mockzip.File{
+				{
+					Name:    path.Join("bin", "pack.exe"),
+					Content: contentZipped,
+				},
+				{
+					Name: "bin/etc",
+				},
+				{
+					Name: "etc",
+				},
+			},
+			false,
+		},
+		{
+			"not found",
+			[]mockzip.File{
+				{
+					Name: "bin/etc",
+				},
+				{
+					Name: "etc",
+				},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			zip, err := mockzip.Zip(tt.files)
+			require.NoError(t, err)
+
+			file := filepath.Join(dir, "pack.zip")
+			err = os.WriteFile(file, zip.Bytes(), 0600)
+			require.NoError(t, err)
+
+			packCli, err := ExtractFile(file, ArchiveZip, "pack.exe", dir)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				content, err := os.ReadFile(packCli)
+
+				require.NoError(t, err)
+				require.EqualValues(t, []byte(contentZipped), content)
+			}
+		})
+	}
+}
+
+func Test_extractTgz(t *testing.T) {
+	const contentZipped = "gzipped pack"
+
+	tests := []struct {
+		name    string
+		files   []mockzip.File
+		wantErr bool
+	}{
+		{
+			"found",
+			[]mockzip.File{
+				{
+					Name:    "bin/pack",
+					Content: contentZipped,
+				},
+				{
+					Name: "bin/etc",
+				},
+				{
+					Name: "etc",
+				},
+			},
+			false,
+		},
+		{
+			"not found",
+			[]mockzip.File{
+				{
+					Name: "bin/etc",
+				},
+				{
+					Name: "etc",
+				},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			zip, err := mockzip.GzippedTar(tt.files)
+			require.NoError(t, err)
+
+			file := filepath.Join(dir, "pack.tgz")
+			err = os.WriteFile(file, zip.Bytes(), 0600)
+			require.NoError(t, err)
+
+			packCli, err := ExtractFile(file, ArchiveTarGz, "pack", dir)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				content, err := os.ReadFile(packCli)
+
+				require.NoError(t, err)
+				require.EqualValues(t, []byte(contentZipped), content)
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal/telemetry"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azureutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
@@ -161,7 +163,7 @@ func Test_CLI_InfraCreateAndDelete(t *testing.T) {
 			func(_ context.Context, _ string) (azcore.TokenCredential, error) {
 				return cred, nil
 			}),
-		client)
+		client, cloud.AzurePublic())
 
 	// Verify that resource groups are created with tag
 	resourceManager := infra.NewAzureResourceManager(azCli, deploymentOperations)
@@ -173,6 +175,86 @@ func Test_CLI_InfraCreateAndDelete(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test_CLI_DeploymentStacks_ProvisionAndDown provisions the infrastructure as a deployment stack which detaches the
+// resources it stops managing, and checks that down still deletes every resource of the stack.
+func Test_CLI_DeploymentStacks_ProvisionAndDown(t *testing.T) {
+	// The test has no recording yet. Until one is captured with AZURE_RECORD_MODE=record, it only runs live.
+	recordMode := strings.ToLower(os.Getenv("AZURE_RECORD_MODE"))
+	recordingPath := filepath.Join("testdata", "recordings", t.Name()+".yaml")
+	if _, err := os.Stat(recordingPath); errors.Is(err, os.ErrNotExist) && recordMode != "live" && recordMode != "record" {
+		t.Skip("Skipping test because it has no recording. Run it with AZURE_RECORD_MODE=live or record.")
+	}
+
+	t.Parallel()
+	ctx, cancel := newTestContext(t)
+	defer cancel()
+
+	dir := tempDirWithDiagnostics(t)
+	t.Logf("DIR: %s", dir)
+
+	session := recording.Start(t)
+
+	envName := randomOrStoredEnvName(session)
+	t.Logf("AZURE_ENV_NAME: %s", envName)
+
+	cli := azdcli.NewCLI(t, azdcli.WithSession(session))
+	cli.WorkingDirectory = dir
+	cli.Env = append(cli.Env, os.Environ()...)
+	cli.Env = append(cli.Env, "AZURE_LOCATION=eastus2")
+
+	err := copySample(dir, "storage-stack")
+	require.NoError(t, err, "failed expanding sample")
+
+	_, err = cli.RunCommandWithStdIn(ctx, stdinForInit(envName), "init")
+	require.NoError(t, err)
+
+	_, err = cli.RunCommandWithStdIn(ctx, stdinForProvision(), "provision")
+	require.NoError(t, err)
+
+	env, err := envFromAzdRoot(ctx, dir, envName)
+	require.NoError(t, err)
+
+	accountName, ok := env.Dotenv()["AZURE_STORAGE_ACCOUNT_NAME"]
+	require.True(t, ok)
+	require.Regexp(t, `st\S*`, accountName)
+
+	if session != nil {
+		session.Variables[recording.SubscriptionIdKey] = env.GetSubscriptionId()
+	}
+
+	cred, err := azidentity.NewAzureCLICredential(nil)
+	if err != nil {
+		t.Fatal("could not create credential")
+	}
+
+	var client *http.Client
+	if session != nil {
+		client = session.ProxyClient
+	} else {
+		client = http.DefaultClient
+	}
+
+	credentialProvider := mockaccount.SubscriptionCredentialProviderFunc(
+		func(_ context.Context, _ string) (azcore.TokenCredential, error) {
+			return cred, nil
+		})
+	azCli := azcli.NewAzCli(credentialProvider, client, azcli.NewAzCliArgs{})
+	deploymentOperations := azapi.NewDeploymentOperations(credentialProvider, client, cloud.AzurePublic())
+	resourceManager := infra.NewAzureResourceManager(azCli, deploymentOperations)
+
+	rgs, err := resourceManager.GetResourceGroupsForEnvironment(ctx, env.GetSubscriptionId(), env.Name())
+	require.NoError(t, err)
+	require.NotEmpty(t, rgs)
+
+	_, err = cli.RunCommand(ctx, "down", "--force", "--purge")
+	require.NoError(t, err)
+
+	// Deleting the stack deletes the resource groups it manages, even though the stack detaches unmanaged resources.
+	_, err = resourceManager.GetResourceGroupsForEnvironment(ctx, env.GetSubscriptionId(), env.Name())
+	var notFound *azureutil.ResourceNotFoundError
+	require.ErrorAs(t, err, &notFound, "resource groups of the stack should have been deleted")
+}
+
 func Test_CLI_ProvisionState(t *testing.T) {
 	t.Parallel()
 
@@ -369,7 +451,7 @@ func Test_CLI_InfraCreateAndDeleteUpperCase(t *testing.T) {
 			func(_ context.Context, _ string) (azcore.TokenCredential, error) {
 				return cred, nil
 			}),
-		client)
+		client, cloud.AzurePublic())
 
 	// Verify that resource groups are created with tag
 	resourceManager := infra.NewAzureResourceManager(azCli, deploymentOperations)
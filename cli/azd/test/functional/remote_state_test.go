@@ -10,6 +10,7 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/test/azdcli"
@@ -73,14 +74,16 @@ func createBlobClient(
 	authManager, err := auth.NewManager(
 		fileConfigManager,
 		config.NewUserConfigManager(fileConfigManager),
-		httpClient, mockContext.Console,
+		cloud.AzurePublic(),
+		httpClient, mockContext.CommandRunner, mockContext.Console, nil,
 	)
 	require.NoError(t, err)
 
 	credentials, err := authManager.CredentialForCurrentUser(*mockContext.Context, nil)
 	require.NoError(t, err)
 
-	sdkClient, err := storage.NewBlobSdkClient(*mockContext.Context, credentials, storageConfig, httpClient, "azd")
+	sdkClient, err := storage.NewBlobSdkClient(
+		*mockContext.Context, credentials, storageConfig, httpClient, "azd", cloud.AzurePublic())
 	require.NoError(t, err)
 	require.NotNil(t, sdkClient)
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
@@ -28,7 +29,8 @@ func NewDeploymentOperationsServiceFromMockContext(
 		mockaccount.SubscriptionCredentialProviderFunc(func(_ context.Context, _ string) (azcore.TokenCredential, error) {
 			return mockContext.Credentials, nil
 		}),
-		mockContext.HttpClient)
+		mockContext.HttpClient,
+		cloud.AzurePublic())
 }
 
 func NewDeploymentsServiceFromMockContext(
@@ -37,5 +39,26 @@ func NewDeploymentsServiceFromMockContext(
 		mockaccount.SubscriptionCredentialProviderFunc(func(_ context.Context, _ string) (azcore.TokenCredential, error) {
 			return mockContext.Credentials, nil
 		}),
-		mockContext.HttpClient)
+		mockContext.HttpClient,
+		cloud.AzurePublic())
+}
+
+func NewDeploymentStacksFromMockContext(
+	mockContext *mocks.MockContext) azapi.DeploymentStacks {
+	return azapi.NewDeploymentStacks(
+		mockaccount.SubscriptionCredentialProviderFunc(func(_ context.Context, _ string) (azcore.TokenCredential, error) {
+			return mockContext.Credentials, nil
+		}),
+		mockContext.HttpClient,
+		cloud.AzurePublic())
+}
+
+func NewResourceAvailabilityFromMockContext(
+	mockContext *mocks.MockContext) azapi.ResourceAvailability {
+	return azapi.NewResourceAvailability(
+		mockaccount.SubscriptionCredentialProviderFunc(func(_ context.Context, _ string) (azcore.TokenCredential, error) {
+			return mockContext.Credentials, nil
+		}),
+		mockContext.HttpClient,
+		cloud.AzurePublic())
 }
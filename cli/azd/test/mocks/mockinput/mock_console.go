@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
@@ -87,6 +88,8 @@ func (c *MockConsole) MessageUxItem(ctx context.Context, item ux.UxItem) {
 	c.Message(ctx, item.ToString(""))
 }
 
+func (c *MockConsole) Event(ctx context.Context, eventType contracts.EventDataType, data any) {}
+
 func (c *MockConsole) ShowSpinner(ctx context.Context, title string, format input.SpinnerUxType) {
 	c.spinnerOps = append(c.spinnerOps, SpinnerOp{
 		Op:      SpinnerOpShow,